@@ -17,8 +17,13 @@ import (
 	b2c2nats "github.com/Checker-Finance/adapters/b2c2-adapter/internal/nats"
 	internalsecrets "github.com/Checker-Finance/adapters/b2c2-adapter/internal/secrets"
 	"github.com/Checker-Finance/adapters/b2c2-adapter/pkg/config"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/rate"
+	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	pkglogger "github.com/Checker-Finance/adapters/pkg/logger"
 	pkgsecrets "github.com/Checker-Finance/adapters/pkg/secrets"
 )
@@ -35,6 +40,12 @@ func main() {
 	pkglogger.Init(cfg.ServiceName, cfg.Env, cfg.LogLevel)
 	defer pkglogger.Sync()
 
+	shutdownTracing, err := tracing.Init(ctx, cfg.ServiceName)
+	if err != nil {
+		slog.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("starting b2c2-adapter", "version", Version)
 
 	// --- AWS Secrets Manager provider ---
@@ -78,16 +89,77 @@ func main() {
 	defer nc.Close()
 
 	// --- Publisher ---
-	pub, err := publisher.New(nc, "evt.b2c2", "B2C2_EVENTS")
+	pub, err := publisher.NewWithOptions(nc, "evt.b2c2", "B2C2_EVENTS", publisher.Options{
+		Async:      cfg.NATSPublishAsync,
+		MaxPending: cfg.NATSPublishMaxPending,
+	})
 	if err != nil {
 		slog.Error("failed to init NATS publisher", "error", err)
 		os.Exit(1)
 	}
+	if err := pub.EnsureStreams(publisher.StreamSpec{
+		Name:       cfg.NATSStream,
+		Subjects:   []string{"evt.trade.*.v1.B2C2"},
+		Duplicates: cfg.NATSDedupWindow,
+	}); err != nil {
+		slog.Error("failed to verify jetstream streams", "error", err)
+		os.Exit(1)
+	}
 
 	natsPublisher := b2c2nats.NewPublisher(pub)
 
 	// --- B2C2 service ---
 	service := b2c2.NewService(client, resolver, natsPublisher)
+	service.SetInstrumentCatalog(b2c2.NewInstrumentCatalog(client, cfg.InstrumentsTTL))
+
+	// --- Postgres (optional): terminal trades with their outbox events, and the audit trail ---
+	var (
+		auditRecorder *audit.Recorder
+		auditHandler  *audit.Handler
+	)
+	if cfg.DatabaseURL != "" {
+		pg, err := store.NewPGPool(ctx, cfg.DatabaseURL, store.PGPoolConfig{MaxConns: int32(cfg.PGMaxConns)})
+		if err != nil {
+			slog.Error("failed to connect to postgres", "error", err)
+			os.Exit(1)
+		}
+		defer pg.Close()
+		tradeSyncWriter := legacy.NewTradeSyncWriter(pg, "b2c2-adapter")
+		service.SetFillStore(b2c2.NewPGFillStore(tradeSyncWriter))
+		service.SetTradeSync(tradeSyncWriter)
+
+		// --- Outbox relay: publishes terminal trade events queued with their t_order rows ---
+		outboxRelay := outbox.NewRelay(outbox.NewPGStore(pg, "b2c2-adapter"), pub, outbox.RelayConfig{})
+		go outboxRelay.Start(ctx)
+
+		auditStore := audit.NewPGStore(pg)
+		auditRecorder = audit.NewRecorder(auditStore, "b2c2")
+		auditHandler = audit.NewHandler(auditStore, "b2c2")
+		service.SetAuditRecorder(auditRecorder)
+	} else {
+		slog.Warn("DATABASE_URL not set; trade events are published directly, fills are kept in memory, reconciliation is per replica and no audit trail is kept")
+	}
+
+	// --- Streaming price feed ---
+	priceStream := b2c2.NewPriceStream(resolver, b2c2nats.NewLadderPublisher(nc), b2c2.StreamConfig{
+		Instruments:  cfg.StreamInstruments,
+		Levels:       cfg.StreamLevels,
+		MaxAge:       cfg.StreamMaxAge,
+		DefaultWSURL: cfg.DefaultWSURL,
+	})
+	priceStream.Start(ctx, clients)
+	defer priceStream.Stop()
+	if cfg.RFQFromStream {
+		service.SetPriceStream(priceStream)
+	}
+
+	// --- Trade reconciler ---
+	reconciler := b2c2.NewReconciler(service, b2c2.ReconcilerConfig{
+		Interval: cfg.ReconInterval,
+		Lookback: cfg.ReconLookback,
+		Grace:    cfg.ReconGrace,
+	})
+	go reconciler.Start(ctx)
 
 	// --- NATS command consumer ---
 	consumer := b2c2nats.NewCommandConsumer(nc, service)
@@ -99,8 +171,10 @@ func main() {
 
 	// --- Fiber HTTP server ---
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(tracing.FiberMiddleware())
 	handler := b2c2api.NewB2C2Handler(service)
-	b2c2api.RegisterRoutes(app, handler, nc)
+	reconHandler := b2c2api.NewReconciliationHandler(reconciler)
+	b2c2api.RegisterRoutes(app, handler, reconHandler, auditHandler, nc)
 
 	go func() {
 		if err := app.Listen(fmt.Sprintf(":%d", cfg.HealthPort)); err != nil {
@@ -121,4 +195,10 @@ func main() {
 	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
 		slog.Error("fiber shutdown error", "error", err)
 	}
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		slog.Warn("audit.flush_incomplete", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("tracing shutdown error", "error", err)
+	}
 }
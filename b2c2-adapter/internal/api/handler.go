@@ -2,19 +2,24 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // B2CService defines the service methods used by the HTTP handler.
 type B2CService interface {
-	CreateRFQ(ctx context.Context, clientID, pair, side, quantity, clientRFQID string) (*b2c2.RFQResponse, error)
-	ExecuteRFQ(ctx context.Context, clientID, pair, side, quantity, price, rfqID, clientOrderID string) (*b2c2.OrderResponse, error)
+	CreateRFQ(ctx context.Context, clientID string, spec b2c2.InstrumentSpec, side, quantity, clientRFQID string) (*b2c2.RFQResponse, error)
+	ExecuteRFQ(ctx context.Context, clientID string, spec b2c2.InstrumentSpec, side, quantity, price, rfqID, clientOrderID string) (*b2c2.OrderResponse, error)
 	GetBalance(ctx context.Context, clientID string) (b2c2.BalanceResponse, error)
 	GetProducts(ctx context.Context, clientID string) ([]b2c2.Instrument, error)
+	GetOrder(ctx context.Context, clientID, orderID string) (*b2c2.OrderResponse, error)
+	GetCFDPositions(ctx context.Context, clientID string) ([]b2c2.CFDPosition, error)
+	GetMarginRequirements(ctx context.Context, clientID string) (*b2c2.MarginRequirements, error)
 }
 
 // B2C2Handler handles HTTP API requests for B2C2 operations.
@@ -37,15 +42,25 @@ func (h *B2C2Handler) CreateRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	resp, err := h.service.CreateRFQ(c.Context(), req.ClientID, req.Pair, req.Side, req.Quantity, req.ID)
+	spec, err := b2c2.NewInstrumentSpec(req.Pair, req.Settlement, req.Tenor)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	resp, err := h.service.CreateRFQ(c.UserContext(), req.ClientID, spec, req.Side, req.Quantity, req.ID)
 	if err != nil {
 		slog.Error("b2c2.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(RFQCreateResponse{
+		resp := RFQCreateResponse{
 			QuoteID:  req.ID,
 			ErrorMsg: err.Error(),
-		})
+		}
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			resp.ErrorCode = verr.Code
+			resp.ErrorMsg = verr.Message
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(resp)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(RFQCreateResponse{
@@ -72,15 +87,25 @@ func (h *B2C2Handler) ExecuteOrderHandler(c *fiber.Ctx) error {
 		"rfqId", req.RFQID,
 	)
 
-	resp, err := h.service.ExecuteRFQ(c.Context(), req.ClientID, req.Pair, req.Side, req.Quantity, req.Price, req.RFQID, req.ClientOrderID)
+	spec, err := b2c2.NewInstrumentSpec(req.Pair, req.Settlement, req.Tenor)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	resp, err := h.service.ExecuteRFQ(c.UserContext(), req.ClientID, spec, req.Side, req.Quantity, req.Price, req.RFQID, req.ClientOrderID)
 	if err != nil {
 		slog.Error("b2c2.execute_order.failed",
 			"client", req.ClientID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(OrderExecuteResponse{
+		resp := OrderExecuteResponse{
 			OrderID:  req.OrderID,
 			ErrorMsg: err.Error(),
-		})
+		}
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			resp.ErrorCode = verr.Code
+			resp.ErrorMsg = verr.Message
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(resp)
 	}
 
 	price := ""
@@ -103,7 +128,7 @@ func (h *B2C2Handler) GetProductsHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "clientId query param is required"})
 	}
 
-	instruments, err := h.service.GetProducts(c.Context(), clientID)
+	instruments, err := h.service.GetProducts(c.UserContext(), clientID)
 	if err != nil {
 		slog.Error("b2c2.get_products.failed", "client", clientID, "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
@@ -122,7 +147,7 @@ func (h *B2C2Handler) GetBalancesHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "missing client_id"})
 	}
 
-	balances, err := h.service.GetBalance(c.Context(), clientID)
+	balances, err := h.service.GetBalance(c.UserContext(), clientID)
 	if err != nil {
 		slog.Error("b2c2.get_balances.failed", "client", clientID, "error", err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
@@ -130,3 +155,58 @@ func (h *B2C2Handler) GetBalancesHandler(c *fiber.Ctx) error {
 
 	return c.JSON(balances)
 }
+
+// GetOrderHandler handles GET /api/v1/orders/:order_id.
+func (h *B2C2Handler) GetOrderHandler(c *fiber.Ctx) error {
+	orderID := c.Params("order_id")
+	clientID := c.Query("clientId")
+	if clientID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "clientId query param is required"})
+	}
+
+	order, err := h.service.GetOrder(c.UserContext(), clientID, orderID)
+	if err != nil {
+		if errors.Is(err, b2c2.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "order not found"})
+		}
+		slog.Error("b2c2.get_order.failed", "client", clientID, "orderId", orderID, "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(order)
+}
+
+// GetCFDPositionsHandler handles GET /api/v1/cfd/positions/:client_id.
+func (h *B2C2Handler) GetCFDPositionsHandler(c *fiber.Ctx) error {
+	clientID := c.Params("client_id")
+	if clientID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "missing client_id"})
+	}
+
+	positions, err := h.service.GetCFDPositions(c.UserContext(), clientID)
+	if err != nil {
+		slog.Error("b2c2.get_cfd_positions.failed", "client", clientID, "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(fiber.Map{
+		"count":     len(positions),
+		"positions": positions,
+	})
+}
+
+// GetMarginHandler handles GET /api/v1/cfd/margin/:client_id.
+func (h *B2C2Handler) GetMarginHandler(c *fiber.Ctx) error {
+	clientID := c.Params("client_id")
+	if clientID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "missing client_id"})
+	}
+
+	margin, err := h.service.GetMarginRequirements(c.UserContext(), clientID)
+	if err != nil {
+		slog.Error("b2c2.get_margin.failed", "client", clientID, "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(margin)
+}
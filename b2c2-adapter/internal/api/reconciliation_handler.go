@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+)
+
+// ReconciliationReports is satisfied by b2c2.Reconciler.
+type ReconciliationReports interface {
+	LastReport(clientID string) (*b2c2.ReconciliationReport, bool)
+}
+
+// ReconciliationHandler serves the trade reconciler's latest reports.
+type ReconciliationHandler struct {
+	reports ReconciliationReports
+}
+
+// NewReconciliationHandler creates a new ReconciliationHandler.
+func NewReconciliationHandler(reports ReconciliationReports) *ReconciliationHandler {
+	return &ReconciliationHandler{reports: reports}
+}
+
+// GetReportHandler handles GET /api/v1/reconciliation/:client_id.
+func (h *ReconciliationHandler) GetReportHandler(c *fiber.Ctx) error {
+	clientID := c.Params("client_id")
+	report, ok := h.reports.LastReport(clientID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no reconciliation report for " + clientID})
+	}
+	return c.JSON(report)
+}
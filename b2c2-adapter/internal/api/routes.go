@@ -7,10 +7,12 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/nats-io/nats.go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Checker-Finance/adapters/internal/audit"
 )
 
 // RegisterRoutes registers all HTTP routes on the Fiber app.
-func RegisterRoutes(app *fiber.App, h *B2C2Handler, nc *nats.Conn) {
+func RegisterRoutes(app *fiber.App, h *B2C2Handler, reconHandler *ReconciliationHandler, auditHandler *audit.Handler, nc *nats.Conn) {
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -37,6 +39,15 @@ func RegisterRoutes(app *fiber.App, h *B2C2Handler, nc *nats.Conn) {
 	v1 := app.Group("/api/v1")
 	v1.Post("/quotes", h.CreateRFQHandler)
 	v1.Post("/orders", h.ExecuteOrderHandler)
+	v1.Get("/orders/:order_id", h.GetOrderHandler)
 	v1.Get("/products", h.GetProductsHandler)
 	v1.Get("/balances/:client_id", h.GetBalancesHandler)
+	v1.Get("/cfd/positions/:client_id", h.GetCFDPositionsHandler)
+	v1.Get("/cfd/margin/:client_id", h.GetMarginHandler)
+	v1.Get("/reconciliation/:client_id", reconHandler.GetReportHandler)
+
+	// Quote-to-trade audit trail (requires Postgres)
+	if auditHandler != nil {
+		v1.Get("/audit/:id", auditHandler.Timeline)
+	}
 }
@@ -4,11 +4,13 @@ import "fmt"
 
 // RFQCreateRequest is the payload for POST /api/v1/quotes.
 type RFQCreateRequest struct {
-	ID       string `json:"id"`
-	ClientID string `json:"clientId"`
-	Pair     string `json:"pair"`      // canonical format, e.g. "usd:btc"
-	Side     string `json:"orderSide"` // "buy" or "sell"
-	Quantity string `json:"quantity"`
+	ID         string `json:"id"`
+	ClientID   string `json:"clientId"`
+	Pair       string `json:"pair"`      // canonical format, e.g. "usd:btc"
+	Side       string `json:"orderSide"` // "buy" or "sell"
+	Quantity   string `json:"quantity"`
+	Settlement string `json:"settlement,omitempty"` // SPOT (default), CFD or FORWARD
+	Tenor      string `json:"tenor,omitempty"`      // required for FORWARD, e.g. "1M"
 }
 
 // Validate checks that RFQCreateRequest has all required fields.
@@ -34,6 +36,7 @@ type RFQCreateResponse struct {
 	ProviderQuoteID string `json:"providerQuoteId"`
 	Price           string `json:"price"`
 	ExpireAt        string `json:"expireAt"`
+	ErrorCode       string `json:"errorCode,omitempty"`
 	ErrorMsg        string `json:"errorMessage,omitempty"`
 }
 
@@ -47,6 +50,8 @@ type OrderExecuteRequest struct {
 	Price         string `json:"price"`
 	RFQID         string `json:"rfqId"`
 	ClientOrderID string `json:"clientOrderId"`
+	Settlement    string `json:"settlement,omitempty"` // SPOT (default), CFD or FORWARD
+	Tenor         string `json:"tenor,omitempty"`      // required for FORWARD, e.g. "1M"
 }
 
 // Validate checks that OrderExecuteRequest has all required fields.
@@ -73,5 +78,6 @@ type OrderExecuteResponse struct {
 	Status          string `json:"status"`
 	Price           string `json:"price,omitempty"`
 	ExecutedAt      string `json:"executedAt,omitempty"`
+	ErrorCode       string `json:"errorCode,omitempty"`
 	ErrorMsg        string `json:"errorMessage,omitempty"`
 }
@@ -4,14 +4,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/rate"
 )
 
+// ErrNotFound is returned when B2C2 answers 404 for a lookup.
+var ErrNotFound = errors.New("b2c2: not found")
+
+// historyPageSize is the page size used when walking /trade/ and /ledger/.
+const historyPageSize = 100
+
+// maxHistoryPages bounds a single paginated walk.
+const maxHistoryPages = 100
+
 // Client wraps HTTP communication with the B2C2 API.
 // A single Client instance serves all tenants; credentials are supplied per-request.
 type Client struct {
@@ -24,6 +36,9 @@ func NewClient(rateMgr *rate.Manager) *Client {
 	exec := httpclient.New(rateMgr, httpClient, 2, "b2c2", func(status int, body []byte) error {
 		var errResp ErrorResponse
 		_ = json.Unmarshal(body, &errResp)
+		if status == http.StatusNotFound {
+			return fmt.Errorf("%w: %s", ErrNotFound, string(body))
+		}
 		return fmt.Errorf("b2c2 returned %d: %s", status, string(body))
 	})
 	return &Client{
@@ -71,6 +86,115 @@ func (c *Client) GetInstruments(ctx context.Context, cfg *B2C2ClientConfig) ([]I
 	return resp, nil
 }
 
+// GetCFDPositions retrieves the client's open CFD positions.
+// GET /cfd/open_positions/
+func (c *Client) GetCFDPositions(ctx context.Context, cfg *B2C2ClientConfig) ([]CFDPosition, error) {
+	var resp []CFDPosition
+	if err := c.getJSON(ctx, cfg, "/cfd/open_positions/", &resp); err != nil {
+		return nil, fmt.Errorf("b2c2: get_cfd_positions: %w", err)
+	}
+	return resp, nil
+}
+
+// GetMarginRequirements retrieves the client's margin summary.
+// GET /margin_requirements/
+func (c *Client) GetMarginRequirements(ctx context.Context, cfg *B2C2ClientConfig) (*MarginRequirements, error) {
+	var resp MarginRequirements
+	if err := c.getJSON(ctx, cfg, "/margin_requirements/", &resp); err != nil {
+		return nil, fmt.Errorf("b2c2: get_margin_requirements: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetOrder retrieves a single order by its B2C2 order ID.
+// GET /order/{order_id}/
+func (c *Client) GetOrder(ctx context.Context, cfg *B2C2ClientConfig, orderID string) (*OrderResponse, error) {
+	ctx = httpclient.WithEndpoint(ctx, "/order/{order_id}/")
+	var resp OrderResponse
+	if err := c.getJSON(ctx, cfg, "/order/"+url.PathEscape(orderID)+"/", &resp); err != nil {
+		return nil, fmt.Errorf("b2c2: get_order: %w", err)
+	}
+	return &resp, nil
+}
+
+// ListTrades retrieves one page of executed trades.
+// GET /trade/
+func (c *Client) ListTrades(ctx context.Context, cfg *B2C2ClientConfig, q HistoryQuery) ([]Trade, error) {
+	var resp []Trade
+	if err := c.getJSON(ctx, cfg, "/trade/?"+q.values().Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("b2c2: list_trades: %w", err)
+	}
+	return resp, nil
+}
+
+// ListAllTrades walks every page of /trade/ matching q.
+func (c *Client) ListAllTrades(ctx context.Context, cfg *B2C2ClientConfig, q HistoryQuery) ([]Trade, error) {
+	return paginate(q, func(page HistoryQuery) ([]Trade, error) {
+		return c.ListTrades(ctx, cfg, page)
+	})
+}
+
+// ListLedger retrieves one page of ledger entries.
+// GET /ledger/
+func (c *Client) ListLedger(ctx context.Context, cfg *B2C2ClientConfig, q HistoryQuery) ([]LedgerEntry, error) {
+	var resp []LedgerEntry
+	if err := c.getJSON(ctx, cfg, "/ledger/?"+q.values().Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("b2c2: list_ledger: %w", err)
+	}
+	return resp, nil
+}
+
+// ListAllLedger walks every page of /ledger/ matching q.
+func (c *Client) ListAllLedger(ctx context.Context, cfg *B2C2ClientConfig, q HistoryQuery) ([]LedgerEntry, error) {
+	return paginate(q, func(page HistoryQuery) ([]LedgerEntry, error) {
+		return c.ListLedger(ctx, cfg, page)
+	})
+}
+
+// paginate calls fetch with increasing offsets until a short page is returned.
+func paginate[T any](q HistoryQuery, fetch func(HistoryQuery) ([]T, error)) ([]T, error) {
+	if q.Limit <= 0 {
+		q.Limit = historyPageSize
+	}
+	var all []T
+	for page := 0; page < maxHistoryPages; page++ {
+		items, err := fetch(q)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < q.Limit {
+			return all, nil
+		}
+		q.Offset += len(items)
+	}
+	return all, fmt.Errorf("b2c2: pagination stopped after %d pages", maxHistoryPages)
+}
+
+// values encodes the query in B2C2's filter syntax.
+func (q HistoryQuery) values() url.Values {
+	v := url.Values{}
+	if !q.Since.IsZero() {
+		v.Set("created__gte", q.Since.UTC().Format(time.RFC3339))
+	}
+	if !q.Until.IsZero() {
+		v.Set("created__lt", q.Until.UTC().Format(time.RFC3339))
+	}
+	if q.Instrument != "" {
+		v.Set("instrument", q.Instrument)
+	}
+	if q.Type != "" {
+		v.Set("type", q.Type)
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		v.Set("offset", strconv.Itoa(q.Offset))
+	}
+	return v
+}
+
 // getJSON performs an authenticated GET request and decodes the JSON response.
 func (c *Client) getJSON(ctx context.Context, cfg *B2C2ClientConfig, path string, out any) error {
 	url := cfg.BaseURL + path
@@ -0,0 +1,178 @@
+package b2c2
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/publisher"
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+// FillEventID returns the JetStream message ID of a fill event.
+func FillEventID(event *FillArrivedEvent) string {
+	return publisher.TradeEventID("B2C2", event.OrderID, model.StatusFilled)
+}
+
+// CancelEventID returns the JetStream message ID of a cancel event.
+func CancelEventID(event *OrderCanceledEvent) string {
+	return publisher.TradeEventID("B2C2", event.OrderID, model.StatusCancelled)
+}
+
+// FillRecord is a FillArrivedEvent as published, with the client it belongs to.
+type FillRecord struct {
+	ClientID   string
+	Instrument string // B2C2 instrument the order executed on; empty if unknown
+
+	Event       FillArrivedEvent
+	PublishedAt time.Time
+}
+
+// FillStore keeps the fills the adapter has published so they can be
+// reconciled against B2C2's trade history.
+type FillStore interface {
+	Record(ctx context.Context, r FillRecord) error
+	// Between returns the client's fills published in [from, to).
+	Between(ctx context.Context, clientID string, from, to time.Time) ([]FillRecord, error)
+}
+
+// FillLog is an in-memory FillStore. After a restart only fills published
+// since then are known, and fills published by other replicas never are, so
+// it is only used when no database is configured.
+type FillLog struct {
+	mu    sync.Mutex
+	fills map[string]FillRecord // B2C2 order ID → record
+}
+
+// NewFillLog creates an empty FillLog.
+func NewFillLog() *FillLog {
+	return &FillLog{fills: make(map[string]FillRecord)}
+}
+
+// Record stores a published fill.
+func (l *FillLog) Record(_ context.Context, r FillRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fills[r.Event.ExternalOrderID] = r
+	return nil
+}
+
+// Between returns the client's fills published in [from, to).
+func (l *FillLog) Between(_ context.Context, clientID string, from, to time.Time) ([]FillRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var out []FillRecord
+	for _, r := range l.fills {
+		if r.ClientID == clientID && !r.PublishedAt.Before(from) && r.PublishedAt.Before(to) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// Prune drops fills published before cutoff.
+func (l *FillLog) Prune(cutoff time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for id, r := range l.fills {
+		if r.PublishedAt.Before(cutoff) {
+			delete(l.fills, id)
+		}
+	}
+}
+
+// PGFillStore keeps published fills as filled trades in the legacy
+// activity.t_order table, keyed by B2C2 order ID, so reconciliation survives
+// restarts and covers fills published by any replica.
+type PGFillStore struct {
+	writer *legacy.TradeSyncWriter
+}
+
+// NewPGFillStore constructs a FillStore over writer's activity.t_order rows.
+func NewPGFillStore(writer *legacy.TradeSyncWriter) *PGFillStore {
+	return &PGFillStore{writer: writer}
+}
+
+// Record upserts the fill as a filled trade. Fills are normally written with
+// their event by TradeSync; Record is the fallback when that fails.
+func (s *PGFillStore) Record(ctx context.Context, r FillRecord) error {
+	return s.writer.SyncTradeUpsert(ctx, r.Trade())
+}
+
+// Trade returns the fill as a filled trade, keyed by B2C2 order ID.
+func (r FillRecord) Trade() *model.TradeConfirmation {
+	price, _ := strconv.ParseFloat(r.Event.Price, 64)
+	quantity, _ := strconv.ParseFloat(r.Event.QuantityFilled, 64)
+	return &model.TradeConfirmation{
+		TradeID:         r.Event.ExternalOrderID,
+		ClientID:        r.ClientID,
+		OrderID:         r.Event.OrderID,
+		Instrument:      r.Event.InstrumentPair,
+		Side:            strings.ToUpper(r.Event.Side),
+		Quantity:        quantity,
+		Price:           price,
+		Venue:           "B2C2",
+		Status:          model.StatusFilled,
+		ExecutedAt:      r.PublishedAt,
+		RFQID:           r.Event.RequestForQuoteID,
+		ProviderOrderID: r.Event.ExternalOrderID,
+	}
+}
+
+// cancelledTrade returns a no-liquidity order as a cancelled trade, keyed by
+// B2C2 order ID when B2C2 returned one.
+func cancelledTrade(resp *OrderResponse, event *OrderCanceledEvent) *model.TradeConfirmation {
+	tradeID := resp.OrderID
+	if tradeID == "" {
+		tradeID = event.OrderID
+	}
+	quantity, _ := strconv.ParseFloat(event.Quantity, 64)
+	price, _ := strconv.ParseFloat(event.QuotedPrice, 64)
+	return &model.TradeConfirmation{
+		TradeID:         tradeID,
+		ClientID:        event.ClientID,
+		OrderID:         event.OrderID,
+		Instrument:      event.InstrumentPair,
+		Side:            strings.ToUpper(event.Side),
+		Quantity:        quantity,
+		Price:           price,
+		Venue:           "B2C2",
+		Status:          model.StatusCancelled,
+		ExecutedAt:      time.Now(),
+		RFQID:           event.RequestForQuoteID,
+		ProviderOrderID: resp.OrderID,
+	}
+}
+
+// Between returns the client's fills recorded with an order time in
+// [from, to); cancelled orders are skipped. activity.t_order holds the
+// canonical pair rather than the B2C2 instrument, so the returned records
+// leave Instrument empty.
+func (s *PGFillStore) Between(ctx context.Context, clientID string, from, to time.Time) ([]FillRecord, error) {
+	trades, err := s.writer.TradesBetween(ctx, clientID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]FillRecord, 0, len(trades))
+	for _, t := range trades {
+		if t.Status != model.StatusFilled {
+			continue
+		}
+		out = append(out, FillRecord{
+			ClientID: t.ClientID,
+			Event: FillArrivedEvent{
+				ExternalOrderID: t.TradeID,
+				InstrumentPair:  t.Instrument,
+				QuantityFilled:  strconv.FormatFloat(t.Quantity, 'f', -1, 64),
+				Price:           strconv.FormatFloat(t.Price, 'f', -1, 64),
+				Side:            t.Side,
+				Status:          t.Status,
+			},
+			PublishedAt: t.ExecutedAt,
+		})
+	}
+	return out, nil
+}
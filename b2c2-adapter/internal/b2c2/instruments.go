@@ -0,0 +1,195 @@
+package b2c2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+var (
+	// ErrUnsupportedSettlement is returned for settlement types B2C2 does not trade.
+	ErrUnsupportedSettlement = errors.New("b2c2: unsupported settlement type")
+	// ErrInstrumentNotListed is returned when the mapped instrument is absent from
+	// the client's /instruments/ list or is inactive.
+	ErrInstrumentNotListed = errors.New("b2c2: instrument not listed")
+)
+
+// InstrumentSpec identifies what a caller wants to trade: a canonical pair plus the
+// settlement type and, for forwards, the tenor.
+type InstrumentSpec struct {
+	Pair       string               // canonical, e.g. "btc:usd"
+	Settlement model.SettlementType // empty means SPOT
+	Tenor      string               // forwards only, e.g. "1W", "1M"
+}
+
+// NewInstrumentSpec parses a settlement string into an InstrumentSpec.
+func NewInstrumentSpec(pair, settlement, tenor string) (InstrumentSpec, error) {
+	spec := InstrumentSpec{Pair: pair, Tenor: tenor}
+	if settlement != "" {
+		t, err := model.ParseSettlementType(settlement)
+		if err != nil {
+			return InstrumentSpec{}, err
+		}
+		spec.Settlement = t
+	}
+	return spec, nil
+}
+
+// IsSpot reports whether the spec maps to a B2C2 SPOT instrument.
+func (s InstrumentSpec) IsSpot() bool {
+	switch s.Settlement {
+	case "", model.SettlementTypeSPOT:
+		return true
+	default:
+		return false
+	}
+}
+
+// B2C2Name maps the spec to a B2C2 instrument name:
+//
+//	SPOT             → BTCUSD.SPOT
+//	TOD / TOM        → BTCUSD.TOD / BTCUSD.TOM
+//	CFD              → BTCUSD.CFD
+//	FORWARD + tenor  → BTCUSD.FWD.1M
+//
+// TOD and TOM keep their own value date: they are tradeable only if the
+// client's instrument list carries the matching name, never as SPOT.
+func (s InstrumentSpec) B2C2Name() (string, error) {
+	base := strings.TrimSuffix(ToB2C2Instrument(s.Pair), ".SPOT")
+	tenor := strings.ToUpper(strings.TrimSpace(s.Tenor))
+
+	switch s.Settlement {
+	case "", model.SettlementTypeSPOT, model.SettlementTypeTOD, model.SettlementTypeTOM, model.SettlementTypeCFD:
+		if tenor != "" {
+			return "", fmt.Errorf("%w: tenor %q is only valid for forwards", ErrUnsupportedSettlement, s.Tenor)
+		}
+		if s.IsSpot() {
+			return base + ".SPOT", nil
+		}
+		return base + "." + string(s.Settlement), nil
+	case model.SettlementTypeFORWARD:
+		if tenor == "" {
+			return "", fmt.Errorf("%w: forward requires a tenor", ErrUnsupportedSettlement)
+		}
+		return base + ".FWD." + tenor, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedSettlement, s.Settlement)
+	}
+}
+
+// InstrumentCatalog caches each client's live /instruments/ list and resolves
+// InstrumentSpecs against it.
+type InstrumentCatalog struct {
+	client *Client
+	ttl    time.Duration
+
+	mu      sync.Mutex
+	entries map[string]catalogEntry // clientID → instruments
+}
+
+type catalogEntry struct {
+	byName    map[string]Instrument
+	fetchedAt time.Time
+}
+
+// NewInstrumentCatalog creates a catalog that refreshes a client's list after ttl.
+func NewInstrumentCatalog(client *Client, ttl time.Duration) *InstrumentCatalog {
+	if ttl <= 0 {
+		ttl = 10 * time.Minute
+	}
+	return &InstrumentCatalog{
+		client:  client,
+		ttl:     ttl,
+		entries: make(map[string]catalogEntry),
+	}
+}
+
+// Resolve maps spec to a B2C2 instrument name and checks that the client's
+// /instruments/ list carries it as active. If the list cannot be fetched, a
+// SPOT name is returned unvalidated so spot trading keeps working; other
+// settlement types are rejected.
+func (c *InstrumentCatalog) Resolve(ctx context.Context, clientID string, cfg *B2C2ClientConfig, spec InstrumentSpec) (string, error) {
+	name, err := spec.B2C2Name()
+	if err != nil {
+		return "", err
+	}
+
+	byName, err := c.instruments(ctx, clientID, cfg)
+	if err != nil {
+		if spec.IsSpot() {
+			slog.Warn("b2c2.instruments.validation_skipped", "clientId", clientID, "instrument", name, "error", err)
+			return name, nil
+		}
+		return "", fmt.Errorf("b2c2: validate %s: %w", name, err)
+	}
+
+	inst, ok := byName[name]
+	if !ok || !inst.IsActive {
+		return "", fmt.Errorf("%w: %s", ErrInstrumentNotListed, name)
+	}
+	return name, nil
+}
+
+// ValidateQuantity checks quantity against the min/max size B2C2 lists for the
+// named instrument. Quantities are in the underlying currency. If the list
+// cannot be fetched only the sign is checked.
+func (c *InstrumentCatalog) ValidateQuantity(ctx context.Context, clientID string, cfg *B2C2ClientConfig, name, quantity string) error {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(quantity), 64)
+	if err != nil {
+		return &model.RFQValidationError{Code: model.RFQErrInvalidAmount, Message: fmt.Sprintf("quantity %q is not a number", quantity)}
+	}
+
+	var product model.Product
+	if byName, err := c.instruments(ctx, clientID, cfg); err == nil {
+		if inst, ok := byName[name]; ok {
+			product = inst.Product()
+		}
+	}
+	return product.ValidateAmount(amount, product.NotionalCurrency)
+}
+
+// Product converts the instrument to a model.Product carrying its size limits.
+// Unparseable limits are left unset.
+func (i Instrument) Product() model.Product {
+	minSize, _ := strconv.ParseFloat(i.MinQuantity, 64)
+	maxSize, _ := strconv.ParseFloat(i.MaxQuantity, 64)
+	return model.Product{
+		VenueCode:        "B2C2",
+		InstrumentSymbol: i.Name,
+		ProductName:      i.Name,
+		IsBlocked:        !i.IsActive,
+		MinSize:          minSize,
+		MaxSize:          maxSize,
+		NotionalCurrency: strings.ToUpper(i.UnderlyingCurrency),
+	}
+}
+
+func (c *InstrumentCatalog) instruments(ctx context.Context, clientID string, cfg *B2C2ClientConfig) (map[string]Instrument, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[clientID]
+	c.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < c.ttl {
+		return entry.byName, nil
+	}
+
+	list, err := c.client.GetInstruments(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]Instrument, len(list))
+	for _, inst := range list {
+		byName[inst.Name] = inst
+	}
+
+	c.mu.Lock()
+	c.entries[clientID] = catalogEntry{byName: byName, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return byName, nil
+}
@@ -0,0 +1,179 @@
+package b2c2_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+func TestInstrumentSpec_B2C2Name(t *testing.T) {
+	tests := []struct {
+		settlement model.SettlementType
+		tenor      string
+		want       string
+		wantErr    bool
+	}{
+		{"", "", "BTCUSD.SPOT", false},
+		{model.SettlementTypeSPOT, "", "BTCUSD.SPOT", false},
+		{model.SettlementTypeTOD, "", "BTCUSD.TOD", false},
+		{model.SettlementTypeTOM, "", "BTCUSD.TOM", false},
+		{model.SettlementTypeCFD, "", "BTCUSD.CFD", false},
+		{model.SettlementTypeFORWARD, "1m", "BTCUSD.FWD.1M", false},
+		{model.SettlementTypeFORWARD, "", "", true},
+		{model.SettlementTypeSPOT, "1M", "", true},
+		{model.SettlementTypeNDF, "1M", "", true},
+		{model.SettlementTypeSWAP, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.settlement)+"_"+tt.tenor, func(t *testing.T) {
+			spec := b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: tt.settlement, Tenor: tt.tenor}
+			got, err := spec.B2C2Name()
+			if tt.wantErr {
+				if !errors.Is(err, b2c2.ErrUnsupportedSettlement) {
+					t.Fatalf("expected ErrUnsupportedSettlement, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("B2C2Name() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewInstrumentSpec_RejectsUnknownSettlement(t *testing.T) {
+	if _, err := b2c2.NewInstrumentSpec("btc:usd", "weekly", ""); err == nil {
+		t.Fatal("expected error for unknown settlement")
+	}
+	spec, err := b2c2.NewInstrumentSpec("btc:usd", "cfd", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.Settlement != model.SettlementTypeCFD {
+		t.Errorf("settlement = %q, want CFD", spec.Settlement)
+	}
+}
+
+func TestInstrumentCatalog_Resolve(t *testing.T) {
+	instruments := []b2c2.Instrument{
+		{Name: "BTCUSD.SPOT", IsActive: true},
+		{Name: "BTCUSD.CFD", IsActive: true},
+		{Name: "BTCUSD.FWD.1M", IsActive: true},
+		{Name: "ETHUSD.CFD", IsActive: false},
+	}
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(instruments)
+	}))
+	defer srv.Close()
+
+	catalog := b2c2.NewInstrumentCatalog(b2c2.NewClient(nil), 0)
+	cfg := &b2c2.B2C2ClientConfig{BaseURL: srv.URL}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		spec    b2c2.InstrumentSpec
+		want    string
+		wantErr error
+	}{
+		{"listed cfd", b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: model.SettlementTypeCFD}, "BTCUSD.CFD", nil},
+		{"listed forward", b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: model.SettlementTypeFORWARD, Tenor: "1M"}, "BTCUSD.FWD.1M", nil},
+		{"unlisted tenor", b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: model.SettlementTypeFORWARD, Tenor: "3M"}, "", b2c2.ErrInstrumentNotListed},
+		{"inactive", b2c2.InstrumentSpec{Pair: "eth:usd", Settlement: model.SettlementTypeCFD}, "", b2c2.ErrInstrumentNotListed},
+		{"unlisted spot", b2c2.InstrumentSpec{Pair: "doge:usd"}, "", b2c2.ErrInstrumentNotListed},
+		{"tom not collapsed onto spot", b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: model.SettlementTypeTOM}, "", b2c2.ErrInstrumentNotListed},
+		{"tod not collapsed onto spot", b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: model.SettlementTypeTOD}, "", b2c2.ErrInstrumentNotListed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := catalog.Resolve(ctx, "client-1", cfg, tt.spec)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if calls.Load() != 1 {
+		t.Errorf("expected the instrument list to be fetched once, got %d", calls.Load())
+	}
+}
+
+func TestInstrumentCatalog_FallsBackToSpotWhenListUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":{}}`, http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	catalog := b2c2.NewInstrumentCatalog(b2c2.NewClient(nil), 0)
+	cfg := &b2c2.B2C2ClientConfig{BaseURL: srv.URL}
+
+	got, err := catalog.Resolve(context.Background(), "client-1", cfg, b2c2.InstrumentSpec{Pair: "btc:usd"})
+	if err != nil || got != "BTCUSD.SPOT" {
+		t.Fatalf("spot: got %q, %v", got, err)
+	}
+	if _, err := catalog.Resolve(context.Background(), "client-1", cfg, b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: model.SettlementTypeCFD}); err == nil {
+		t.Fatal("expected CFD to be rejected when the instrument list is unavailable")
+	}
+	if _, err := catalog.Resolve(context.Background(), "client-1", cfg, b2c2.InstrumentSpec{Pair: "btc:usd", Settlement: model.SettlementTypeTOM}); err == nil {
+		t.Fatal("expected TOM to be rejected when the instrument list is unavailable")
+	}
+}
+
+func TestInstrumentCatalog_ValidateQuantity(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]b2c2.Instrument{
+			{Name: "BTCUSD.SPOT", UnderlyingCurrency: "BTC", IsActive: true, MinQuantity: "0.001", MaxQuantity: "100"},
+		})
+	}))
+	defer srv.Close()
+
+	catalog := b2c2.NewInstrumentCatalog(b2c2.NewClient(nil), 0)
+	cfg := &b2c2.B2C2ClientConfig{BaseURL: srv.URL}
+
+	tests := []struct {
+		name     string
+		quantity string
+		wantCode string
+	}{
+		{"within limits", "1.5", ""},
+		{"below minimum", "0.0001", model.RFQErrBelowMinSize},
+		{"above maximum", "250", model.RFQErrAboveMaxSize},
+		{"not a number", "abc", model.RFQErrInvalidAmount},
+		{"zero", "0", model.RFQErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := catalog.ValidateQuantity(context.Background(), "client-1", cfg, "BTCUSD.SPOT", tt.quantity)
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			verr, ok := model.AsRFQValidationError(err)
+			if !ok || verr.Code != tt.wantCode {
+				t.Fatalf("expected %s, got %v", tt.wantCode, err)
+			}
+		})
+	}
+}
@@ -56,6 +56,24 @@ func FromRFQResponse(resp *RFQResponse, cmd *SubmitRequestForQuoteCommand) *Quot
 	}
 }
 
+// FromPriceLadder converts a streamed ladder level to a QuoteArrivedEvent.
+// The quote is valid for maxAge from the ladder timestamp. Each call mints a
+// new quote ID carrying that expiry, prefixed so execution can tell it apart
+// from a B2C2 RFQ ID.
+func FromPriceLadder(ladder *PriceLadder, level LadderLevel, cmd *SubmitRequestForQuoteCommand, maxAge time.Duration) *QuoteArrivedEvent {
+	expiry := ladder.Timestamp.Add(maxAge)
+	return &QuoteArrivedEvent{
+		RequestForQuoteID: cmd.ID,
+		ExternalQuoteID:   newLadderQuoteID(ladder.Instrument, expiry),
+		Price:             level.Price,
+		Side:              cmd.Side,
+		InstrumentPair:    cmd.InstrumentPair,
+		Quantity:          cmd.Quantity,
+		Expiry:            expiry.Format(time.RFC3339Nano),
+		Provider:          "b2c2",
+	}
+}
+
 //
 // ────────────────────────────────────────────────────────────
 //   Order Mapping
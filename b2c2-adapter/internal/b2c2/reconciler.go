@@ -0,0 +1,273 @@
+package b2c2
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/metrics"
+)
+
+// Discrepancy kinds reported by the Reconciler.
+const (
+	// DiscrepancyMissingFill: B2C2 has a trade for which no fill was published.
+	DiscrepancyMissingFill = "missing_fill"
+	// DiscrepancyUnknownFill: a fill was published but B2C2 has no matching trade.
+	DiscrepancyUnknownFill = "unknown_fill"
+	// DiscrepancyMismatch: trade and fill exist but disagree on a field.
+	DiscrepancyMismatch = "mismatch"
+)
+
+// ReconcilerConfig tunes the trade reconciler.
+type ReconcilerConfig struct {
+	// Interval between reconciliation runs.
+	Interval time.Duration
+	// Lookback is the window of trades and fills compared on each run.
+	Lookback time.Duration
+	// Grace excludes the most recent trades and fills, which may still be in flight.
+	// Zero disables it.
+	Grace time.Duration
+}
+
+// Discrepancy describes one disagreement between B2C2 and the fills we published.
+type Discrepancy struct {
+	Kind     string `json:"kind"`
+	OrderID  string `json:"orderId"` // B2C2 order ID
+	TradeID  string `json:"tradeId,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Expected string `json:"expected,omitempty"` // B2C2 value
+	Actual   string `json:"actual,omitempty"`   // published value
+}
+
+// ReconciliationReport is the outcome of reconciling one client over one window.
+type ReconciliationReport struct {
+	ClientID      string        `json:"clientId"`
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to"`
+	TradesChecked int           `json:"tradesChecked"`
+	FillsChecked  int           `json:"fillsChecked"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// Reconciler periodically compares B2C2's trade history with the FillArrivedEvents
+// the service published and flags missing, unknown and mismatched fills.
+type Reconciler struct {
+	service *Service
+	cfg     ReconcilerConfig
+	now     func() time.Time
+
+	mu      sync.RWMutex
+	reports map[string]*ReconciliationReport
+	counted map[string]time.Time // discrepancy key → first seen
+}
+
+// NewReconciler constructs a Reconciler over the service's fill store.
+func NewReconciler(service *Service, cfg ReconcilerConfig) *Reconciler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Minute
+	}
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 24 * time.Hour
+	}
+	if cfg.Grace < 0 {
+		cfg.Grace = 0
+	}
+	return &Reconciler{
+		service: service,
+		cfg:     cfg,
+		now:     time.Now,
+		reports: make(map[string]*ReconciliationReport),
+		counted: make(map[string]time.Time),
+	}
+}
+
+// Start runs reconciliation every Interval until ctx is cancelled.
+func (r *Reconciler) Start(ctx context.Context) {
+	slog.Info("b2c2.recon.started", "interval", r.cfg.Interval, "lookback", r.cfg.Lookback)
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce reconciles every discovered client.
+func (r *Reconciler) RunOnce(ctx context.Context) {
+	clients, err := r.service.resolver.DiscoverClients(ctx)
+	if err != nil {
+		slog.Warn("b2c2.recon.discover_failed", "error", err)
+		return
+	}
+	for _, clientID := range clients {
+		if _, err := r.Reconcile(ctx, clientID); err != nil {
+			slog.Warn("b2c2.recon.failed", "clientId", clientID, "error", err)
+		}
+	}
+	cutoff := r.now().Add(-r.cfg.Lookback - r.cfg.Grace)
+	if log, ok := r.service.fills.(*FillLog); ok {
+		log.Prune(cutoff)
+	}
+	r.mu.Lock()
+	for key, seen := range r.counted {
+		if seen.Before(cutoff) {
+			delete(r.counted, key)
+		}
+	}
+	r.mu.Unlock()
+}
+
+// Reconcile compares one client's trades and published fills over the lookback window.
+func (r *Reconciler) Reconcile(ctx context.Context, clientID string) (*ReconciliationReport, error) {
+	to := r.now().UTC().Add(-r.cfg.Grace)
+	from := to.Add(-r.cfg.Lookback)
+
+	trades, err := r.service.ListTrades(ctx, clientID, HistoryQuery{Since: from, Until: to})
+	if err != nil {
+		metrics.IncReconciliationRun(clientID, "error")
+		return nil, fmt.Errorf("b2c2.recon: %w", err)
+	}
+	fills, err := r.service.fills.Between(ctx, clientID, from, to)
+	if err != nil {
+		metrics.IncReconciliationRun(clientID, "error")
+		return nil, fmt.Errorf("b2c2.recon: load fills: %w", err)
+	}
+
+	report := &ReconciliationReport{
+		ClientID:      clientID,
+		From:          from,
+		To:            to,
+		TradesChecked: len(trades),
+		FillsChecked:  len(fills),
+		Discrepancies: compareTrades(trades, fills),
+	}
+
+	result := "ok"
+	for _, d := range report.Discrepancies {
+		result = "discrepancies"
+		// A discrepancy stays in the window for Lookback; count and log it
+		// the first time it is seen only.
+		if !r.firstSeen(clientID, d) {
+			continue
+		}
+		metrics.IncDiscrepancy(clientID, d.Kind)
+		slog.Warn("b2c2.recon.discrepancy",
+			"clientId", clientID,
+			"kind", d.Kind,
+			"orderId", d.OrderID,
+			"tradeId", d.TradeID,
+			"field", d.Field,
+			"b2c2", d.Expected,
+			"published", d.Actual,
+		)
+	}
+	metrics.IncReconciliationRun(clientID, result)
+	metrics.SetLastReconciliation(clientID, r.now())
+
+	slog.Info("b2c2.recon.completed",
+		"clientId", clientID,
+		"trades", report.TradesChecked,
+		"fills", report.FillsChecked,
+		"discrepancies", len(report.Discrepancies),
+	)
+
+	r.mu.Lock()
+	r.reports[clientID] = report
+	r.mu.Unlock()
+	return report, nil
+}
+
+// LastReport returns the most recent report for a client, if any.
+func (r *Reconciler) LastReport(clientID string) (*ReconciliationReport, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	report, ok := r.reports[clientID]
+	return report, ok
+}
+
+// firstSeen records d and reports whether it was not seen before.
+func (r *Reconciler) firstSeen(clientID string, d Discrepancy) bool {
+	key := strings.Join([]string{clientID, d.Kind, d.OrderID, d.Field}, "|")
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.counted[key]; ok {
+		return false
+	}
+	r.counted[key] = r.now()
+	return true
+}
+
+// compareTrades matches trades to fills by B2C2 order ID. Trades published
+// outside the fill log window appear as missing fills and vice versa, so the
+// window edges are kept a Grace period behind now.
+func compareTrades(trades []Trade, fills []FillRecord) []Discrepancy {
+	byOrder := make(map[string]FillRecord, len(fills))
+	for _, f := range fills {
+		byOrder[f.Event.ExternalOrderID] = f
+	}
+
+	var out []Discrepancy
+	seen := make(map[string]bool, len(trades))
+	for _, t := range trades {
+		seen[t.OrderID] = true
+		f, ok := byOrder[t.OrderID]
+		if !ok {
+			out = append(out, Discrepancy{Kind: DiscrepancyMissingFill, OrderID: t.OrderID, TradeID: t.TradeID})
+			continue
+		}
+		out = append(out, compareFields(t, f)...)
+	}
+	for _, f := range fills {
+		if !seen[f.Event.ExternalOrderID] {
+			out = append(out, Discrepancy{Kind: DiscrepancyUnknownFill, OrderID: f.Event.ExternalOrderID})
+		}
+	}
+	return out
+}
+
+func compareFields(t Trade, r FillRecord) []Discrepancy {
+	f := r.Event
+	var out []Discrepancy
+	mismatch := func(field, expected, actual string) {
+		out = append(out, Discrepancy{
+			Kind:     DiscrepancyMismatch,
+			OrderID:  t.OrderID,
+			TradeID:  t.TradeID,
+			Field:    field,
+			Expected: expected,
+			Actual:   actual,
+		})
+	}
+	if !decimalEqual(t.Quantity, f.QuantityFilled) {
+		mismatch("quantity", t.Quantity, f.QuantityFilled)
+	}
+	if !decimalEqual(t.Price, f.Price) {
+		mismatch("price", t.Price, f.Price)
+	}
+	if !strings.EqualFold(t.Side, f.Side) {
+		mismatch("side", t.Side, f.Side)
+	}
+	if r.Instrument != "" && t.Instrument != r.Instrument {
+		mismatch("instrument", t.Instrument, r.Instrument)
+	}
+	return out
+}
+
+// decimalEqual compares two decimal strings numerically ("1.50" == "1.5").
+func decimalEqual(a, b string) bool {
+	da, errA := decimal.NewFromString(a)
+	db, errB := decimal.NewFromString(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return da.Equal(db)
+}
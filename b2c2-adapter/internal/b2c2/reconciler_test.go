@@ -0,0 +1,200 @@
+package b2c2_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/metrics"
+)
+
+// newHistoryServer lists BTCUSD.SPOT, fills every POST /order/ at the requested price and serves
+// trades from GET /trade/ honouring limit/offset.
+func newHistoryServer(t *testing.T, trades []b2c2.Trade) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/instruments", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode([]b2c2.Instrument{{Name: "BTCUSD.SPOT", IsActive: true}})
+	})
+	mux.HandleFunc("/order/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if r.URL.Path != "/order/o1/" {
+				http.Error(w, `{"errors":{"message":"not found"}}`, http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(b2c2.OrderResponse{OrderID: "o1", Status: "FILLED"})
+			return
+		}
+		var req b2c2.OrderRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		price := req.Price
+		_ = json.NewEncoder(w).Encode(b2c2.OrderResponse{
+			OrderID:       req.ClientOrderID,
+			Instrument:    req.Instrument,
+			Side:          req.Side,
+			Quantity:      req.Quantity,
+			ExecutedPrice: &price,
+			Status:        "FILLED",
+		})
+	})
+	mux.HandleFunc("/trade/", func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := min(offset+limit, len(trades))
+		if offset > end {
+			offset = end
+		}
+		_ = json.NewEncoder(w).Encode(trades[offset:end])
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestClient_ListAllTradesPaginates(t *testing.T) {
+	trades := make([]b2c2.Trade, 5)
+	for i := range trades {
+		trades[i] = b2c2.Trade{TradeID: "t" + strconv.Itoa(i)}
+	}
+	srv := newHistoryServer(t, trades)
+
+	client := b2c2.NewClient(nil)
+	got, err := client.ListAllTrades(context.Background(), &b2c2.B2C2ClientConfig{BaseURL: srv.URL}, b2c2.HistoryQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 5 {
+		t.Fatalf("expected 5 trades, got %d", len(got))
+	}
+	if got[4].TradeID != "t4" {
+		t.Errorf("last trade = %q, want t4", got[4].TradeID)
+	}
+}
+
+func TestService_GetOrderNotFound(t *testing.T) {
+	srv := newHistoryServer(t, nil)
+	resolver := &mockResolver{cfg: &b2c2.B2C2ClientConfig{BaseURL: srv.URL}}
+	svc := b2c2.NewService(b2c2.NewClient(nil), resolver, &mockPublisher{})
+
+	order, err := svc.GetOrder(context.Background(), "client-1", "o1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.OrderID != "o1" {
+		t.Errorf("orderId = %q, want o1", order.OrderID)
+	}
+
+	_, err = svc.GetOrder(context.Background(), "client-1", "missing")
+	if !errors.Is(err, b2c2.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestReconciler_FlagsDiscrepancies(t *testing.T) {
+	trades := []b2c2.Trade{
+		{TradeID: "t1", OrderID: "o1", Instrument: "BTCUSD.SPOT", Side: "buy", Quantity: "1.0", Price: "100"},
+		{TradeID: "t2", OrderID: "o2", Instrument: "BTCUSD.SPOT", Side: "buy", Quantity: "1", Price: "101"},
+		{TradeID: "t4", OrderID: "o4", Instrument: "BTCUSD.SPOT", Side: "sell", Quantity: "2", Price: "99"},
+	}
+	srv := newHistoryServer(t, trades)
+	resolver := &mockResolver{cfg: &b2c2.B2C2ClientConfig{BaseURL: srv.URL}}
+	svc := b2c2.NewService(b2c2.NewClient(nil), resolver, &mockPublisher{})
+
+	// The fake echoes client_order_id as the B2C2 order ID.
+	for _, o := range []struct{ id, price string }{{"o1", "100.00"}, {"o2", "100"}, {"o3", "100"}} {
+		err := svc.HandleOrderCommand(context.Background(), &b2c2.SubmitOrderCommand{
+			OrderID:        "ord-" + o.id,
+			ClientOrderID:  o.id,
+			ClientID:       "client-1",
+			InstrumentPair: "btc:usd",
+			Side:           "BUY",
+			Quantity:       "1",
+			Price:          o.price,
+		})
+		if err != nil {
+			t.Fatalf("order %s: %v", o.id, err)
+		}
+	}
+
+	rec := b2c2.NewReconciler(svc, b2c2.ReconcilerConfig{})
+	report, err := rec.Reconcile(context.Background(), "client-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.TradesChecked != 3 || report.FillsChecked != 3 {
+		t.Fatalf("checked %d trades / %d fills, want 3 / 3", report.TradesChecked, report.FillsChecked)
+	}
+
+	kinds := map[string]b2c2.Discrepancy{}
+	for _, d := range report.Discrepancies {
+		kinds[d.Kind+":"+d.OrderID] = d
+	}
+	if len(kinds) != 3 {
+		t.Fatalf("expected 3 discrepancies, got %+v", report.Discrepancies)
+	}
+	if d, ok := kinds[b2c2.DiscrepancyMismatch+":o2"]; !ok || d.Field != "price" || d.Expected != "101" {
+		t.Errorf("expected price mismatch on o2, got %+v", d)
+	}
+	if _, ok := kinds[b2c2.DiscrepancyMissingFill+":o4"]; !ok {
+		t.Error("expected missing fill for o4")
+	}
+	if _, ok := kinds[b2c2.DiscrepancyUnknownFill+":o3"]; !ok {
+		t.Error("expected unknown fill for o3")
+	}
+
+	if last, ok := rec.LastReport("client-1"); !ok || last != report {
+		t.Error("expected LastReport to return the latest report")
+	}
+
+	missing := metrics.B2C2ReconciliationDiscrepancies.WithLabelValues("client-1", b2c2.DiscrepancyMissingFill)
+	before := testutil.ToFloat64(missing)
+	if _, err := rec.Reconcile(context.Background(), "client-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if after := testutil.ToFloat64(missing); after != before {
+		t.Errorf("discrepancies must be counted once, counter went %v → %v", before, after)
+	}
+}
+
+func TestReconciler_SharedFillStore(t *testing.T) {
+	trades := []b2c2.Trade{
+		{TradeID: "t1", OrderID: "o1", Instrument: "BTCUSD.SPOT", Side: "buy", Quantity: "1", Price: "100"},
+	}
+	srv := newHistoryServer(t, trades)
+	resolver := &mockResolver{cfg: &b2c2.B2C2ClientConfig{BaseURL: srv.URL}}
+	fills := b2c2.NewFillLog()
+
+	// One replica executes the order; another, sharing the fill store, reconciles it.
+	executor := b2c2.NewService(b2c2.NewClient(nil), resolver, &mockPublisher{})
+	executor.SetFillStore(fills)
+	err := executor.HandleOrderCommand(context.Background(), &b2c2.SubmitOrderCommand{
+		OrderID:        "ord-o1",
+		ClientOrderID:  "o1",
+		ClientID:       "client-1",
+		InstrumentPair: "btc:usd",
+		Side:           "BUY",
+		Quantity:       "1",
+		Price:          "100",
+	})
+	if err != nil {
+		t.Fatalf("order: %v", err)
+	}
+
+	other := b2c2.NewService(b2c2.NewClient(nil), resolver, &mockPublisher{})
+	other.SetFillStore(fills)
+	report, err := b2c2.NewReconciler(other, b2c2.ReconcilerConfig{}).Reconcile(context.Background(), "client-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Discrepancies) != 0 {
+		t.Errorf("expected no discrepancies across replicas, got %+v", report.Discrepancies)
+	}
+}
@@ -6,6 +6,11 @@ import (
 	"log/slog"
 	"strings"
 	"time"
+
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
+	"github.com/Checker-Finance/adapters/internal/metrics"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // Service contains business logic for the B2C2 adapter.
@@ -15,6 +20,11 @@ type Service struct {
 	client    *Client
 	resolver  ConfigResolver
 	publisher Publisher
+	stream    *PriceStream
+	fills     FillStore
+	tradeSync TradeSync
+	catalog   *InstrumentCatalog
+	auditor   *audit.Recorder
 }
 
 // NewService constructs a new B2C2 service.
@@ -23,36 +33,99 @@ func NewService(client *Client, resolver ConfigResolver, publisher Publisher) *S
 		client:    client,
 		resolver:  resolver,
 		publisher: publisher,
+		fills:     NewFillLog(),
+		catalog:   NewInstrumentCatalog(client, 0),
 	}
 }
 
-// CreateRFQ requests a quote from B2C2. pair is in canonical format (e.g. "usd:btc").
-func (s *Service) CreateRFQ(ctx context.Context, clientID, pair, side, quantity, clientRFQID string) (*RFQResponse, error) {
+// SetInstrumentCatalog replaces the catalog used to validate instruments.
+func (s *Service) SetInstrumentCatalog(c *InstrumentCatalog) {
+	s.catalog = c
+}
+
+// SetFillStore replaces the in-memory log of published fills, e.g. with a
+// PGFillStore shared by all replicas.
+func (s *Service) SetFillStore(fs FillStore) {
+	s.fills = fs
+}
+
+// SetTradeSync makes terminal trades be written to activity.t_order together
+// with their event, which the outbox relay then publishes.
+func (s *Service) SetTradeSync(ts TradeSync) {
+	s.tradeSync = ts
+}
+
+// SetAuditRecorder sets the recorder for the quote-to-trade audit trail.
+func (s *Service) SetAuditRecorder(r *audit.Recorder) {
+	s.auditor = r
+}
+
+// SetPriceStream lets HandleRFQCommand answer from the streamed price ladder
+// when the requested size fits a level.
+func (s *Service) SetPriceStream(ps *PriceStream) {
+	s.stream = ps
+}
+
+// CreateRFQ requests a quote from B2C2. The instrument is derived from spec and
+// must be listed for the client.
+func (s *Service) CreateRFQ(ctx context.Context, clientID string, spec InstrumentSpec, side, quantity, clientRFQID string) (*RFQResponse, error) {
 	cfg, err := s.resolver.Resolve(ctx, clientID)
 	if err != nil {
 		return nil, fmt.Errorf("b2c2.create_rfq: resolve config for %q: %w", clientID, err)
 	}
+	instrument, err := s.validateInstrument(ctx, clientID, cfg, spec, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("b2c2.create_rfq: %w", err)
+	}
 	req := &RFQRequest{
-		Instrument:  ToB2C2Instrument(pair),
+		Instrument:  instrument,
 		Side:        strings.ToLower(side),
 		Quantity:    quantity,
 		ClientRFQID: clientRFQID,
 	}
+	s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: clientID}, req)
+
+	ctx, raw := httpclient.CaptureResponse(ctx)
 	resp, err := s.client.RequestQuote(ctx, cfg, req)
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindQuoteResponse,
+			ClientID: clientID,
+			Error:    err.Error(),
+		}, raw.Body)
 		return nil, fmt.Errorf("b2c2.create_rfq: %w", err)
 	}
+	metrics.RecordQuote("b2c2", resp.RFQID, time.Now())
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindQuoteResponse,
+		ClientID: clientID,
+		QuoteID:  resp.RFQID,
+	}, raw.Body)
 	return resp, nil
 }
 
-// ExecuteRFQ submits a FOK order to B2C2. pair is in canonical format (e.g. "usd:btc").
-func (s *Service) ExecuteRFQ(ctx context.Context, clientID, pair, side, quantity, price, rfqID, clientOrderID string) (*OrderResponse, error) {
+// ExecuteRFQ submits a FOK order to B2C2. The instrument is derived from spec and
+// must be listed for the client.
+func (s *Service) ExecuteRFQ(ctx context.Context, clientID string, spec InstrumentSpec, side, quantity, price, rfqID, clientOrderID string) (*OrderResponse, error) {
 	cfg, err := s.resolver.Resolve(ctx, clientID)
 	if err != nil {
 		return nil, fmt.Errorf("b2c2.execute_rfq: resolve config for %q: %w", clientID, err)
 	}
+	instrument, err := s.validateInstrument(ctx, clientID, cfg, spec, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("b2c2.execute_rfq: %w", err)
+	}
+	quoteID := rfqID
+	// Ladder quotes were never issued by B2C2; the FOK limit price carries them,
+	// so their expiry is enforced here.
+	if isLadderQuoteID(rfqID) {
+		if err := checkLadderQuote(rfqID, time.Now()); err != nil {
+			return nil, fmt.Errorf("b2c2.execute_rfq: %w", err)
+		}
+		rfqID = ""
+	}
 	req := &OrderRequest{
-		Instrument:    ToB2C2Instrument(pair),
+		Instrument:    instrument,
 		Side:          strings.ToLower(side),
 		Quantity:      quantity,
 		Price:         price,
@@ -61,13 +134,49 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, pair, side, quantity
 		ClientOrderID: clientOrderID,
 		ValidUntil:    time.Now().UTC().Add(10 * time.Second).Format(time.RFC3339),
 	}
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteRequest,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+	}, req)
+
+	ctx, raw := httpclient.CaptureResponse(ctx)
 	resp, err := s.client.ExecuteOrder(ctx, cfg, req)
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			Error:    err.Error(),
+		}, raw.Body)
 		return nil, fmt.Errorf("b2c2.execute_rfq: %w", err)
 	}
+	if resp.ExecutedPrice != nil {
+		metrics.ObserveQuoteFill("b2c2", quoteID, time.Now())
+	}
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteResponse,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+		TradeID:  resp.OrderID,
+		Status:   resp.Status,
+	}, raw.Body)
 	return resp, nil
 }
 
+// validateInstrument resolves spec to a listed B2C2 instrument and checks
+// quantity against its size limits.
+func (s *Service) validateInstrument(ctx context.Context, clientID string, cfg *B2C2ClientConfig, spec InstrumentSpec, quantity string) (string, error) {
+	instrument, err := s.catalog.Resolve(ctx, clientID, cfg, spec)
+	if err != nil {
+		return "", err
+	}
+	if err := s.catalog.ValidateQuantity(ctx, clientID, cfg, instrument, quantity); err != nil {
+		return "", err
+	}
+	return instrument, nil
+}
+
 // GetBalance fetches the account balance for a client.
 func (s *Service) GetBalance(ctx context.Context, clientID string) (BalanceResponse, error) {
 	cfg, err := s.resolver.Resolve(ctx, clientID)
@@ -86,7 +195,53 @@ func (s *Service) GetProducts(ctx context.Context, clientID string) ([]Instrumen
 	return s.client.GetInstruments(ctx, cfg)
 }
 
+// GetCFDPositions fetches a client's open CFD positions.
+func (s *Service) GetCFDPositions(ctx context.Context, clientID string) ([]CFDPosition, error) {
+	cfg, err := s.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("b2c2.get_cfd_positions: resolve config for %q: %w", clientID, err)
+	}
+	return s.client.GetCFDPositions(ctx, cfg)
+}
+
+// GetMarginRequirements fetches a client's margin summary.
+func (s *Service) GetMarginRequirements(ctx context.Context, clientID string) (*MarginRequirements, error) {
+	cfg, err := s.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("b2c2.get_margin_requirements: resolve config for %q: %w", clientID, err)
+	}
+	return s.client.GetMarginRequirements(ctx, cfg)
+}
+
+// GetOrder looks up an order by its B2C2 order ID.
+func (s *Service) GetOrder(ctx context.Context, clientID, orderID string) (*OrderResponse, error) {
+	cfg, err := s.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("b2c2.get_order: resolve config for %q: %w", clientID, err)
+	}
+	return s.client.GetOrder(ctx, cfg, orderID)
+}
+
+// ListTrades returns every trade matching q for a client, following pagination.
+func (s *Service) ListTrades(ctx context.Context, clientID string, q HistoryQuery) ([]Trade, error) {
+	cfg, err := s.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("b2c2.list_trades: resolve config for %q: %w", clientID, err)
+	}
+	return s.client.ListAllTrades(ctx, cfg, q)
+}
+
+// ListLedger returns every ledger entry matching q for a client, following pagination.
+func (s *Service) ListLedger(ctx context.Context, clientID string, q HistoryQuery) ([]LedgerEntry, error) {
+	cfg, err := s.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("b2c2.list_ledger: resolve config for %q: %w", clientID, err)
+	}
+	return s.client.ListAllLedger(ctx, cfg, q)
+}
+
 // HandleRFQCommand processes a SubmitRequestForQuoteCommand:
+// answers from the streamed ladder when possible, otherwise
 // resolves client config → calls B2C2 RFQ API → publishes QuoteArrivedEvent.
 func (s *Service) HandleRFQCommand(ctx context.Context, cmd *SubmitRequestForQuoteCommand) error {
 	clientID := cmd.EffectiveClientID()
@@ -97,7 +252,42 @@ func (s *Service) HandleRFQCommand(ctx context.Context, cmd *SubmitRequestForQuo
 		"quantity", cmd.Quantity,
 	)
 
-	resp, err := s.CreateRFQ(ctx, clientID, cmd.InstrumentPair, cmd.Side, cmd.Quantity, cmd.ID)
+	spec, err := NewInstrumentSpec(cmd.InstrumentPair, cmd.Settlement, cmd.Tenor)
+	if err != nil {
+		return fmt.Errorf("b2c2.rfq: %w", err)
+	}
+
+	if spec.IsSpot() && s.stream != nil {
+		// Ladder answers get the same instrument and size checks as B2C2 RFQs.
+		cfg, err := s.resolver.Resolve(ctx, clientID)
+		if err != nil {
+			return fmt.Errorf("b2c2.rfq: resolve config for %q: %w", clientID, err)
+		}
+		if _, err := s.validateInstrument(ctx, clientID, cfg, spec, cmd.Quantity); err != nil {
+			return fmt.Errorf("b2c2.rfq: %w", err)
+		}
+		if event, ok := s.quoteFromLadder(clientID, cmd); ok {
+			metrics.RecordQuote("b2c2", event.ExternalQuoteID, time.Now())
+			s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: clientID}, cmd)
+			s.auditor.Record(ctx, audit.Event{
+				Kind:     audit.KindQuoteResponse,
+				ClientID: clientID,
+				QuoteID:  event.ExternalQuoteID,
+				Source:   "ladder",
+			}, event)
+			slog.Info("b2c2.rfq.answered_from_ladder",
+				"quoteId", event.ExternalQuoteID,
+				"price", event.Price,
+				"validUntil", event.Expiry,
+			)
+			if err := s.publisher.PublishQuoteEvent(ctx, event); err != nil {
+				return fmt.Errorf("b2c2.rfq: publish quote event: %w", err)
+			}
+			return nil
+		}
+	}
+
+	resp, err := s.CreateRFQ(ctx, clientID, spec, cmd.Side, cmd.Quantity, cmd.ID)
 	if err != nil {
 		return fmt.Errorf("b2c2.rfq: %w", err)
 	}
@@ -116,6 +306,20 @@ func (s *Service) HandleRFQCommand(ctx context.Context, cmd *SubmitRequestForQuo
 	return nil
 }
 
+// quoteFromLadder builds a QuoteArrivedEvent from the client's cached ladder if it
+// is fresh and has a level covering the requested quantity.
+func (s *Service) quoteFromLadder(clientID string, cmd *SubmitRequestForQuoteCommand) (*QuoteArrivedEvent, bool) {
+	ladder, ok := s.stream.Latest(clientID, cmd.InstrumentPair)
+	if !ok {
+		return nil, false
+	}
+	level, ok := ladder.PriceForQuantity(strings.ToLower(cmd.Side), cmd.Quantity)
+	if !ok {
+		return nil, false
+	}
+	return FromPriceLadder(ladder, level, cmd, s.stream.cfg.MaxAge), true
+}
+
 // HandleOrderCommand processes a SubmitOrderCommand:
 // resolves client config → calls B2C2 order API → publishes FillArrivedEvent or OrderCanceledEvent.
 func (s *Service) HandleOrderCommand(ctx context.Context, cmd *SubmitOrderCommand) error {
@@ -126,7 +330,12 @@ func (s *Service) HandleOrderCommand(ctx context.Context, cmd *SubmitOrderComman
 		"side", cmd.Side,
 	)
 
-	resp, err := s.ExecuteRFQ(ctx, cmd.ClientID, cmd.InstrumentPair, cmd.Side, cmd.Quantity, cmd.Price, cmd.RequestForQuoteID, cmd.ClientOrderID)
+	spec, err := NewInstrumentSpec(cmd.InstrumentPair, cmd.Settlement, cmd.Tenor)
+	if err != nil {
+		return fmt.Errorf("b2c2.order: %w", err)
+	}
+
+	resp, err := s.ExecuteRFQ(ctx, cmd.ClientID, spec, cmd.Side, cmd.Quantity, cmd.Price, cmd.RequestForQuoteID, cmd.ClientOrderID)
 	if err != nil {
 		return fmt.Errorf("b2c2.order: %w", err)
 	}
@@ -137,15 +346,27 @@ func (s *Service) HandleOrderCommand(ctx context.Context, cmd *SubmitOrderComman
 			"executedPrice", *resp.ExecutedPrice,
 		)
 		event := FromOrderResponseFilled(resp, cmd)
+		record := FillRecord{ClientID: cmd.ClientID, Instrument: resp.Instrument, Event: *event, PublishedAt: time.Now()}
+		if s.syncTerminalTrade(ctx, record.Trade(), SubjectFilled, FillEventID(event), event) {
+			return nil
+		}
 		if err := s.publisher.PublishFillEvent(ctx, event); err != nil {
 			return fmt.Errorf("b2c2.order: publish fill event: %w", err)
 		}
+		if err := s.fills.Record(ctx, record); err != nil {
+			slog.Warn("b2c2.order.fill_record_failed",
+				"orderId", resp.OrderID,
+				"error", err)
+		}
 	} else {
 		slog.Info("b2c2.order.no_liquidity",
 			"orderId", resp.OrderID,
 			"status", resp.Status,
 		)
 		event := FromOrderResponseCanceled(resp, cmd)
+		if s.syncTerminalTrade(ctx, cancelledTrade(resp, event), SubjectCancelled, CancelEventID(event), event) {
+			return nil
+		}
 		if err := s.publisher.PublishCancelEvent(ctx, event); err != nil {
 			return fmt.Errorf("b2c2.order: publish cancel event: %w", err)
 		}
@@ -154,6 +375,23 @@ func (s *Service) HandleOrderCommand(ctx context.Context, cmd *SubmitOrderComman
 	return nil
 }
 
+// syncTerminalTrade writes trade and queues its event in one transaction, and
+// reports whether it did. Without a database, or if the transaction fails,
+// the caller publishes the event directly under the same message ID.
+func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfirmation, subject, msgID string, event any) bool {
+	if s.tradeSync == nil {
+		return false
+	}
+	if err := s.tradeSync.SyncTradeWithEvent(ctx, trade, subject, msgID, event); err != nil {
+		slog.Warn("b2c2.trade_sync_failed",
+			"orderId", trade.OrderID,
+			"status", trade.Status,
+			"error", err)
+		return false
+	}
+	return true
+}
+
 // HandleCancelCommand processes a CancelOrderCommand.
 // B2C2 FOK orders are synchronous and cannot be cancelled post-submission;
 // this is a no-op that logs the attempt.
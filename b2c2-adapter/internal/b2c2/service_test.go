@@ -3,10 +3,14 @@ package b2c2_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 
 	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // ─── Mock Resolver ────────────────────────────────────────────────────────────
@@ -216,3 +220,126 @@ func TestResolverError(t *testing.T) {
 		t.Fatal("expected error, got nil")
 	}
 }
+
+// auditLog is an audit.Store collecting appended events.
+type auditLog struct {
+	events []audit.Event
+}
+
+func (l *auditLog) Append(_ context.Context, e audit.Event) error {
+	l.events = append(l.events, e)
+	return nil
+}
+
+func (l *auditLog) Timeline(_ context.Context, _, _ string) ([]audit.Event, error) {
+	return l.events, nil
+}
+
+func TestHandleOrderCommand_RecordsAuditTrail(t *testing.T) {
+	srv := newHistoryServer(t, nil)
+	log := &auditLog{}
+	recorder := audit.NewRecorder(log, "b2c2")
+	svc := b2c2.NewService(b2c2.NewClient(nil), &mockResolver{cfg: &b2c2.B2C2ClientConfig{BaseURL: srv.URL}}, &mockPublisher{})
+	svc.SetAuditRecorder(recorder)
+
+	err := svc.HandleOrderCommand(context.Background(), &b2c2.SubmitOrderCommand{
+		OrderID:           "ord-o1",
+		ClientOrderID:     "o1",
+		ClientID:          "client-1",
+		InstrumentPair:    "btc:usd",
+		Side:              "BUY",
+		Quantity:          "1",
+		Price:             "100",
+		RequestForQuoteID: "rfq-1",
+	})
+	if err != nil {
+		t.Fatalf("order: %v", err)
+	}
+	if err := recorder.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if len(log.events) != 2 {
+		t.Fatalf("expected execute request and response, got %+v", log.events)
+	}
+	req, resp := log.events[0], log.events[1]
+	if req.Kind != audit.KindExecuteRequest || req.QuoteID != "rfq-1" || req.ClientID != "client-1" {
+		t.Errorf("unexpected execute request event: %+v", req)
+	}
+	if resp.Kind != audit.KindExecuteResponse || resp.TradeID != "o1" || resp.Status != "FILLED" {
+		t.Errorf("unexpected execute response event: %+v", resp)
+	}
+	if !strings.Contains(string(resp.Payload), `"order_id":"o1"`) {
+		t.Errorf("execute response should carry the raw venue body, got %s", resp.Payload)
+	}
+}
+
+// ─── Mock TradeSync ───────────────────────────────────────────────────────────
+
+type syncedTrade struct {
+	trade   *model.TradeConfirmation
+	subject string
+	msgID   string
+}
+
+type mockTradeSync struct {
+	synced []syncedTrade
+	err    error
+}
+
+func (m *mockTradeSync) SyncTradeWithEvent(_ context.Context, trade *model.TradeConfirmation, subject, msgID string, _ any) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.synced = append(m.synced, syncedTrade{trade: trade, subject: subject, msgID: msgID})
+	return nil
+}
+
+func TestHandleOrderCommand_TerminalTradeGoesThroughOutbox(t *testing.T) {
+	tests := []struct {
+		name        string
+		syncErr     error
+		wantSynced  int
+		wantDirect  int
+		wantFillLog int
+	}{
+		{name: "queued with the trade", wantSynced: 1},
+		{name: "published directly when the transaction fails", syncErr: errors.New("db down"), wantDirect: 1, wantFillLog: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newHistoryServer(t, nil)
+			pub := &mockPublisher{}
+			sync := &mockTradeSync{err: tc.syncErr}
+			fills := b2c2.NewFillLog()
+			svc := b2c2.NewService(b2c2.NewClient(nil), &mockResolver{cfg: &b2c2.B2C2ClientConfig{BaseURL: srv.URL}}, pub)
+			svc.SetTradeSync(sync)
+			svc.SetFillStore(fills)
+
+			err := svc.HandleOrderCommand(context.Background(), &b2c2.SubmitOrderCommand{
+				OrderID: "ord-o1", ClientOrderID: "o1", ClientID: "client-1",
+				InstrumentPair: "btc:usd", Side: "BUY", Quantity: "1", Price: "100", RequestForQuoteID: "rfq-1",
+			})
+			if err != nil {
+				t.Fatalf("order: %v", err)
+			}
+
+			if len(sync.synced) != tc.wantSynced {
+				t.Fatalf("expected %d synced trades, got %d", tc.wantSynced, len(sync.synced))
+			}
+			if tc.wantSynced > 0 {
+				got := sync.synced[0]
+				if got.subject != b2c2.SubjectFilled || got.msgID != "trade:B2C2:ord-o1:filled" || got.trade.Status != model.StatusFilled {
+					t.Errorf("unexpected synced trade: %+v %+v", got, got.trade)
+				}
+			}
+			if len(pub.fillEvents) != tc.wantDirect {
+				t.Errorf("expected %d direct fill events, got %d", tc.wantDirect, len(pub.fillEvents))
+			}
+			recorded, _ := fills.Between(context.Background(), "client-1", time.Time{}, time.Now().Add(time.Minute))
+			if len(recorded) != tc.wantFillLog {
+				t.Errorf("expected %d fills in the log, got %d", tc.wantFillLog, len(recorded))
+			}
+		})
+	}
+}
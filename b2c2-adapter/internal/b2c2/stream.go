@@ -0,0 +1,358 @@
+package b2c2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Checker-Finance/adapters/internal/tracing"
+)
+
+// ladderQuotePrefix marks quote IDs answered from the streamed ladder rather than
+// a B2C2 RFQ, so execution knows not to send them as rfq_id.
+const ladderQuotePrefix = "ladder:"
+
+// ErrLadderQuoteExpired is returned when an order executes a ladder quote past
+// its expiry, or one whose ID was not issued by this adapter.
+var ErrLadderQuoteExpired = errors.New("b2c2: ladder quote expired")
+
+// StreamConfig configures the streaming price feed.
+type StreamConfig struct {
+	// Instruments are the canonical pairs to subscribe to (e.g. "btc:usd").
+	Instruments []string
+	// Levels are the ladder quantities requested from B2C2 (e.g. "1", "5", "10").
+	Levels []string
+	// MaxAge is how long a cached ladder may be used to answer an RFQ.
+	MaxAge time.Duration
+	// ReconnectDelay is the pause before re-dialling a dropped socket.
+	ReconnectDelay time.Duration
+	// DefaultWSURL is used when a client's secret carries no ws_url.
+	DefaultWSURL string
+}
+
+// PriceStream maintains one B2C2 quotes WebSocket per client, subscribed to the
+// configured instruments. Every price frame updates an in-memory ladder cache
+// and is published as market data.
+type PriceStream struct {
+	resolver  ConfigResolver
+	publisher LadderPublisher
+	cfg       StreamConfig
+	dialer    *websocket.Dialer
+
+	// pairs maps B2C2 instrument names back to the configured canonical pairs.
+	pairs map[string]string
+
+	mu      sync.RWMutex
+	ladders map[string]*PriceLadder // ladderKey(clientID, instrument) → latest ladder
+	cancels map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewPriceStream constructs a PriceStream. Call Start to begin streaming.
+func NewPriceStream(resolver ConfigResolver, publisher LadderPublisher, cfg StreamConfig) *PriceStream {
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = 2 * time.Second
+	}
+	if cfg.ReconnectDelay <= 0 {
+		cfg.ReconnectDelay = 5 * time.Second
+	}
+	pairs := make(map[string]string, len(cfg.Instruments))
+	for _, pair := range cfg.Instruments {
+		pairs[ToB2C2Instrument(pair)] = pair
+	}
+	return &PriceStream{
+		resolver:  resolver,
+		publisher: publisher,
+		cfg:       cfg,
+		dialer: &websocket.Dialer{
+			Proxy:            http.ProxyFromEnvironment,
+			HandshakeTimeout: 10 * time.Second,
+		},
+		pairs:   pairs,
+		ladders: make(map[string]*PriceLadder),
+		cancels: make(map[string]context.CancelFunc),
+	}
+}
+
+// Start opens a streaming session for each client. Sessions reconnect on their
+// own until ctx is cancelled or Stop is called.
+func (p *PriceStream) Start(ctx context.Context, clientIDs []string) {
+	if len(p.cfg.Instruments) == 0 {
+		slog.Info("b2c2.stream.disabled", "reason", "no instruments configured")
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, clientID := range clientIDs {
+		if _, running := p.cancels[clientID]; running {
+			continue
+		}
+		clientCtx, cancel := context.WithCancel(ctx)
+		p.cancels[clientID] = cancel
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.run(clientCtx, clientID)
+		}()
+	}
+	slog.Info("b2c2.stream.started",
+		"clients", clientIDs,
+		"instruments", p.cfg.Instruments,
+		"levels", p.cfg.Levels,
+	)
+}
+
+// Stop closes every streaming session and waits for them to exit.
+func (p *PriceStream) Stop() {
+	p.mu.Lock()
+	for clientID, cancel := range p.cancels {
+		cancel()
+		delete(p.cancels, clientID)
+	}
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// Latest returns the cached ladder for a client and canonical pair if it is
+// younger than MaxAge.
+func (p *PriceStream) Latest(clientID, pair string) (*PriceLadder, bool) {
+	p.mu.RLock()
+	ladder, ok := p.ladders[ladderKey(clientID, ToB2C2Instrument(pair))]
+	p.mu.RUnlock()
+	if !ok || time.Since(ladder.Timestamp) > p.cfg.MaxAge {
+		return nil, false
+	}
+	return ladder, true
+}
+
+// run keeps a client's session alive, reconnecting after ReconnectDelay.
+func (p *PriceStream) run(ctx context.Context, clientID string) {
+	for {
+		err := p.stream(ctx, clientID)
+		if ctx.Err() != nil {
+			slog.Info("b2c2.stream.stopped", "clientId", clientID)
+			return
+		}
+		slog.Warn("b2c2.stream.disconnected",
+			"clientId", clientID,
+			"error", err,
+			"retryIn", p.cfg.ReconnectDelay,
+		)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.cfg.ReconnectDelay):
+		}
+	}
+}
+
+// stream dials, subscribes and reads price frames until the socket fails or ctx ends.
+func (p *PriceStream) stream(ctx context.Context, clientID string) error {
+	cfg, err := p.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("resolve config: %w", err)
+	}
+
+	wsURL := cfg.WSURL
+	if wsURL == "" {
+		wsURL = p.cfg.DefaultWSURL
+	}
+	header := http.Header{}
+	header.Set("Authorization", "Token "+cfg.APIToken)
+
+	dialCtx, span := tracing.Start(ctx, "b2c2.ws.dial",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("client_id", clientID)))
+	tracing.InjectHTTP(dialCtx, header)
+	conn, _, err := p.dialer.DialContext(dialCtx, wsURL, header)
+	if err != nil {
+		err = fmt.Errorf("dial %q: %w", wsURL, err)
+		tracing.End(span, err)
+		return err
+	}
+	tracing.End(span, nil)
+	defer conn.Close()
+
+	// Unblock ReadMessage when the session is cancelled.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	slog.Info("b2c2.stream.connected", "clientId", clientID, "url", wsURL)
+
+	for instrument := range p.pairs {
+		sub := WSSubscribeRequest{
+			Event:      "subscribe",
+			Instrument: instrument,
+			Levels:     p.cfg.Levels,
+			Tag:        clientID,
+		}
+		if err := conn.WriteJSON(sub); err != nil {
+			return fmt.Errorf("subscribe %s: %w", instrument, err)
+		}
+	}
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+		p.handleMessage(ctx, clientID, raw)
+	}
+}
+
+// handleMessage dispatches a single frame from the quotes socket.
+func (p *PriceStream) handleMessage(ctx context.Context, clientID string, raw []byte) {
+	var msg WSMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		slog.Warn("b2c2.stream.unmarshal_failed", "clientId", clientID, "error", err)
+		return
+	}
+
+	if msg.Success != nil && !*msg.Success {
+		slog.Error("b2c2.stream.error",
+			"clientId", clientID,
+			"event", msg.Event,
+			"instrument", msg.Instrument,
+			"errorCode", msg.ErrorCode,
+			"errorMessage", msg.ErrorMessage,
+		)
+		return
+	}
+
+	switch msg.Event {
+	case "price":
+		p.handlePrice(ctx, clientID, &msg)
+	case "subscribe":
+		slog.Info("b2c2.stream.subscribed", "clientId", clientID, "instrument", msg.Instrument)
+	default:
+		slog.Debug("b2c2.stream.ignored", "clientId", clientID, "event", msg.Event)
+	}
+}
+
+func (p *PriceStream) handlePrice(ctx context.Context, clientID string, msg *WSMessage) {
+	var levels WSPriceLevels
+	if err := json.Unmarshal(msg.Levels, &levels); err != nil {
+		slog.Warn("b2c2.stream.levels_unmarshal_failed",
+			"clientId", clientID,
+			"instrument", msg.Instrument,
+			"error", err)
+		return
+	}
+
+	ts := time.Now().UTC()
+	if msg.Timestamp > 0 {
+		ts = time.UnixMilli(msg.Timestamp).UTC()
+	}
+
+	ladder := &PriceLadder{
+		ClientID:       clientID,
+		Instrument:     msg.Instrument,
+		InstrumentPair: p.pairs[msg.Instrument],
+		Buy:            levels.Buy,
+		Sell:           levels.Sell,
+		Timestamp:      ts,
+		Provider:       "b2c2",
+	}
+
+	p.mu.Lock()
+	p.ladders[ladderKey(clientID, msg.Instrument)] = ladder
+	p.mu.Unlock()
+
+	if p.publisher == nil {
+		return
+	}
+	if err := p.publisher.PublishPriceLadder(ctx, ladder); err != nil {
+		slog.Warn("b2c2.stream.publish_failed",
+			"clientId", clientID,
+			"instrument", msg.Instrument,
+			"error", err)
+	}
+}
+
+func ladderKey(clientID, instrument string) string {
+	return clientID + "|" + instrument
+}
+
+// PriceForQuantity returns the ladder price for side at the smallest level whose
+// quantity covers the requested quantity. ok is false when no level is large enough.
+func (l *PriceLadder) PriceForQuantity(side, quantity string) (LadderLevel, bool) {
+	qty, err := decimal.NewFromString(quantity)
+	if err != nil || !qty.IsPositive() {
+		return LadderLevel{}, false
+	}
+
+	var levels []LadderLevel
+	switch side {
+	case "buy":
+		levels = l.Buy
+	case "sell":
+		levels = l.Sell
+	default:
+		return LadderLevel{}, false
+	}
+
+	type sized struct {
+		qty   decimal.Decimal
+		level LadderLevel
+	}
+	candidates := make([]sized, 0, len(levels))
+	for _, lvl := range levels {
+		q, err := decimal.NewFromString(lvl.Quantity)
+		if err != nil || lvl.Price == "" {
+			continue
+		}
+		candidates = append(candidates, sized{qty: q, level: lvl})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].qty.LessThan(candidates[j].qty) })
+
+	for _, c := range candidates {
+		if qty.LessThanOrEqual(c.qty) {
+			return c.level, true
+		}
+	}
+	return LadderLevel{}, false
+}
+
+// isLadderQuoteID reports whether a quote ID was issued from the streamed ladder.
+func isLadderQuoteID(id string) bool {
+	return strings.HasPrefix(id, ladderQuotePrefix)
+}
+
+// newLadderQuoteID mints a unique ID for a quote answered from the ladder:
+// ladder:<instrument>:<expiry unix ms>:<uuid>. The expiry travels with the ID
+// so any replica can check it at execution.
+func newLadderQuoteID(instrument string, expiry time.Time) string {
+	return fmt.Sprintf("%s%s:%d:%s", ladderQuotePrefix, instrument, expiry.UnixMilli(), uuid.NewString())
+}
+
+// checkLadderQuote rejects a ladder quote ID that has expired at now or that
+// carries no expiry.
+func checkLadderQuote(id string, now time.Time) error {
+	parts := strings.Split(strings.TrimPrefix(id, ladderQuotePrefix), ":")
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: malformed quote id %q", ErrLadderQuoteExpired, id)
+	}
+	ms, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed quote id %q", ErrLadderQuoteExpired, id)
+	}
+	if expiry := time.UnixMilli(ms); now.After(expiry) {
+		return fmt.Errorf("%w: %s expired at %s", ErrLadderQuoteExpired, id, expiry.UTC().Format(time.RFC3339Nano))
+	}
+	return nil
+}
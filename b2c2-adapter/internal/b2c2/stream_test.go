@@ -0,0 +1,241 @@
+package b2c2_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+)
+
+// ─── Mock Ladder Publisher ────────────────────────────────────────────────────
+
+type mockLadderPublisher struct {
+	mu      sync.Mutex
+	ladders []*b2c2.PriceLadder
+}
+
+func (m *mockLadderPublisher) PublishPriceLadder(_ context.Context, l *b2c2.PriceLadder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ladders = append(m.ladders, l)
+	return nil
+}
+
+func (m *mockLadderPublisher) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.ladders)
+}
+
+// newQuotesServer answers every subscribe with an acknowledgement followed by one price frame.
+func newQuotesServer(t *testing.T, gotToken chan<- string) *httptest.Server {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken <- r.Header.Get("Authorization")
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			var sub b2c2.WSSubscribeRequest
+			if err := conn.ReadJSON(&sub); err != nil {
+				return
+			}
+			ack := fmt.Sprintf(`{"event":"subscribe","success":true,"instrument":%q,"levels":["1","5"]}`, sub.Instrument)
+			price := fmt.Sprintf(`{"event":"price","instrument":%q,"timestamp":%d,"levels":{`+
+				`"buy":[{"quantity":"1","price":"100.1"},{"quantity":"5","price":"100.5"}],`+
+				`"sell":[{"quantity":"1","price":"99.9"},{"quantity":"5","price":"99.5"}]}}`,
+				sub.Instrument, time.Now().UnixMilli())
+			for _, frame := range []string{ack, price} {
+				if err := conn.WriteMessage(websocket.TextMessage, []byte(frame)); err != nil {
+					return
+				}
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// ─── Tests ────────────────────────────────────────────────────────────────────
+
+func TestPriceLadder_PriceForQuantity(t *testing.T) {
+	ladder := &b2c2.PriceLadder{
+		Buy:  []b2c2.LadderLevel{{Quantity: "5", Price: "100.5"}, {Quantity: "1", Price: "100.1"}},
+		Sell: []b2c2.LadderLevel{{Quantity: "1", Price: "99.9"}, {Quantity: "5", Price: "99.5"}},
+	}
+
+	tests := []struct {
+		side, quantity string
+		wantPrice      string
+		wantOK         bool
+	}{
+		{"buy", "0.5", "100.1", true},
+		{"buy", "1", "100.1", true},
+		{"buy", "2", "100.5", true},
+		{"sell", "5", "99.5", true},
+		{"sell", "5.01", "", false},
+		{"buy", "0", "", false},
+		{"buy", "abc", "", false},
+		{"hold", "1", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.side+"_"+tt.quantity, func(t *testing.T) {
+			level, ok := ladder.PriceForQuantity(tt.side, tt.quantity)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if level.Price != tt.wantPrice {
+				t.Errorf("price = %q, want %q", level.Price, tt.wantPrice)
+			}
+		})
+	}
+}
+
+func TestPriceStream_CachesPublishesAndAnswersRFQ(t *testing.T) {
+	gotToken := make(chan string, 1)
+	srv := newQuotesServer(t, gotToken)
+	rest := newHistoryServer(t, nil)
+
+	resolver := &mockResolver{cfg: &b2c2.B2C2ClientConfig{
+		APIToken: "tok",
+		BaseURL:  rest.URL,
+		WSURL:    "ws" + strings.TrimPrefix(srv.URL, "http"),
+	}}
+	ladderPub := &mockLadderPublisher{}
+	stream := b2c2.NewPriceStream(resolver, ladderPub, b2c2.StreamConfig{
+		Instruments: []string{"btc:usd"},
+		Levels:      []string{"1", "5"},
+		MaxAge:      time.Minute,
+	})
+	stream.Start(context.Background(), []string{"client-1"})
+	t.Cleanup(stream.Stop)
+
+	select {
+	case token := <-gotToken:
+		if token != "Token tok" {
+			t.Errorf("Authorization = %q, want %q", token, "Token tok")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("stream never connected")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for ladderPub.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if ladderPub.count() == 0 {
+		t.Fatal("expected a published price ladder")
+	}
+
+	ladder, ok := stream.Latest("client-1", "btc:usd")
+	if !ok {
+		t.Fatal("expected a cached ladder")
+	}
+	if ladder.Instrument != "BTCUSD.SPOT" || ladder.InstrumentPair != "btc:usd" {
+		t.Errorf("unexpected ladder instrument %q / %q", ladder.Instrument, ladder.InstrumentPair)
+	}
+
+	pub := &mockPublisher{}
+	svc := b2c2.NewService(b2c2.NewClient(nil), resolver, pub)
+	svc.SetPriceStream(stream)
+
+	cmd := &b2c2.SubmitRequestForQuoteCommand{
+		ID:             "rfq-1",
+		InstrumentPair: "btc:usd",
+		Quantity:       "3",
+		Side:           "BUY",
+		ClientID:       "client-1",
+	}
+	if err := svc.HandleRFQCommand(context.Background(), cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pub.quoteEvents) != 1 {
+		t.Fatalf("expected 1 quote event, got %d", len(pub.quoteEvents))
+	}
+	event := pub.quoteEvents[0]
+	if event.Price != "100.5" {
+		t.Errorf("price = %q, want 100.5", event.Price)
+	}
+	if !strings.HasPrefix(event.ExternalQuoteID, "ladder:BTCUSD.SPOT:") {
+		t.Errorf("unexpected externalQuoteId %q", event.ExternalQuoteID)
+	}
+
+	// A second RFQ against the same ladder level gets its own quote ID.
+	if err := svc.HandleRFQCommand(context.Background(), cmd); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pub.quoteEvents) != 2 || pub.quoteEvents[1].ExternalQuoteID == event.ExternalQuoteID {
+		t.Errorf("expected distinct ladder quote IDs, got %+v", pub.quoteEvents)
+	}
+
+	// Ladder answers run the same size validation as B2C2 RFQs.
+	bad := *cmd
+	bad.Quantity = "-1"
+	if err := svc.HandleRFQCommand(context.Background(), &bad); err == nil {
+		t.Error("expected a negative quantity to be rejected")
+	}
+	if len(pub.quoteEvents) != 2 {
+		t.Errorf("expected no quote for an invalid RFQ, got %d events", len(pub.quoteEvents))
+	}
+
+	raw, err := json.Marshal(ladder)
+	if err != nil {
+		t.Fatalf("marshal ladder: %v", err)
+	}
+	if !strings.Contains(string(raw), `"instrumentPair":"btc:usd"`) {
+		t.Errorf("unexpected ladder JSON %s", raw)
+	}
+}
+
+func TestHandleOrderCommand_RejectsExpiredLadderQuote(t *testing.T) {
+	ladder := &b2c2.PriceLadder{Instrument: "BTCUSD.SPOT", Timestamp: time.Now().Add(-time.Minute)}
+	rfq := &b2c2.SubmitRequestForQuoteCommand{ID: "rfq-1", InstrumentPair: "btc:usd", Side: "BUY", Quantity: "1"}
+	expired := b2c2.FromPriceLadder(ladder, b2c2.LadderLevel{Quantity: "1", Price: "100"}, rfq, time.Second)
+	ladder.Timestamp = time.Now()
+	fresh := b2c2.FromPriceLadder(ladder, b2c2.LadderLevel{Quantity: "1", Price: "100"}, rfq, time.Minute)
+
+	tests := []struct {
+		name    string
+		quoteID string
+		wantErr error
+	}{
+		{"fresh", fresh.ExternalQuoteID, nil},
+		{"expired", expired.ExternalQuoteID, b2c2.ErrLadderQuoteExpired},
+		{"malformed", "ladder:BTCUSD.SPOT:123", b2c2.ErrLadderQuoteExpired},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newHistoryServer(t, nil)
+			pub := &mockPublisher{}
+			svc := b2c2.NewService(b2c2.NewClient(nil), &mockResolver{cfg: &b2c2.B2C2ClientConfig{BaseURL: srv.URL}}, pub)
+
+			err := svc.HandleOrderCommand(context.Background(), &b2c2.SubmitOrderCommand{
+				OrderID: "ord-o1", ClientOrderID: "o1", ClientID: "client-1",
+				InstrumentPair: "btc:usd", Side: "BUY", Quantity: "1", Price: "100", RequestForQuoteID: tc.quoteID,
+			})
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			wantFills := 1
+			if tc.wantErr != nil {
+				wantFills = 0
+			}
+			if len(pub.fillEvents) != wantFills {
+				t.Errorf("expected %d fill events, got %d", wantFills, len(pub.fillEvents))
+			}
+		})
+	}
+}
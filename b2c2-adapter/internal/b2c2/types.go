@@ -1,6 +1,12 @@
 package b2c2
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
 
 //
 // ────────────────────────────────────────────────────────────
@@ -10,10 +16,11 @@ import "context"
 
 // B2C2ClientConfig holds per-client B2C2 API configuration.
 // Secret path: {env}/{clientId}/b2c2
-// Secret JSON format: {"api_token":"...","base_url":"https://api.b2c2.net"}
+// Secret JSON format: {"api_token":"...","base_url":"https://api.b2c2.net","ws_url":"wss://socket.b2c2.net/quotes"}
 type B2C2ClientConfig struct {
 	APIToken string // Static API token for Authorization header
 	BaseURL  string // B2C2 API base URL (prod or UAT)
+	WSURL    string // B2C2 streaming price WebSocket URL (prod or UAT)
 }
 
 // ConfigResolver resolves per-client B2C2 configuration.
@@ -109,6 +116,129 @@ type Instrument struct {
 	MinQuantity        string `json:"min_quantity"`
 }
 
+//
+// ────────────────────────────────────────────────────────────
+//   B2C2 API: CFD Positions / Margin
+// ────────────────────────────────────────────────────────────
+//
+
+// CFDPosition is an open CFD position from GET /cfd/open_positions/.
+type CFDPosition struct {
+	PositionID    string `json:"id"`
+	Instrument    string `json:"instrument"` // e.g. "BTCUSD.CFD"
+	Side          string `json:"side"`
+	Quantity      string `json:"quantity"`
+	OpenPrice     string `json:"open_price"`
+	MarginUsage   string `json:"margin_usage"`
+	UnrealisedPnL string `json:"unrealised_pnl"`
+	Created       string `json:"created"`
+}
+
+// MarginRequirements is the account-level margin summary from GET /margin_requirements/.
+type MarginRequirements struct {
+	Currency          string `json:"currency"`
+	Equity            string `json:"equity"`
+	MarginRequirement string `json:"margin_requirement"`
+	MarginUsage       string `json:"margin_usage"`
+	RiskExposure      string `json:"risk_exposure"`
+	MaxRiskExposure   string `json:"max_risk_exposure"`
+}
+
+//
+// ────────────────────────────────────────────────────────────
+//   B2C2 API: Trade / Ledger History
+// ────────────────────────────────────────────────────────────
+//
+
+// HistoryQuery filters and pages the /trade/ and /ledger/ endpoints.
+// Zero values are omitted from the query string.
+type HistoryQuery struct {
+	Since      time.Time // created__gte
+	Until      time.Time // created__lt
+	Instrument string    // trades only, e.g. "BTCUSD.SPOT"
+	Type       string    // ledger only, e.g. "trade", "transfer"
+	Limit      int
+	Offset     int
+}
+
+// Trade is a single executed trade from GET /trade/.
+type Trade struct {
+	TradeID       string `json:"trade_id"`
+	OrderID       string `json:"order"`
+	RFQID         string `json:"rfq_id"`
+	Instrument    string `json:"instrument"`
+	Side          string `json:"side"`
+	Quantity      string `json:"quantity"`
+	Price         string `json:"price"`
+	Created       string `json:"created"`
+	Origin        string `json:"origin"`
+	ExecutingUnit string `json:"executing_unit,omitempty"`
+}
+
+// LedgerEntry is a single balance movement from GET /ledger/.
+type LedgerEntry struct {
+	TransactionID string `json:"transaction_id"`
+	Created       string `json:"created"`
+	Reference     string `json:"reference"` // trade ID for trade legs
+	Currency      string `json:"currency"`
+	Amount        string `json:"amount"`
+	Type          string `json:"type"` // "trade", "transfer", "funding", ...
+	Group         string `json:"group"`
+}
+
+//
+// ────────────────────────────────────────────────────────────
+//   B2C2 WebSocket: Streaming Price Ladder
+// ────────────────────────────────────────────────────────────
+//
+
+// WSSubscribeRequest subscribes to (or unsubscribes from) the price ladder of an instrument.
+// Levels are the quantities B2C2 should price, e.g. ["1", "5", "10"].
+type WSSubscribeRequest struct {
+	Event      string   `json:"event"` // "subscribe" | "unsubscribe"
+	Instrument string   `json:"instrument"`
+	Levels     []string `json:"levels"`
+	Tag        string   `json:"tag,omitempty"`
+}
+
+// WSMessage is the common envelope of every frame B2C2 sends on the quotes socket.
+// Levels is an array of quantities on subscribe acknowledgements and a
+// WSPriceLevels object on price frames, so it is decoded lazily.
+type WSMessage struct {
+	Event        string          `json:"event"` // "tradable_instruments" | "subscribe" | "unsubscribe" | "price"
+	Success      *bool           `json:"success,omitempty"`
+	Instrument   string          `json:"instrument,omitempty"`
+	Levels       json.RawMessage `json:"levels,omitempty"`
+	Timestamp    int64           `json:"timestamp,omitempty"` // unix milliseconds
+	Tag          string          `json:"tag,omitempty"`
+	ErrorCode    int             `json:"error_code,omitempty"`
+	ErrorMessage string          `json:"error_message,omitempty"`
+}
+
+// WSPriceLevels holds the buy and sell sides of a price frame.
+type WSPriceLevels struct {
+	Buy  []LadderLevel `json:"buy"`
+	Sell []LadderLevel `json:"sell"`
+}
+
+// LadderLevel is a single quantity/price point of a price ladder.
+type LadderLevel struct {
+	Quantity string `json:"quantity"`
+	Price    string `json:"price"`
+}
+
+// PriceLadder is the latest streamed ladder for one client and instrument.
+// It is published to evt.md.price_ladder.v1.B2C2.<instrument>.
+type PriceLadder struct {
+	ClientID       string        `json:"clientId"`
+	Instrument     string        `json:"instrument"`     // B2C2 format, e.g. "BTCUSD.SPOT"
+	InstrumentPair string        `json:"instrumentPair"` // canonical, e.g. "btc:usd"
+	Buy            []LadderLevel `json:"buy"`
+	Sell           []LadderLevel `json:"sell"`
+	Timestamp      time.Time     `json:"timestamp"`
+	Provider       string        `json:"provider"`
+}
+
 //
 // ────────────────────────────────────────────────────────────
 //   B2C2 API: Error Response
@@ -132,6 +262,8 @@ type SubmitRequestForQuoteCommand struct {
 	InstrumentPair string `json:"instrumentPair"` // canonical e.g. "usd:btc"
 	Quantity       string `json:"quantity"`
 	Side           string `json:"side"`
+	Settlement     string `json:"settlement,omitempty"` // model.SettlementType; empty means SPOT
+	Tenor          string `json:"tenor,omitempty"`      // forwards only, e.g. "1M"
 	IssuerId       string `json:"issuerId,omitempty"`
 	ClientID       string `json:"clientId,omitempty"`
 	Provider       string `json:"provider,omitempty"`
@@ -152,6 +284,8 @@ type SubmitOrderCommand struct {
 	Quantity          string `json:"quantity"`
 	Price             string `json:"price"`
 	Side              string `json:"side"`
+	Settlement        string `json:"settlement,omitempty"` // model.SettlementType; empty means SPOT
+	Tenor             string `json:"tenor,omitempty"`      // forwards only, e.g. "1M"
 	ClientOrderID     string `json:"clientOrderId"`
 	RequestForQuoteID string `json:"requestForQuoteId"`
 	ClientID          string `json:"clientId"`
@@ -218,9 +352,26 @@ type OrderCanceledEvent struct {
 // ────────────────────────────────────────────────────────────
 //
 
+// JetStream subjects of the terminal trade events.
+const (
+	SubjectFilled    = "evt.trade.filled.v1.B2C2"
+	SubjectCancelled = "evt.trade.cancelled.v1.B2C2"
+)
+
 // Publisher publishes canonical events to RabbitMQ.
 type Publisher interface {
 	PublishQuoteEvent(ctx context.Context, event *QuoteArrivedEvent) error
 	PublishFillEvent(ctx context.Context, event *FillArrivedEvent) error
 	PublishCancelEvent(ctx context.Context, event *OrderCanceledEvent) error
 }
+
+// TradeSync records a terminal trade in activity.t_order and queues the event
+// announcing it in one transaction. *legacy.TradeSyncWriter satisfies it.
+type TradeSync interface {
+	SyncTradeWithEvent(ctx context.Context, trade *model.TradeConfirmation, subject, msgID string, payload any) error
+}
+
+// LadderPublisher publishes streamed price ladders as market data.
+type LadderPublisher interface {
+	PublishPriceLadder(ctx context.Context, ladder *PriceLadder) error
+}
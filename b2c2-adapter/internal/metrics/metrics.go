@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// B2C2ReconciliationRuns counts reconciliation runs per client by result (ok, discrepancies, error).
+	B2C2ReconciliationRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "b2c2_reconciliation_runs_total",
+			Help: "Number of B2C2 trade reconciliation runs by client ID and result.",
+		},
+		[]string{"client_id", "result"},
+	)
+
+	// B2C2ReconciliationDiscrepancies counts discrepancies found between B2C2 trades and published fills.
+	B2C2ReconciliationDiscrepancies = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "b2c2_reconciliation_discrepancies_total",
+			Help: "Number of discrepancies between B2C2 trades and published fills, by client ID and kind.",
+		},
+		[]string{"client_id", "kind"},
+	)
+
+	// B2C2ReconciliationLastRun records the time of the last completed reconciliation per client.
+	B2C2ReconciliationLastRun = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "b2c2_reconciliation_last_run_timestamp_seconds",
+			Help: "Unix time of the last completed B2C2 reconciliation run by client ID.",
+		},
+		[]string{"client_id"},
+	)
+)
+
+// IncReconciliationRun increments the reconciliation run counter.
+func IncReconciliationRun(clientID, result string) {
+	B2C2ReconciliationRuns.WithLabelValues(clientID, result).Inc()
+}
+
+// IncDiscrepancy increments the discrepancy counter for a client and kind.
+func IncDiscrepancy(clientID, kind string) {
+	B2C2ReconciliationDiscrepancies.WithLabelValues(clientID, kind).Inc()
+}
+
+// SetLastReconciliation records when a client was last reconciled.
+func SetLastReconciliation(clientID string, t time.Time) {
+	B2C2ReconciliationLastRun.WithLabelValues(clientID).Set(float64(t.Unix()))
+}
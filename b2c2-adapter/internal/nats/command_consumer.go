@@ -9,6 +9,8 @@ import (
 	"github.com/nats-io/nats.go"
 
 	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+	"github.com/Checker-Finance/adapters/internal/metrics"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 )
 
 // B2C2Service defines the service interface consumed by the NATS command consumer.
@@ -34,14 +36,22 @@ func NewCommandConsumer(nc *nats.Conn, service B2C2Service) *CommandConsumer {
 // Handlers inherit ctx so they respect shutdown cancellation.
 func (c *CommandConsumer) Subscribe(ctx context.Context, rfqSubject, orderSubject, cancelSubject string) error {
 	rfqSub, err := c.nc.Subscribe(rfqSubject, func(msg *nats.Msg) {
+		start := time.Now()
+		msgCtx, span := tracing.StartConsumer(ctx, msg)
+		var err error
+		defer func() {
+			tracing.End(span, err)
+			metrics.ObserveCommand("b2c2", "rfq", start, err)
+		}()
+
 		var cmd b2c2.SubmitRequestForQuoteCommand
-		if err := json.Unmarshal(msg.Data, &cmd); err != nil {
+		if err = json.Unmarshal(msg.Data, &cmd); err != nil {
 			slog.Error("b2c2.consumer.rfq_unmarshal_failed", "error", err)
 			return
 		}
-		msgCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		msgCtx, cancel := context.WithTimeout(msgCtx, 30*time.Second)
 		defer cancel()
-		if err := c.service.HandleRFQCommand(msgCtx, &cmd); err != nil {
+		if err = c.service.HandleRFQCommand(msgCtx, &cmd); err != nil {
 			slog.Error("b2c2.consumer.rfq_handle_failed", "error", err)
 		}
 	})
@@ -51,14 +61,22 @@ func (c *CommandConsumer) Subscribe(ctx context.Context, rfqSubject, orderSubjec
 	c.subs = append(c.subs, rfqSub)
 
 	orderSub, err := c.nc.Subscribe(orderSubject, func(msg *nats.Msg) {
+		start := time.Now()
+		msgCtx, span := tracing.StartConsumer(ctx, msg)
+		var err error
+		defer func() {
+			tracing.End(span, err)
+			metrics.ObserveCommand("b2c2", "order", start, err)
+		}()
+
 		var cmd b2c2.SubmitOrderCommand
-		if err := json.Unmarshal(msg.Data, &cmd); err != nil {
+		if err = json.Unmarshal(msg.Data, &cmd); err != nil {
 			slog.Error("b2c2.consumer.order_unmarshal_failed", "error", err)
 			return
 		}
-		msgCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		msgCtx, cancel := context.WithTimeout(msgCtx, 30*time.Second)
 		defer cancel()
-		if err := c.service.HandleOrderCommand(msgCtx, &cmd); err != nil {
+		if err = c.service.HandleOrderCommand(msgCtx, &cmd); err != nil {
 			slog.Error("b2c2.consumer.order_handle_failed", "error", err)
 		}
 	})
@@ -68,14 +86,22 @@ func (c *CommandConsumer) Subscribe(ctx context.Context, rfqSubject, orderSubjec
 	c.subs = append(c.subs, orderSub)
 
 	cancelSub, err := c.nc.Subscribe(cancelSubject, func(msg *nats.Msg) {
+		start := time.Now()
+		msgCtx, span := tracing.StartConsumer(ctx, msg)
+		var err error
+		defer func() {
+			tracing.End(span, err)
+			metrics.ObserveCommand("b2c2", "cancel", start, err)
+		}()
+
 		var cmd b2c2.CancelOrderCommand
-		if err := json.Unmarshal(msg.Data, &cmd); err != nil {
+		if err = json.Unmarshal(msg.Data, &cmd); err != nil {
 			slog.Error("b2c2.consumer.cancel_unmarshal_failed", "error", err)
 			return
 		}
-		msgCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		msgCtx, cancel := context.WithTimeout(msgCtx, 5*time.Second)
 		defer cancel()
-		if err := c.service.HandleCancelCommand(msgCtx, &cmd); err != nil {
+		if err = c.service.HandleCancelCommand(msgCtx, &cmd); err != nil {
 			slog.Error("b2c2.consumer.cancel_handle_failed", "error", err)
 		}
 	})
@@ -0,0 +1,35 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/Checker-Finance/adapters/b2c2-adapter/internal/b2c2"
+)
+
+// subjectPriceLadderPrefix is suffixed with the B2C2 instrument, e.g.
+// evt.md.price_ladder.v1.B2C2.BTCUSD.SPOT.
+const subjectPriceLadderPrefix = "evt.md.price_ladder.v1.B2C2."
+
+// LadderPublisher implements b2c2.LadderPublisher over core NATS. Market data is
+// high-rate and superseded by the next frame, so it bypasses JetStream.
+type LadderPublisher struct {
+	nc *nats.Conn
+}
+
+// NewLadderPublisher creates a LadderPublisher on the given connection.
+func NewLadderPublisher(nc *nats.Conn) *LadderPublisher {
+	return &LadderPublisher{nc: nc}
+}
+
+// PublishPriceLadder publishes a price ladder snapshot.
+func (p *LadderPublisher) PublishPriceLadder(_ context.Context, ladder *b2c2.PriceLadder) error {
+	data, err := json.Marshal(ladder)
+	if err != nil {
+		return fmt.Errorf("marshal price ladder: %w", err)
+	}
+	return p.nc.Publish(subjectPriceLadderPrefix+ladder.Instrument, data)
+}
@@ -8,11 +8,7 @@ import (
 	"github.com/Checker-Finance/adapters/internal/publisher"
 )
 
-const (
-	subjectQuoteReady = "evt.trade.quote_ready.v1.B2C2"
-	subjectFilled     = "evt.trade.filled.v1.B2C2"
-	subjectCancelled  = "evt.trade.cancelled.v1.B2C2"
-)
+const subjectQuoteReady = "evt.trade.quote_ready.v1.B2C2"
 
 // Publisher implements b2c2.Publisher using NATS JetStream.
 type Publisher struct {
@@ -39,7 +35,8 @@ func (p *Publisher) PublishFillEvent(ctx context.Context, event *b2c2.FillArrive
 		"orderId", event.OrderID,
 		"externalOrderId", event.ExternalOrderID,
 	)
-	return p.pub.Publish(ctx, subjectFilled, event)
+	return p.pub.Publish(ctx, b2c2.SubjectFilled, event,
+		publisher.WithMsgID(b2c2.FillEventID(event)))
 }
 
 // PublishCancelEvent publishes an OrderCanceledEvent to NATS.
@@ -48,5 +45,6 @@ func (p *Publisher) PublishCancelEvent(ctx context.Context, event *b2c2.OrderCan
 		"orderId", event.OrderID,
 		"reason", event.Reason,
 	)
-	return p.pub.Publish(ctx, subjectCancelled, event)
+	return p.pub.Publish(ctx, b2c2.SubjectCancelled, event,
+		publisher.WithMsgID(b2c2.CancelEventID(event)))
 }
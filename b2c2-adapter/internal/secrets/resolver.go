@@ -14,7 +14,7 @@ import (
 // It is a thin wrapper over the generic intsecrets.AWSResolver[b2c2.B2C2ClientConfig].
 //
 // Secret naming convention: {env}/{clientID}/b2c2
-// Secret JSON format:       {"api_token":"...","base_url":"https://api.b2c2.net"}
+// Secret JSON format:       {"api_token":"...","base_url":"https://api.b2c2.net","ws_url":"wss://socket.b2c2.net/quotes"}
 type AWSResolver struct {
 	inner *intsecrets.AWSResolver[b2c2.B2C2ClientConfig]
 	cfg   *config.Config
@@ -49,6 +49,7 @@ func (r *AWSResolver) parse(m map[string]string) (b2c2.B2C2ClientConfig, error)
 	cfg := b2c2.B2C2ClientConfig{
 		APIToken: m["api_token"],
 		BaseURL:  m["base_url"],
+		WSURL:    m["ws_url"],
 	}
 	if cfg.APIToken == "" {
 		return b2c2.B2C2ClientConfig{}, fmt.Errorf("missing required field 'api_token'")
@@ -57,5 +58,8 @@ func (r *AWSResolver) parse(m map[string]string) (b2c2.B2C2ClientConfig, error)
 	if cfg.BaseURL == "" {
 		cfg.BaseURL = r.cfg.DefaultBaseURL
 	}
+	if cfg.WSURL == "" {
+		cfg.WSURL = r.cfg.DefaultWSURL
+	}
 	return cfg, nil
 }
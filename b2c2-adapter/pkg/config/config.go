@@ -22,9 +22,28 @@ type Config struct {
 	InboundOrderSubject  string
 	InboundCancelSubject string
 	DefaultBaseURL       string
+	DefaultWSURL         string
+	StreamInstruments    []string
+	StreamLevels         []string
+	StreamMaxAge         time.Duration
+	RFQFromStream        bool
+	InstrumentsTTL       time.Duration
+	ReconInterval        time.Duration
+	ReconLookback        time.Duration
+	ReconGrace           time.Duration
 	CacheTTL             time.Duration
 	CleanupFreq          time.Duration
 	HealthPort           int
+
+	// Postgres (optional): published fills are kept in activity.t_order
+	DatabaseURL string
+	PGMaxConns  int
+
+	// JetStream publishing
+	NATSStream            string        // Stream that must capture the adapter's trade events
+	NATSDedupWindow       time.Duration // Duplicate window enforced on NATSStream
+	NATSPublishAsync      bool          // Publish without waiting for each ack
+	NATSPublishMaxPending int           // Async publishes awaiting an ack before publishing blocks
 }
 
 // Load loads configuration from environment variables, then overlays any values
@@ -42,9 +61,26 @@ func Load(ctx context.Context) *Config {
 		InboundOrderSubject:  pkgconfig.GetEnv("B2C2_INBOUND_ORDER_SUBJECT", "cmd.lp.trade_execute.v1.B2C2"),
 		InboundCancelSubject: pkgconfig.GetEnv("B2C2_INBOUND_CANCEL_SUBJECT", "cmd.lp.trade_cancel.v1.B2C2"),
 		DefaultBaseURL:       pkgconfig.GetEnv("B2C2_DEFAULT_BASE_URL", "https://api.b2c2.net"),
+		DefaultWSURL:         pkgconfig.GetEnv("B2C2_DEFAULT_WS_URL", "wss://socket.b2c2.net/quotes"),
+		StreamInstruments:    pkgconfig.GetEnvList("B2C2_STREAM_INSTRUMENTS", nil),
+		StreamLevels:         pkgconfig.GetEnvList("B2C2_STREAM_LEVELS", []string{"1", "5", "10"}),
+		StreamMaxAge:         pkgconfig.GetEnvDuration("B2C2_STREAM_MAX_AGE", 2*time.Second),
+		RFQFromStream:        pkgconfig.GetEnvBool("B2C2_RFQ_FROM_STREAM", false),
+		InstrumentsTTL:       pkgconfig.GetEnvDuration("B2C2_INSTRUMENTS_TTL", 10*time.Minute),
+		ReconInterval:        pkgconfig.GetEnvDuration("B2C2_RECON_INTERVAL", 10*time.Minute),
+		ReconLookback:        pkgconfig.GetEnvDuration("B2C2_RECON_LOOKBACK", 24*time.Hour),
+		ReconGrace:           pkgconfig.GetEnvDuration("B2C2_RECON_GRACE", time.Minute),
 		CacheTTL:             pkgconfig.GetEnvDuration("CACHE_TTL", 30*time.Minute),
 		CleanupFreq:          pkgconfig.GetEnvDuration("CACHE_CLEANUP_FREQ", 10*time.Minute),
 		HealthPort:           pkgconfig.GetEnvInt("HEALTH_PORT", 9050),
+
+		DatabaseURL: pkgconfig.GetEnv("DATABASE_URL", ""),
+		PGMaxConns:  pkgconfig.GetEnvInt("PG_MAX_CONNS", 4),
+
+		NATSStream:            pkgconfig.GetEnv("NATS_STREAM", "B2C2_EVENTS"),
+		NATSDedupWindow:       pkgconfig.GetEnvDuration("NATS_DEDUP_WINDOW", 2*time.Minute),
+		NATSPublishAsync:      pkgconfig.GetEnvBool("NATS_PUBLISH_ASYNC", false),
+		NATSPublishMaxPending: pkgconfig.GetEnvInt("NATS_PUBLISH_MAX_PENDING", 256),
 	}
 
 	secretPath := fmt.Sprintf("%s/%s", cfg.Env, cfg.ServiceName)
@@ -67,4 +103,7 @@ func (c *Config) applyServiceSecret(m map[string]string) {
 	if v := m["log_level"]; v != "" {
 		c.LogLevel = v
 	}
+	if v := m["database_url"]; v != "" {
+		c.DatabaseURL = v
+	}
 }
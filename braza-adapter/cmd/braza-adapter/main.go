@@ -7,12 +7,16 @@ import (
 	"os"
 	"os/signal"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/Checker-Finance/adapters/braza-adapter/internal/api"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	sharedauth "github.com/Checker-Finance/adapters/internal/auth"
 	"github.com/Checker-Finance/adapters/internal/jobs"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/pkg/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/nats-io/nats.go"
@@ -24,6 +28,7 @@ import (
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/rate"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/logger"
 	pkgsecrets "github.com/Checker-Finance/adapters/pkg/secrets"
 )
@@ -35,6 +40,13 @@ func main() {
 	cfg := config.Load(ctx)
 
 	logger.Init(cfg.ServiceName, cfg.Env, cfg.LogLevel)
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.ServiceName)
+	if err != nil {
+		slog.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("starting [braza-adapter]...")
 	slog.Info("connection to DSN", "dsn", utils.MaskDSN(cfg.DatabaseURL))
 	// --- Connect to NATS ---
@@ -64,16 +76,29 @@ func main() {
 	)
 
 	// --- Auth Manager (handles Braza JWTs) ---
-	authMgr := auth.NewManager(
-		awsProvider, cacheAdapter, cfg.BrazaBaseURL,
-	)
+	authMgr := auth.NewManager(awsProvider, cfg.BrazaBaseURL)
 
 	// --- Publisher ---
-	pub, err := publisher.New(nc, "evt.braza", "BRAZA_EVENTS")
+	pub, err := publisher.NewWithOptions(nc, "evt.braza", "BRAZA_EVENTS", publisher.Options{
+		Async:      cfg.NATSPublishAsync,
+		MaxPending: cfg.NATSPublishMaxPending,
+	})
 	if err != nil {
 		slog.Error("failed to init publisher", "error", err)
 		os.Exit(1)
 	}
+	if err := pub.EnsureStreams(
+		publisher.StreamSpec{
+			Name:       cfg.NATSStream,
+			Subjects:   []string{"evt.trade.*.v1.BRAZA"},
+			Duplicates: cfg.NATSDedupWindow,
+		},
+		// Quote, balance and recon subjects may live in other streams, some shared by several adapters
+		publisher.StreamSpec{Subjects: []string{braza.SubjectBalanceUpdate, jobs.SubjectSummaryRefreshed}},
+	); err != nil {
+		slog.Error("failed to verify jetstream streams", "error", err)
+		os.Exit(1)
+	}
 
 	// --- Rate limiter ---
 	rateMgr := rate.NewManager(rate.Config{
@@ -89,6 +114,14 @@ func main() {
 		os.Exit(1)
 	}
 	defer st.Close() //nolint:errcheck
+	if cfg.TokenPersist {
+		tokenStore, err := sharedauth.NewEncryptedStore(st, cfg.TokenEncryptionKey)
+		if err != nil {
+			slog.Error("failed to init token store", "error", err)
+			os.Exit(1)
+		}
+		authMgr.SetStore(tokenStore)
+	}
 
 	productResolver := braza.NewProductResolver(cfg.BrazaBaseURL)
 
@@ -100,6 +133,13 @@ func main() {
 	go rfqSweeper.Start(ctx)
 
 	tradeSyncWriter := legacy.NewTradeSyncWriter(st.(*store.HybridStore).PG, "braza-adapter")
+
+	// --- Outbox relay: publishes terminal trade events queued with their t_order rows ---
+	outboxRelay := outbox.NewRelay(outbox.NewPGStore(st.(*store.HybridStore).PG, "braza-adapter"), pub, outbox.RelayConfig{})
+	go outboxRelay.Start(ctx)
+
+	// --- Audit trail: append-only record of each RFQ from request to final status ---
+	auditStore := audit.NewPGStore(st.(*store.HybridStore).PG)
 	// --- Braza service (core adapter logic) ---
 	brazaSvc := braza.NewService(
 		ctx,
@@ -116,6 +156,7 @@ func main() {
 	)
 
 	app := fiber.New()
+	app.Use(tracing.FiberMiddleware())
 	h := &api.Handler{
 		Service: brazaSvc,
 		Store:   st,
@@ -129,16 +170,6 @@ func main() {
 		TradeSync: tradeSyncWriter,
 	}
 
-	api.RegisterRoutes(app, nc, st, h, ph, oh)
-
-	go func() {
-		slog.Info("HTTP API listening", "port", cfg.Port)
-		if err := app.Listen(fmt.Sprintf(":%d", cfg.Port)); err != nil {
-			slog.Error("fiber.listen_failed", "error", err)
-			os.Exit(1)
-		}
-	}()
-
 	// --- Create Poller (handles balances + trade tracking) ---
 	poller := braza.NewPoller(
 		*cfg,
@@ -153,6 +184,49 @@ func main() {
 	)
 
 	brazaSvc.SetPoller(poller)
+	auditRecorder := audit.NewRecorder(auditStore, "braza")
+	brazaSvc.SetAuditRecorder(auditRecorder)
+
+	// --- Per-client balance + product sync, following discovered clients ---
+	productSyncer := braza.NewProductSyncer(st, brazaSvc.Client(), resolver, cfg.ProductSyncFreq)
+	watcher := braza.NewClientWatcher(
+		resolver,
+		parseClientIDs(cfg.ClientBalancesIDs),
+		cfg.DiscoveryInterval,
+		func(clientCtx context.Context, clientID string) {
+			// The poller can return on its own (manual stop), so the product
+			// syncer gets a context that ends with it and is joined before the
+			// client's session is forgotten.
+			clientCtx, cancelClient := context.WithCancel(clientCtx)
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				productSyncer.Start(clientCtx, clientID, cfg.Venue)
+			}()
+			poller.RunClient(clientCtx, clientID)
+			cancelClient()
+			wg.Wait()
+			authMgr.ForgetClient(clientID)
+		},
+	)
+
+	auditHandler := audit.NewHandler(auditStore, "braza")
+	api.RegisterRoutes(app, nc, st, h, ph, oh, watcher, auditHandler)
+
+	go func() {
+		slog.Info("HTTP API listening", "port", cfg.Port)
+		if err := app.Listen(fmt.Sprintf(":%d", cfg.Port)); err != nil {
+			slog.Error("fiber.listen_failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	// --- Reconcile executions Braza accepted without an order ID ---
+	brazaSvc.LoadUnresolved(ctx)
+	if cfg.ReconcileInterval > 0 {
+		go brazaSvc.StartReconciler(ctx, cfg.ReconcileInterval)
+	}
 
 	refresher := jobs.NewSummaryRefresher(
 		nc,
@@ -163,14 +237,8 @@ func main() {
 
 	go refresher.Start(ctx)
 
-	// --- Define tenants/clients/desks ---
-	clientsBalances := parseClientIDs(cfg.ClientBalancesIDs)
-	if len(clientsBalances) == 0 {
-		slog.Warn("no client IDs configured; skipping balance poller startup")
-	} else {
-		// --- Start periodic poller ---
-		go poller.Start(ctx, clientsBalances)
-	}
+	go watcher.Start(ctx)
+	go authMgr.RefreshAllTokens(ctx)
 
 	// --- Main process stays alive until interrupted ---
 	slog.Info("[braza-adapter] running",
@@ -184,6 +252,12 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	app.ShutdownWithContext(shutdownCtx) //nolint:errcheck
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		slog.Warn("audit.flush_incomplete", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("tracing.shutdown_failed", "error", err)
+	}
 }
 
 // parseClientIDs safely splits and trims a comma-separated list of client IDs.
@@ -55,9 +55,13 @@ func (h *Handler) CreateRFQHandler(c *fiber.Ctx) error {
 		ExpireAt: time.Now().Unix(),
 	}
 
-	rfq, err := h.Service.CreateRFQ(c.Context(), r)
+	rfq, err := h.Service.CreateRFQ(c.UserContext(), r)
 	if err != nil {
 		res.ErrorMsg = err.Error()
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			res.ErrorCode = verr.Code
+			res.ErrorMsg = verr.Message
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(res)
 	}
 
@@ -83,7 +87,7 @@ func (h *Handler) ExecuteRFQHandler(c *fiber.Ctx) error {
 	}
 
 	slog.Info("attempting to execute quote", "client", req.ClientID, "quoteID", req.QuoteID)
-	trade, err := h.Service.ExecuteRFQ(c.Context(), req.ClientID, req.QuoteID)
+	trade, err := h.Service.ExecuteRFQ(c.UserContext(), req.ClientID, req.QuoteID)
 	if err != nil {
 		res.ErrorMsg = err.Error()
 		return c.Status(fiber.StatusBadRequest).JSON(res)
@@ -20,7 +20,7 @@ func NewProductsHandler(svc *braza.Service, cfg *config.Config) *ProductsHandler
 
 // GET /api/v1/products
 func (h *ProductsHandler) ListProducts(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(c.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 3*time.Second)
 	defer cancel()
 
 	venue := c.Query("venue", "braza")
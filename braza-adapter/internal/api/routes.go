@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/Checker-Finance/adapters/braza-adapter/internal/braza"
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/store"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
@@ -11,7 +13,12 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store, h *Handler, ph *ProductsHandler, oh *OrderResolveHandler) {
+// ClientLister reports the clients the adapter is currently serving.
+type ClientLister interface {
+	ActiveClients() []braza.ActiveClient
+}
+
+func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store, h *Handler, ph *ProductsHandler, oh *OrderResolveHandler, clients ClientLister, auditHandler *audit.Handler) {
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -52,4 +59,15 @@ func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store, h *Handler, p
 	v1.Post("/orders", h.ExecuteRFQHandler)
 	v1.Post("/resolve-order/:quoteId", oh.ResolveOrder)
 	v1.Get("/products", ph.ListProducts)
+	v1.Get("/audit/:id", auditHandler.Timeline)
+
+	if clients != nil {
+		v1.Get("/admin/clients", func(c *fiber.Ctx) error {
+			active := clients.ActiveClients()
+			return c.JSON(fiber.Map{
+				"count":   len(active),
+				"clients": active,
+			})
+		})
+	}
 }
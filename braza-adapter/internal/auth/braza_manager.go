@@ -8,10 +8,12 @@ import (
 	"log/slog"
 	"net/http"
 	"time"
+
+	sharedauth "github.com/Checker-Finance/adapters/internal/auth"
 )
 
-// BrazaManager handles JWT authentication and refresh lifecycle for the Braza API.
-// It is called by the multi-tenant auth.Manager for a specific tenant/client/venue.
+// BrazaManager performs JWT login and refresh against the Braza API. Caching
+// is left to the multi-tenant auth.Manager.
 type BrazaManager struct {
 	baseURL string
 	client  *http.Client
@@ -25,43 +27,23 @@ func NewBrazaManager(baseURL string) *BrazaManager {
 	}
 }
 
-// GetValidToken retrieves a valid access token (from cache, refresh, or login).
-func (m *BrazaManager) GetValidToken(
-	ctx context.Context,
-	cache *CacheAdapter,
-	clientID string,
-	creds Credentials,
-) (string, error) {
-	key := fmt.Sprintf("braza:token:%s:", clientID)
-
-	// 1. Attempt to reuse cached token if valid
-	val, _ := cache.Get(ctx, key)
-	if val != "" {
-		var tb TokenBundle
-		if err := json.Unmarshal([]byte(val), &tb); err == nil {
-			if time.Now().Unix() < tb.Exp-300 {
-				// Still valid for >5min
-				return tb.AccessToken, nil
-			}
-			// Try refresh before expiring
-			if tb.RefreshToken != "" {
-				if newTok, err := m.refresh(ctx, tb.RefreshToken); err == nil {
-					m.saveToken(ctx, cache, key, newTok)
-					return newTok.AccessToken, nil
-				}
-				slog.Warn("braza.refresh_failed", "error", err)
-			}
+// fetch obtains a new token for creds, using current's refresh token when it
+// has one and falling back to a fresh login.
+func (m *BrazaManager) fetch(ctx context.Context, creds Credentials, current sharedauth.Token) (sharedauth.Token, error) {
+	if current.RefreshToken != "" {
+		tb, err := m.refresh(ctx, current.RefreshToken)
+		if err == nil {
+			return tb.token(), nil
 		}
+		slog.Warn("braza.refresh_failed", "error", err)
 	}
 
-	// 2. Fallback to login
-	newTok, err := m.login(ctx, creds)
+	tb, err := m.login(ctx, creds)
 	if err != nil {
 		slog.Error("braza.login_failed", "error", err)
-		return "", err
+		return sharedauth.Token{}, err
 	}
-	m.saveToken(ctx, cache, key, newTok)
-	return newTok.AccessToken, nil
+	return tb.token(), nil
 }
 
 // login authenticates with Braza /auth/ to obtain new tokens.
@@ -136,9 +118,3 @@ func (m *BrazaManager) refresh(ctx context.Context, refreshToken string) (TokenB
 	slog.Info("braza.refresh_success")
 	return tr, nil
 }
-
-// saveToken stores a token bundle into cache.
-func (m *BrazaManager) saveToken(ctx context.Context, cache *CacheAdapter, key string, tb TokenBundle) {
-	b, _ := json.Marshal(tb)
-	_ = cache.SetWithTTL(ctx, key, string(b), time.Until(time.Unix(tb.Exp, 0)))
-}
@@ -7,25 +7,40 @@ import (
 	"time"
 
 	"github.com/Checker-Finance/adapters/braza-adapter/pkg/config"
+	sharedauth "github.com/Checker-Finance/adapters/internal/auth"
 	"github.com/Checker-Finance/adapters/pkg/secrets"
 )
 
+const (
+	// tokenRefreshBefore is how long before expiry a token is replaced.
+	tokenRefreshBefore = 5 * time.Minute
+
+	// tokenRefreshInterval is how often RefreshAllTokens checks known clients.
+	// It must be shorter than tokenRefreshBefore.
+	tokenRefreshInterval = time.Minute
+)
+
 // Manager orchestrates multi-tenant credential lookup and adapter-specific auth.
 type Manager struct {
 	secrets   secrets.Provider
-	cache     *CacheAdapter
 	brazaAuth *BrazaManager
+	tokens    *sharedauth.TokenManager
 }
 
 // NewManager constructs the multi-tenant auth manager.
-func NewManager(secretsProv secrets.Provider, cache *CacheAdapter, brazaBaseURL string) *Manager {
+func NewManager(secretsProv secrets.Provider, brazaBaseURL string) *Manager {
 	return &Manager{
 		secrets:   secretsProv,
-		cache:     cache,
 		brazaAuth: NewBrazaManager(brazaBaseURL),
+		tokens:    sharedauth.NewTokenManager("braza", tokenRefreshBefore),
 	}
 }
 
+// SetStore persists tokens in store so replicas share them.
+func (m *Manager) SetStore(store sharedauth.TokenStore) {
+	m.tokens.SetStore(store)
+}
+
 // GetCredentials resolves the username/password for a given tenant/client/venue.
 func (m *Manager) GetCredentials(ctx context.Context, cfg config.Config, clientID, venue string) (Credentials, error) {
 	key := fmt.Sprintf("%s/%s/%s", cfg.Env, clientID, venue)
@@ -40,22 +55,27 @@ func (m *Manager) GetCredentials(ctx context.Context, cfg config.Config, clientI
 	}, nil
 }
 
-// GetValidToken uses cached or refreshed tokens via the venue-specific manager.
+// GetValidToken returns a cached token for clientID, refreshing or logging in
+// again when it is close to expiry.
 func (m *Manager) GetValidToken(ctx context.Context, clientID string, creds Credentials) (string, error) {
-	return m.brazaAuth.GetValidToken(ctx, m.cache, clientID, creds)
+	return m.tokens.Token(ctx, clientID, func(ctx context.Context, current sharedauth.Token) (sharedauth.Token, error) {
+		return m.brazaAuth.fetch(ctx, creds, current)
+	})
 }
 
-// RefreshAllTokens (optional) periodically refreshes all cached tokens.
+// InvalidateToken discards token for clientID after Braza rejected it with
+// 401. A token already replaced by a concurrent refresh is kept.
+func (m *Manager) InvalidateToken(ctx context.Context, clientID, token string) {
+	m.tokens.Invalidate(ctx, clientID, token)
+}
+
+// ForgetClient stops background refresh for clientID and drops its token.
+func (m *Manager) ForgetClient(clientID string) {
+	m.tokens.Forget(clientID)
+}
+
+// RefreshAllTokens periodically refreshes the tokens of every client that has
+// requested one, so requests never wait on a refresh or login.
 func (m *Manager) RefreshAllTokens(ctx context.Context) {
-	ticker := time.NewTicker(30 * time.Minute)
-	defer ticker.Stop()
-	for {
-		select {
-		case <-ticker.C:
-			slog.Info("periodic token refresh tick")
-			// iterate over cached keys, refresh if expiring
-		case <-ctx.Done():
-			return
-		}
-	}
+	m.tokens.Run(ctx, tokenRefreshInterval)
 }
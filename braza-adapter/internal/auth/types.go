@@ -1,5 +1,11 @@
 package auth
 
+import (
+	"time"
+
+	sharedauth "github.com/Checker-Finance/adapters/internal/auth"
+)
+
 // Credentials represents username/password needed to log in to Braza.
 type Credentials struct {
 	Username string `json:"username"`
@@ -12,3 +18,12 @@ type TokenBundle struct {
 	RefreshToken string `json:"refresh_token"`
 	Exp          int64  `json:"exp"` // Unix timestamp
 }
+
+// token converts the bundle to the shared token type.
+func (tb TokenBundle) token() sharedauth.Token {
+	return sharedauth.Token{
+		AccessToken:  tb.AccessToken,
+		RefreshToken: tb.RefreshToken,
+		ExpiresAt:    time.Unix(tb.Exp, 0),
+	}
+}
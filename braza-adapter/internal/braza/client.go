@@ -4,71 +4,191 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
 
+	"github.com/Checker-Finance/adapters/braza-adapter/internal/auth"
 	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/rate"
 )
 
-// Client wraps low-level HTTP communication with Braza's API.
-// It handles JWT authorization, rate limiting, and basic retry logic.
+// APIError is a 4xx response from Braza.
+type APIError struct {
+	Status int
+	Detail string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("braza returned %d: %s", e.Status, e.Detail)
+}
+
+// isUnauthorized reports whether err is a 401 from Braza.
+func isUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized
+}
+
+// mapBrazaError converts a 4xx response body into an *APIError. Braza returns
+// DRF-style bodies: {"detail": "..."} or a map of field errors.
+func mapBrazaError(status int, body []byte) error {
+	var errBody map[string]any
+	_ = json.Unmarshal(body, &errBody)
+
+	slog.Warn("braza.client_error",
+		"status", status,
+		"body", string(body))
+
+	detail, _ := errBody["detail"].(string)
+	if detail == "" {
+		detail, _ = errBody["message"].(string)
+	}
+	if detail == "" {
+		detail = string(body)
+	}
+	return &APIError{Status: status, Detail: detail}
+}
+
+// Client wraps low-level HTTP communication with the Braza API.
+// Credentials are supplied per-request so that a single Client instance can
+// serve multiple tenants; tokens come from the shared auth.Manager.
 type Client struct {
 	baseURL string
-	token   string
 	exec    *httpclient.Executor
+	authMgr *auth.Manager
 }
 
-// NewClient constructs a new Braza HTTP client instance.
-func NewClient(baseURL, token string, rateMgr *rate.Manager) *Client {
+// NewClient constructs a new Braza HTTP client with rate limiting and retries.
+func NewClient(baseURL string, rateMgr *rate.Manager, authMgr *auth.Manager) *Client {
 	httpClient := &http.Client{Timeout: 10 * time.Second}
-	exec := httpclient.New(rateMgr, httpClient, 2, "braza", func(status int, body []byte) error {
-		slog.Warn("braza.non_200",
-			"status", status,
-			"body", string(body))
-		return fmt.Errorf("braza returned %d", status)
-	})
 	return &Client{
 		baseURL: baseURL,
-		token:   token,
-		exec:    exec,
+		exec:    httpclient.New(rateMgr, httpClient, 2, "braza", mapBrazaError),
+		authMgr: authMgr,
+	}
+}
+
+// GetBalances returns the client's balances per currency.
+// GET /trader-api/me/balance
+func (c *Client) GetBalances(ctx context.Context, clientID string, creds auth.Credentials) (BrazaBalancesResponse, error) {
+	const endpoint = "/trader-api/me/balance"
+	var resp BrazaBalancesResponse
+	if err := c.do(ctx, clientID, creds, http.MethodGet, endpoint, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PreviewQuotation creates an executable quotation.
+// POST /rates-ttl/v2/order/preview-quotation
+func (c *Client) PreviewQuotation(ctx context.Context, clientID string, creds auth.Credentials, req BrazaRFQRequest) (*BrazaQuoteResponse, error) {
+	const endpoint = "/rates-ttl/v2/order/preview-quotation"
+	var resp BrazaQuoteResponse
+	if err := c.do(ctx, clientID, creds, http.MethodPost, endpoint, endpoint, req, &resp); err != nil {
+		return nil, err
 	}
+	return &resp, nil
 }
 
-// WithToken updates the bearer token (used when the auth manager refreshes JWTs).
-func (c *Client) WithToken(token string) {
-	c.token = token
+// ExecuteOrder executes a previewed quotation.
+// POST /rates-ttl/v2/order/{quoteId}/execute-order
+func (c *Client) ExecuteOrder(ctx context.Context, clientID string, creds auth.Credentials, quoteID string) (*BrazaExecuteResponse, error) {
+	var resp BrazaExecuteResponse
+	err := c.do(ctx, clientID, creds, http.MethodPost,
+		"/rates-ttl/v2/order/{id}/execute-order",
+		"/rates-ttl/v2/order/"+quoteID+"/execute-order", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
-// GetJSON performs an authenticated GET request and decodes the JSON response into `out`.
-func (c *Client) GetJSON(ctx context.Context, path string, out any) error {
-	url := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// GetOrder retrieves an order by its Braza order ID.
+// GET /trader-api/order/{id}
+func (c *Client) GetOrder(ctx context.Context, clientID string, creds auth.Credentials, orderID string) (*BrazaOrderStatus, error) {
+	var resp BrazaOrderStatus
+	err := c.do(ctx, clientID, creds, http.MethodGet,
+		"/trader-api/order/{id}",
+		"/trader-api/order/"+orderID, nil, &resp)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	return &resp, nil
+}
+
+// ListOrders returns the client's most recent orders.
+// GET /trader-api/order/list
+func (c *Client) ListOrders(ctx context.Context, clientID string, creds auth.Credentials) (*BrazaOrderListResponse, error) {
+	const endpoint = "/trader-api/order/list"
+	var resp BrazaOrderListResponse
+	if err := c.do(ctx, clientID, creds, http.MethodGet, endpoint, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ListProducts returns the products tradable by the client.
+// GET /trader-api/product/list
+func (c *Client) ListProducts(ctx context.Context, clientID string, creds auth.Credentials) (*BrazaProductListResponse, error) {
+	const endpoint = "/trader-api/product/list"
+	var resp BrazaProductListResponse
+	if err := c.do(ctx, clientID, creds, http.MethodGet, endpoint, endpoint, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
 
-	return c.exec.DoJSON(ctx, req, "braza_api", out)
+// do performs an authenticated request, recorded in the venue metrics under endpoint.
+// A 401 discards the cached token and retries once with a fresh one.
+func (c *Client) do(
+	ctx context.Context,
+	clientID string,
+	creds auth.Credentials,
+	method, endpoint, path string,
+	body, out any,
+) error {
+	ctx = httpclient.WithEndpoint(ctx, endpoint)
+	token, err := c.send(ctx, clientID, creds, method, path, body, out)
+	if isUnauthorized(err) {
+		slog.Info("braza.token_rejected",
+			"client", clientID,
+			"endpoint", endpoint)
+		c.authMgr.InvalidateToken(ctx, clientID, token)
+		_, err = c.send(ctx, clientID, creds, method, path, body, out)
+	}
+	return err
 }
 
-// PostJSON performs an authenticated POST request with a JSON body.
-func (c *Client) PostJSON(ctx context.Context, path string, body any, out any) error {
-	data, err := json.Marshal(body)
+// send builds one authenticated request and executes it, returning the
+// bearer token it used.
+func (c *Client) send(
+	ctx context.Context,
+	clientID string,
+	creds auth.Credentials,
+	method, path string,
+	body, out any,
+) (string, error) {
+	token, err := c.authMgr.GetValidToken(ctx, clientID, creds)
 	if err != nil {
-		return err
+		return "", fmt.Errorf("braza: get auth token: %w", err)
+	}
+
+	var bodyBytes []byte
+	if body != nil {
+		if bodyBytes, err = json.Marshal(body); err != nil {
+			return token, err
+		}
 	}
-	url := fmt.Sprintf("%s%s", c.baseURL, path)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return err
+		return token, err
 	}
+	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 
-	return c.exec.DoJSON(ctx, req, "braza_api", out)
+	return token, c.exec.DoJSON(ctx, req, clientID, out)
 }
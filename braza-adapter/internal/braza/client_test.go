@@ -0,0 +1,105 @@
+package braza
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/braza-adapter/internal/auth"
+)
+
+// newTestClient returns a Client whose auth manager logs in against srv.
+func newTestClient(srv *httptest.Server) *Client {
+	authMgr := auth.NewManager(nil, srv.URL)
+	return NewClient(srv.URL, nil, authMgr)
+}
+
+func TestClient_RetriesOnceWithFreshTokenOn401(t *testing.T) {
+	var logins, orders atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/auth/":
+			n := logins.Add(1)
+			_ = json.NewEncoder(w).Encode(auth.TokenBundle{AccessToken: fmt.Sprintf("tok-%d", n)})
+		case "/trader-api/order/42":
+			orders.Add(1)
+			if r.Header.Get("Authorization") == "Bearer tok-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"detail":"Token expired"}`))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(BrazaOrderStatus{ID: 42, Status: "Finalizado"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	order, err := newTestClient(srv).GetOrder(context.Background(), "c1", auth.Credentials{Username: "u"}, "42")
+	require.NoError(t, err)
+	assert.Equal(t, 42, order.ID)
+	assert.EqualValues(t, 2, orders.Load(), "401 should be retried exactly once")
+	assert.EqualValues(t, 2, logins.Load())
+}
+
+func TestClient_MapsErrorDetail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/" {
+			_ = json.NewEncoder(w).Encode(auth.TokenBundle{AccessToken: "tok"})
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"detail":"Quotation expired"}`))
+	}))
+	defer srv.Close()
+
+	_, err := newTestClient(srv).ExecuteOrder(context.Background(), "c1", auth.Credentials{}, "q-1")
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr), "got %v", err)
+	assert.Equal(t, http.StatusBadRequest, apiErr.Status)
+	assert.Equal(t, "Quotation expired", apiErr.Detail)
+}
+
+func TestClient_AcceptsAny2xx(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/" {
+			_ = json.NewEncoder(w).Encode(auth.TokenBundle{AccessToken: "tok"})
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":7,"status_order":"Processing"}`))
+	}))
+	defer srv.Close()
+
+	resp, err := newTestClient(srv).ExecuteOrder(context.Background(), "c1", auth.Credentials{}, "q-1")
+	require.NoError(t, err)
+	assert.Equal(t, "7", resp.OrderID())
+}
+
+func TestClient_ReusesCachedToken(t *testing.T) {
+	var logins atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/" {
+			logins.Add(1)
+			_ = json.NewEncoder(w).Encode(auth.TokenBundle{AccessToken: "tok"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(BrazaOrderListResponse{})
+	}))
+	defer srv.Close()
+
+	client := newTestClient(srv)
+	for range 3 {
+		_, err := client.ListOrders(context.Background(), "c1", auth.Credentials{})
+		require.NoError(t, err)
+	}
+	assert.EqualValues(t, 1, logins.Load(), "token should be served from cache")
+}
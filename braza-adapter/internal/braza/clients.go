@@ -0,0 +1,173 @@
+package braza
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ClientDiscoverer lists the client IDs that have Braza credentials.
+type ClientDiscoverer interface {
+	DiscoverClients(ctx context.Context) ([]string, error)
+}
+
+// ActiveClient describes a client whose per-client loops are running.
+type ActiveClient struct {
+	ClientID   string    `json:"client_id"`
+	Source     string    `json:"source"` // "discovered" or "static"
+	ActiveFrom time.Time `json:"active_from"`
+}
+
+type watchedClient struct {
+	info   ActiveClient
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// ClientWatcher keeps one set of per-client loops (balance polling, product
+// sync) running for every client in Secrets Manager. Discovery is re-run
+// periodically; loops are started for new clients and stopped for clients
+// whose secrets were removed. Statically configured clients are always kept.
+type ClientWatcher struct {
+	discoverer ClientDiscoverer
+	static     []string
+	interval   time.Duration
+	run        func(ctx context.Context, clientID string)
+
+	mu     sync.Mutex
+	active map[string]*watchedClient
+}
+
+// NewClientWatcher constructs a watcher. run is started in its own goroutine
+// for each active client and must return once its context is cancelled.
+func NewClientWatcher(
+	discoverer ClientDiscoverer,
+	static []string,
+	interval time.Duration,
+	run func(ctx context.Context, clientID string),
+) *ClientWatcher {
+	return &ClientWatcher{
+		discoverer: discoverer,
+		static:     static,
+		interval:   interval,
+		run:        run,
+		active:     make(map[string]*watchedClient),
+	}
+}
+
+// Start syncs the client set immediately and then every interval until ctx
+// is cancelled, at which point all per-client loops are stopped.
+// A non-positive interval discovers once at startup only.
+func (w *ClientWatcher) Start(ctx context.Context) {
+	var tick <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		w.Sync(ctx)
+
+		select {
+		case <-tick:
+		case <-ctx.Done():
+			w.stopAll()
+			slog.Info("braza.client_watcher_stopped")
+			return
+		}
+	}
+}
+
+// Sync discovers clients and reconciles the running loops against them. If
+// discovery fails the current set is left untouched.
+func (w *ClientWatcher) Sync(ctx context.Context) {
+	want := make(map[string]string, len(w.static))
+	if w.discoverer != nil {
+		discovered, err := w.discoverer.DiscoverClients(ctx)
+		if err != nil {
+			slog.Warn("braza.client_discovery_failed", "error", err)
+			return
+		}
+		for _, id := range discovered {
+			want[id] = "discovered"
+		}
+	}
+	for _, id := range w.static {
+		if id != "" {
+			want[id] = "static"
+		}
+	}
+
+	w.mu.Lock()
+	var stopped []*watchedClient
+	for id, wc := range w.active {
+		if _, ok := want[id]; !ok {
+			wc.cancel()
+			stopped = append(stopped, wc)
+			delete(w.active, id)
+			slog.Info("braza.client_removed", "client", id)
+		}
+	}
+	for id, source := range want {
+		if _, ok := w.active[id]; ok {
+			continue
+		}
+		w.active[id] = w.startLocked(ctx, id, source)
+		slog.Info("braza.client_added", "client", id, "source", source)
+	}
+	w.mu.Unlock()
+
+	for _, wc := range stopped {
+		<-wc.done
+	}
+}
+
+// startLocked launches run for clientID. w.mu must be held.
+func (w *ClientWatcher) startLocked(ctx context.Context, clientID, source string) *watchedClient {
+	clientCtx, cancel := context.WithCancel(ctx)
+	wc := &watchedClient{
+		info: ActiveClient{
+			ClientID:   clientID,
+			Source:     source,
+			ActiveFrom: time.Now().UTC(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(wc.done)
+		w.run(clientCtx, clientID)
+	}()
+	return wc
+}
+
+// stopAll cancels every per-client loop and waits for them to return.
+func (w *ClientWatcher) stopAll() {
+	w.mu.Lock()
+	active := w.active
+	w.active = make(map[string]*watchedClient)
+	w.mu.Unlock()
+
+	for _, wc := range active {
+		wc.cancel()
+	}
+	for _, wc := range active {
+		<-wc.done
+	}
+}
+
+// ActiveClients returns the clients whose loops are running, sorted by ID.
+func (w *ClientWatcher) ActiveClients() []ActiveClient {
+	w.mu.Lock()
+	out := make([]ActiveClient, 0, len(w.active))
+	for _, wc := range w.active {
+		out = append(out, wc.info)
+	}
+	w.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].ClientID < out[j].ClientID })
+	return out
+}
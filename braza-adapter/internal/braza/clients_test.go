@@ -0,0 +1,91 @@
+package braza
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDiscoverer struct {
+	mu      sync.Mutex
+	clients []string
+	err     error
+}
+
+func (f *fakeDiscoverer) DiscoverClients(context.Context) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.clients, f.err
+}
+
+func (f *fakeDiscoverer) set(clients []string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.clients, f.err = clients, err
+}
+
+func activeIDs(w *ClientWatcher) []string {
+	var ids []string
+	for _, c := range w.ActiveClients() {
+		ids = append(ids, c.ClientID)
+	}
+	return ids
+}
+
+func TestClientWatcher_Sync_FollowsDiscoveredClients(t *testing.T) {
+	disc := &fakeDiscoverer{clients: []string{"c1", "c2"}}
+
+	var mu sync.Mutex
+	running := map[string]bool{}
+	w := NewClientWatcher(disc, []string{"static"}, 0, func(ctx context.Context, clientID string) {
+		mu.Lock()
+		running[clientID] = true
+		mu.Unlock()
+		<-ctx.Done()
+		mu.Lock()
+		running[clientID] = false
+		mu.Unlock()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w.Sync(ctx)
+	assert.Equal(t, []string{"c1", "c2", "static"}, activeIDs(w))
+
+	disc.set([]string{"c2", "c3"}, nil)
+	w.Sync(ctx)
+	assert.Equal(t, []string{"c2", "c3", "static"}, activeIDs(w))
+	mu.Lock()
+	assert.False(t, running["c1"], "removed client's loop should have returned")
+	mu.Unlock()
+
+	disc.set(nil, errors.New("secrets manager unavailable"))
+	w.Sync(ctx)
+	assert.Equal(t, []string{"c2", "c3", "static"}, activeIDs(w), "failed discovery keeps the current set")
+
+	w.stopAll()
+	assert.Empty(t, w.ActiveClients())
+	mu.Lock()
+	defer mu.Unlock()
+	for id, r := range running {
+		require.False(t, r, "loop for %s still running", id)
+	}
+}
+
+func TestClientWatcher_ActiveClients_ReportsSource(t *testing.T) {
+	w := NewClientWatcher(&fakeDiscoverer{clients: []string{"c1"}}, []string{"c1", "s1"}, 0,
+		func(ctx context.Context, _ string) { <-ctx.Done() })
+	w.Sync(context.Background())
+	defer w.stopAll()
+
+	active := w.ActiveClients()
+	require.Len(t, active, 2)
+	assert.Equal(t, "static", active[0].Source, "static configuration wins for c1")
+	assert.Equal(t, "static", active[1].Source)
+	assert.False(t, active[0].ActiveFrom.IsZero())
+}
@@ -1,6 +1,7 @@
 package braza
 
 import (
+	"cmp"
 	"context"
 	"log/slog"
 	"strings"
@@ -8,7 +9,10 @@ import (
 	"time"
 
 	"github.com/Checker-Finance/adapters/braza-adapter/pkg/config"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/pkg/model"
 
 	"github.com/Checker-Finance/adapters/braza-adapter/internal/auth"
@@ -59,36 +63,33 @@ func NewPoller(
 	}
 }
 
-// Start begins periodic polling for all known tenant/client pairs.
-func (p *Poller) Start(ctx context.Context, clients []string) {
-	slog.Info("braza.poller_started",
-		"clients", len(clients))
+// RunClient polls balances for one client immediately and then every poll
+// interval, until ctx is cancelled or the poller is stopped. Stopping the
+// poller cancels the client's context, aborting an in-flight poll.
+func (p *Poller) RunClient(ctx context.Context, clientID string) {
+	slog.Info("braza.balance_poll_started", "client", clientID)
 
-	// Kick off an immediate balance poll for each client at startup
-	for _, client := range clients {
-		if client == "" {
-			continue
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-p.stopCh:
+			slog.Info("braza.balance_poll_stopping (manual stop)", "client", clientID)
+			cancel()
+		case <-ctx.Done():
 		}
-		go p.pollBalancesOnce(ctx, client)
-	}
+	}()
 
 	ticker := time.NewTicker(p.pollInterval)
 	defer ticker.Stop()
 
 	for {
+		p.pollBalancesOnce(ctx, clientID)
+
 		select {
 		case <-ticker.C:
-			for _, client := range clients {
-				if client == "" {
-					continue
-				}
-				go p.pollBalancesOnce(ctx, client)
-			}
 		case <-ctx.Done():
-			slog.Info("braza.poller_stopped (context cancelled)")
-			return
-		case <-p.stopCh:
-			slog.Info("braza.poller_stopped (manual stop)")
+			slog.Info("braza.balance_poll_stopped", "client", clientID)
 			return
 		}
 	}
@@ -124,12 +125,15 @@ func (p *Poller) pollBalancesOnce(ctx context.Context, clientID string) {
 	}
 }
 
-// PollTradeStatus continuously checks a Braza order until it reaches a terminal state.
+// PollTradeStatus continuously checks a Braza order, identified by the order
+// ID Braza returned on execution, until it reaches a terminal state. The legacy
+// order ID is looked up from quoteID as the poll progresses; polling does not
+// depend on it.
 func (p *Poller) PollTradeStatus(
 	parentCtx context.Context,
 	clientID,
-	externalOrderID,
-	orderID string,
+	quoteID,
+	externalOrderID string,
 	creds auth.Credentials,
 ) {
 
@@ -145,17 +149,20 @@ func (p *Poller) PollTradeStatus(
 	// Create *dedicated child context* for this poller
 	ctx, cancel := context.WithCancel(parentCtx)
 	p.activeTrades.Store(externalOrderID, cancel)
+	submitted := time.Now()
+	untrack := metrics.TrackPolledTrade("braza")
 
 	go func() {
 		defer func() {
 			p.activeTrades.Delete(externalOrderID)
 			cancel() // ensure cleanup
+			untrack()
 		}()
 
 		ticker := time.NewTicker(10 * time.Second)
 		defer ticker.Stop()
 
-		var lastStatus string
+		var lastStatus, orderID string
 
 		for {
 			select {
@@ -168,7 +175,10 @@ func (p *Poller) PollTradeStatus(
 
 			case <-ticker.C:
 				// IMPORTANT: use child ctx
-				order, err := p.service.FetchTradeStatus(ctx, clientID, externalOrderID, creds)
+				pollStart := time.Now()
+				pollCtx, raw := httpclient.CaptureResponse(ctx)
+				order, err := p.service.FetchTradeStatus(pollCtx, clientID, externalOrderID, creds)
+				metrics.ObservePoll("braza", pollStart)
 				if err != nil {
 					slog.Warn("braza.trade_poll_error",
 						"external_order_id", externalOrderID,
@@ -179,13 +189,26 @@ func (p *Poller) PollTradeStatus(
 
 				rawStatus := order.Status
 				status := NormalizeOrderStatus(rawStatus)
+				if orderID == "" {
+					orderID = p.service.internalOrderID(ctx, quoteID)
+				}
 
 				// Emit status change only when it actually changes
 				if status != lastStatus {
+					p.service.auditor.Record(ctx, audit.Event{
+						Kind:       audit.KindStatusTransition,
+						ClientID:   clientID,
+						QuoteID:    quoteID,
+						TradeID:    externalOrderID,
+						PrevStatus: lastStatus,
+						Status:     status,
+						Source:     "poller",
+					}, raw.Body)
 					lastStatus = status
 
 					event := map[string]any{
 						"client_id":         clientID,
+						"quote_id":          quoteID,
 						"order_id":          orderID,
 						"external_order_id": externalOrderID,
 						"status":            status,
@@ -201,6 +224,7 @@ func (p *Poller) PollTradeStatus(
 
 					slog.Info("braza.trade_status_changed",
 						"order_id", orderID,
+						"external_order_id", externalOrderID,
 						"client", clientID,
 						"raw_status", rawStatus,
 						"normalized_status", status)
@@ -208,12 +232,37 @@ func (p *Poller) PollTradeStatus(
 
 				// --- Terminal Status Handling ---
 				if isTerminalStatus(status) {
+					metrics.ObserveTimeToTerminal("braza", status, submitted)
+					if status == model.StatusFilled {
+						metrics.ObserveQuoteFill("braza", quoteID, time.Now())
+					}
 
-					// --- 1. Sync into legacy.activity.t_order ---
-					if p.tradeSync != nil {
+					finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.BRAZA"
+					msgID := publisher.TradeEventID("BRAZA", cmp.Or(orderID, externalOrderID), status)
+					event := map[string]any{
+						"client_id":         clientID,
+						"quote_id":          quoteID,
+						"order_id":          orderID,
+						"external_order_id": externalOrderID,
+						"status":            status,
+						"final":             true,
+						"timestamp":         time.Now().UTC(),
+					}
+
+					// --- 1. Sync into legacy.activity.t_order, queueing the final event ---
+					queued := false
+					if p.tradeSync != nil && orderID == "" {
+						slog.Warn("legacy.trade_sync_skipped",
+							"quote_id", quoteID,
+							"external_order_id", externalOrderID,
+							"client", clientID,
+							"reason", "internal_order_id_missing",
+						)
+					} else if p.tradeSync != nil {
 						trade := p.service.BuildTradeConfirmationFromOrder(clientID, orderID, order)
 						if trade != nil {
-							if err := p.tradeSync.SyncTradeUpsert(ctx, trade); err != nil {
+							trade.ProviderRFQID = quoteID
+							if err := p.tradeSync.SyncTradeWithEvent(ctx, trade, finalSubject, msgID, event); err != nil {
 								slog.Warn("legacy.trade_sync_failed",
 									"order_id", trade.TradeID,
 									"external_order_id", externalOrderID,
@@ -221,6 +270,7 @@ func (p *Poller) PollTradeStatus(
 									"error", err,
 								)
 							} else {
+								queued = true
 								slog.Info("legacy.trade_sync_upsert",
 									"order_id", trade.TradeID,
 									"client_id", trade.ClientID,
@@ -238,19 +288,13 @@ func (p *Poller) PollTradeStatus(
 						}
 					}
 
-					// --- 2. Emit final event ---
-					finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.BRAZA"
-					if err := p.publisher.Publish(ctx, finalSubject, map[string]any{
-						"client_id":         clientID,
-						"order_id":          orderID,
-						"external_order_id": externalOrderID,
-						"status":            status,
-						"final":             true,
-						"timestamp":         time.Now().UTC(),
-					}); err != nil {
-						slog.Debug("nats.publish_failed",
-							"subject", finalSubject,
-							"error", err)
+					// --- 2. Emit final event directly when it could not be queued ---
+					if !queued {
+						if err := p.publisher.Publish(ctx, finalSubject, event, publisher.WithMsgID(msgID)); err != nil {
+							slog.Debug("nats.publish_failed",
+								"subject", finalSubject,
+								"error", err)
+						}
 					}
 
 					slog.Info("braza.trade_poll_complete",
@@ -0,0 +1,41 @@
+package braza
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Checker-Finance/adapters/braza-adapter/pkg/config"
+	pkgsecrets "github.com/Checker-Finance/adapters/pkg/secrets"
+)
+
+// blockingProvider blocks Resolve until its context is cancelled.
+type blockingProvider struct {
+	started chan struct{}
+}
+
+func (p *blockingProvider) Resolve(ctx context.Context, _ string) (pkgsecrets.Credentials, error) {
+	close(p.started)
+	<-ctx.Done()
+	return pkgsecrets.Credentials{}, ctx.Err()
+}
+
+func TestPoller_StopCancelsInFlightPoll(t *testing.T) {
+	provider := &blockingProvider{started: make(chan struct{})}
+	poller := NewPoller(config.Config{}, nil, nil, nil, provider, nil, nil, time.Hour, nil)
+
+	done := make(chan struct{})
+	go func() {
+		poller.RunClient(context.Background(), "client-1")
+		close(done)
+	}()
+
+	<-provider.started
+	poller.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunClient did not return after Stop while a poll was in flight")
+	}
+}
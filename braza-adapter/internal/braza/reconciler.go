@@ -0,0 +1,222 @@
+package braza
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/Checker-Finance/adapters/braza-adapter/internal/auth"
+	"github.com/Checker-Finance/adapters/braza-adapter/internal/metrics"
+	"github.com/Checker-Finance/adapters/internal/tracing"
+)
+
+// unresolvedKey holds the executions awaiting reconciliation, so they survive
+// restarts and deploys.
+const unresolvedKey = "braza:executions:unresolved"
+
+// unresolvedExecution is an execution Braza accepted without returning an
+// order ID. It stays here until the reconciler finds the order or it ages out.
+type unresolvedExecution struct {
+	ClientID   string    `json:"client_id"`
+	QuoteID    string    `json:"quote_id"`
+	ExecutedAt time.Time `json:"executed_at"`
+}
+
+// trackExecution starts polling a non-terminal execution by the Braza order
+// ID in its response. Executions without an ID are handed to the reconciler.
+// Polling runs under the service context, keeping ctx's correlation ID.
+func (s *Service) trackExecution(ctx context.Context, clientID, quoteID string, resp *BrazaExecuteResponse, creds auth.Credentials) {
+	if orderID := resp.OrderID(); orderID != "" {
+		s.poller.PollTradeStatus(tracing.CarryCorrelationID(s.ctx, ctx), clientID, quoteID, orderID, creds)
+		return
+	}
+
+	slog.Warn("braza.execute_rfq.order_id_missing",
+		"client", clientID,
+		"quoteID", quoteID,
+		"status", resp.StatusOrder)
+	metrics.IncUnresolvedExecution("recorded")
+	exec := unresolvedExecution{
+		ClientID:   clientID,
+		QuoteID:    quoteID,
+		ExecutedAt: time.Now(),
+	}
+	s.unresolved.Store(quoteID, exec)
+	s.persistUnresolved(ctx, []unresolvedExecution{exec}, nil)
+}
+
+// LoadUnresolved restores the executions persisted by earlier runs and
+// returns how many were loaded. Call it before StartReconciler.
+func (s *Service) LoadUnresolved(ctx context.Context) int {
+	if s.store == nil {
+		return 0
+	}
+	var stored map[string]unresolvedExecution
+	if err := s.store.GetJSON(ctx, unresolvedKey, &stored); err != nil {
+		return 0
+	}
+	for quoteID, exec := range stored {
+		s.unresolved.Store(quoteID, exec)
+	}
+	if len(stored) > 0 {
+		slog.Info("braza.reconcile.loaded", "unresolved", len(stored))
+	}
+	return len(stored)
+}
+
+// persistUnresolved adds and removes executions in the persisted set. Entries
+// written by other replicas are kept. The set expires after the reconcile
+// window, refreshed on every write.
+func (s *Service) persistUnresolved(ctx context.Context, add []unresolvedExecution, remove []string) {
+	if s.store == nil || (len(add) == 0 && len(remove) == 0) {
+		return
+	}
+	s.unresolvedMu.Lock()
+	defer s.unresolvedMu.Unlock()
+
+	stored := make(map[string]unresolvedExecution)
+	_ = s.store.GetJSON(ctx, unresolvedKey, &stored) // missing until the first write
+	for _, exec := range add {
+		stored[exec.QuoteID] = exec
+	}
+	for _, quoteID := range remove {
+		delete(stored, quoteID)
+	}
+	if err := s.store.SetJSON(ctx, unresolvedKey, stored, s.cfg.ReconcileWindow); err != nil {
+		slog.Warn("braza.reconcile.persist_failed",
+			"unresolved", len(stored),
+			"error", err)
+	}
+}
+
+// UnresolvedCount returns the number of executions awaiting reconciliation.
+func (s *Service) UnresolvedCount() int {
+	n := 0
+	s.unresolved.Range(func(_, _ any) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// StartReconciler runs ReconcileUnresolved every interval until ctx is done.
+func (s *Service) StartReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.ReconcileUnresolved(ctx)
+		case <-ctx.Done():
+			slog.Info("braza.reconciler_stopped")
+			return
+		}
+	}
+}
+
+// ReconcileUnresolved lists recent Braza orders for every client with
+// unresolved executions and starts polling the ones it can match. Executions
+// older than the reconcile window are dropped. Returns how many were resolved.
+func (s *Service) ReconcileUnresolved(ctx context.Context) int {
+	cutoff := time.Now().Add(-s.cfg.ReconcileWindow)
+	byClient := make(map[string][]unresolvedExecution)
+	var done []string
+	defer func() { s.persistUnresolved(ctx, nil, done) }()
+	s.unresolved.Range(func(key, value any) bool {
+		exec := value.(unresolvedExecution)
+		if s.cfg.ReconcileWindow > 0 && exec.ExecutedAt.Before(cutoff) {
+			s.unresolved.Delete(key)
+			done = append(done, exec.QuoteID)
+			metrics.IncUnresolvedExecution("expired")
+			slog.Error("braza.reconcile.expired",
+				"client", exec.ClientID,
+				"quoteID", exec.QuoteID,
+				"executed_at", exec.ExecutedAt)
+			return true
+		}
+		byClient[exec.ClientID] = append(byClient[exec.ClientID], exec)
+		return true
+	})
+
+	resolved := 0
+	for clientID, execs := range byClient {
+		credsMap, err := s.resolver.Resolve(ctx, clientID)
+		if err != nil {
+			slog.Warn("braza.reconcile.resolve_failed",
+				"client", clientID,
+				"error", err)
+			continue
+		}
+		creds := s.BuildCredentials(credsMap)
+
+		orders, err := s.ListRecentOrders(ctx, clientID, creds)
+		if err != nil {
+			slog.Warn("braza.reconcile.list_failed",
+				"client", clientID,
+				"error", err)
+			continue
+		}
+
+		for quoteID, orderID := range matchUnresolved(execs, orders) {
+			s.unresolved.Delete(quoteID)
+			done = append(done, quoteID)
+			metrics.IncUnresolvedExecution("resolved")
+			slog.Info("braza.reconcile.matched",
+				"client", clientID,
+				"quoteID", quoteID,
+				"external_order_id", orderID)
+			if s.poller != nil {
+				s.poller.PollTradeStatus(s.ctx, clientID, quoteID, orderID, creds)
+			}
+			resolved++
+		}
+	}
+	return resolved
+}
+
+// matchUnresolved pairs executions with listed orders. Braza reuses the
+// preview quotation ID as the order UUID, so the match is exact. Returns
+// quoteID -> Braza order ID.
+func matchUnresolved(execs []unresolvedExecution, orders []BrazaOrderStatus) map[string]string {
+	byUUID := make(map[string]int, len(orders))
+	for _, o := range orders {
+		if o.UUID != "" && o.ID > 0 {
+			byUUID[o.UUID] = o.ID
+		}
+	}
+
+	matched := make(map[string]string)
+	for _, exec := range execs {
+		if id, ok := byUUID[exec.QuoteID]; ok {
+			matched[exec.QuoteID] = strconv.Itoa(id)
+		}
+	}
+	return matched
+}
+
+// ListRecentOrders returns the client's most recent Braza orders.
+func (s *Service) ListRecentOrders(ctx context.Context, clientID string, creds auth.Credentials) ([]BrazaOrderStatus, error) {
+	data, err := s.client.ListOrders(ctx, clientID, creds)
+	if err != nil {
+		return nil, err
+	}
+	return data.Results, nil
+}
+
+// internalOrderID looks up the legacy order ID for quoteID, returning "" if
+// the upstream system has not written it yet.
+func (s *Service) internalOrderID(ctx context.Context, quoteID string) string {
+	if s.store == nil {
+		return ""
+	}
+	orderID, err := s.ResolveOrderIDFromQuote(ctx, quoteID)
+	if err != nil {
+		slog.Debug("braza.order_id_resolution_failed",
+			"quoteID", quoteID,
+			"error", err)
+		return ""
+	}
+	return orderID
+}
@@ -0,0 +1,116 @@
+package braza
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Checker-Finance/adapters/braza-adapter/internal/auth"
+	"github.com/Checker-Finance/adapters/braza-adapter/pkg/config"
+	"github.com/Checker-Finance/adapters/internal/store"
+)
+
+// memJSONStore keeps SetJSON values in memory; other Store methods are unused.
+type memJSONStore struct {
+	store.Store
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memJSONStore) SetJSON(_ context.Context, key string, value any, _ time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.data == nil {
+		m.data = make(map[string][]byte)
+	}
+	m.data[key] = b
+	return nil
+}
+
+func (m *memJSONStore) GetJSON(_ context.Context, key string, dest any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.data[key]
+	if !ok {
+		return errors.New("not found")
+	}
+	return json.Unmarshal(b, dest)
+}
+
+func TestBrazaExecuteResponse_OrderID(t *testing.T) {
+	assert.Equal(t, "4821", BrazaExecuteResponse{ID: 4821}.OrderID())
+	assert.Equal(t, "", BrazaExecuteResponse{StatusOrder: "Processing"}.OrderID())
+}
+
+func TestMatchUnresolved(t *testing.T) {
+	execs := []unresolvedExecution{
+		{ClientID: "c1", QuoteID: "q-1"},
+		{ClientID: "c1", QuoteID: "q-2"},
+	}
+	orders := []BrazaOrderStatus{
+		{ID: 10, UUID: "q-other"},
+		{ID: 11, UUID: "q-1"},
+		{ID: 0, UUID: "q-2"},
+	}
+
+	got := matchUnresolved(execs, orders)
+	assert.Equal(t, map[string]string{"q-1": "11"}, got)
+}
+
+func TestService_trackExecution_RecordsMissingOrderID(t *testing.T) {
+	svc := &Service{ctx: context.Background()}
+
+	svc.trackExecution(context.Background(), "c1", "q-1", &BrazaExecuteResponse{StatusOrder: "submitted"}, auth.Credentials{})
+
+	assert.Equal(t, 1, svc.UnresolvedCount())
+}
+
+func TestService_ReconcileUnresolved_DropsExpired(t *testing.T) {
+	svc := &Service{
+		ctx: context.Background(),
+		cfg: config.Config{ReconcileWindow: time.Minute},
+	}
+	svc.unresolved.Store("q-old", unresolvedExecution{
+		ClientID:   "c1",
+		QuoteID:    "q-old",
+		ExecutedAt: time.Now().Add(-time.Hour),
+	})
+
+	assert.Equal(t, 0, svc.ReconcileUnresolved(context.Background()))
+	assert.Equal(t, 0, svc.UnresolvedCount())
+}
+
+func TestService_UnresolvedSurvivesRestart(t *testing.T) {
+	st := &memJSONStore{}
+	cfg := config.Config{ReconcileWindow: time.Hour}
+	ctx := context.Background()
+
+	before := &Service{ctx: ctx, cfg: cfg, store: st}
+	before.trackExecution(ctx, "c1", "q-1", &BrazaExecuteResponse{StatusOrder: "submitted"}, auth.Credentials{})
+	before.trackExecution(ctx, "c1", "q-2", &BrazaExecuteResponse{StatusOrder: "submitted"}, auth.Credentials{})
+
+	after := &Service{ctx: ctx, cfg: cfg, store: st}
+	assert.Equal(t, 2, after.LoadUnresolved(ctx))
+	assert.Equal(t, 2, after.UnresolvedCount())
+
+	// Dropping executions removes them from the persisted set as well.
+	after.unresolved.Range(func(key, value any) bool {
+		exec := value.(unresolvedExecution)
+		exec.ExecutedAt = time.Now().Add(-2 * time.Hour)
+		after.unresolved.Store(key, exec)
+		return true
+	})
+	after.ReconcileUnresolved(ctx)
+
+	restarted := &Service{ctx: ctx, cfg: cfg, store: st}
+	assert.Equal(t, 0, restarted.LoadUnresolved(ctx))
+}
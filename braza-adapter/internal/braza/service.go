@@ -1,22 +1,22 @@
 package braza
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
-	"net"
-	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Checker-Finance/adapters/braza-adapter/internal/auth"
 	intsecrets "github.com/Checker-Finance/adapters/braza-adapter/internal/secrets"
 	"github.com/Checker-Finance/adapters/braza-adapter/pkg/config"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/rate"
 	"github.com/Checker-Finance/adapters/internal/store"
@@ -25,24 +25,27 @@ import (
 	"github.com/nats-io/nats.go"
 )
 
+// SubjectBalanceUpdate is published for every balance fetched from Braza.
+const SubjectBalanceUpdate = "evt.balance.update.v1"
+
 // Service orchestrates Braza API polling, quote/trade submission,
 // and normalized event publishing to NATS.
 type Service struct {
 	ctx             context.Context
 	cfg             config.Config
 	nc              *nats.Conn
-	baseURL         string
-	authMgr         *auth.Manager
 	resolver        *intsecrets.AWSResolver
-	rateMgr         *rate.Manager
 	publisher       *publisher.Publisher
 	store           store.Store
 	mapper          *Mapper
-	client          *http.Client
+	client          *Client
 	productResolver *ProductResolver
 	tradeSyncWriter *legacy.TradeSyncWriter
 
-	poller *Poller
+	poller       *Poller
+	auditor      *audit.Recorder
+	unresolved   sync.Map   // quoteID -> unresolvedExecution
+	unresolvedMu sync.Mutex // serializes writes of the persisted unresolved set
 }
 
 // NewService constructs a fully wired Braza adapter service.
@@ -60,19 +63,14 @@ func NewService(
 	tradeSyncWriter *legacy.TradeSyncWriter,
 ) *Service {
 	return &Service{
-		ctx:       ctx,
-		cfg:       cfg,
-		nc:        nc,
-		baseURL:   baseURL,
-		authMgr:   authMgr,
-		resolver:  resolver,
-		rateMgr:   rateMgr,
-		publisher: pub,
-		store:     st,
-		mapper:    NewMapper(),
-		client: &http.Client{
-			Timeout: 5 * time.Second,
-		},
+		ctx:             ctx,
+		cfg:             cfg,
+		nc:              nc,
+		resolver:        resolver,
+		publisher:       pub,
+		store:           st,
+		mapper:          NewMapper(),
+		client:          NewClient(baseURL, rateMgr, authMgr),
 		productResolver: productResolver,
 		tradeSyncWriter: tradeSyncWriter,
 	}
@@ -82,6 +80,11 @@ func (s *Service) SetPoller(p *Poller) {
 	s.poller = p
 }
 
+// SetAuditRecorder sets the recorder for the quote-to-trade audit trail.
+func (s *Service) SetAuditRecorder(r *audit.Recorder) {
+	s.auditor = r
+}
+
 // FetchAndPublishBalances queries Braza balances and persists + publishes events.
 func (s *Service) FetchAndPublishBalances(
 	ctx context.Context,
@@ -94,44 +97,9 @@ func (s *Service) FetchAndPublishBalances(
 	//	"client", clientID,
 	//)
 
-	token, err := s.authMgr.GetValidToken(ctx, clientID, creds)
-	if err != nil {
-		return fmt.Errorf("token_error: %w", err)
-	}
-
-	url := fmt.Sprintf("%s/trader-api/me/balance", s.baseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	balancesResp, err := s.client.GetBalances(ctx, clientID, creds)
 	if err != nil {
-		return err
-	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("braza balances failed: %d", resp.StatusCode)
-	}
-
-	//body, err := io.ReadAll(resp.Body)
-	//if err != nil {
-	//	return fmt.Errorf("read_body_error: %w", err)
-	//}
-	//
-	//resp.Body = io.NopCloser(bytes.NewBuffer(body))
-	//
-	//s.logger.Sugar().Infow("braza.balance_response.raw",
-	//	"status", resp.StatusCode,
-	//	"body", string(body,
-	//)
-
-	var balancesResp BrazaBalancesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&balancesResp); err != nil {
-		return fmt.Errorf("decode_error: %w", err)
+		return fmt.Errorf("braza balances failed: %w", err)
 	}
 
 	balances := s.mapper.FromBrazaBalances(balancesResp, clientID)
@@ -152,7 +120,7 @@ func (s *Service) FetchAndPublishBalances(
 				"error", err)
 		}
 
-		if err := pub.Publish(ctx, "evt.balance.update.v1", bal); err != nil {
+		if err := pub.Publish(ctx, SubjectBalanceUpdate, bal); err != nil {
 			slog.Warn("publish_failed",
 				"instrument", bal.Instrument,
 				"error", err)
@@ -178,85 +146,56 @@ func (s *Service) CreateRFQ(
 		Password: credsMap.Password,
 	}
 
-	token, err := s.authMgr.GetValidToken(ctx, req.ClientID, creds)
-	if err != nil {
-		return nil, fmt.Errorf("auth_failed: %w", err)
-	}
-
 	brazaReq := s.mapper.ToBrazaRFQ(req)
 	if s.productResolver.IsStale() {
 		_ = s.syncOnce(ctx, req.ClientID, "BRAZA", creds)
 	}
 
-	brazaReq.ProductID, err = s.productResolver.ResolveProductID(ctx, req.CurrencyPair)
+	products, err := s.productResolver.ListProducts("BRAZA")
 	if err != nil {
-		return nil, fmt.Errorf("product_resolver.ResolveProductID_failed: %w", err)
+		return nil, fmt.Errorf("product_resolver.ListProducts_failed: %w", err)
 	}
-
-	body, _ := json.Marshal(brazaReq)
-	slog.Info("sending braza RFQ body",
-		"json", pretty(brazaReq))
-
-	url := fmt.Sprintf("%s/rates-ttl/v2/order/preview-quotation", s.baseURL)
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	if err != nil {
+	if err := model.ValidateRFQ(products, req); err != nil {
+		slog.Warn("braza.create_rfq.rejected",
+			"client", req.ClientID,
+			"pair", req.CurrencyPair,
+			"amount", req.Amount,
+			"error", err)
 		return nil, err
 	}
 
-	httpReq.Header.Set("Authorization", "Bearer "+token)
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Accept", "application/json")
-
-	resp, err := s.client.Do(httpReq)
+	brazaReq.ProductID, err = s.productResolver.ResolveProductID(ctx, req.CurrencyPair)
 	if err != nil {
-		var netErr net.Error
-		if errors.As(err, &netErr) && netErr.Timeout() {
-			slog.Error("braza.request_timeout",
-				"client_id", req.ClientID,
-				"url", url,
-				"timeout", s.client.Timeout,
-				"error", err,
-			)
-			return nil, fmt.Errorf("timeout: Braza did not respond within %s", s.client.Timeout)
-		}
-
-		slog.Error("braza.request_failed",
-			"client_id", req.ClientID,
-			"error", err,
-		)
-		return nil, fmt.Errorf("request_failed: %w", err)
+		return nil, fmt.Errorf("product_resolver.ResolveProductID_failed: %w", err)
 	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	// --- Handle error responses gracefully ---
-	if resp.StatusCode != http.StatusCreated {
-		var errBody map[string]any
-		_ = json.NewDecoder(resp.Body).Decode(&errBody)
 
-		// Extract "detail" if present
-		detail := ""
-		if msg, ok := errBody["detail"].(string); ok {
-			detail = msg
-		}
+	slog.Info("sending braza RFQ body",
+		"json", pretty(brazaReq))
+	s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: req.ClientID}, brazaReq)
 
+	ctx, raw := httpclient.CaptureResponse(ctx)
+	quoteResp, err := s.client.PreviewQuotation(ctx, req.ClientID, creds, brazaReq)
+	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindQuoteResponse,
+			ClientID: req.ClientID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Info("braza.rfq_create_failed",
 			"tenant", req.TenantID,
 			"client", req.ClientID,
-			"status", resp.StatusCode,
-			"reason", detail,
-			"response", errBody,
+			"error", err,
 		)
-
-		return nil, fmt.Errorf("braza rfq create failed [%d]: %s", resp.StatusCode, detail)
+		return nil, fmt.Errorf("braza rfq create failed: %w", err)
 	}
 
-	// --- Decode success response ---
-	var quoteResp BrazaQuoteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&quoteResp); err != nil {
-		return nil, fmt.Errorf("decode_failed: %w", err)
-	}
-
-	quote := s.mapper.FromBrazaQuote(quoteResp, req.ClientID)
+	quote := s.mapper.FromBrazaQuote(*quoteResp, req.ClientID)
+	metrics.RecordQuote("braza", quote.ID, time.Now())
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindQuoteResponse,
+		ClientID: req.ClientID,
+		QuoteID:  quote.ID,
+	}, raw.Body)
 	slog.Info("braza.rfq_created",
 		"client", req.ClientID,
 		"quote_id", quote.ID,
@@ -282,63 +221,43 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*Br
 		Password: credsMap.Password,
 	}
 
-	token, err := s.authMgr.GetValidToken(ctx, clientID, creds)
-	if err != nil {
-		return nil, fmt.Errorf("token_error: %w", err)
-	}
-
-	url := fmt.Sprintf("%s/rates-ttl/v2/order/%s/execute-order", s.baseURL, quoteID)
-	slog.Info("braza.rfq_request_sent", "url", url, "client", clientID, "quoteID", quoteID)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/json")
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteRequest,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+	}, map[string]string{"quote_id": quoteID})
 
-	resp, err := s.client.Do(req)
+	ctx, raw := httpclient.CaptureResponse(ctx)
+	execResp, err := s.client.ExecuteOrder(ctx, clientID, creds, quoteID)
 	if err != nil {
-		return nil, fmt.Errorf("request_failed: %w", err)
-	}
-	defer resp.Body.Close() //nolint:errcheck
-	if resp.StatusCode != http.StatusCreated {
-		var errBody map[string]any
-		_ = json.NewDecoder(resp.Body).Decode(&errBody)
-		detail := ""
-		if msg, ok := errBody["detail"].(string); ok {
-			detail = msg
-		}
-
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Warn("braza.execute_rfq",
 			"client", clientID,
-			"status", resp.StatusCode,
-			"reason", detail,
-			"response", errBody,
+			"quoteID", quoteID,
+			"error", err,
 		)
-
-		return nil, fmt.Errorf("braza rfq execution failed [%d]: %s", resp.StatusCode, detail)
-	}
-
-	var execResp BrazaExecuteResponse
-	if err := json.NewDecoder(resp.Body).Decode(&execResp); err != nil {
-		return nil, fmt.Errorf("decode error: %w", err)
+		return nil, fmt.Errorf("braza rfq execution failed: %w", err)
 	}
 
 	status := NormalizeOrderStatus(execResp.StatusOrder)
 	execResp.StatusOrder = status
-	if strings.ToUpper(status) == "PROCESSING" && s.poller != nil {
-		pollCtx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-		defer cancel()
-		orderID, err := s.ResolveOrderIDFromQuote(pollCtx, quoteID)
-		if err != nil {
-			slog.Warn("braza.order_id_resolution_failed",
-				"quoteID", quoteID,
-				"client", clientID,
-				"error", err,
-			)
-		} else {
-			go s.poller.PollTradeStatus(ctx, clientID, quoteID, orderID, creds)
-		}
-	}
-
-	return &execResp, nil
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteResponse,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+		TradeID:  execResp.OrderID(),
+		Status:   status,
+	}, raw.Body)
+	if !isTerminalStatus(status) && s.poller != nil {
+		s.trackExecution(ctx, clientID, quoteID, execResp, creds)
+	}
+
+	return execResp, nil
 }
 
 // FetchTradeStatus retrieves the latest order/trade status from Braza.
@@ -348,40 +267,13 @@ func (s *Service) FetchTradeStatus(
 	orderID string,
 	creds auth.Credentials,
 ) (*BrazaOrderStatus, error) {
-	token, err := s.authMgr.GetValidToken(ctx, clientID, creds)
+	statusResp, err := s.client.GetOrder(ctx, clientID, creds, orderID)
 	if err != nil {
-		slog.Warn("braza.token_resolve_failed",
-			"client", clientID,
-			"error", err)
-		return nil, err
-	}
-
-	url := fmt.Sprintf("%s/trader-api/order/%s", s.baseURL, orderID)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("braza returned %d for order %s", resp.StatusCode, orderID)
-	}
-
-	var statusResp BrazaOrderStatus
-	if err := json.NewDecoder(resp.Body).Decode(&statusResp); err != nil {
-		return nil, fmt.Errorf("decode error: %w", err)
+		return nil, fmt.Errorf("braza order %s: %w", orderID, err)
 	}
 
 	statusResp.Status = NormalizeOrderStatus(statusResp.Status)
-	return &statusResp, nil
+	return statusResp, nil
 }
 
 func (s *Service) ListProducts(ctx context.Context, clientID, venue string) ([]model.Product, error) {
@@ -475,6 +367,11 @@ func (s *Service) Config() config.Config {
 	return s.cfg
 }
 
+// Expose the Braza HTTP client
+func (s *Service) Client() *Client {
+	return s.client
+}
+
 // Expose resolver
 func (s *Service) Resolver() *intsecrets.AWSResolver {
 	return s.resolver
@@ -489,29 +386,9 @@ func (s *Service) BuildCredentials(r secrets.Credentials) auth.Credentials {
 }
 
 func (s *Service) syncOnce(ctx context.Context, clientID, venue string, creds auth.Credentials) error {
-	token, err := s.authMgr.GetValidToken(ctx, clientID, creds)
-	if err != nil {
-		return fmt.Errorf("token error: %w", err)
-	}
-
-	url := fmt.Sprintf("%s/trader-api/product/list", s.baseURL)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := s.client.Do(req)
+	data, err := s.client.ListProducts(ctx, clientID, creds)
 	if err != nil {
-		return err
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("braza product sync failed: %d", resp.StatusCode)
-	}
-
-	var data BrazaProductListResponse
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return fmt.Errorf("decode error: %w", err)
+		return fmt.Errorf("braza product sync failed: %w", err)
 	}
 
 	products := append(make([]BrazaProductDef, 0, len(data.Results)), data.Results...)
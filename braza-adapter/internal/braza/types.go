@@ -1,5 +1,7 @@
 package braza
 
+import "strconv"
+
 //
 // ────────────────────────────────────────────────
 //   BRAZA → CANONICAL  : Balances
@@ -62,9 +64,20 @@ type BrazaQuoteResponse struct {
 //
 
 type BrazaExecuteResponse struct {
+	ID          int    `json:"id"`           // Braza order ID, used to poll /trader-api/order/{id}
+	UUID        string `json:"uuid"`         // matches the preview quotation ID
 	StatusOrder string `json:"status_order"` // e.g. "Processing"
 }
 
+// OrderID returns the Braza order ID as a string, or "" if the response did
+// not carry one.
+func (r BrazaExecuteResponse) OrderID() string {
+	if r.ID <= 0 {
+		return ""
+	}
+	return strconv.Itoa(r.ID)
+}
+
 //
 // ────────────────────────────────────────────────
 //   BRAZA → CANONICAL  : Order / Trade Status
@@ -85,6 +98,12 @@ type BrazaOrderStatus struct {
 	Timestamp      string  `json:"timestamp"`
 }
 
+// BrazaOrderListResponse is the paginated response of /trader-api/order/list.
+type BrazaOrderListResponse struct {
+	Count   int                `json:"count"`
+	Results []BrazaOrderStatus `json:"results"`
+}
+
 type BrazaProductListResponse struct {
 	Count   int               `json:"count"`
 	Results []BrazaProductDef `json:"results"`
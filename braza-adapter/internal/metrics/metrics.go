@@ -2,7 +2,6 @@ package metrics
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -10,50 +9,19 @@ import (
 )
 
 var (
-	// Tracks the number of outbound API calls to Braza.
-	BrazaRequestsTotal = promauto.NewCounterVec(
+	// Counts executions Braza accepted without an order ID, by outcome
+	// (recorded, resolved, expired).
+	BrazaUnresolvedExecutionsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
-			Name: "braza_api_requests_total",
-			Help: "Total number of Braza API requests made (by endpoint and method).",
+			Name: "braza_unresolved_executions_total",
+			Help: "Executions returned without a Braza order ID, by reconciliation outcome.",
 		},
-		[]string{"endpoint", "method", "status"},
-	)
-
-	// Measures duration of API requests to Braza.
-	BrazaRequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "braza_api_request_duration_seconds",
-			Help:    "Duration of Braza API requests in seconds.",
-			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15), // 1ms → ~16s
-		},
-		[]string{"endpoint", "method"},
-	)
-
-	NATSPublishErrors = promauto.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "nats_publish_errors_total",
-			Help: "Number of NATS publish failures",
-		},
-		[]string{"subject"},
+		[]string{"outcome"},
 	)
 )
 
-// ObserveDuration records the time taken for a function and updates the given histogram.
-func ObserveDuration(v any, start time.Time, labels ...string) {
-	duration := time.Since(start).Seconds()
-
-	switch metric := v.(type) {
-	case *prometheus.HistogramVec:
-		metric.WithLabelValues(labels...).Observe(duration)
-	case *prometheus.SummaryVec:
-		metric.WithLabelValues(labels...).Observe(duration)
-	default:
-		// silently ignore counters; they're not meant for duration tracking
-	}
-}
-
-func IncBrazaRequest(endpoint, method, status string) {
-	BrazaRequestsTotal.WithLabelValues(endpoint, method, status).Inc()
+func IncUnresolvedExecution(outcome string) {
+	BrazaUnresolvedExecutionsTotal.WithLabelValues(outcome).Inc()
 }
 
 func StartServer(addr string) {
@@ -0,0 +1,7 @@
+-- Rollback for 0006_event_outbox.sql
+-- Intentionally a no-op: activity.t_event_outbox is shared by every adapter
+-- that runs the event_outbox migration, so rolling back one adapter must not
+-- drop the other adapters' unpublished events. Drop it by hand once no
+-- adapter writes to it:
+--   DROP TABLE IF EXISTS activity.t_event_outbox;
+SELECT 1;
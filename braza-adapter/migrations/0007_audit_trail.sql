@@ -0,0 +1,52 @@
+BEGIN;
+
+CREATE SCHEMA IF NOT EXISTS audit;
+
+-- Append-only quote-to-trade audit trail: RFQ requests, raw venue quote and
+-- execution responses, execute requests and status transitions, keyed by
+-- correlation ID. Payloads are stored with secrets redacted by the adapter.
+CREATE TABLE IF NOT EXISTS audit.t_rfq_event (
+    id              BIGSERIAL PRIMARY KEY,
+    correlation_id  UUID,
+    venue           VARCHAR(64)  NOT NULL,          -- e.g. "rio"
+    kind            VARCHAR(32)  NOT NULL,          -- rfq_request | quote_response | execute_request | execute_response | status_transition
+    client_id       VARCHAR(255) NOT NULL DEFAULT '',
+    quote_id        VARCHAR(255) NOT NULL DEFAULT '',
+    trade_id        VARCHAR(255) NOT NULL DEFAULT '',
+    prev_status     VARCHAR(64)  NOT NULL DEFAULT '',
+    status          VARCHAR(64)  NOT NULL DEFAULT '',
+    source          VARCHAR(32)  NOT NULL DEFAULT '', -- e.g. "poller", "webhook"
+    error           TEXT         NOT NULL DEFAULT '',
+    payload         JSONB,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rfq_event_correlation
+    ON audit.t_rfq_event(correlation_id)
+    WHERE correlation_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_rfq_event_quote
+    ON audit.t_rfq_event(venue, quote_id)
+    WHERE quote_id <> '';
+CREATE INDEX IF NOT EXISTS idx_rfq_event_trade
+    ON audit.t_rfq_event(venue, trade_id)
+    WHERE trade_id <> '';
+
+-- Reject any change to recorded events.
+CREATE OR REPLACE FUNCTION audit.f_rfq_event_append_only()
+RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit.t_rfq_event is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_rfq_event_append_only ON audit.t_rfq_event;
+CREATE TRIGGER trg_rfq_event_append_only
+    BEFORE UPDATE OR DELETE ON audit.t_rfq_event
+    FOR EACH ROW EXECUTE FUNCTION audit.f_rfq_event_append_only();
+
+DROP TRIGGER IF EXISTS trg_rfq_event_no_truncate ON audit.t_rfq_event;
+CREATE TRIGGER trg_rfq_event_no_truncate
+    BEFORE TRUNCATE ON audit.t_rfq_event
+    FOR EACH STATEMENT EXECUTE FUNCTION audit.f_rfq_event_append_only();
+
+COMMIT;
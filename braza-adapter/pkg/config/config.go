@@ -37,6 +37,21 @@ type Config struct {
 
 	ClientBalancesIDs  string
 	ClientInstrumentID string
+
+	DiscoveryInterval time.Duration // how often Secrets Manager is re-scanned for Braza clients
+	ProductSyncFreq   time.Duration // how often each client's product list is synced
+
+	ReconcileInterval time.Duration // how often unresolved executions are matched against Braza's order list
+	ReconcileWindow   time.Duration // how long an unresolved execution is retried before it is dropped
+
+	TokenPersist       bool   // share Braza tokens between replicas via Redis
+	TokenEncryptionKey string // base64 AES-256 key encrypting persisted tokens
+
+	// JetStream publishing
+	NATSStream            string        // Stream that must capture the adapter's trade events
+	NATSDedupWindow       time.Duration // Duplicate window enforced on NATSStream
+	NATSPublishAsync      bool          // Publish without waiting for each ack
+	NATSPublishMaxPending int           // Async publishes awaiting an ack before publishing blocks
 }
 
 // Load loads configuration from environment variables, then overlays any values
@@ -64,6 +79,17 @@ func Load(ctx context.Context) *Config {
 		ClientBalancesIDs:  pkgconfig.GetEnv("CLIENT_BALANCES_IDS", ""),
 		ClientInstrumentID: pkgconfig.GetEnv("CLIENT_INSTRUMENT_ID", ""),
 		SettlementCutOff:   pkgconfig.GetEnvTime("SETTLEMENT_CUT_OFF", "17:00"),
+		DiscoveryInterval:  pkgconfig.GetEnvDuration("BRAZA_CLIENT_DISCOVERY_INTERVAL", 5*time.Minute),
+		ProductSyncFreq:    pkgconfig.GetEnvDuration("BRAZA_PRODUCT_SYNC_INTERVAL", time.Hour),
+		ReconcileInterval:  pkgconfig.GetEnvDuration("BRAZA_RECONCILE_INTERVAL", 30*time.Second),
+		ReconcileWindow:    pkgconfig.GetEnvDuration("BRAZA_RECONCILE_WINDOW", time.Hour),
+		TokenPersist:       pkgconfig.GetEnvBool("AUTH_TOKEN_PERSIST", false),
+		TokenEncryptionKey: pkgconfig.GetEnv("AUTH_TOKEN_ENCRYPTION_KEY", ""),
+
+		NATSStream:            pkgconfig.GetEnv("NATS_STREAM", "BRAZA_EVENTS"),
+		NATSDedupWindow:       pkgconfig.GetEnvDuration("NATS_DEDUP_WINDOW", 2*time.Minute),
+		NATSPublishAsync:      pkgconfig.GetEnvBool("NATS_PUBLISH_ASYNC", false),
+		NATSPublishMaxPending: pkgconfig.GetEnvInt("NATS_PUBLISH_MAX_PENDING", 256),
 	}
 
 	secretPath := fmt.Sprintf("%s/%s", cfg.Env, cfg.ServiceName)
@@ -13,11 +13,14 @@ import (
 	"github.com/Checker-Finance/adapters/capa-adapter/internal/capa"
 	internalsecrets "github.com/Checker-Finance/adapters/capa-adapter/internal/secrets"
 	"github.com/Checker-Finance/adapters/capa-adapter/pkg/config"
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/jobs"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/rate"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/logger"
 	"github.com/Checker-Finance/adapters/pkg/secrets"
 	"github.com/Checker-Finance/adapters/pkg/utils"
@@ -35,6 +38,13 @@ func main() {
 	cfg.Venue = "capa"
 
 	logger.Init(cfg.ServiceName, cfg.Env, cfg.LogLevel)
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.ServiceName)
+	if err != nil {
+		slog.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("starting [capa-adapter]...")
 	slog.Info("connection to DSN", "dsn", utils.MaskDSN(cfg.DatabaseURL))
 
@@ -72,11 +82,26 @@ func main() {
 	}
 
 	// --- Publisher ---
-	pub, err := publisher.New(nc, "evt.capa", "CAPA_EVENTS")
+	pub, err := publisher.NewWithOptions(nc, "evt.capa", "CAPA_EVENTS", publisher.Options{
+		Async:      cfg.NATSPublishAsync,
+		MaxPending: cfg.NATSPublishMaxPending,
+	})
 	if err != nil {
 		slog.Error("failed to init publisher", "error", err)
 		os.Exit(1)
 	}
+	if err := pub.EnsureStreams(
+		publisher.StreamSpec{
+			Name:       cfg.NATSStream,
+			Subjects:   []string{"evt.trade.*.v1.CAPA"},
+			Duplicates: cfg.NATSDedupWindow,
+		},
+		// Quote, balance and recon subjects may live in other streams, some shared by several adapters
+		publisher.StreamSpec{Subjects: []string{cfg.OutboundSubject, capa.SubjectReconDiscrepancy, jobs.SubjectSummaryRefreshed}},
+	); err != nil {
+		slog.Error("failed to verify jetstream streams", "error", err)
+		os.Exit(1)
+	}
 
 	// --- Rate limiter ---
 	rateMgr := rate.NewManager(rate.Config{
@@ -101,6 +126,13 @@ func main() {
 	// --- Legacy trade sync writer ---
 	tradeSyncWriter := legacy.NewTradeSyncWriter(st.(*store.HybridStore).PG, "capa-adapter")
 
+	// --- Outbox relay: publishes terminal trade events queued with their t_order rows ---
+	outboxRelay := outbox.NewRelay(outbox.NewPGStore(st.(*store.HybridStore).PG, "capa-adapter"), pub, outbox.RelayConfig{})
+	go outboxRelay.Start(ctx)
+
+	// --- Audit trail: append-only record of each RFQ from request to final status ---
+	auditStore := audit.NewPGStore(st.(*store.HybridStore).PG)
+
 	// --- RFQ sweeper: expires stale open RFQs and quotes in the legacy DB ---
 	rfqSweeper := legacy.NewRFQSweeper(
 		st.(*store.HybridStore).PG,
@@ -143,6 +175,24 @@ func main() {
 		tradeSyncWriter,
 	)
 	capaSvc.SetPoller(poller)
+	auditRecorder := audit.NewRecorder(auditStore, "capa")
+	capaSvc.SetAuditRecorder(auditRecorder)
+
+	// --- Destination whitelist: wallets and receivers selectable by alias ---
+	destinations := capa.NewDestinations(
+		capaClient,
+		resolver,
+		capa.NewPGDestinationStore(st.(*store.HybridStore).PG),
+	)
+	capaSvc.SetDestinations(destinations)
+	go capaSvc.StartRouteJanitor(ctx, time.Hour)
+
+	// --- Reconciler: repairs trades whose webhook and poll were both missed ---
+	reconciler := capa.NewReconciler(capaSvc, tradeSyncWriter, pub, capa.ReconcilerConfig{
+		Interval: cfg.ReconInterval,
+		Lookback: cfg.ReconLookback,
+	})
+	go reconciler.Start(ctx)
 
 	// --- Webhook handler ---
 	webhookHandler := capa.NewWebhookHandler(
@@ -168,6 +218,7 @@ func main() {
 		IdleTimeout:  cfg.HTTPIdleTimeout,
 		BodyLimit:    cfg.HTTPBodyLimit,
 	})
+	app.Use(tracing.FiberMiddleware())
 
 	clientValidator := api.NewResolverValidator(resolver)
 	capaHandler := api.NewCapaHandler(capaSvc, clientValidator)
@@ -175,8 +226,10 @@ func main() {
 	productsHandler := api.NewProductsHandler(capaSvc)
 	balanceHandler := api.NewBalanceHandler(st)
 	webhookAPIHandler := api.NewWebhookAPIHandler(webhookHandler, st, resolver)
+	destinationsHandler := api.NewDestinationsHandler(destinations, clientValidator)
+	auditHandler := audit.NewHandler(auditStore, "capa")
 
-	api.RegisterRoutes(app, nc, st, capaHandler, resolveHandler, productsHandler, balanceHandler, webhookAPIHandler)
+	api.RegisterRoutes(app, nc, st, capaHandler, resolveHandler, productsHandler, balanceHandler, webhookAPIHandler, destinationsHandler, auditHandler)
 
 	// Start HTTP server
 	go func() {
@@ -204,10 +257,19 @@ func main() {
 	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
 		slog.Warn("fiber.shutdown_failed", "error", err)
 	}
+	if err := pub.Flush(shutdownCtx); err != nil {
+		slog.Warn("nats.flush_incomplete", "error", err)
+	}
 	if err := nc.Drain(); err != nil {
 		slog.Warn("nats.drain_failed", "error", err)
 	}
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		slog.Warn("audit.flush_incomplete", "error", err)
+	}
 	if err := st.Close(); err != nil {
 		slog.Warn("store.close_failed", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("tracing.shutdown_failed", "error", err)
+	}
 }
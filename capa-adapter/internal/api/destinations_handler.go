@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Checker-Finance/adapters/capa-adapter/internal/capa"
+)
+
+// DestinationService is satisfied by capa.Destinations.
+type DestinationService interface {
+	List(ctx context.Context, clientID string, kind capa.DestinationKind) ([]capa.Destination, error)
+	AddWallet(ctx context.Context, clientID string, in capa.WalletInput) (*capa.Destination, error)
+	AddReceiver(ctx context.Context, clientID string, in capa.ReceiverInput) (*capa.Destination, error)
+	UpdateWallet(ctx context.Context, clientID string, in capa.WalletInput) (*capa.Destination, error)
+	UpdateReceiver(ctx context.Context, clientID string, in capa.ReceiverInput) (*capa.Destination, error)
+	Remove(ctx context.Context, clientID string, kind capa.DestinationKind, alias string) error
+}
+
+// DestinationsHandler handles the /api/v1/clients/:id/{wallets,receivers} endpoints.
+type DestinationsHandler struct {
+	service   DestinationService
+	validator ClientValidator
+}
+
+// NewDestinationsHandler creates a new DestinationsHandler.
+func NewDestinationsHandler(service DestinationService, validator ClientValidator) *DestinationsHandler {
+	return &DestinationsHandler{service: service, validator: validator}
+}
+
+// ListWallets handles GET /api/v1/clients/:id/wallets.
+func (h *DestinationsHandler) ListWallets(c *fiber.Ctx) error {
+	return h.list(c, capa.DestinationWallet)
+}
+
+// ListReceivers handles GET /api/v1/clients/:id/receivers.
+func (h *DestinationsHandler) ListReceivers(c *fiber.Ctx) error {
+	return h.list(c, capa.DestinationReceiver)
+}
+
+// AddWallet handles POST /api/v1/clients/:id/wallets.
+func (h *DestinationsHandler) AddWallet(c *fiber.Ctx) error {
+	clientID, ok := h.client(c)
+	if !ok {
+		return nil
+	}
+	var in capa.WalletInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := in.Validate(); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	dest, err := h.service.AddWallet(c.UserContext(), clientID, in)
+	if err != nil {
+		return destinationError(c, clientID, err)
+	}
+	return c.Status(http.StatusCreated).JSON(dest)
+}
+
+// AddReceiver handles POST /api/v1/clients/:id/receivers.
+func (h *DestinationsHandler) AddReceiver(c *fiber.Ctx) error {
+	clientID, ok := h.client(c)
+	if !ok {
+		return nil
+	}
+	var in capa.ReceiverInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err := in.Validate(); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	dest, err := h.service.AddReceiver(c.UserContext(), clientID, in)
+	if err != nil {
+		return destinationError(c, clientID, err)
+	}
+	return c.Status(http.StatusCreated).JSON(dest)
+}
+
+// UpdateWallet handles PUT /api/v1/clients/:id/wallets/:alias.
+func (h *DestinationsHandler) UpdateWallet(c *fiber.Ctx) error {
+	clientID, ok := h.client(c)
+	if !ok {
+		return nil
+	}
+	var in capa.WalletInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	in.Alias = c.Params("alias")
+	if err := in.Validate(); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	dest, err := h.service.UpdateWallet(c.UserContext(), clientID, in)
+	if err != nil {
+		return destinationError(c, clientID, err)
+	}
+	return c.JSON(dest)
+}
+
+// UpdateReceiver handles PUT /api/v1/clients/:id/receivers/:alias.
+func (h *DestinationsHandler) UpdateReceiver(c *fiber.Ctx) error {
+	clientID, ok := h.client(c)
+	if !ok {
+		return nil
+	}
+	var in capa.ReceiverInput
+	if err := c.BodyParser(&in); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	in.Alias = c.Params("alias")
+	if err := in.Validate(); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	dest, err := h.service.UpdateReceiver(c.UserContext(), clientID, in)
+	if err != nil {
+		return destinationError(c, clientID, err)
+	}
+	return c.JSON(dest)
+}
+
+// RemoveWallet handles DELETE /api/v1/clients/:id/wallets/:alias.
+func (h *DestinationsHandler) RemoveWallet(c *fiber.Ctx) error {
+	return h.remove(c, capa.DestinationWallet)
+}
+
+// RemoveReceiver handles DELETE /api/v1/clients/:id/receivers/:alias.
+func (h *DestinationsHandler) RemoveReceiver(c *fiber.Ctx) error {
+	return h.remove(c, capa.DestinationReceiver)
+}
+
+func (h *DestinationsHandler) list(c *fiber.Ctx, kind capa.DestinationKind) error {
+	clientID, ok := h.client(c)
+	if !ok {
+		return nil
+	}
+	dests, err := h.service.List(c.UserContext(), clientID, kind)
+	if err != nil {
+		return destinationError(c, clientID, err)
+	}
+	return c.JSON(fiber.Map{
+		"count":        len(dests),
+		"destinations": dests,
+	})
+}
+
+func (h *DestinationsHandler) remove(c *fiber.Ctx, kind capa.DestinationKind) error {
+	clientID, ok := h.client(c)
+	if !ok {
+		return nil
+	}
+	if err := h.service.Remove(c.UserContext(), clientID, kind, c.Params("alias")); err != nil {
+		return destinationError(c, clientID, err)
+	}
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// client returns the :id path parameter, writing an error response and
+// returning false if it is missing or not a configured client.
+func (h *DestinationsHandler) client(c *fiber.Ctx) (string, bool) {
+	clientID := c.Params("id")
+	if clientID == "" {
+		_ = c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "missing client id"})
+		return "", false
+	}
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), clientID) {
+		_ = c.Status(http.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
+		return "", false
+	}
+	return clientID, true
+}
+
+// destinationError maps destination errors to HTTP statuses.
+func destinationError(c *fiber.Ctx, clientID string, err error) error {
+	status := http.StatusBadGateway
+	switch {
+	case errors.Is(err, capa.ErrDestinationExists):
+		status = http.StatusConflict
+	case errors.Is(err, capa.ErrDestinationNotFound):
+		status = http.StatusNotFound
+	}
+	slog.Warn("capa.destinations.failed",
+		"client", clientID,
+		"status", status,
+		"error", err)
+	return c.Status(status).JSON(fiber.Map{"error": err.Error()})
+}
@@ -12,7 +12,7 @@ import (
 // RFQService defines the interface for RFQ operations used by the handler.
 type RFQService interface {
 	CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Quote, error)
-	ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*model.TradeConfirmation, error)
+	ExecuteRFQTo(ctx context.Context, clientID, quoteID, destination string) (*model.TradeConfirmation, error)
 }
 
 // ClientValidator checks whether a client ID is configured and allowed.
@@ -44,21 +44,26 @@ func (h *CapaHandler) CreateRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
 	r := toRFQRequest(req)
 
-	quote, err := h.service.CreateRFQ(c.Context(), r)
+	quote, err := h.service.CreateRFQ(c.UserContext(), r)
 	if err != nil {
 		slog.Error("capa.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(RFQResponse{
+		resp := RFQResponse{
 			QuoteID:  req.ID,
 			ErrorMsg: err.Error(),
-		})
+		}
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			resp.ErrorCode = verr.Code
+			resp.ErrorMsg = verr.Message
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(resp)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(RFQResponse{
@@ -79,7 +84,7 @@ func (h *CapaHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
@@ -90,9 +95,10 @@ func (h *CapaHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 
 	slog.Info("capa.execute_rfq",
 		"client", req.ClientID,
-		"quote_id", quoteID)
+		"quote_id", quoteID,
+		"destination", req.Destination)
 
-	trade, err := h.service.ExecuteRFQ(c.Context(), req.ClientID, quoteID)
+	trade, err := h.service.ExecuteRFQTo(c.UserContext(), req.ClientID, quoteID, req.Destination)
 	if err != nil {
 		slog.Error("capa.execute_rfq.failed",
 			"client", req.ClientID,
@@ -121,5 +127,6 @@ func toRFQRequest(req RFQCreateRequest) model.RFQRequest {
 		CurrencyPair:   req.CurrencyPair,
 		Amount:         req.Amount,
 		CurrencyAmount: req.AmountDenomination,
+		Destination:    req.Destination,
 	}
 }
@@ -8,6 +8,7 @@ type RFQCreateRequest struct {
 	AmountDenomination string  `json:"amountDenomination"`
 	Side               string  `json:"orderSide"`
 	Amount             float64 `json:"quantity"`
+	Destination        string  `json:"destination,omitempty"` // whitelisted wallet or receiver alias
 }
 
 // RFQExecuteRequest is the payload for executing a quote.
@@ -22,6 +23,7 @@ type RFQExecuteRequest struct {
 	Quantity                  float64 `json:"quantity"`
 	Price                     float64 `json:"price"`
 	OrderSide                 string  `json:"orderSide"`
+	Destination               string  `json:"destination,omitempty"` // overrides the quote's wallet or receiver alias
 }
 
 // ResolveQuoteID returns the quote ID from QuoteID, falling back to ProviderQuoteID.
@@ -6,6 +6,7 @@ type RFQResponse struct {
 	ProviderQuoteId string  `json:"providerQuoteId"`
 	Price           float64 `json:"price"`
 	ExpireAt        int64   `json:"expireAt"`
+	ErrorCode       string  `json:"errorCode,omitempty"`
 	ErrorMsg        string  `json:"errorMessage,omitempty"`
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/store"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
@@ -21,6 +22,8 @@ func RegisterRoutes(
 	productsHandler *ProductsHandler,
 	balanceHandler *BalanceHandler,
 	webhookHandler *WebhookAPIHandler,
+	destinationsHandler *DestinationsHandler,
+	auditHandler *audit.Handler,
 ) {
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
@@ -65,6 +68,23 @@ func RegisterRoutes(
 	v1.Get("/products", productsHandler.ListProducts)
 	v1.Get("/balances/:client_id", balanceHandler.GetBalances)
 
+	// Destination whitelist (requires Postgres)
+	if destinationsHandler != nil {
+		v1.Get("/clients/:id/wallets", destinationsHandler.ListWallets)
+		v1.Post("/clients/:id/wallets", destinationsHandler.AddWallet)
+		v1.Put("/clients/:id/wallets/:alias", destinationsHandler.UpdateWallet)
+		v1.Delete("/clients/:id/wallets/:alias", destinationsHandler.RemoveWallet)
+		v1.Get("/clients/:id/receivers", destinationsHandler.ListReceivers)
+		v1.Post("/clients/:id/receivers", destinationsHandler.AddReceiver)
+		v1.Put("/clients/:id/receivers/:alias", destinationsHandler.UpdateReceiver)
+		v1.Delete("/clients/:id/receivers/:alias", destinationsHandler.RemoveReceiver)
+	}
+
+	// Quote-to-trade audit trail (requires Postgres)
+	if auditHandler != nil {
+		v1.Get("/audit/:id", auditHandler.Timeline)
+	}
+
 	// Webhook routes
 	app.Post("/webhooks/capa/transactions", webhookHandler.HandleWebhook)
 }
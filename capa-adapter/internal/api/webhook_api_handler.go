@@ -60,7 +60,7 @@ func (h *WebhookAPIHandler) HandleWebhook(c *fiber.Ctx) error {
 	}
 	if txID != "" {
 		var storedClientID string
-		if err := h.store.GetJSON(c.Context(), "capa:tx:"+txID+":client", &storedClientID); err == nil && storedClientID != "" {
+		if err := h.store.GetJSON(c.UserContext(), "capa:tx:"+txID+":client", &storedClientID); err == nil && storedClientID != "" {
 			clientID = storedClientID
 		}
 	}
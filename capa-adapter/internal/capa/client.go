@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"time"
 
-	"github.com/Checker-Finance/adapters/capa-adapter/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/rate"
 )
@@ -51,12 +50,9 @@ func NewClient(rateMgr *rate.Manager) *Client {
 // GetCrossRampQuote creates a new cross-ramp (fiat→fiat) quote.
 // POST /api/partner/v2/cross-ramp/quotes
 func (c *Client) GetCrossRampQuote(ctx context.Context, cfg *CapaClientConfig, req *CapaCrossRampQuoteRequest) (*CapaQuoteResponse, error) {
-	const endpoint, method = "/api/partner/v2/cross-ramp/quotes", http.MethodPost
-	start := time.Now()
+	const endpoint = "/api/partner/v2/cross-ramp/quotes"
 	var resp CapaQuoteResponse
 	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
-	metrics.IncCapaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.CapaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -66,12 +62,9 @@ func (c *Client) GetCrossRampQuote(ctx context.Context, cfg *CapaClientConfig, r
 // GetQuote creates a new on-ramp or off-ramp quote.
 // POST /api/partner/v2/quotes
 func (c *Client) GetQuote(ctx context.Context, cfg *CapaClientConfig, req *CapaQuoteRequest) (*CapaQuoteResponse, error) {
-	const endpoint, method = "/api/partner/v2/quotes", http.MethodPost
-	start := time.Now()
+	const endpoint = "/api/partner/v2/quotes"
 	var resp CapaQuoteResponse
 	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
-	metrics.IncCapaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.CapaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -81,12 +74,9 @@ func (c *Client) GetQuote(ctx context.Context, cfg *CapaClientConfig, req *CapaQ
 // CreateCrossRamp executes a cross-ramp (fiat→fiat) transaction.
 // POST /api/partner/v2/cross-ramp
 func (c *Client) CreateCrossRamp(ctx context.Context, cfg *CapaClientConfig, req *CapaCrossRampExecuteRequest) (*CapaExecuteResponse, error) {
-	const endpoint, method = "/api/partner/v2/cross-ramp", http.MethodPost
-	start := time.Now()
+	const endpoint = "/api/partner/v2/cross-ramp"
 	var resp CapaExecuteResponse
 	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
-	metrics.IncCapaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.CapaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -96,12 +86,9 @@ func (c *Client) CreateCrossRamp(ctx context.Context, cfg *CapaClientConfig, req
 // CreateOnRamp executes an on-ramp (fiat→crypto) transaction.
 // POST /api/partner/v2/on-ramp
 func (c *Client) CreateOnRamp(ctx context.Context, cfg *CapaClientConfig, req *CapaOnRampExecuteRequest) (*CapaExecuteResponse, error) {
-	const endpoint, method = "/api/partner/v2/on-ramp", http.MethodPost
-	start := time.Now()
+	const endpoint = "/api/partner/v2/on-ramp"
 	var resp CapaExecuteResponse
 	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
-	metrics.IncCapaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.CapaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -111,12 +98,9 @@ func (c *Client) CreateOnRamp(ctx context.Context, cfg *CapaClientConfig, req *C
 // CreateOffRamp executes an off-ramp (crypto→fiat) transaction.
 // POST /api/partner/v2/off-ramp
 func (c *Client) CreateOffRamp(ctx context.Context, cfg *CapaClientConfig, req *CapaOffRampExecuteRequest) (*CapaExecuteResponse, error) {
-	const endpoint, method = "/api/partner/v2/off-ramp", http.MethodPost
-	start := time.Now()
+	const endpoint = "/api/partner/v2/off-ramp"
 	var resp CapaExecuteResponse
 	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
-	metrics.IncCapaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.CapaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -126,12 +110,9 @@ func (c *Client) CreateOffRamp(ctx context.Context, cfg *CapaClientConfig, req *
 // GetTransaction retrieves a transaction by ID.
 // GET /api/partner/v2/transactions/{transactionId}
 func (c *Client) GetTransaction(ctx context.Context, cfg *CapaClientConfig, txID string) (*CapaTransactionResponse, error) {
-	const endpoint, method = "/api/partner/v2/transactions/{id}", http.MethodGet
-	start := time.Now()
+	ctx = httpclient.WithEndpoint(ctx, "/api/partner/v2/transactions/{id}")
 	var resp CapaTransactionResponse
 	err := c.getJSON(ctx, cfg, "/api/partner/v2/transactions/"+txID, &resp)
-	metrics.IncCapaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.CapaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -141,26 +122,55 @@ func (c *Client) GetTransaction(ctx context.Context, cfg *CapaClientConfig, txID
 // GetTransactions retrieves a list of transactions for the partner.
 // GET /api/partner/v2/transactions
 func (c *Client) GetTransactions(ctx context.Context, cfg *CapaClientConfig) ([]CapaTransaction, error) {
-	const endpoint, method = "/api/partner/v2/transactions", http.MethodGet
-	start := time.Now()
+	const endpoint = "/api/partner/v2/transactions"
 	var resp struct {
 		Transactions []CapaTransaction `json:"transactions"`
 	}
 	err := c.getJSON(ctx, cfg, endpoint, &resp)
-	metrics.IncCapaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.CapaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
 	return resp.Transactions, nil
 }
 
-// statusLabel returns "ok" or "error" for use as a Prometheus label.
-func statusLabel(err error) string {
+// CreateReceiver registers a payout beneficiary for off-ramps.
+// POST /api/partner/v2/receivers
+func (c *Client) CreateReceiver(ctx context.Context, cfg *CapaClientConfig, req *CapaReceiverRequest) (*CapaReceiver, error) {
+	const endpoint = "/api/partner/v2/receivers"
+	var resp CapaReceiver
+	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
 	if err != nil {
-		return "error"
+		return nil, err
 	}
-	return "ok"
+	return &resp, nil
+}
+
+// DeleteReceiver removes a payout beneficiary.
+// DELETE /api/partner/v2/receivers/{receiverId}
+func (c *Client) DeleteReceiver(ctx context.Context, cfg *CapaClientConfig, receiverID string) error {
+	ctx = httpclient.WithEndpoint(ctx, "/api/partner/v2/receivers/{id}")
+	err := c.deleteJSON(ctx, cfg, "/api/partner/v2/receivers/"+receiverID)
+	return err
+}
+
+// CreateWallet registers a destination wallet for on-ramps.
+// POST /api/partner/v2/wallets
+func (c *Client) CreateWallet(ctx context.Context, cfg *CapaClientConfig, req *CapaWalletRequest) (*CapaWalletResponse, error) {
+	const endpoint = "/api/partner/v2/wallets"
+	var resp CapaWalletResponse
+	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DeleteWallet removes a destination wallet.
+// DELETE /api/partner/v2/wallets/{walletId}
+func (c *Client) DeleteWallet(ctx context.Context, cfg *CapaClientConfig, walletID string) error {
+	ctx = httpclient.WithEndpoint(ctx, "/api/partner/v2/wallets/{id}")
+	err := c.deleteJSON(ctx, cfg, "/api/partner/v2/wallets/"+walletID)
+	return err
 }
 
 // getJSON performs an authenticated GET request and decodes the JSON response.
@@ -194,6 +204,17 @@ func (c *Client) postJSON(ctx context.Context, cfg *CapaClientConfig, path strin
 	return c.exec.DoJSON(ctx, req, cfg.UserID, out)
 }
 
+// deleteJSON performs an authenticated DELETE request, discarding any body.
+func (c *Client) deleteJSON(ctx context.Context, cfg *CapaClientConfig, path string) error {
+	url := cfg.BaseURL + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	setHeaders(req, cfg.APIKey)
+	return c.exec.DoJSON(ctx, req, cfg.UserID, nil)
+}
+
 // setHeaders sets required headers for Capa API requests.
 func setHeaders(req *http.Request, apiKey string) {
 	req.Header.Set("partner-api-key", apiKey)
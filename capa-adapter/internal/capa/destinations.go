@@ -0,0 +1,430 @@
+package capa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DestinationKind distinguishes on-ramp wallets from off-ramp receivers.
+type DestinationKind string
+
+const (
+	// DestinationWallet is an on-ramp destination wallet.
+	DestinationWallet DestinationKind = "wallet"
+	// DestinationReceiver is an off-ramp payout beneficiary.
+	DestinationReceiver DestinationKind = "receiver"
+)
+
+var (
+	// ErrDestinationExists is returned when a client already has a
+	// destination of the same kind under the alias.
+	ErrDestinationExists = errors.New("capa: destination alias already exists")
+	// ErrDestinationNotFound is returned when no destination has the alias.
+	ErrDestinationNotFound = errors.New("capa: destination not found")
+)
+
+// aliasPattern restricts aliases to short, URL-safe identifiers.
+var aliasPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Destination is a whitelisted wallet or receiver a client's RFQs may select
+// by alias. CapaID is the ID Capa assigned when it was registered.
+type Destination struct {
+	ClientID   string          `json:"clientId"`
+	Kind       DestinationKind `json:"kind"`
+	Alias      string          `json:"alias"`
+	CapaID     string          `json:"capaId"`
+	Address    string          `json:"address,omitempty"`    // wallet only
+	Blockchain string          `json:"blockchain,omitempty"` // wallet only
+	Token      string          `json:"token,omitempty"`      // wallet only
+	Name       string          `json:"name,omitempty"`       // receiver only
+	Country    string          `json:"country,omitempty"`    // receiver only
+	Currency   string          `json:"currency,omitempty"`   // receiver only
+	BankCode   string          `json:"bankCode,omitempty"`   // receiver only
+	Account    string          `json:"account,omitempty"`    // receiver only, masked
+	CreatedAt  time.Time       `json:"createdAt"`
+}
+
+// DestinationStore persists the destination whitelist.
+type DestinationStore interface {
+	ListDestinations(ctx context.Context, clientID string) ([]Destination, error)
+	// InsertDestination returns ErrDestinationExists if the alias is taken.
+	InsertDestination(ctx context.Context, d Destination) error
+	// UpdateDestination replaces the destination with d's client, kind and
+	// alias, returning ErrDestinationNotFound if there is none.
+	UpdateDestination(ctx context.Context, d Destination) error
+	// DeleteDestination returns ErrDestinationNotFound if there is none.
+	DeleteDestination(ctx context.Context, clientID string, kind DestinationKind, alias string) error
+}
+
+// WalletInput is a request to whitelist a wallet.
+type WalletInput struct {
+	Alias      string `json:"alias"`
+	Address    string `json:"address"`
+	Blockchain string `json:"blockchain"`
+	Token      string `json:"token"`
+}
+
+// Validate checks that the wallet has an alias, address, chain and token.
+func (in *WalletInput) Validate() error {
+	in.Blockchain = strings.ToUpper(strings.TrimSpace(in.Blockchain))
+	in.Token = strings.ToUpper(strings.TrimSpace(in.Token))
+	in.Address = strings.TrimSpace(in.Address)
+	if err := validateAlias(in.Alias); err != nil {
+		return err
+	}
+	switch {
+	case in.Address == "":
+		return fmt.Errorf("address is required")
+	case in.Blockchain == "":
+		return fmt.Errorf("blockchain is required")
+	case !knownCryptos[in.Token]:
+		return fmt.Errorf("token %q is not supported", in.Token)
+	}
+	return nil
+}
+
+// ReceiverInput is a request to whitelist a payout beneficiary.
+type ReceiverInput struct {
+	Alias         string `json:"alias"`
+	Name          string `json:"name"`
+	Country       string `json:"country"`
+	Currency      string `json:"currency"`
+	BankCode      string `json:"bankCode"`
+	AccountNumber string `json:"accountNumber"`
+	AccountType   string `json:"accountType"`
+}
+
+// Validate checks that the receiver has an alias, name, country, fiat
+// currency and account number.
+func (in *ReceiverInput) Validate() error {
+	in.Country = strings.ToUpper(strings.TrimSpace(in.Country))
+	in.Currency = strings.ToUpper(strings.TrimSpace(in.Currency))
+	in.AccountNumber = strings.TrimSpace(in.AccountNumber)
+	if err := validateAlias(in.Alias); err != nil {
+		return err
+	}
+	switch {
+	case strings.TrimSpace(in.Name) == "":
+		return fmt.Errorf("name is required")
+	case len(in.Country) != 2:
+		return fmt.Errorf("country must be an ISO 3166 alpha-2 code")
+	case len(in.Currency) != 3 || knownCryptos[in.Currency]:
+		return fmt.Errorf("currency must be a fiat ISO 4217 code")
+	case in.AccountNumber == "":
+		return fmt.Errorf("accountNumber is required")
+	}
+	return nil
+}
+
+func validateAlias(alias string) error {
+	if !aliasPattern.MatchString(alias) {
+		return fmt.Errorf("alias must be 1-64 lowercase letters, digits, '-' or '_'")
+	}
+	return nil
+}
+
+// Destinations registers wallets and receivers with Capa and keeps the
+// per-client whitelist that RFQs select destinations from.
+type Destinations struct {
+	client   *Client
+	resolver ConfigResolver
+	store    DestinationStore
+}
+
+// NewDestinations constructs a Destinations manager.
+func NewDestinations(client *Client, resolver ConfigResolver, store DestinationStore) *Destinations {
+	return &Destinations{client: client, resolver: resolver, store: store}
+}
+
+// List returns a client's whitelisted destinations of kind.
+func (d *Destinations) List(ctx context.Context, clientID string, kind DestinationKind) ([]Destination, error) {
+	all, err := d.store.ListDestinations(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Destination, 0, len(all))
+	for _, dest := range all {
+		if dest.Kind == kind {
+			out = append(out, dest)
+		}
+	}
+	return out, nil
+}
+
+// AddWallet registers a wallet with Capa and whitelists it under in.Alias.
+func (d *Destinations) AddWallet(ctx context.Context, clientID string, in WalletInput) (*Destination, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+	cfg, err := d.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve client config for %q: %w", clientID, err)
+	}
+	if err := d.ensureFree(ctx, clientID, DestinationWallet, in.Alias); err != nil {
+		return nil, err
+	}
+
+	dest, err := d.registerWallet(ctx, cfg, clientID, in)
+	if err != nil {
+		return nil, err
+	}
+	return d.insert(ctx, cfg, *dest)
+}
+
+// AddReceiver registers a payout beneficiary with Capa and whitelists it
+// under in.Alias. Only the last four digits of the account are kept.
+func (d *Destinations) AddReceiver(ctx context.Context, clientID string, in ReceiverInput) (*Destination, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+	cfg, err := d.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve client config for %q: %w", clientID, err)
+	}
+	if err := d.ensureFree(ctx, clientID, DestinationReceiver, in.Alias); err != nil {
+		return nil, err
+	}
+
+	dest, err := d.registerReceiver(ctx, cfg, clientID, in)
+	if err != nil {
+		return nil, err
+	}
+	return d.insert(ctx, cfg, *dest)
+}
+
+// UpdateWallet replaces the wallet whitelisted under in.Alias. Capa wallets
+// cannot be edited, so the new wallet is registered first and the old
+// registration removed once the whitelist points at the new one.
+func (d *Destinations) UpdateWallet(ctx context.Context, clientID string, in WalletInput) (*Destination, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+	old, err := d.find(ctx, clientID, DestinationWallet, in.Alias)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := d.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve client config for %q: %w", clientID, err)
+	}
+
+	dest, err := d.registerWallet(ctx, cfg, clientID, in)
+	if err != nil {
+		return nil, err
+	}
+	return d.replace(ctx, cfg, old, *dest)
+}
+
+// UpdateReceiver replaces the receiver whitelisted under in.Alias, in the
+// same way as UpdateWallet.
+func (d *Destinations) UpdateReceiver(ctx context.Context, clientID string, in ReceiverInput) (*Destination, error) {
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+	old, err := d.find(ctx, clientID, DestinationReceiver, in.Alias)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := d.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve client config for %q: %w", clientID, err)
+	}
+
+	dest, err := d.registerReceiver(ctx, cfg, clientID, in)
+	if err != nil {
+		return nil, err
+	}
+	return d.replace(ctx, cfg, old, *dest)
+}
+
+// Remove deletes a whitelisted destination from Capa and the whitelist.
+func (d *Destinations) Remove(ctx context.Context, clientID string, kind DestinationKind, alias string) error {
+	dest, err := d.find(ctx, clientID, kind, alias)
+	if err != nil {
+		return err
+	}
+	cfg, err := d.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return fmt.Errorf("resolve client config for %q: %w", clientID, err)
+	}
+
+	if err := d.deregister(ctx, cfg, *dest); err != nil {
+		return fmt.Errorf("capa %s removal failed: %w", kind, err)
+	}
+
+	if err := d.store.DeleteDestination(ctx, clientID, kind, alias); err != nil {
+		return err
+	}
+	slog.Info("capa.destination_removed",
+		"client", clientID,
+		"kind", string(kind),
+		"alias", alias)
+	return nil
+}
+
+// Apply returns a copy of cfg whose Wallets and Receivers also include the
+// client's stored whitelist. Stored entries win over secret entries with the
+// same alias.
+func (d *Destinations) Apply(ctx context.Context, clientID string, cfg *CapaClientConfig) (*CapaClientConfig, error) {
+	stored, err := d.store.ListDestinations(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("load destinations for %q: %w", clientID, err)
+	}
+	if len(stored) == 0 {
+		return cfg, nil
+	}
+
+	merged := *cfg
+	merged.Wallets = maps.Clone(cfg.Wallets)
+	merged.Receivers = maps.Clone(cfg.Receivers)
+	if merged.Wallets == nil {
+		merged.Wallets = make(map[string]CapaWallet)
+	}
+	if merged.Receivers == nil {
+		merged.Receivers = make(map[string]string)
+	}
+	for _, dest := range stored {
+		switch dest.Kind {
+		case DestinationWallet:
+			merged.Wallets[dest.Alias] = CapaWallet{Address: dest.Address, Blockchain: dest.Blockchain, Token: dest.Token}
+		case DestinationReceiver:
+			merged.Receivers[dest.Alias] = dest.CapaID
+		}
+	}
+	return &merged, nil
+}
+
+func (d *Destinations) registerWallet(ctx context.Context, cfg *CapaClientConfig, clientID string, in WalletInput) (*Destination, error) {
+	resp, err := d.client.CreateWallet(ctx, cfg, &CapaWalletRequest{
+		UserID:           cfg.UserID,
+		WalletAddress:    in.Address,
+		BlockchainSymbol: in.Blockchain,
+		TokenSymbol:      in.Token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("capa wallet registration failed: %w", err)
+	}
+	return &Destination{
+		ClientID:   clientID,
+		Kind:       DestinationWallet,
+		Alias:      in.Alias,
+		CapaID:     resp.ID,
+		Address:    in.Address,
+		Blockchain: in.Blockchain,
+		Token:      in.Token,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+func (d *Destinations) registerReceiver(ctx context.Context, cfg *CapaClientConfig, clientID string, in ReceiverInput) (*Destination, error) {
+	resp, err := d.client.CreateReceiver(ctx, cfg, &CapaReceiverRequest{
+		UserID:        cfg.UserID,
+		Name:          in.Name,
+		Country:       in.Country,
+		Currency:      in.Currency,
+		BankCode:      in.BankCode,
+		AccountNumber: in.AccountNumber,
+		AccountType:   in.AccountType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("capa receiver registration failed: %w", err)
+	}
+	return &Destination{
+		ClientID:  clientID,
+		Kind:      DestinationReceiver,
+		Alias:     in.Alias,
+		CapaID:    resp.ID,
+		Name:      in.Name,
+		Country:   in.Country,
+		Currency:  in.Currency,
+		BankCode:  in.BankCode,
+		Account:   maskAccount(in.AccountNumber),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// deregister removes a registration from Capa.
+func (d *Destinations) deregister(ctx context.Context, cfg *CapaClientConfig, dest Destination) error {
+	if dest.Kind == DestinationWallet {
+		return d.client.DeleteWallet(ctx, cfg, dest.CapaID)
+	}
+	return d.client.DeleteReceiver(ctx, cfg, dest.CapaID)
+}
+
+func (d *Destinations) insert(ctx context.Context, cfg *CapaClientConfig, dest Destination) (*Destination, error) {
+	if err := d.store.InsertDestination(ctx, dest); err != nil {
+		// Don't leave an orphan registered at Capa.
+		_ = d.deregister(ctx, cfg, dest)
+		return nil, err
+	}
+	slog.Info("capa.destination_added",
+		"client", dest.ClientID,
+		"kind", string(dest.Kind),
+		"alias", dest.Alias,
+		"capa_id", dest.CapaID)
+	return &dest, nil
+}
+
+// replace points the whitelist entry old at dest, then removes old's Capa
+// registration. A failed removal is logged: the whitelist no longer selects
+// it.
+func (d *Destinations) replace(ctx context.Context, cfg *CapaClientConfig, old *Destination, dest Destination) (*Destination, error) {
+	dest.CreatedAt = old.CreatedAt
+	if err := d.store.UpdateDestination(ctx, dest); err != nil {
+		_ = d.deregister(ctx, cfg, dest)
+		return nil, err
+	}
+	if err := d.deregister(ctx, cfg, *old); err != nil {
+		slog.Warn("capa.destination_deregister_failed",
+			"client", dest.ClientID,
+			"kind", string(dest.Kind),
+			"alias", dest.Alias,
+			"capa_id", old.CapaID,
+			"error", err)
+	}
+	slog.Info("capa.destination_updated",
+		"client", dest.ClientID,
+		"kind", string(dest.Kind),
+		"alias", dest.Alias,
+		"capa_id", dest.CapaID)
+	return &dest, nil
+}
+
+func (d *Destinations) ensureFree(ctx context.Context, clientID string, kind DestinationKind, alias string) error {
+	_, err := d.find(ctx, clientID, kind, alias)
+	switch {
+	case err == nil:
+		return ErrDestinationExists
+	case errors.Is(err, ErrDestinationNotFound):
+		return nil
+	default:
+		return err
+	}
+}
+
+func (d *Destinations) find(ctx context.Context, clientID string, kind DestinationKind, alias string) (*Destination, error) {
+	all, err := d.store.ListDestinations(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	for _, dest := range all {
+		if dest.Kind == kind && dest.Alias == alias {
+			return &dest, nil
+		}
+	}
+	return nil, ErrDestinationNotFound
+}
+
+// maskAccount keeps the last four characters of an account number.
+func maskAccount(account string) string {
+	if len(account) <= 4 {
+		return account
+	}
+	return strings.Repeat("*", len(account)-4) + account[len(account)-4:]
+}
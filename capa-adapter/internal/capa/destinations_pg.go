@@ -0,0 +1,113 @@
+package capa
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgUniqueViolation is the Postgres SQLSTATE for a unique constraint violation.
+const pgUniqueViolation = "23505"
+
+// PGDestinationStore keeps the destination whitelist in reference.capa_destinations.
+type PGDestinationStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPGDestinationStore constructs a DestinationStore backed by Postgres.
+func NewPGDestinationStore(db *pgxpool.Pool) *PGDestinationStore {
+	return &PGDestinationStore{db: db}
+}
+
+// ListDestinations returns all of a client's destinations ordered by alias.
+func (s *PGDestinationStore) ListDestinations(ctx context.Context, clientID string) ([]Destination, error) {
+	rows, err := s.db.Query(ctx, `
+		SELECT client_id, kind, alias, capa_id,
+		       COALESCE(address, ''), COALESCE(blockchain, ''), COALESCE(token, ''),
+		       COALESCE(name, ''), COALESCE(country, ''), COALESCE(currency, ''),
+		       COALESCE(bank_code, ''), COALESCE(account_masked, ''), created_at
+		FROM reference.capa_destinations
+		WHERE client_id = $1
+		ORDER BY kind, alias;
+	`, clientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Destination
+	for rows.Next() {
+		var d Destination
+		if err := rows.Scan(
+			&d.ClientID, &d.Kind, &d.Alias, &d.CapaID,
+			&d.Address, &d.Blockchain, &d.Token,
+			&d.Name, &d.Country, &d.Currency,
+			&d.BankCode, &d.Account, &d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// InsertDestination adds a destination, failing with ErrDestinationExists if
+// the client already uses the alias for that kind.
+func (s *PGDestinationStore) InsertDestination(ctx context.Context, d Destination) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO reference.capa_destinations
+			(client_id, kind, alias, capa_id, address, blockchain, token,
+			 name, country, currency, bank_code, account_masked, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''),
+		        NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), NULLIF($11, ''), NULLIF($12, ''), $13);
+	`,
+		d.ClientID, d.Kind, d.Alias, d.CapaID, d.Address, d.Blockchain, d.Token,
+		d.Name, d.Country, d.Currency, d.BankCode, d.Account, d.CreatedAt,
+	)
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return ErrDestinationExists
+	}
+	return err
+}
+
+// UpdateDestination overwrites the Capa ID and details of a destination,
+// failing with ErrDestinationNotFound if there was none.
+func (s *PGDestinationStore) UpdateDestination(ctx context.Context, d Destination) error {
+	tag, err := s.db.Exec(ctx, `
+		UPDATE reference.capa_destinations
+		SET capa_id = $4,
+		    address = NULLIF($5, ''), blockchain = NULLIF($6, ''), token = NULLIF($7, ''),
+		    name = NULLIF($8, ''), country = NULLIF($9, ''), currency = NULLIF($10, ''),
+		    bank_code = NULLIF($11, ''), account_masked = NULLIF($12, '')
+		WHERE client_id = $1 AND kind = $2 AND alias = $3;
+	`,
+		d.ClientID, d.Kind, d.Alias, d.CapaID, d.Address, d.Blockchain, d.Token,
+		d.Name, d.Country, d.Currency, d.BankCode, d.Account,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDestinationNotFound
+	}
+	return nil
+}
+
+// DeleteDestination removes a destination, failing with
+// ErrDestinationNotFound if there was none.
+func (s *PGDestinationStore) DeleteDestination(ctx context.Context, clientID string, kind DestinationKind, alias string) error {
+	tag, err := s.db.Exec(ctx, `
+		DELETE FROM reference.capa_destinations
+		WHERE client_id = $1 AND kind = $2 AND alias = $3;
+	`, clientID, kind, alias)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDestinationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package capa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memDestinationStore is an in-memory DestinationStore.
+type memDestinationStore struct {
+	mu    sync.Mutex
+	dests []Destination
+}
+
+func (m *memDestinationStore) ListDestinations(_ context.Context, clientID string) ([]Destination, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Destination
+	for _, d := range m.dests {
+		if d.ClientID == clientID {
+			out = append(out, d)
+		}
+	}
+	return out, nil
+}
+
+func (m *memDestinationStore) InsertDestination(_ context.Context, d Destination) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range m.dests {
+		if e.ClientID == d.ClientID && e.Kind == d.Kind && e.Alias == d.Alias {
+			return ErrDestinationExists
+		}
+	}
+	m.dests = append(m.dests, d)
+	return nil
+}
+
+func (m *memDestinationStore) UpdateDestination(_ context.Context, d Destination) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, e := range m.dests {
+		if e.ClientID == d.ClientID && e.Kind == d.Kind && e.Alias == d.Alias {
+			m.dests[i] = d
+			return nil
+		}
+	}
+	return ErrDestinationNotFound
+}
+
+func (m *memDestinationStore) DeleteDestination(_ context.Context, clientID string, kind DestinationKind, alias string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, e := range m.dests {
+		if e.ClientID == clientID && e.Kind == kind && e.Alias == alias {
+			m.dests = append(m.dests[:i], m.dests[i+1:]...)
+			return nil
+		}
+	}
+	return ErrDestinationNotFound
+}
+
+// newDestinationServer registers receivers and wallets with sequential IDs
+// (rcv-001, wal-001, ...) and records every request as "METHOD path".
+func newDestinationServer(t *testing.T, requests *[]string) *httptest.Server {
+	t.Helper()
+	var receivers, wallets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/partner/v2/receivers":
+			var req CapaReceiverRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			receivers++
+			writeJSON(w, CapaReceiver{ID: fmt.Sprintf("rcv-%03d", receivers), Name: req.Name})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/partner/v2/wallets":
+			wallets++
+			writeJSON(w, CapaWalletResponse{ID: fmt.Sprintf("wal-%03d", wallets)})
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newTestDestinations(t *testing.T, requests *[]string) (*Destinations, *memDestinationStore) {
+	t.Helper()
+	svc := newTestService(t, newDestinationServer(t, requests).URL)
+	store := &memDestinationStore{}
+	return NewDestinations(svc.client, svc.configResolver, store), store
+}
+
+func TestDestinations_AddReceiverMasksAccount(t *testing.T) {
+	var requests []string
+	dests, store := newTestDestinations(t, &requests)
+
+	dest, err := dests.AddReceiver(context.Background(), "client-001", ReceiverInput{
+		Alias:         "mx-ops",
+		Name:          "Ops MX",
+		Country:       "mx",
+		Currency:      "mxn",
+		AccountNumber: "012345678901234567",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rcv-001", dest.CapaID)
+	assert.Equal(t, "MXN", dest.Currency)
+	assert.Equal(t, "**************4567", dest.Account)
+	assert.Equal(t, []string{"POST /api/partner/v2/receivers"}, requests)
+	require.Len(t, store.dests, 1)
+}
+
+func TestDestinations_DuplicateAliasNotRegistered(t *testing.T) {
+	var requests []string
+	dests, _ := newTestDestinations(t, &requests)
+	in := WalletInput{Alias: "treasury", Address: "0xabc", Blockchain: "pol", Token: "usdc"}
+
+	_, err := dests.AddWallet(context.Background(), "client-001", in)
+	require.NoError(t, err)
+	_, err = dests.AddWallet(context.Background(), "client-001", in)
+	assert.True(t, errors.Is(err, ErrDestinationExists), "got %v", err)
+	assert.Len(t, requests, 1, "duplicate must not be registered with Capa")
+}
+
+func TestDestinations_Validation(t *testing.T) {
+	var requests []string
+	dests, _ := newTestDestinations(t, &requests)
+	ctx := context.Background()
+
+	_, err := dests.AddWallet(ctx, "client-001", WalletInput{Alias: "Bad Alias", Address: "0x1", Blockchain: "POL", Token: "USDC"})
+	assert.ErrorContains(t, err, "alias")
+	_, err = dests.AddWallet(ctx, "client-001", WalletInput{Alias: "w", Address: "0x1", Blockchain: "POL", Token: "DOGE"})
+	assert.ErrorContains(t, err, "token")
+	_, err = dests.AddReceiver(ctx, "client-001", ReceiverInput{Alias: "r", Name: "n", Country: "MX", Currency: "USDC", AccountNumber: "1"})
+	assert.ErrorContains(t, err, "fiat")
+	assert.Empty(t, requests)
+}
+
+func TestDestinations_Remove(t *testing.T) {
+	var requests []string
+	dests, store := newTestDestinations(t, &requests)
+	ctx := context.Background()
+
+	_, err := dests.AddWallet(ctx, "client-001", WalletInput{Alias: "treasury", Address: "0xabc", Blockchain: "POL", Token: "USDC"})
+	require.NoError(t, err)
+
+	require.NoError(t, dests.Remove(ctx, "client-001", DestinationWallet, "treasury"))
+	assert.Equal(t, "DELETE /api/partner/v2/wallets/wal-001", requests[len(requests)-1])
+	assert.Empty(t, store.dests)
+
+	err = dests.Remove(ctx, "client-001", DestinationWallet, "treasury")
+	assert.True(t, errors.Is(err, ErrDestinationNotFound), "got %v", err)
+}
+
+func TestDestinations_UpdateWallet(t *testing.T) {
+	var requests []string
+	dests, store := newTestDestinations(t, &requests)
+	ctx := context.Background()
+
+	_, err := dests.AddWallet(ctx, "client-001", WalletInput{Alias: "treasury", Address: "0xabc", Blockchain: "POL", Token: "USDC"})
+	require.NoError(t, err)
+
+	dest, err := dests.UpdateWallet(ctx, "client-001", WalletInput{Alias: "treasury", Address: "0xdef", Blockchain: "ETH", Token: "USDC"})
+	require.NoError(t, err)
+	assert.Equal(t, "wal-002", dest.CapaID)
+	assert.Equal(t, []string{
+		"POST /api/partner/v2/wallets",
+		"POST /api/partner/v2/wallets",
+		"DELETE /api/partner/v2/wallets/wal-001",
+	}, requests, "the new wallet is registered before the old one is removed")
+	require.Len(t, store.dests, 1)
+	assert.Equal(t, "0xdef", store.dests[0].Address)
+
+	_, err = dests.UpdateWallet(ctx, "client-001", WalletInput{Alias: "unknown", Address: "0xdef", Blockchain: "ETH", Token: "USDC"})
+	assert.True(t, errors.Is(err, ErrDestinationNotFound), "got %v", err)
+	assert.Len(t, requests, 3, "unknown aliases are not registered with Capa")
+}
+
+func TestService_RoutesToStoredDestinations(t *testing.T) {
+	var calls []executeCall
+	svc := newTestService(t, newRoutingServer(t, &calls).URL)
+	store := &memDestinationStore{dests: []Destination{
+		{ClientID: "client-001", Kind: DestinationReceiver, Alias: "mx-ops", CapaID: "rcv-mx"},
+		{ClientID: "client-001", Kind: DestinationReceiver, Alias: "mx-payroll", CapaID: "rcv-payroll"},
+	}}
+	svc.SetDestinations(NewDestinations(svc.client, svc.configResolver, store))
+
+	quoteAndExecute(t, svc, "USDC:MXN", "mx-ops")
+
+	// The execute request may redirect to another whitelisted receiver.
+	_, err := svc.CreateRFQ(context.Background(), rfqTo("USDC:MXN", "mx-ops"))
+	require.NoError(t, err)
+	_, err = svc.ExecuteRFQTo(context.Background(), "client-001", "capa-qt-001", "mx-payroll")
+	require.NoError(t, err)
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, "rcv-mx", calls[0].body["receiverId"])
+	assert.Equal(t, "rcv-payroll", calls[1].body["receiverId"])
+
+	// Unlisted receivers are refused at execution too.
+	_, err = svc.CreateRFQ(context.Background(), rfqTo("USDC:MXN", "mx-ops"))
+	require.NoError(t, err)
+	_, err = svc.ExecuteRFQTo(context.Background(), "client-001", "capa-qt-001", "rcv-unknown")
+	assert.True(t, errors.Is(err, ErrUnknownDestination), "got %v", err)
+	assert.Len(t, calls, 2)
+}
+
+func TestService_RefusesDestinationRemovedAfterQuote(t *testing.T) {
+	var calls []executeCall
+	svc := newTestService(t, newRoutingServer(t, &calls).URL)
+	store := &memDestinationStore{dests: []Destination{
+		{ClientID: "client-001", Kind: DestinationReceiver, Alias: "mx-ops", CapaID: "rcv-mx"},
+	}}
+	svc.SetDestinations(NewDestinations(svc.client, svc.configResolver, store))
+
+	_, err := svc.CreateRFQ(context.Background(), rfqTo("USDC:MXN", "mx-ops"))
+	require.NoError(t, err)
+	require.NoError(t, store.DeleteDestination(context.Background(), "client-001", DestinationReceiver, "mx-ops"))
+
+	_, err = svc.ExecuteRFQ(context.Background(), "client-001", "capa-qt-001")
+	assert.True(t, errors.Is(err, ErrUnknownDestination), "got %v", err)
+	assert.Empty(t, calls, "a receiver removed after quoting must not be paid")
+}
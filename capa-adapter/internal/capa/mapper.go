@@ -132,22 +132,22 @@ func (m *Mapper) ToCrossRampExecuteRequest(userID, quoteID string) *CapaCrossRam
 }
 
 // ToOnRampExecuteRequest builds the execute payload for an on-ramp transaction.
-func (m *Mapper) ToOnRampExecuteRequest(userID, quoteID string, cfg *CapaClientConfig) *CapaOnRampExecuteRequest {
+func (m *Mapper) ToOnRampExecuteRequest(userID, quoteID string, wallet CapaWallet) *CapaOnRampExecuteRequest {
 	return &CapaOnRampExecuteRequest{
 		UserID:           userID,
 		QuoteID:          quoteID,
-		WalletAddress:    cfg.WalletAddress,
-		BlockchainSymbol: cfg.BlockchainSymbol,
-		TokenSymbol:      cfg.TokenSymbol,
+		WalletAddress:    wallet.Address,
+		BlockchainSymbol: wallet.Blockchain,
+		TokenSymbol:      wallet.Token,
 	}
 }
 
 // ToOffRampExecuteRequest builds the execute payload for an off-ramp transaction.
-func (m *Mapper) ToOffRampExecuteRequest(userID, quoteID string, cfg *CapaClientConfig) *CapaOffRampExecuteRequest {
+func (m *Mapper) ToOffRampExecuteRequest(userID, quoteID, receiverID string) *CapaOffRampExecuteRequest {
 	return &CapaOffRampExecuteRequest{
 		UserID:     userID,
 		QuoteID:    quoteID,
-		ReceiverID: cfg.ReceiverID,
+		ReceiverID: receiverID,
 	}
 }
 
@@ -8,9 +8,13 @@ import (
 
 	"github.com/Checker-Finance/adapters/capa-adapter/internal/metrics"
 	"github.com/Checker-Finance/adapters/capa-adapter/pkg/config"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // Poller continuously checks Capa transaction status for active trades.
@@ -80,11 +84,14 @@ func (p *Poller) PollTradeStatus(
 
 	ctx, cancel := context.WithCancel(parentCtx)
 	p.activeTrades.Store(txID, cancel)
+	submitted := time.Now()
+	untrack := sharedmetrics.TrackPolledTrade("capa")
 
 	go func() {
 		defer func() {
 			p.activeTrades.Delete(txID)
 			cancel()
+			untrack()
 		}()
 
 		ticker := time.NewTicker(p.pollInterval)
@@ -108,7 +115,10 @@ func (p *Poller) PollTradeStatus(
 				return
 
 			case <-ticker.C:
-				tx, err := p.service.FetchTransactionStatus(ctx, clientID, txID)
+				pollStart := time.Now()
+				pollCtx, raw := httpclient.CaptureResponse(ctx)
+				tx, err := p.service.FetchTransactionStatus(pollCtx, clientID, txID)
+				sharedmetrics.ObservePoll("capa", pollStart)
 				if err != nil {
 					slog.Warn("capa.trade_poll_error",
 						"tx_id", txID,
@@ -122,6 +132,15 @@ func (p *Poller) PollTradeStatus(
 
 				// Emit status change only when it actually changes
 				if status != lastStatus {
+					p.service.auditor.Record(ctx, audit.Event{
+						Kind:       audit.KindStatusTransition,
+						ClientID:   clientID,
+						QuoteID:    quoteID,
+						TradeID:    txID,
+						PrevStatus: lastStatus,
+						Status:     status,
+						Source:     "poller",
+					}, raw.Body)
 					lastStatus = status
 
 					if p.publisher != nil {
@@ -150,6 +169,10 @@ func (p *Poller) PollTradeStatus(
 				}
 
 				if IsTerminalStatus(rawStatus) {
+					sharedmetrics.ObserveTimeToTerminal("capa", status, submitted)
+					if status == model.StatusFilled {
+						sharedmetrics.ObserveQuoteFill("capa", quoteID, time.Now())
+					}
 					p.handleTerminalStatus(ctx, clientID, txID, quoteID, tx, status)
 					return
 				}
@@ -167,16 +190,29 @@ func (p *Poller) handleTerminalStatus(
 	tx *CapaTransaction,
 	status string,
 ) {
-	// 1. Sync to legacy database
+	finalSubject := tradeEventSubject(status)
+	msgID := publisher.TradeEventID("CAPA", txID, status)
+	event := map[string]any{
+		"client_id": clientID,
+		"trade_id":  txID,
+		"quote_id":  quoteID,
+		"status":    status,
+		"final":     true,
+		"timestamp": time.Now().UTC(),
+	}
+
+	// 1. Sync to legacy database, queueing the final event in the same transaction
+	queued := false
 	if p.tradeSync != nil {
 		trade := p.service.BuildTradeConfirmationFromTx(clientID, tx)
 		if trade != nil {
-			if err := p.tradeSync.SyncTradeUpsert(ctx, trade); err != nil {
+			if err := p.tradeSync.SyncTradeWithEvent(ctx, trade, finalSubject, msgID, event); err != nil {
 				slog.Warn("legacy.trade_sync_failed",
 					"tx_id", txID,
 					"client", clientID,
 					"error", err)
 			} else {
+				queued = true
 				slog.Info("legacy.trade_sync_upsert",
 					"tx_id", txID,
 					"client", clientID,
@@ -186,17 +222,9 @@ func (p *Poller) handleTerminalStatus(
 		}
 	}
 
-	// 2. Emit final event
-	if p.publisher != nil {
-		finalSubject := tradeEventSubject(status)
-		if err := p.publisher.Publish(ctx, finalSubject, map[string]any{
-			"client_id": clientID,
-			"trade_id":  txID,
-			"quote_id":  quoteID,
-			"status":    status,
-			"final":     true,
-			"timestamp": time.Now().UTC(),
-		}); err != nil {
+	// 2. Emit final event directly when it could not be queued
+	if !queued && p.publisher != nil {
+		if err := p.publisher.Publish(ctx, finalSubject, event, publisher.WithMsgID(msgID)); err != nil {
 			metrics.IncNATSPublishError(finalSubject)
 			slog.Debug("nats.publish_failed",
 				"subject", finalSubject,
@@ -1,31 +1,49 @@
 package capa
 
 import (
+	"strings"
 	"time"
 
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
+// capaProduct returns a supported Capa pair. Size limits are in the pair's
+// base currency.
+func capaProduct(symbol string, minSize, maxSize float64, pricePrecision int) model.Product {
+	return model.Product{
+		VenueCode:        "CAPA",
+		InstrumentSymbol: symbol,
+		ProductID:        symbol,
+		ProductName:      symbol,
+		MinSize:          minSize,
+		MaxSize:          maxSize,
+		SizeIncrement:    0.01,
+		PricePrecision:   pricePrecision,
+		NotionalCurrency: symbol[:strings.Index(symbol, "/")],
+		AsOf:             time.Time{},
+	}
+}
+
 // capaSupportedProducts is the static list of Capa supported currency pairs.
 // Capa supports LATAM fiat/crypto conversions across MXN, DOP, USD, EUR and
 // multiple blockchains. This list covers the cross-ramp, on-ramp, and off-ramp flows.
 var capaSupportedProducts = []model.Product{
 	// Cross-ramp (fiat ↔ fiat)
-	{VenueCode: "CAPA", InstrumentSymbol: "USD/MXN", ProductID: "USD/MXN", ProductName: "USD/MXN", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "USD/DOP", ProductID: "USD/DOP", ProductName: "USD/DOP", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "EUR/MXN", ProductID: "EUR/MXN", ProductName: "EUR/MXN", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "EUR/DOP", ProductID: "EUR/DOP", ProductName: "EUR/DOP", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "MXN/DOP", ProductID: "MXN/DOP", ProductName: "MXN/DOP", AsOf: time.Time{}},
+	capaProduct("USD/MXN", 50, 1_000_000, 4),
+	capaProduct("USD/DOP", 50, 1_000_000, 4),
+	capaProduct("EUR/MXN", 50, 1_000_000, 4),
+	capaProduct("EUR/DOP", 50, 1_000_000, 4),
+	capaProduct("MXN/DOP", 1_000, 20_000_000, 6),
 	// On-ramp (fiat → crypto)
-	{VenueCode: "CAPA", InstrumentSymbol: "USD/USDC", ProductID: "USD/USDC", ProductName: "USD/USDC", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "USD/USDT", ProductID: "USD/USDT", ProductName: "USD/USDT", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "MXN/USDC", ProductID: "MXN/USDC", ProductName: "MXN/USDC", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "MXN/USDT", ProductID: "MXN/USDT", ProductName: "MXN/USDT", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "DOP/USDC", ProductID: "DOP/USDC", ProductName: "DOP/USDC", AsOf: time.Time{}},
+	capaProduct("USD/USDC", 50, 1_000_000, 6),
+	capaProduct("USD/USDT", 50, 1_000_000, 6),
+	capaProduct("MXN/USDC", 1_000, 20_000_000, 6),
+	capaProduct("MXN/USDT", 1_000, 20_000_000, 6),
+	capaProduct("DOP/USDC", 3_000, 60_000_000, 6),
 	// Off-ramp (crypto → fiat)
-	{VenueCode: "CAPA", InstrumentSymbol: "USDC/MXN", ProductID: "USDC/MXN", ProductName: "USDC/MXN", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "USDT/MXN", ProductID: "USDT/MXN", ProductName: "USDT/MXN", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "USDC/USD", ProductID: "USDC/USD", ProductName: "USDC/USD", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "USDT/USD", ProductID: "USDT/USD", ProductName: "USDT/USD", AsOf: time.Time{}},
-	{VenueCode: "CAPA", InstrumentSymbol: "USDC/DOP", ProductID: "USDC/DOP", ProductName: "USDC/DOP", AsOf: time.Time{}},
+	capaProduct("USDC/MXN", 50, 1_000_000, 4),
+	capaProduct("USDT/MXN", 50, 1_000_000, 4),
+	capaProduct("USDC/USD", 50, 1_000_000, 6),
+	capaProduct("USDT/USD", 50, 1_000_000, 6),
+	capaProduct("USDC/DOP", 50, 1_000_000, 4),
 }
@@ -0,0 +1,319 @@
+package capa
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/Checker-Finance/adapters/capa-adapter/internal/metrics"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/publisher"
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+// SubjectReconDiscrepancy is published for every discrepancy found.
+const SubjectReconDiscrepancy = "evt.recon.discrepancy.v1"
+
+// Discrepancy kinds reported by the Reconciler.
+const (
+	// DiscrepancyMissing: a terminal Capa transaction has no activity.t_order row.
+	DiscrepancyMissing = "missing"
+	// DiscrepancyStaleStatus: the synced row has a different status than Capa.
+	DiscrepancyStaleStatus = "stale_status"
+	// DiscrepancyMismatch: statuses agree but price or quantity differ.
+	DiscrepancyMismatch = "mismatch"
+)
+
+// TradeLedger reads and writes the trades synced to the legacy database.
+// *legacy.TradeSyncWriter satisfies it.
+type TradeLedger interface {
+	LookupTrades(ctx context.Context, tradeIDs []string) (map[string]legacy.SyncedTrade, error)
+	SyncTradeUpsert(ctx context.Context, trade *model.TradeConfirmation) error
+	SyncTradeWithEvent(ctx context.Context, trade *model.TradeConfirmation, subject, msgID string, payload any) error
+}
+
+// ReconcilerConfig tunes the transaction reconciler.
+type ReconcilerConfig struct {
+	// Interval between reconciliation runs.
+	Interval time.Duration
+	// Lookback limits reconciliation to transactions created this recently.
+	Lookback time.Duration
+}
+
+// Discrepancy describes one disagreement between Capa and activity.t_order.
+type Discrepancy struct {
+	Kind     string `json:"kind"`
+	TradeID  string `json:"trade_id"`
+	Field    string `json:"field,omitempty"`
+	Expected string `json:"expected,omitempty"` // Capa value
+	Actual   string `json:"actual,omitempty"`   // synced value
+}
+
+// ReconciliationReport is the outcome of reconciling one client.
+type ReconciliationReport struct {
+	ClientID      string        `json:"client_id"`
+	Checked       int           `json:"checked"`
+	Repaired      int           `json:"repaired"`
+	Resumed       int           `json:"resumed"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+}
+
+// Reconciler periodically lists each client's recent Capa transactions and
+// repairs activity.t_order for any whose webhook and poll were both missed:
+// missing or stale rows are upserted and their final event is published.
+// Pending transactions that are not being polled have polling resumed.
+type Reconciler struct {
+	service   *Service
+	ledger    TradeLedger
+	publisher *publisher.Publisher
+	cfg       ReconcilerConfig
+	now       func() time.Time
+}
+
+// NewReconciler constructs a Reconciler writing repairs through ledger.
+func NewReconciler(service *Service, ledger TradeLedger, pub *publisher.Publisher, cfg ReconcilerConfig) *Reconciler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Minute
+	}
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 24 * time.Hour
+	}
+	return &Reconciler{
+		service:   service,
+		ledger:    ledger,
+		publisher: pub,
+		cfg:       cfg,
+		now:       time.Now,
+	}
+}
+
+// Start runs reconciliation every Interval until ctx is cancelled.
+func (r *Reconciler) Start(ctx context.Context) {
+	slog.Info("capa.recon.started", "interval", r.cfg.Interval, "lookback", r.cfg.Lookback)
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		}
+	}
+}
+
+// RunOnce reconciles every discovered client.
+func (r *Reconciler) RunOnce(ctx context.Context) {
+	clients, err := r.service.configResolver.DiscoverClients(ctx)
+	if err != nil {
+		slog.Warn("capa.recon.discover_failed", "error", err)
+		return
+	}
+	for _, clientID := range clients {
+		if _, err := r.Reconcile(ctx, clientID); err != nil {
+			slog.Warn("capa.recon.failed", "client", clientID, "error", err)
+		}
+	}
+}
+
+// Reconcile compares one client's recent Capa transactions with the synced
+// trades and repairs any differences.
+func (r *Reconciler) Reconcile(ctx context.Context, clientID string) (*ReconciliationReport, error) {
+	txs, err := r.service.ListTransactions(ctx, clientID)
+	if err != nil {
+		metrics.IncReconciliationRun("error")
+		return nil, fmt.Errorf("capa.recon: %w", err)
+	}
+	txs = r.recent(txs)
+
+	ids := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		if IsTerminalStatus(tx.Status) {
+			ids = append(ids, tx.ID)
+		}
+	}
+	synced, err := r.ledger.LookupTrades(ctx, ids)
+	if err != nil {
+		metrics.IncReconciliationRun("error")
+		return nil, fmt.Errorf("capa.recon: lookup synced trades: %w", err)
+	}
+
+	report := &ReconciliationReport{ClientID: clientID, Checked: len(txs)}
+	for i := range txs {
+		tx := &txs[i]
+		if !IsTerminalStatus(tx.Status) {
+			if r.resumePolling(ctx, clientID, tx) {
+				report.Resumed++
+			}
+			continue
+		}
+
+		trade := r.service.BuildTradeConfirmationFromTx(clientID, tx)
+		found := diffTrade(trade, synced)
+		if len(found) == 0 {
+			continue
+		}
+		report.Discrepancies = append(report.Discrepancies, found...)
+
+		// A missing row or stale status means the final event was never
+		// published either; queue it with the repair.
+		if err := r.repair(ctx, trade, found[0].Kind != DiscrepancyMismatch); err != nil {
+			slog.Warn("capa.recon.repair_failed",
+				"client", clientID,
+				"tx_id", tx.ID,
+				"error", err)
+			r.reportDiscrepancies(ctx, clientID, found, false)
+			continue
+		}
+		report.Repaired++
+		r.reportDiscrepancies(ctx, clientID, found, true)
+		if found[0].Kind != DiscrepancyMismatch {
+			r.service.auditor.Record(ctx, audit.Event{
+				Kind:       audit.KindStatusTransition,
+				ClientID:   clientID,
+				QuoteID:    tx.QuoteID,
+				TradeID:    tx.ID,
+				PrevStatus: found[0].Actual,
+				Status:     trade.Status,
+				Source:     "reconciler",
+			}, tx)
+		}
+	}
+
+	result := "ok"
+	if len(report.Discrepancies) > 0 {
+		result = "discrepancies"
+	}
+	metrics.IncReconciliationRun(result)
+
+	slog.Info("capa.recon.completed",
+		"client", clientID,
+		"checked", report.Checked,
+		"discrepancies", len(report.Discrepancies),
+		"repaired", report.Repaired,
+		"resumed", report.Resumed)
+	return report, nil
+}
+
+// recent drops transactions created before the lookback window. Transactions
+// without a parseable creation time are kept.
+func (r *Reconciler) recent(txs []CapaTransaction) []CapaTransaction {
+	cutoff := r.now().Add(-r.cfg.Lookback)
+	out := txs[:0]
+	for _, tx := range txs {
+		if created, err := time.Parse(time.RFC3339, tx.CreatedAt); err == nil && created.Before(cutoff) {
+			continue
+		}
+		out = append(out, tx)
+	}
+	return out
+}
+
+// resumePolling restarts status polling for a pending transaction whose poll
+// was lost, e.g. to a restart. Reports whether polling was started.
+func (r *Reconciler) resumePolling(ctx context.Context, clientID string, tx *CapaTransaction) bool {
+	p := r.service.poller
+	if p == nil {
+		return false
+	}
+	if _, active := p.activeTrades.Load(tx.ID); active {
+		return false
+	}
+	slog.Info("capa.recon.polling_resumed",
+		"client", clientID,
+		"tx_id", tx.ID,
+		"status", tx.Status)
+	p.PollTradeStatus(r.service.ctx, clientID, tx.QuoteID, tx.ID)
+	return true
+}
+
+// diffTrade compares a terminal trade with its synced row.
+func diffTrade(trade *model.TradeConfirmation, synced map[string]legacy.SyncedTrade) []Discrepancy {
+	row, ok := synced[trade.TradeID]
+	if !ok {
+		return []Discrepancy{{Kind: DiscrepancyMissing, TradeID: trade.TradeID, Expected: trade.Status}}
+	}
+	if row.Status != trade.Status {
+		return []Discrepancy{{
+			Kind:     DiscrepancyStaleStatus,
+			TradeID:  trade.TradeID,
+			Field:    "status",
+			Expected: trade.Status,
+			Actual:   row.Status,
+		}}
+	}
+
+	var out []Discrepancy
+	mismatch := func(field string, expected, actual float64) {
+		if math.Abs(expected-actual) <= 1e-9*math.Max(1, math.Abs(expected)) {
+			return
+		}
+		out = append(out, Discrepancy{
+			Kind:     DiscrepancyMismatch,
+			TradeID:  trade.TradeID,
+			Field:    field,
+			Expected: fmt.Sprint(expected),
+			Actual:   fmt.Sprint(actual),
+		})
+	}
+	mismatch("price", trade.Price, row.Price)
+	mismatch("quantity", trade.Quantity, row.Quantity)
+	return out
+}
+
+// reportDiscrepancies logs, counts and publishes discrepancies for a trade.
+func (r *Reconciler) reportDiscrepancies(ctx context.Context, clientID string, found []Discrepancy, repaired bool) {
+	for _, d := range found {
+		metrics.IncDiscrepancy(d.Kind)
+		slog.Warn("capa.recon.discrepancy",
+			"client", clientID,
+			"kind", d.Kind,
+			"tx_id", d.TradeID,
+			"field", d.Field,
+			"capa", d.Expected,
+			"synced", d.Actual,
+			"repaired", repaired)
+
+		if r.publisher == nil {
+			continue
+		}
+		if err := r.publisher.Publish(ctx, SubjectReconDiscrepancy, map[string]any{
+			"venue":       "CAPA",
+			"client_id":   clientID,
+			"trade_id":    d.TradeID,
+			"kind":        d.Kind,
+			"field":       d.Field,
+			"expected":    d.Expected,
+			"actual":      d.Actual,
+			"repaired":    repaired,
+			"detected_at": r.now().UTC(),
+		}); err != nil {
+			metrics.IncNATSPublishError(SubjectReconDiscrepancy)
+			slog.Warn("capa.publish_failed",
+				"subject", SubjectReconDiscrepancy,
+				"error", err)
+		}
+	}
+}
+
+// repair upserts the trade's row, together with its final event when
+// withEvent is set.
+func (r *Reconciler) repair(ctx context.Context, trade *model.TradeConfirmation, withEvent bool) error {
+	if !withEvent {
+		return r.ledger.SyncTradeUpsert(ctx, trade)
+	}
+	subject := tradeEventSubject(trade.Status)
+	msgID := publisher.TradeEventID("CAPA", trade.TradeID, trade.Status)
+	return r.ledger.SyncTradeWithEvent(ctx, trade, subject, msgID, map[string]any{
+		"client_id": trade.ClientID,
+		"trade_id":  trade.TradeID,
+		"quote_id":  trade.ProviderRFQID,
+		"status":    trade.Status,
+		"final":     true,
+		"source":    "reconciler",
+		"timestamp": time.Now().UTC(),
+	})
+}
@@ -0,0 +1,159 @@
+package capa
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+// fakeLedger is an in-memory TradeLedger.
+type fakeLedger struct {
+	rows      map[string]legacy.SyncedTrade
+	upserted  []*model.TradeConfirmation
+	events    map[string]string // trade ID -> queued event subject
+	lookupErr error
+}
+
+func (l *fakeLedger) LookupTrades(_ context.Context, ids []string) (map[string]legacy.SyncedTrade, error) {
+	if l.lookupErr != nil {
+		return nil, l.lookupErr
+	}
+	out := make(map[string]legacy.SyncedTrade)
+	for _, id := range ids {
+		if row, ok := l.rows[id]; ok {
+			out[id] = row
+		}
+	}
+	return out, nil
+}
+
+func (l *fakeLedger) SyncTradeUpsert(_ context.Context, trade *model.TradeConfirmation) error {
+	l.upserted = append(l.upserted, trade)
+	return nil
+}
+
+func (l *fakeLedger) SyncTradeWithEvent(_ context.Context, trade *model.TradeConfirmation, subject, _ string, _ any) error {
+	l.upserted = append(l.upserted, trade)
+	if l.events == nil {
+		l.events = make(map[string]string)
+	}
+	l.events[trade.TradeID] = subject
+	return nil
+}
+
+// newTransactionsServer serves txs from GET /api/partner/v2/transactions.
+func newTransactionsServer(t *testing.T, txs []CapaTransaction) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/partner/v2/transactions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, map[string]any{"transactions": txs})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func completedTx(id string, createdAt time.Time) CapaTransaction {
+	return CapaTransaction{
+		ID:                  id,
+		QuoteID:             "qt-" + id,
+		UserID:              "user-001",
+		SourceCurrency:      "USD",
+		DestinationCurrency: "MXN",
+		SourceAmount:        1000,
+		ExchangeRate:        17.0,
+		Status:              "COMPLETED",
+		CreatedAt:           createdAt.Format(time.RFC3339),
+	}
+}
+
+func TestReconciler_RepairsMissingAndStaleTrades(t *testing.T) {
+	now := time.Now()
+	txs := []CapaTransaction{
+		completedTx("tx-synced", now),
+		completedTx("tx-missing", now),
+		completedTx("tx-stale", now),
+		completedTx("tx-price", now),
+		completedTx("tx-old", now.Add(-48*time.Hour)),
+	}
+	other := completedTx("tx-other-user", now)
+	other.UserID = "user-999"
+	txs = append(txs, other)
+
+	ledger := &fakeLedger{rows: map[string]legacy.SyncedTrade{
+		"tx-synced": {TradeID: "tx-synced", Status: "filled", Price: 17.0, Quantity: 1000},
+		"tx-stale":  {TradeID: "tx-stale", Status: "pending", Price: 17.0, Quantity: 1000},
+		"tx-price":  {TradeID: "tx-price", Status: "filled", Price: 16.5, Quantity: 1000},
+	}}
+
+	svc := newTestService(t, newTransactionsServer(t, txs).URL)
+	r := NewReconciler(svc, ledger, nil, ReconcilerConfig{Lookback: 24 * time.Hour})
+
+	report, err := r.Reconcile(context.Background(), "client-001")
+	require.NoError(t, err)
+	assert.Equal(t, 4, report.Checked, "old and other users' transactions are skipped")
+	assert.Equal(t, 3, report.Repaired)
+
+	kinds := map[string]string{}
+	for _, d := range report.Discrepancies {
+		kinds[d.TradeID] = d.Kind
+	}
+	assert.Equal(t, map[string]string{
+		"tx-missing": DiscrepancyMissing,
+		"tx-stale":   DiscrepancyStaleStatus,
+		"tx-price":   DiscrepancyMismatch,
+	}, kinds)
+
+	require.Len(t, ledger.upserted, 3)
+	for _, trade := range ledger.upserted {
+		assert.Equal(t, "filled", trade.Status)
+		assert.Equal(t, "client-001", trade.ClientID)
+	}
+	assert.Equal(t, map[string]string{
+		"tx-missing": "evt.trade.filled.v1.CAPA",
+		"tx-stale":   "evt.trade.filled.v1.CAPA",
+	}, ledger.events, "final events are queued only for rows that never announced the fill")
+}
+
+func TestReconciler_ResumesPollingForPendingTransactions(t *testing.T) {
+	pending := completedTx("tx-pending", time.Now())
+	pending.Status = "IN_PROGRESS"
+
+	svc := newTestService(t, newTransactionsServer(t, []CapaTransaction{pending}).URL)
+	poller := NewPoller(svc.cfg, svc, nil, nil, time.Hour, nil)
+	defer poller.Stop()
+	svc.SetPoller(poller)
+
+	r := NewReconciler(svc, &fakeLedger{}, nil, ReconcilerConfig{})
+	report, err := r.Reconcile(context.Background(), "client-001")
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Resumed)
+	assert.Empty(t, report.Discrepancies)
+
+	_, active := poller.activeTrades.Load("tx-pending")
+	assert.True(t, active)
+
+	report, err = r.Reconcile(context.Background(), "client-001")
+	require.NoError(t, err)
+	assert.Zero(t, report.Resumed, "an active poll is not started twice")
+}
+
+func TestReconciler_LookupErrorFailsRun(t *testing.T) {
+	svc := newTestService(t, newTransactionsServer(t, []CapaTransaction{completedTx("tx-1", time.Now())}).URL)
+	ledger := &fakeLedger{lookupErr: errors.New("db down")}
+
+	_, err := NewReconciler(svc, ledger, nil, ReconcilerConfig{}).Reconcile(context.Background(), "client-001")
+	require.Error(t, err)
+	assert.Empty(t, ledger.upserted)
+}
@@ -0,0 +1,221 @@
+package capa
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// routeTTL is how long a quote's route is kept for execution. Capa quotes
+// expire long before this; the margin covers slow executions.
+const routeTTL = 24 * time.Hour
+
+var (
+	// ErrUnknownDestination is returned when an RFQ names a destination that
+	// is not whitelisted for the client.
+	ErrUnknownDestination = errors.New("capa: destination not whitelisted for client")
+	// ErrNoDestination is returned when an on/off-ramp RFQ names no
+	// destination and the client has no single default.
+	ErrNoDestination = errors.New("capa: no destination configured for client")
+)
+
+// Route is the transaction type and destination chosen for a quote at
+// CreateRFQ time and reused when it is executed.
+type Route struct {
+	ClientID   string          `json:"client_id"`
+	TxType     TransactionType `json:"tx_type"`
+	Wallet     CapaWallet      `json:"wallet,omitzero"`
+	ReceiverID string          `json:"receiver_id,omitempty"`
+}
+
+// resolveRoute picks the destination for a transaction of txType. destination
+// is an alias (or the address/receiver ID itself) from the client's whitelist;
+// empty selects the client's default.
+func resolveRoute(cfg *CapaClientConfig, clientID string, txType TransactionType, destination string) (Route, error) {
+	route := Route{ClientID: clientID, TxType: txType}
+	switch txType {
+	case OnRamp:
+		wallet, err := resolveWallet(cfg, destination)
+		if err != nil {
+			return Route{}, err
+		}
+		route.Wallet = wallet
+	case OffRamp:
+		receiverID, err := resolveReceiver(cfg, destination)
+		if err != nil {
+			return Route{}, err
+		}
+		route.ReceiverID = receiverID
+	default:
+		if destination != "" {
+			return Route{}, fmt.Errorf("%w: cross-ramp quotes take no destination", ErrUnknownDestination)
+		}
+	}
+	return route, nil
+}
+
+// recheckRoute resolves a stored route's destination against the client's
+// current whitelist, so a wallet or receiver removed after the quote was made
+// can no longer be paid out to.
+func recheckRoute(cfg *CapaClientConfig, route Route) (Route, error) {
+	switch route.TxType {
+	case OnRamp:
+		current, err := resolveRoute(cfg, route.ClientID, OnRamp, route.Wallet.Address)
+		if err != nil {
+			return Route{}, err
+		}
+		if current.Wallet != route.Wallet {
+			return Route{}, fmt.Errorf("%w: wallet %q changed since the quote", ErrUnknownDestination, route.Wallet.Address)
+		}
+		return current, nil
+	case OffRamp:
+		return resolveRoute(cfg, route.ClientID, OffRamp, route.ReceiverID)
+	default:
+		return route, nil
+	}
+}
+
+func resolveWallet(cfg *CapaClientConfig, destination string) (CapaWallet, error) {
+	if destination == "" {
+		if cfg.WalletAddress != "" {
+			return CapaWallet{Address: cfg.WalletAddress, Blockchain: cfg.BlockchainSymbol, Token: cfg.TokenSymbol}, nil
+		}
+		if len(cfg.Wallets) == 1 {
+			for _, w := range cfg.Wallets {
+				return w, nil
+			}
+		}
+		return CapaWallet{}, fmt.Errorf("%w: on-ramp needs a wallet", ErrNoDestination)
+	}
+	if w, ok := cfg.Wallets[destination]; ok {
+		return w, nil
+	}
+	for _, w := range cfg.Wallets {
+		if w.Address == destination {
+			return w, nil
+		}
+	}
+	if destination == cfg.WalletAddress {
+		return CapaWallet{Address: cfg.WalletAddress, Blockchain: cfg.BlockchainSymbol, Token: cfg.TokenSymbol}, nil
+	}
+	return CapaWallet{}, fmt.Errorf("%w: wallet %q", ErrUnknownDestination, destination)
+}
+
+func resolveReceiver(cfg *CapaClientConfig, destination string) (string, error) {
+	if destination == "" {
+		if cfg.ReceiverID != "" {
+			return cfg.ReceiverID, nil
+		}
+		if len(cfg.Receivers) == 1 {
+			for _, id := range cfg.Receivers {
+				return id, nil
+			}
+		}
+		return "", fmt.Errorf("%w: off-ramp needs a receiver", ErrNoDestination)
+	}
+	if id, ok := cfg.Receivers[destination]; ok {
+		return id, nil
+	}
+	for _, id := range cfg.Receivers {
+		if id == destination {
+			return id, nil
+		}
+	}
+	if destination == cfg.ReceiverID {
+		return cfg.ReceiverID, nil
+	}
+	return "", fmt.Errorf("%w: receiver %q", ErrUnknownDestination, destination)
+}
+
+// routeKey is the store key holding a quote's route.
+func routeKey(quoteID string) string {
+	return "capa:quote:" + quoteID + ":route"
+}
+
+// cachedRoute is a route held in memory with the time it was recorded.
+type cachedRoute struct {
+	route    Route
+	storedAt time.Time
+}
+
+// rememberRoute records the route chosen for a quote, in memory and in the
+// store so another replica can execute it. StartRouteJanitor drops in-memory
+// routes of quotes that are never executed.
+func (s *Service) rememberRoute(ctx context.Context, quoteID string, route Route) {
+	s.routes.Store(quoteID, cachedRoute{route: route, storedAt: time.Now()})
+	if s.store == nil {
+		return
+	}
+	if err := s.store.SetJSON(ctx, routeKey(quoteID), route, routeTTL); err != nil {
+		slog.Warn("capa.route_store_failed",
+			"quote_id", quoteID,
+			"error", err)
+	}
+}
+
+// lookupRoute returns the route recorded for a quote. ok is false for quotes
+// this adapter did not issue, e.g. before routes were recorded.
+func (s *Service) lookupRoute(ctx context.Context, quoteID string) (route Route, ok bool) {
+	if v, found := s.routes.Load(quoteID); found {
+		if cached := v.(cachedRoute); time.Since(cached.storedAt) < routeTTL {
+			return cached.route, true
+		}
+		s.routes.Delete(quoteID)
+	}
+	if s.store == nil {
+		return Route{}, false
+	}
+	if err := s.store.GetJSON(ctx, routeKey(quoteID), &route); err != nil || route.TxType == "" {
+		return Route{}, false
+	}
+	return route, true
+}
+
+// PruneRoutes forgets in-memory routes older than routeTTL and returns how
+// many it dropped.
+func (s *Service) PruneRoutes() int {
+	cutoff := time.Now().Add(-routeTTL)
+	pruned := 0
+	s.routes.Range(func(key, value any) bool {
+		if value.(cachedRoute).storedAt.Before(cutoff) && s.routes.CompareAndDelete(key, value) {
+			pruned++
+		}
+		return true
+	})
+	return pruned
+}
+
+// StartRouteJanitor runs PruneRoutes every interval until ctx is done.
+func (s *Service) StartRouteJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if n := s.PruneRoutes(); n > 0 {
+				slog.Info("capa.route_janitor.pruned", "count", n)
+			}
+		case <-ctx.Done():
+			slog.Info("capa.route_janitor.stopped")
+			return
+		}
+	}
+}
+
+// legacyRoute infers a route from the client's configured fields, as was done
+// before routes were recorded with quotes.
+func legacyRoute(cfg *CapaClientConfig, clientID string) Route {
+	route := Route{ClientID: clientID, TxType: CrossRamp}
+	switch {
+	case cfg.WalletAddress != "":
+		route.TxType = OnRamp
+		route.Wallet = CapaWallet{Address: cfg.WalletAddress, Blockchain: cfg.BlockchainSymbol, Token: cfg.TokenSymbol}
+	case cfg.ReceiverID != "":
+		route.TxType = OffRamp
+		route.ReceiverID = cfg.ReceiverID
+	}
+	return route
+}
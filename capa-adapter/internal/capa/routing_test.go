@@ -0,0 +1,158 @@
+package capa
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+// executeCall records the endpoint and body of an execute request.
+type executeCall struct {
+	path string
+	body map[string]any
+}
+
+// newRoutingServer quotes every request as capa-qt-001 and records execute calls.
+func newRoutingServer(t *testing.T, calls *[]executeCall) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "quotes") {
+			writeJSON(w, CapaQuoteResponse{ID: "capa-qt-001", ExchangeRate: 1})
+			return
+		}
+		raw, _ := io.ReadAll(r.Body)
+		var body map[string]any
+		_ = json.Unmarshal(raw, &body)
+		*calls = append(*calls, executeCall{path: r.URL.Path, body: body})
+		writeJSON(w, CapaExecuteResponse{Transaction: CapaTransaction{ID: "tx-001", Status: "COMPLETED"}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newRoutingService(t *testing.T, calls *[]executeCall) *Service {
+	t.Helper()
+	svc := newTestService(t, newRoutingServer(t, calls).URL)
+	cfg := svc.configResolver.(*mockConfigResolver).cfg
+	cfg.WalletAddress = "0xdefault"
+	cfg.BlockchainSymbol = "POL"
+	cfg.TokenSymbol = "USDC"
+	cfg.Wallets = map[string]CapaWallet{
+		"treasury": {Address: "0xtreasury", Blockchain: "ETH", Token: "USDC"},
+	}
+	cfg.Receivers = map[string]string{"mx-ops": "rcv-mx"}
+	return svc
+}
+
+func rfqTo(pair, destination string) model.RFQRequest {
+	return model.RFQRequest{
+		ClientID:     "client-001",
+		CurrencyPair: pair,
+		Side:         "SELL",
+		Amount:       1000,
+		Destination:  destination,
+	}
+}
+
+func quoteAndExecute(t *testing.T, svc *Service, pair, destination string) {
+	t.Helper()
+	ctx := context.Background()
+	quote, err := svc.CreateRFQ(ctx, rfqTo(pair, destination))
+	require.NoError(t, err)
+	_, err = svc.ExecuteRFQ(ctx, "client-001", quote.ID)
+	require.NoError(t, err)
+}
+
+func TestRouting_CrossRampForClientWithWallet(t *testing.T) {
+	var calls []executeCall
+	svc := newRoutingService(t, &calls)
+
+	quoteAndExecute(t, svc, "USD:MXN", "")
+
+	require.Len(t, calls, 1)
+	assert.Equal(t, "/api/partner/v2/cross-ramp", calls[0].path, "fiat pair must cross-ramp even with a wallet configured")
+}
+
+func TestRouting_OnRampDefaultAndOverride(t *testing.T) {
+	var calls []executeCall
+	svc := newRoutingService(t, &calls)
+
+	quoteAndExecute(t, svc, "USD:USDC", "")
+	quoteAndExecute(t, svc, "USD:USDC", "treasury")
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, "/api/partner/v2/on-ramp", calls[0].path)
+	assert.Equal(t, "0xdefault", calls[0].body["walletAddress"])
+	assert.Equal(t, "0xtreasury", calls[1].body["walletAddress"])
+	assert.Equal(t, "ETH", calls[1].body["blockchainSymbol"])
+}
+
+func TestRouting_OffRampUsesWhitelistedReceiver(t *testing.T) {
+	var calls []executeCall
+	svc := newRoutingService(t, &calls)
+
+	quoteAndExecute(t, svc, "USDC:MXN", "mx-ops")
+
+	require.Len(t, calls, 1)
+	assert.Equal(t, "/api/partner/v2/off-ramp", calls[0].path)
+	assert.Equal(t, "rcv-mx", calls[0].body["receiverId"])
+}
+
+func TestRouting_RejectsUnknownOrMissingDestination(t *testing.T) {
+	var calls []executeCall
+	svc := newRoutingService(t, &calls)
+	ctx := context.Background()
+
+	_, err := svc.CreateRFQ(ctx, model.RFQRequest{
+		ClientID: "client-001", CurrencyPair: "USD:USDC", Side: "SELL", Amount: 1000, Destination: "0xattacker",
+	})
+	assert.True(t, errors.Is(err, ErrUnknownDestination), "got %v", err)
+
+	// Off-ramp with two receivers and no default must name one.
+	svc.configResolver.(*mockConfigResolver).cfg.Receivers["br-ops"] = "rcv-br"
+	_, err = svc.CreateRFQ(ctx, model.RFQRequest{
+		ClientID: "client-001", CurrencyPair: "USDC:MXN", Side: "SELL", Amount: 1000,
+	})
+	assert.True(t, errors.Is(err, ErrNoDestination), "got %v", err)
+}
+
+func TestRouting_RejectsOtherClientsQuote(t *testing.T) {
+	var calls []executeCall
+	svc := newRoutingService(t, &calls)
+	svc.rememberRoute(context.Background(), "qt-other", Route{ClientID: "client-002", TxType: CrossRamp})
+
+	_, err := svc.ExecuteRFQ(context.Background(), "client-001", "qt-other")
+	require.Error(t, err)
+	assert.Empty(t, calls)
+}
+
+func TestRouting_ExpiredRoutesArePruned(t *testing.T) {
+	var calls []executeCall
+	svc := newRoutingService(t, &calls)
+	svc.routes.Store("qt-old", cachedRoute{
+		route:    Route{ClientID: "client-001", TxType: CrossRamp},
+		storedAt: time.Now().Add(-routeTTL - time.Minute),
+	})
+
+	_, ok := svc.lookupRoute(context.Background(), "qt-old")
+	assert.False(t, ok, "an expired route must not be served")
+
+	svc.routes.Store("qt-old", cachedRoute{storedAt: time.Now().Add(-routeTTL - time.Minute)})
+	svc.rememberRoute(context.Background(), "qt-new", Route{ClientID: "client-001", TxType: CrossRamp})
+	assert.Equal(t, 1, svc.PruneRoutes())
+	_, found := svc.routes.Load("qt-old")
+	assert.False(t, found, "the janitor must sweep expired routes")
+	_, ok = svc.lookupRoute(context.Background(), "qt-new")
+	assert.True(t, ok)
+}
@@ -5,15 +5,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/nats-io/nats.go"
 
 	"github.com/Checker-Finance/adapters/capa-adapter/internal/metrics"
 	"github.com/Checker-Finance/adapters/capa-adapter/pkg/config"
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
@@ -30,6 +35,10 @@ type Service struct {
 	mapper          *Mapper
 	tradeSyncWriter *legacy.TradeSyncWriter
 	poller          *Poller
+	destinations    *Destinations
+	auditor         *audit.Recorder
+
+	routes sync.Map // quoteID → cachedRoute
 }
 
 // NewService constructs a fully wired Capa adapter service.
@@ -61,6 +70,16 @@ func (s *Service) SetPoller(p *Poller) {
 	s.poller = p
 }
 
+// SetDestinations enables the stored destination whitelist for RFQs.
+func (s *Service) SetDestinations(d *Destinations) {
+	s.destinations = d
+}
+
+// SetAuditRecorder sets the recorder for the quote-to-trade audit trail.
+func (s *Service) SetAuditRecorder(r *audit.Recorder) {
+	s.auditor = r
+}
+
 // resolveConfig resolves the per-client Capa configuration.
 func (s *Service) resolveConfig(ctx context.Context, clientID string) (*CapaClientConfig, error) {
 	cfg, err := s.configResolver.Resolve(ctx, clientID)
@@ -73,8 +92,20 @@ func (s *Service) resolveConfig(ctx context.Context, clientID string) (*CapaClie
 	return cfg, nil
 }
 
+// resolveRoutingConfig resolves the client's configuration including its
+// stored destination whitelist.
+func (s *Service) resolveRoutingConfig(ctx context.Context, clientID string) (*CapaClientConfig, error) {
+	cfg, err := s.resolveConfig(ctx, clientID)
+	if err != nil || s.destinations == nil {
+		return cfg, err
+	}
+	return s.destinations.Apply(ctx, clientID, cfg)
+}
+
 // CreateRFQ creates a new executable quote on Capa, routing to the correct endpoint
-// based on the transaction type detected from the currency pair.
+// based on the transaction type detected from the currency pair. The type and
+// destination (req.Destination or the client's default) are recorded with the
+// quote for ExecuteRFQ.
 func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Quote, error) {
 	slog.Info("capa.create_rfq.start",
 		"client", req.ClientID,
@@ -83,7 +114,7 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 		"amount", req.Amount,
 	)
 
-	clientCfg, err := s.resolveConfig(ctx, req.ClientID)
+	clientCfg, err := s.resolveRoutingConfig(ctx, req.ClientID)
 	if err != nil {
 		return nil, err
 	}
@@ -93,19 +124,43 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 		"pair", req.CurrencyPair,
 		"tx_type", string(txType))
 
+	route, err := resolveRoute(clientCfg, req.ClientID, txType, req.Destination)
+	if err != nil {
+		slog.Warn("capa.create_rfq.rejected",
+			"client", req.ClientID,
+			"pair", req.CurrencyPair,
+			"destination", req.Destination,
+			"error", err)
+		return nil, err
+	}
+
 	var quoteResp *CapaQuoteResponse
+	ctx, raw := httpclient.CaptureResponse(ctx)
 	switch txType {
 	case CrossRamp:
 		capaReq := s.mapper.ToCrossRampQuoteRequest(req, clientCfg.UserID)
+		if err := validateRFQ(req, capaReq.AmountCurrency); err != nil {
+			return nil, err
+		}
 		slog.Debug("capa.cross_ramp_quote_request", "json", pretty(capaReq))
+		s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: req.ClientID}, capaReq)
 		quoteResp, err = s.client.GetCrossRampQuote(ctx, clientCfg, capaReq)
 	default: // OnRamp or OffRamp
 		capaReq := s.mapper.ToOnOffRampQuoteRequest(req, clientCfg.UserID, txType)
+		if err := validateRFQ(req, capaReq.AmountCurrency); err != nil {
+			return nil, err
+		}
 		slog.Debug("capa.on_off_ramp_quote_request", "json", pretty(capaReq))
+		s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: req.ClientID}, capaReq)
 		quoteResp, err = s.client.GetQuote(ctx, clientCfg, capaReq)
 	}
 
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindQuoteResponse,
+			ClientID: req.ClientID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Error("capa.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
@@ -113,6 +168,13 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 	}
 
 	quote := s.mapper.FromCapaQuote(quoteResp, req.ClientID)
+	sharedmetrics.RecordQuote("capa", quote.ID, time.Now())
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindQuoteResponse,
+		ClientID: req.ClientID,
+		QuoteID:  quote.ID,
+	}, raw.Body)
+	s.rememberRoute(ctx, quote.ID, route)
 
 	slog.Info("capa.rfq_created",
 		"client", req.ClientID,
@@ -124,47 +186,109 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 	return quote, nil
 }
 
+// validateRFQ checks req against the supported products. amountCurrency is
+// the leg Capa will read the amount in, which depends on side and flow.
+func validateRFQ(req model.RFQRequest, amountCurrency string) error {
+	req.CurrencyAmount = amountCurrency
+	if err := model.ValidateRFQ(capaSupportedProducts, req); err != nil {
+		slog.Warn("capa.create_rfq.rejected",
+			"client", req.ClientID,
+			"pair", req.CurrencyPair,
+			"amount", req.Amount,
+			"amount_currency", amountCurrency,
+			"error", err)
+		return err
+	}
+	return nil
+}
+
 // ExecuteRFQ executes an existing quote on Capa, creating a transaction.
 func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*model.TradeConfirmation, error) {
+	return s.ExecuteRFQTo(ctx, clientID, quoteID, "")
+}
+
+// ExecuteRFQTo executes a quote, paying out to destination (a whitelisted
+// wallet or receiver alias) instead of the one chosen at quote time. An empty
+// destination keeps the quote's.
+func (s *Service) ExecuteRFQTo(ctx context.Context, clientID, quoteID, destination string) (*model.TradeConfirmation, error) {
 	slog.Info("capa.execute_rfq.start",
 		"client", clientID,
 		"quote_id", quoteID,
+		"destination", destination,
 	)
 
-	clientCfg, err := s.resolveConfig(ctx, clientID)
+	clientCfg, err := s.resolveRoutingConfig(ctx, clientID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Determine transaction type from per-client config routing fields.
-	// Clients are configured for a specific flow:
-	//   WalletAddress set → on-ramp (fiat→crypto)
-	//   ReceiverID set    → off-ramp (crypto→fiat)
-	//   otherwise         → cross-ramp (fiat→fiat)
-	txType := clientTxType(clientCfg)
+	// Execute through the endpoint and destination chosen when the quote was
+	// created. Quotes without a recorded route fall back to the client's
+	// configured flow.
+	route, ok := s.lookupRoute(ctx, quoteID)
+	if !ok {
+		route = legacyRoute(clientCfg, clientID)
+		slog.Warn("capa.execute_rfq.route_unknown",
+			"client", clientID,
+			"quote_id", quoteID,
+			"tx_type", string(route.TxType))
+	} else if route.ClientID != clientID {
+		return nil, fmt.Errorf("capa quote %s was not issued to client %q", quoteID, clientID)
+	}
+	if destination != "" {
+		route, err = resolveRoute(clientCfg, clientID, route.TxType, destination)
+	} else {
+		route, err = recheckRoute(clientCfg, route)
+	}
+	if err != nil {
+		slog.Warn("capa.execute_rfq.destination_rejected",
+			"client", clientID,
+			"quote_id", quoteID,
+			"error", err)
+		return nil, err
+	}
 
 	var execResp *CapaExecuteResponse
-	switch txType {
+	ctx, raw := httpclient.CaptureResponse(ctx)
+	requested := audit.Event{Kind: audit.KindExecuteRequest, ClientID: clientID, QuoteID: quoteID}
+	switch route.TxType {
 	case OnRamp:
-		execReq := s.mapper.ToOnRampExecuteRequest(clientCfg.UserID, quoteID, clientCfg)
+		execReq := s.mapper.ToOnRampExecuteRequest(clientCfg.UserID, quoteID, route.Wallet)
+		s.auditor.Record(ctx, requested, execReq)
 		execResp, err = s.client.CreateOnRamp(ctx, clientCfg, execReq)
 	case OffRamp:
-		execReq := s.mapper.ToOffRampExecuteRequest(clientCfg.UserID, quoteID, clientCfg)
+		execReq := s.mapper.ToOffRampExecuteRequest(clientCfg.UserID, quoteID, route.ReceiverID)
+		s.auditor.Record(ctx, requested, execReq)
 		execResp, err = s.client.CreateOffRamp(ctx, clientCfg, execReq)
 	default: // CrossRamp
 		execReq := s.mapper.ToCrossRampExecuteRequest(clientCfg.UserID, quoteID)
+		s.auditor.Record(ctx, requested, execReq)
 		execResp, err = s.client.CreateCrossRamp(ctx, clientCfg, execReq)
 	}
 
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Error("capa.execute_rfq.failed",
 			"client", clientID,
 			"quote_id", quoteID,
 			"error", err)
 		return nil, fmt.Errorf("capa quote execution failed: %w", err)
 	}
+	s.routes.Delete(quoteID)
 
 	trade := s.mapper.FromCapaExecuteResponse(execResp, clientID, quoteID)
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteResponse,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+		TradeID:  trade.TradeID,
+		Status:   trade.Status,
+	}, raw.Body)
 
 	slog.Info("capa.trade_created",
 		"client", clientID,
@@ -187,8 +311,11 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 		slog.Info("capa.starting_status_poll",
 			"transaction_id", trade.TradeID,
 			"client", clientID)
-		go s.poller.PollTradeStatus(s.ctx, clientID, quoteID, trade.TradeID)
+		go s.poller.PollTradeStatus(tracing.CarryCorrelationID(s.ctx, ctx), clientID, quoteID, trade.TradeID)
 	} else if IsTerminalStatus(execResp.Transaction.Status) {
+		if trade.Status == model.StatusFilled {
+			sharedmetrics.ObserveQuoteFill("capa", quoteID, time.Now())
+		}
 		s.syncTerminalTrade(ctx, trade)
 	}
 
@@ -214,6 +341,31 @@ func (s *Service) FetchTransactionStatus(ctx context.Context, clientID, txID str
 	return &resp.Transaction, nil
 }
 
+// ListTransactions returns the client's transactions from Capa. Transactions
+// belonging to another Capa user under the same partner key are dropped.
+func (s *Service) ListTransactions(ctx context.Context, clientID string) ([]CapaTransaction, error) {
+	clientCfg, err := s.resolveConfig(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	txs, err := s.client.GetTransactions(ctx, clientCfg)
+	if err != nil {
+		slog.Warn("capa.list_transactions.failed",
+			"client", clientID,
+			"error", err)
+		return nil, err
+	}
+
+	out := txs[:0]
+	for _, tx := range txs {
+		if tx.UserID == "" || tx.UserID == clientCfg.UserID {
+			out = append(out, tx)
+		}
+	}
+	return out, nil
+}
+
 // BuildTradeConfirmationFromTx converts a Capa transaction to a canonical TradeConfirmation.
 func (s *Service) BuildTradeConfirmationFromTx(clientID string, tx *CapaTransaction) *model.TradeConfirmation {
 	if tx == nil {
@@ -243,8 +395,18 @@ func (s *Service) ListProducts() []model.Product {
 
 // syncTerminalTrade syncs a terminal trade to the legacy database and publishes final event.
 func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfirmation) {
+	subject := tradeEventSubject(trade.Status)
+	msgID := publisher.TradeEventID("CAPA", trade.TradeID, trade.Status)
+	event := map[string]any{
+		"client_id": trade.ClientID,
+		"trade_id":  trade.TradeID,
+		"status":    trade.Status,
+		"final":     true,
+		"timestamp": time.Now().UTC(),
+	}
+
 	if s.tradeSyncWriter != nil {
-		if err := s.tradeSyncWriter.SyncTradeUpsert(ctx, trade); err != nil {
+		if err := s.tradeSyncWriter.SyncTradeWithEvent(ctx, trade, subject, msgID, event); err != nil {
 			slog.Warn("capa.trade_sync_failed",
 				"trade_id", trade.TradeID,
 				"client", trade.ClientID,
@@ -254,20 +416,15 @@ func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfi
 				"trade_id", trade.TradeID,
 				"client", trade.ClientID,
 				"status", trade.Status)
+			return
 		}
 	}
 
+	// The event could not be queued with the trade; publish it directly.
 	if s.publisher == nil {
 		return
 	}
-	subject := tradeEventSubject(trade.Status)
-	if err := s.publisher.Publish(ctx, subject, map[string]any{
-		"client_id": trade.ClientID,
-		"trade_id":  trade.TradeID,
-		"status":    trade.Status,
-		"final":     true,
-		"timestamp": time.Now().UTC(),
-	}); err != nil {
+	if err := s.publisher.Publish(ctx, subject, event, publisher.WithMsgID(msgID)); err != nil {
 		metrics.IncNATSPublishError(subject)
 		slog.Warn("capa.publish_failed",
 			"subject", subject,
@@ -357,17 +514,6 @@ func (s *Service) Config() config.Config {
 	return s.cfg
 }
 
-// clientTxType infers the transaction type from the per-client config routing fields.
-func clientTxType(cfg *CapaClientConfig) TransactionType {
-	if cfg.WalletAddress != "" {
-		return OnRamp
-	}
-	if cfg.ReceiverID != "" {
-		return OffRamp
-	}
-	return CrossRamp
-}
-
 // tradeEventSubject builds a NATS event subject for a trade status.
 // status must already be normalized (lowercase, e.g. "filled", "cancelled", "rejected").
 func tradeEventSubject(status string) string {
@@ -146,6 +146,42 @@ func TestService_CreateRFQ_CrossRamp_Success(t *testing.T) {
 	assert.Equal(t, "CAPA", quote.Venue)
 }
 
+func TestService_CreateRFQ_ValidatesInAmountCurrency(t *testing.T) {
+	server := mockCapaServer(t, &CapaQuoteResponse{ID: "capa-qt-002", ExchangeRate: 17.0}, nil, nil)
+	defer server.Close()
+	svc := newTestService(t, server.URL)
+
+	// Selling USD quotes the USD leg: 10 USD is below the 50 USD minimum.
+	_, err := svc.CreateRFQ(context.Background(), model.RFQRequest{
+		ClientID:     "client-001",
+		CurrencyPair: "USD:MXN",
+		Side:         "SELL",
+		Amount:       10,
+	})
+	verr, ok := model.AsRFQValidationError(err)
+	require.True(t, ok, "got %v", err)
+	assert.Equal(t, model.RFQErrBelowMinSize, verr.Code)
+
+	// Buying quotes the MXN leg, which the USD limits do not apply to.
+	_, err = svc.CreateRFQ(context.Background(), model.RFQRequest{
+		ClientID:     "client-001",
+		CurrencyPair: "USD:MXN",
+		Side:         "BUY",
+		Amount:       10,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.CreateRFQ(context.Background(), model.RFQRequest{
+		ClientID:     "client-001",
+		CurrencyPair: "GBP:MXN",
+		Side:         "SELL",
+		Amount:       1000,
+	})
+	verr, ok = model.AsRFQValidationError(err)
+	require.True(t, ok, "got %v", err)
+	assert.Equal(t, model.RFQErrUnsupportedPair, verr.Code)
+}
+
 func TestService_CreateRFQ_ConfigError(t *testing.T) {
 	svc := &Service{
 		ctx:    context.Background(),
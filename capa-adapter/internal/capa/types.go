@@ -19,6 +19,18 @@ type CapaClientConfig struct {
 	BlockchainSymbol string // Blockchain symbol (on-ramp only, e.g. "POL")
 	TokenSymbol      string // Token symbol (on-ramp only, e.g. "USDC")
 	ReceiverID       string // Receiver ID for off-ramp payouts (off-ramp only)
+
+	// Whitelisted destinations an RFQ may select by alias. The single-value
+	// fields above remain the defaults when an RFQ names no destination.
+	Wallets   map[string]CapaWallet // On-ramp wallets by alias
+	Receivers map[string]string     // Off-ramp receiver IDs by alias
+}
+
+// CapaWallet is an on-ramp destination wallet.
+type CapaWallet struct {
+	Address    string `json:"address"`
+	Blockchain string `json:"blockchain"`
+	Token      string `json:"token"`
 }
 
 // ConfigResolver resolves per-client Capa configuration.
@@ -160,6 +172,54 @@ type CapaTransactionResponse struct {
 	Transaction CapaTransaction `json:"transaction"`
 }
 
+//
+// ────────────────────────────────────────────────
+//   Capa API: Receivers and Wallets
+// ────────────────────────────────────────────────
+//
+
+// CapaReceiverRequest is the payload for POST /api/partner/v2/receivers.
+type CapaReceiverRequest struct {
+	UserID        string `json:"userId"`
+	Name          string `json:"name"`
+	Country       string `json:"country"`
+	Currency      string `json:"currency"`
+	BankCode      string `json:"bankCode,omitempty"`
+	AccountNumber string `json:"accountNumber"`
+	AccountType   string `json:"accountType,omitempty"`
+}
+
+// CapaReceiver is a payout beneficiary registered with Capa.
+type CapaReceiver struct {
+	ID            string `json:"id"`
+	UserID        string `json:"userId"`
+	Name          string `json:"name"`
+	Country       string `json:"country"`
+	Currency      string `json:"currency"`
+	BankCode      string `json:"bankCode,omitempty"`
+	AccountNumber string `json:"accountNumber"`
+	AccountType   string `json:"accountType,omitempty"`
+	Status        string `json:"status,omitempty"`
+}
+
+// CapaWalletRequest is the payload for POST /api/partner/v2/wallets.
+type CapaWalletRequest struct {
+	UserID           string `json:"userId"`
+	WalletAddress    string `json:"walletAddress"`
+	BlockchainSymbol string `json:"blockchainSymbol"`
+	TokenSymbol      string `json:"tokenSymbol"`
+}
+
+// CapaWalletResponse is a destination wallet registered with Capa.
+type CapaWalletResponse struct {
+	ID               string `json:"id"`
+	UserID           string `json:"userId"`
+	WalletAddress    string `json:"walletAddress"`
+	BlockchainSymbol string `json:"blockchainSymbol"`
+	TokenSymbol      string `json:"tokenSymbol"`
+	Status           string `json:"status,omitempty"`
+}
+
 //
 // ────────────────────────────────────────────────
 //   Capa API: Webhook Events
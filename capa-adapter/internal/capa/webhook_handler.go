@@ -5,10 +5,13 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
 	"github.com/Checker-Finance/adapters/internal/webhooks"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // WebhookHandler handles incoming webhook events from Capa.
@@ -43,14 +46,18 @@ func NewWebhookHandler(
 // ProcessWebhookEvent validates the HMAC-SHA256 signature and processes a Capa webhook event.
 // It cancels active polling on terminal events and publishes NATS events.
 func (h *WebhookHandler) ProcessWebhookEvent(ctx context.Context, clientID string, event *CapaWebhookEvent, signature string, body []byte) error {
+	metrics.IncWebhook("capa", "received")
+
 	// Validate HMAC-SHA256 signature using the per-client webhook secret.
 	if h.resolver != nil && clientID != "" {
 		if clientCfg, err := h.resolver.Resolve(ctx, clientID); err == nil && clientCfg.WebhookSecret != "" {
 			if signature == "" || !webhooks.ValidateHMACSHA256(clientCfg.WebhookSecret, signature, body) {
+				metrics.IncWebhook("capa", "invalid_signature")
 				slog.Warn("capa.webhook.invalid_signature",
 					"client", clientID)
 				return ErrInvalidSignature
 			}
+			metrics.IncWebhook("capa", "verified")
 		}
 	}
 
@@ -71,6 +78,17 @@ func (h *WebhookHandler) ProcessWebhookEvent(ctx context.Context, clientID strin
 
 	normalizedStatus := NormalizeCapaStatus(rawStatus)
 
+	if h.service != nil {
+		h.service.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindStatusTransition,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			TradeID:  txID,
+			Status:   normalizedStatus,
+			Source:   "webhook",
+		}, body)
+	}
+
 	// Publish status change event
 	if h.publisher != nil {
 		statusEvent := map[string]any{
@@ -92,6 +110,9 @@ func (h *WebhookHandler) ProcessWebhookEvent(ctx context.Context, clientID strin
 
 	// Handle terminal statuses
 	if IsTerminalStatus(rawStatus) {
+		if normalizedStatus == model.StatusFilled {
+			metrics.ObserveQuoteFill("capa", quoteID, time.Now())
+		}
 		h.handleTerminalWebhook(ctx, clientID, txID, quoteID, event, normalizedStatus)
 	}
 
@@ -113,7 +134,20 @@ func (h *WebhookHandler) handleTerminalWebhook(
 	event *CapaWebhookEvent,
 	status string,
 ) {
-	// Sync to legacy database
+	finalSubject := tradeEventSubject(status)
+	msgID := publisher.TradeEventID("CAPA", txID, status)
+	final := map[string]any{
+		"client_id": clientID,
+		"trade_id":  txID,
+		"quote_id":  quoteID,
+		"status":    status,
+		"final":     true,
+		"source":    "webhook",
+		"timestamp": time.Now().UTC(),
+	}
+
+	// Sync to legacy database, queueing the final event in the same transaction
+	queued := false
 	if h.tradeSync != nil && h.service != nil {
 		tx := event.Transaction
 		if tx.ID == "" {
@@ -127,12 +161,13 @@ func (h *WebhookHandler) handleTerminalWebhook(
 		}
 		trade := h.service.BuildTradeConfirmationFromTx(clientID, &tx)
 		if trade != nil {
-			if err := h.tradeSync.SyncTradeUpsert(ctx, trade); err != nil {
+			if err := h.tradeSync.SyncTradeWithEvent(ctx, trade, finalSubject, msgID, final); err != nil {
 				slog.Warn("capa.webhook.trade_sync_failed",
 					"tx_id", txID,
 					"client", clientID,
 					"error", err)
 			} else {
+				queued = true
 				slog.Info("capa.webhook.trade_synced",
 					"tx_id", txID,
 					"client", clientID,
@@ -141,18 +176,9 @@ func (h *WebhookHandler) handleTerminalWebhook(
 		}
 	}
 
-	// Publish final event
-	if h.publisher != nil {
-		finalSubject := tradeEventSubject(status)
-		if err := h.publisher.Publish(ctx, finalSubject, map[string]any{
-			"client_id": clientID,
-			"trade_id":  txID,
-			"quote_id":  quoteID,
-			"status":    status,
-			"final":     true,
-			"source":    "webhook",
-			"timestamp": time.Now().UTC(),
-		}); err != nil {
+	// Publish final event directly when it could not be queued
+	if !queued && h.publisher != nil {
+		if err := h.publisher.Publish(ctx, finalSubject, final, publisher.WithMsgID(msgID)); err != nil {
 			slog.Warn("capa.webhook.publish_final_failed",
 				"subject", finalSubject,
 				"error", err)
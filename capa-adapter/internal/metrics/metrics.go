@@ -1,59 +1,43 @@
 package metrics
 
 import (
-	"time"
-
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 )
 
+// IncNATSPublishError increments the shared NATS publish error counter for the given subject.
+func IncNATSPublishError(subject string) {
+	sharedmetrics.IncNATSPublishError("capa", subject)
+}
+
 var (
-	// CapaRequestsTotal tracks the number of outbound API calls to Capa.
-	CapaRequestsTotal = promauto.NewCounterVec(
+	// ReconciliationRuns counts reconciliation runs by result (ok, discrepancies, error).
+	ReconciliationRuns = promauto.NewCounterVec(
 		prometheus.CounterOpts{
-			Name: "capa_api_requests_total",
-			Help: "Total number of Capa API requests made (by endpoint, method, and status).",
+			Name: "capa_reconciliation_runs_total",
+			Help: "Number of Capa transaction reconciliation runs by result.",
 		},
-		[]string{"endpoint", "method", "status"},
+		[]string{"result"},
 	)
 
-	// CapaRequestDuration measures the duration of outbound Capa API calls.
-	CapaRequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "capa_api_request_duration_seconds",
-			Help:    "Duration of Capa API requests in seconds.",
-			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15), // 1ms → ~16s
-		},
-		[]string{"endpoint", "method"},
-	)
-
-	// NATSPublishErrors tracks NATS publish failures by subject.
-	NATSPublishErrors = promauto.NewCounterVec(
+	// ReconciliationDiscrepancies counts discrepancies between Capa and activity.t_order by kind.
+	ReconciliationDiscrepancies = promauto.NewCounterVec(
 		prometheus.CounterOpts{
-			Name: "capa_nats_publish_errors_total",
-			Help: "Number of NATS publish failures by subject.",
+			Name: "capa_reconciliation_discrepancies_total",
+			Help: "Number of discrepancies between Capa transactions and synced trades by kind.",
 		},
-		[]string{"subject"},
+		[]string{"kind"},
 	)
 )
 
-// IncCapaRequest increments the Capa API request counter.
-func IncCapaRequest(endpoint, method, status string) {
-	CapaRequestsTotal.WithLabelValues(endpoint, method, status).Inc()
+// IncReconciliationRun increments the reconciliation run counter.
+func IncReconciliationRun(result string) {
+	ReconciliationRuns.WithLabelValues(result).Inc()
 }
 
-// ObserveDuration records elapsed time since start into a HistogramVec or SummaryVec.
-func ObserveDuration(v any, start time.Time, labels ...string) {
-	duration := time.Since(start).Seconds()
-	switch metric := v.(type) {
-	case *prometheus.HistogramVec:
-		metric.WithLabelValues(labels...).Observe(duration)
-	case *prometheus.SummaryVec:
-		metric.WithLabelValues(labels...).Observe(duration)
-	}
-}
-
-// IncNATSPublishError increments the NATS publish error counter for the given subject.
-func IncNATSPublishError(subject string) {
-	NATSPublishErrors.WithLabelValues(subject).Inc()
+// IncDiscrepancy increments the reconciliation discrepancy counter.
+func IncDiscrepancy(kind string) {
+	ReconciliationDiscrepancies.WithLabelValues(kind).Inc()
 }
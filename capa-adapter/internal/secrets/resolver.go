@@ -2,6 +2,7 @@ package secrets
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"github.com/Checker-Finance/adapters/capa-adapter/internal/capa"
@@ -15,6 +16,10 @@ import (
 //
 // Secret naming convention: {env}/{clientID}/capa
 // Secret JSON format:       {"api_key": "...", "base_url": "...", "user_id": "...", "webhook_secret": "..."}
+//
+// Optional whitelisted destinations are JSON-encoded strings keyed by alias:
+// "wallets": {"treasury": {"address": "0x..", "blockchain": "POL", "token": "USDC"}}
+// and "receivers": {"mx-ops": "<receiver id>"}.
 type AWSResolver struct {
 	inner *intsecrets.AWSResolver[capa.CapaClientConfig]
 }
@@ -55,6 +60,16 @@ func parseCapaConfig(m map[string]string) (capa.CapaClientConfig, error) {
 		TokenSymbol:      m["token_symbol"],
 		ReceiverID:       m["receiver_id"],
 	}
+	if v := m["wallets"]; v != "" {
+		if err := json.Unmarshal([]byte(v), &cfg.Wallets); err != nil {
+			return capa.CapaClientConfig{}, fmt.Errorf("invalid 'wallets': %w", err)
+		}
+	}
+	if v := m["receivers"]; v != "" {
+		if err := json.Unmarshal([]byte(v), &cfg.Receivers); err != nil {
+			return capa.CapaClientConfig{}, fmt.Errorf("invalid 'receivers': %w", err)
+		}
+	}
 	if cfg.APIKey == "" {
 		return capa.CapaClientConfig{}, fmt.Errorf("missing required field 'api_key'")
 	}
@@ -0,0 +1,24 @@
+CREATE SCHEMA IF NOT EXISTS reference;
+
+-- Whitelisted Capa destinations per client. RFQs select one by alias:
+-- wallets for on-ramp, receivers (payout beneficiaries) for off-ramp.
+CREATE TABLE IF NOT EXISTS reference.capa_destinations (
+    id              BIGSERIAL PRIMARY KEY,
+    client_id       VARCHAR(255) NOT NULL,
+    kind            VARCHAR(16)  NOT NULL CHECK (kind IN ('wallet', 'receiver')),
+    alias           VARCHAR(64)  NOT NULL,
+    capa_id         VARCHAR(255) NOT NULL,          -- ID assigned by Capa
+    address         VARCHAR(255),                   -- wallet only
+    blockchain      VARCHAR(32),                    -- wallet only
+    token           VARCHAR(32),                    -- wallet only
+    name            VARCHAR(255),                   -- receiver only
+    country         CHAR(2),                        -- receiver only
+    currency        CHAR(3),                        -- receiver only
+    bank_code       VARCHAR(64),                    -- receiver only
+    account_masked  VARCHAR(64),                    -- receiver only, last 4 digits
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (client_id, kind, alias)
+);
+
+CREATE INDEX IF NOT EXISTS idx_capa_destinations_client
+    ON reference.capa_destinations(client_id);
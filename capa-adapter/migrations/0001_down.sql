@@ -0,0 +1,5 @@
+-- Rollback for 0001_capa_destinations.sql
+-- WARNING: This will drop the destination whitelist.
+BEGIN;
+DROP TABLE IF EXISTS reference.capa_destinations;
+COMMIT;
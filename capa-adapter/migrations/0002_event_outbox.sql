@@ -0,0 +1,30 @@
+BEGIN;
+
+CREATE SCHEMA IF NOT EXISTS activity;
+
+-- Transactional outbox: terminal trade events are written here in the same
+-- transaction as their activity.t_order upsert and published to JetStream by
+-- the adapters' outbox relay. msg_id doubles as the JetStream Nats-Msg-Id.
+CREATE TABLE IF NOT EXISTS activity.t_event_outbox (
+    id              BIGSERIAL PRIMARY KEY,
+    msg_id          VARCHAR(512) NOT NULL UNIQUE,
+    subject         VARCHAR(255) NOT NULL,
+    payload         JSONB        NOT NULL,
+    source          VARCHAR(64)  NOT NULL,          -- e.g. "rio-adapter"
+    attempts        INT          NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    locked_until    TIMESTAMPTZ,                    -- relay lease
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    sent_at         TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_unsent
+    ON activity.t_event_outbox(id)
+    WHERE sent_at IS NULL;
+
+-- Relay retention: sent rows are purged per source once old enough.
+CREATE INDEX IF NOT EXISTS idx_event_outbox_sent
+    ON activity.t_event_outbox(source, sent_at)
+    WHERE sent_at IS NOT NULL;
+
+COMMIT;
@@ -0,0 +1,7 @@
+-- Rollback for 0003_audit_trail.sql
+-- Intentionally a no-op: audit.t_rfq_event is shared by every adapter that
+-- runs the audit_trail migration, so rolling back one adapter must not drop
+-- the other venues' audit trail. Drop it by hand once no adapter writes to it:
+--   DROP TABLE IF EXISTS audit.t_rfq_event;
+--   DROP FUNCTION IF EXISTS audit.f_rfq_event_append_only();
+SELECT 1;
@@ -45,6 +45,14 @@ type Config struct {
 	RFQSweepInterval       time.Duration // How often to expire stale RFQs/quotes in the legacy DB
 	RFQSweepTTL            time.Duration // Age threshold after which an open RFQ/quote is expired
 	SummaryRefreshInterval time.Duration // How often to refresh the balance summary materialized view
+	ReconInterval          time.Duration // How often to reconcile Capa transactions against activity.t_order
+	ReconLookback          time.Duration // Age of the oldest transaction reconciled
+
+	// JetStream publishing
+	NATSStream            string        // Stream that must capture the adapter's trade events
+	NATSDedupWindow       time.Duration // Duplicate window enforced on NATSStream
+	NATSPublishAsync      bool          // Publish without waiting for each ack
+	NATSPublishMaxPending int           // Async publishes awaiting an ack before publishing blocks
 }
 
 // Load loads configuration from environment variables, then overlays any values
@@ -80,6 +88,13 @@ func Load(ctx context.Context) *Config {
 		RFQSweepInterval:       pkgconfig.GetEnvDuration("RFQ_SWEEP_INTERVAL", 5*time.Minute),
 		RFQSweepTTL:            pkgconfig.GetEnvDuration("RFQ_SWEEP_TTL", 15*time.Minute),
 		SummaryRefreshInterval: pkgconfig.GetEnvDuration("SUMMARY_REFRESH_INTERVAL", 24*time.Hour),
+		ReconInterval:          pkgconfig.GetEnvDuration("CAPA_RECON_INTERVAL", 10*time.Minute),
+		ReconLookback:          pkgconfig.GetEnvDuration("CAPA_RECON_LOOKBACK", 24*time.Hour),
+
+		NATSStream:            pkgconfig.GetEnv("NATS_STREAM", "CAPA_EVENTS"),
+		NATSDedupWindow:       pkgconfig.GetEnvDuration("NATS_DEDUP_WINDOW", 2*time.Minute),
+		NATSPublishAsync:      pkgconfig.GetEnvBool("NATS_PUBLISH_ASYNC", false),
+		NATSPublishMaxPending: pkgconfig.GetEnvInt("NATS_PUBLISH_MAX_PENDING", 256),
 	}
 
 	secretPath := fmt.Sprintf("%s/%s", cfg.Env, cfg.ServiceName)
@@ -0,0 +1,165 @@
+// Package audit keeps an append-only record of each RFQ's life: what was
+// requested, what the venue quoted, what was executed and every status the
+// trade went through. Events are keyed by correlation ID and carry the raw
+// venue payloads with secrets redacted.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Checker-Finance/adapters/internal/tracing"
+)
+
+// Kind identifies the step of the quote-to-trade flow an event records.
+type Kind string
+
+const (
+	KindRFQRequest       Kind = "rfq_request"
+	KindQuoteResponse    Kind = "quote_response"
+	KindExecuteRequest   Kind = "execute_request"
+	KindExecuteResponse  Kind = "execute_response"
+	KindStatusTransition Kind = "status_transition"
+)
+
+// Event is one entry in an RFQ's audit trail.
+type Event struct {
+	ID            int64           `json:"id"`
+	CorrelationID *uuid.UUID      `json:"correlation_id,omitempty"`
+	Venue         string          `json:"venue"`
+	Kind          Kind            `json:"kind"`
+	ClientID      string          `json:"client_id,omitempty"`
+	QuoteID       string          `json:"quote_id,omitempty"`
+	TradeID       string          `json:"trade_id,omitempty"`
+	PrevStatus    string          `json:"prev_status,omitempty"`
+	Status        string          `json:"status,omitempty"`
+	Source        string          `json:"source,omitempty"` // e.g. "poller", "webhook"
+	Error         string          `json:"error,omitempty"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Store persists audit events. Implementations must never update or delete
+// an appended event.
+type Store interface {
+	Append(ctx context.Context, e Event) error
+	// Timeline returns the events of the RFQ that quote or trade id belongs
+	// to, oldest first.
+	Timeline(ctx context.Context, venue, id string) ([]Event, error)
+}
+
+// appendTimeout bounds a single audit write so a slow database cannot back
+// up the queue indefinitely.
+const appendTimeout = 5 * time.Second
+
+// queueSize bounds the events waiting to be written. When it is full new
+// events are dropped rather than stalling the trade that produced them.
+const queueSize = 1024
+
+// Recorder appends events for one venue from a background writer. A nil
+// *Recorder records nothing, so services can run without an audit store.
+type Recorder struct {
+	store Store
+	venue string
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan Event
+	done   chan struct{}
+}
+
+// NewRecorder constructs a Recorder writing venue's events to store and
+// starts its writer. Call Close on shutdown to flush queued events.
+func NewRecorder(store Store, venue string) *Recorder {
+	r := &Recorder{
+		store: store,
+		venue: venue,
+		queue: make(chan Event, queueSize),
+		done:  make(chan struct{}),
+	}
+	go r.run()
+	return r
+}
+
+// Record redacts payload and queues e for appending, taking the correlation
+// ID from ctx. payload may be raw JSON bytes or any value that marshals to
+// JSON. It never blocks: when the queue is full or the recorder is closed the
+// event is logged and dropped, and write failures are logged and otherwise
+// ignored. The audit trail never delays a trade.
+func (r *Recorder) Record(ctx context.Context, e Event, payload any) {
+	if r == nil || r.store == nil {
+		return
+	}
+	e.Venue = r.venue
+	if id, ok := tracing.CorrelationID(ctx); ok {
+		e.CorrelationID = &id
+	}
+	e.Payload = Redact(payload)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		r.logDropped(e, "closed")
+		return
+	}
+	select {
+	case r.queue <- e:
+	default:
+		r.logDropped(e, "queue_full")
+	}
+}
+
+// Close stops accepting events and waits until queued events are written or
+// ctx is done.
+func (r *Recorder) Close(ctx context.Context) error {
+	if r == nil || r.queue == nil {
+		return nil
+	}
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.queue)
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Recorder) run() {
+	defer close(r.done)
+	for e := range r.queue {
+		r.append(e)
+	}
+}
+
+func (r *Recorder) append(e Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), appendTimeout)
+	defer cancel()
+	if err := r.store.Append(ctx, e); err != nil {
+		slog.Warn("audit.append_failed",
+			"venue", r.venue,
+			"kind", e.Kind,
+			"quote_id", e.QuoteID,
+			"trade_id", e.TradeID,
+			"error", err)
+	}
+}
+
+func (r *Recorder) logDropped(e Event, reason string) {
+	slog.Warn("audit.event_dropped",
+		"venue", r.venue,
+		"kind", e.Kind,
+		"quote_id", e.QuoteID,
+		"trade_id", e.TradeID,
+		"reason", reason)
+}
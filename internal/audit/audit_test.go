@@ -0,0 +1,175 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/Checker-Finance/adapters/internal/tracing"
+)
+
+// memStore is an in-memory Store matching events by quote or trade ID.
+type memStore struct {
+	events []Event
+	err    error
+}
+
+func (s *memStore) Append(_ context.Context, e Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	e.ID = int64(len(s.events) + 1)
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *memStore) Timeline(_ context.Context, venue, id string) ([]Event, error) {
+	var out []Event
+	for _, e := range s.events {
+		if e.Venue == venue && (e.QuoteID == id || e.TradeID == id) {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+func TestRecorder_Record(t *testing.T) {
+	st := &memStore{}
+	r := NewRecorder(st, "rio")
+	id := uuid.New()
+	ctx := tracing.WithCorrelationID(context.Background(), id)
+
+	r.Record(ctx, Event{Kind: KindQuoteResponse, ClientID: "c1", QuoteID: "q-1"},
+		[]byte(`{"id":"q-1","apiKey":"k"}`))
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if len(st.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(st.events))
+	}
+	e := st.events[0]
+	if e.Venue != "rio" {
+		t.Errorf("expected venue rio, got %q", e.Venue)
+	}
+	if e.CorrelationID == nil || *e.CorrelationID != id {
+		t.Errorf("expected correlation ID %s, got %v", id, e.CorrelationID)
+	}
+	if string(e.Payload) != `{"apiKey":"[REDACTED]","id":"q-1"}` {
+		t.Errorf("expected redacted payload, got %s", e.Payload)
+	}
+}
+
+func TestRecorder_NilAndFailingStore(t *testing.T) {
+	var r *Recorder
+	r.Record(context.Background(), Event{Kind: KindRFQRequest}, nil) // must not panic
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Errorf("close of nil recorder: %v", err)
+	}
+
+	st := &memStore{err: errors.New("db down")}
+	failing := NewRecorder(st, "rio")
+	failing.Record(context.Background(), Event{Kind: KindRFQRequest}, nil)
+	if err := failing.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	failing.Record(context.Background(), Event{Kind: KindRFQRequest}, nil) // dropped after close
+}
+
+// blockingStore holds every Append until release is closed.
+type blockingStore struct {
+	memStore
+	release chan struct{}
+}
+
+func (s *blockingStore) Append(ctx context.Context, e Event) error {
+	<-s.release
+	return s.memStore.Append(ctx, e)
+}
+
+func TestRecorder_DoesNotBlockOnSlowStore(t *testing.T) {
+	st := &blockingStore{release: make(chan struct{})}
+	r := NewRecorder(st, "xfx")
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < queueSize+10; i++ {
+			r.Record(context.Background(), Event{Kind: KindExecuteRequest, QuoteID: "q-1"}, nil)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Record blocked on a stalled store")
+	}
+
+	close(st.release)
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if n := len(st.events); n == 0 || n > queueSize+1 {
+		t.Errorf("expected the queued events to be written and the overflow dropped, got %d", n)
+	}
+}
+
+func TestRecorder_OutlivesCancelledContext(t *testing.T) {
+	st := &memStore{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := NewRecorder(st, "capa")
+	r.Record(ctx, Event{Kind: KindExecuteResponse, TradeID: "t-1"}, nil)
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if len(st.events) != 1 {
+		t.Errorf("expected event recorded after cancellation, got %d", len(st.events))
+	}
+}
+
+func TestHandler_Timeline(t *testing.T) {
+	st := &memStore{}
+	r := NewRecorder(st, "capa")
+	r.Record(context.Background(), Event{Kind: KindExecuteRequest, QuoteID: "q-1"}, nil)
+	r.Record(context.Background(), Event{Kind: KindExecuteResponse, QuoteID: "q-1", TradeID: "t-1", Status: "pending"}, nil)
+	if err := r.Close(context.Background()); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	app := fiber.New()
+	app.Get("/api/v1/audit/:id", NewHandler(st, "capa").Timeline)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/audit/q-1", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	var body struct {
+		Count  int     `json:"count"`
+		Events []Event `json:"events"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if body.Count != 2 || body.Events[1].Kind != KindExecuteResponse {
+		t.Errorf("unexpected timeline: %+v", body)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/audit/unknown", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("expected 404 for unknown id, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Handler serves a venue's audit trail over HTTP.
+type Handler struct {
+	store Store
+	venue string
+}
+
+// NewHandler creates a Handler reading venue's events from store.
+func NewHandler(store Store, venue string) *Handler {
+	return &Handler{store: store, venue: venue}
+}
+
+// Timeline handles GET /api/v1/audit/:id, where id is a quote or trade ID.
+func (h *Handler) Timeline(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "id is required"})
+	}
+
+	events, err := h.store.Timeline(c.UserContext(), h.venue, id)
+	if err != nil {
+		slog.Error("audit.timeline_failed", "venue", h.venue, "id", id, "error", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "failed to load audit trail"})
+	}
+	if len(events) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "no audit events for " + id})
+	}
+
+	return c.JSON(fiber.Map{
+		"id":     id,
+		"venue":  h.venue,
+		"count":  len(events),
+		"events": events,
+	})
+}
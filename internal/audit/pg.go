@@ -0,0 +1,79 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PGStore is the Postgres-backed audit Store over audit.t_rfq_event. The
+// table rejects updates and deletes, so the trail is append-only even for
+// other writers.
+type PGStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPGStore constructs a Store over audit.t_rfq_event.
+func NewPGStore(db *pgxpool.Pool) *PGStore {
+	return &PGStore{db: db}
+}
+
+// Append inserts e.
+func (s *PGStore) Append(ctx context.Context, e Event) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO audit.t_rfq_event
+			(correlation_id, venue, kind, client_id, quote_id, trade_id,
+			 prev_status, status, source, error, payload)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+	`, e.CorrelationID, e.Venue, string(e.Kind), e.ClientID, e.QuoteID, e.TradeID,
+		e.PrevStatus, e.Status, e.Source, e.Error, nullableJSON(e.Payload))
+	return err
+}
+
+// Timeline returns the events whose quote or trade ID is id, together with
+// every event sharing a correlation ID or quote ID with them. That pulls in
+// the RFQ request, which is recorded before the venue assigns a quote ID.
+func (s *PGStore) Timeline(ctx context.Context, venue, id string) ([]Event, error) {
+	rows, err := s.db.Query(ctx, `
+		WITH seed AS (
+			SELECT correlation_id, quote_id
+			FROM audit.t_rfq_event
+			WHERE venue = $1 AND (quote_id = $2 OR trade_id = $2)
+		)
+		SELECT id, correlation_id, venue, kind, client_id, quote_id, trade_id,
+		       prev_status, status, source, error, payload, created_at
+		FROM audit.t_rfq_event
+		WHERE venue = $1
+		  AND (quote_id = $2
+		       OR trade_id = $2
+		       OR correlation_id IN (SELECT correlation_id FROM seed WHERE correlation_id IS NOT NULL)
+		       OR quote_id IN (SELECT quote_id FROM seed WHERE quote_id <> ''))
+		ORDER BY id;
+	`, venue, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Event
+	for rows.Next() {
+		var e Event
+		var kind string
+		if err := rows.Scan(&e.ID, &e.CorrelationID, &e.Venue, &kind, &e.ClientID,
+			&e.QuoteID, &e.TradeID, &e.PrevStatus, &e.Status, &e.Source, &e.Error,
+			&e.Payload, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Kind = Kind(kind)
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// nullableJSON stores an empty payload as SQL NULL rather than invalid JSONB.
+func nullableJSON(b []byte) any {
+	if len(b) == 0 {
+		return nil
+	}
+	return b
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Redacted replaces the value of a sensitive field.
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys are normalized field names (lowercase, without '_' or '-')
+// whose values are never stored. A bare "token" is not among them: venues use
+// it for assets (e.g. "token": "USDC").
+var sensitiveKeys = map[string]bool{
+	"authorization": true,
+	"signature":     true,
+	"sign":          true,
+}
+
+// sensitiveSuffixes catch variants such as "client_secret" or "x-api-key".
+var sensitiveSuffixes = []string{
+	"secret",
+	"password",
+	"passphrase",
+	"apikey",
+	"privatekey",
+	"accesstoken",
+	"refreshtoken",
+	"idtoken",
+	"authtoken",
+	"bearertoken",
+}
+
+func isSensitive(key string) bool {
+	k := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+	if sensitiveKeys[k] {
+		return true
+	}
+	for _, s := range sensitiveSuffixes {
+		if strings.HasSuffix(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact marshals payload to JSON with the values of sensitive fields, at
+// any depth, replaced by Redacted. Raw bytes that are not JSON are kept as a
+// JSON string. A nil payload yields nil.
+func Redact(payload any) json.RawMessage {
+	var data []byte
+	switch p := payload.(type) {
+	case nil:
+		return nil
+	case json.RawMessage:
+		data = p
+	case []byte:
+		data = p
+	default:
+		b, err := json.Marshal(p)
+		if err != nil {
+			b, _ = json.Marshal(map[string]string{"marshal_error": err.Error()})
+		}
+		data = b
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		out, _ := json.Marshal(string(data))
+		return out
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if isSensitive(k) {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redactValue(val)
+		}
+		return t
+	case []any:
+		for i, val := range t {
+			t[i] = redactValue(val)
+		}
+		return t
+	default:
+		return v
+	}
+}
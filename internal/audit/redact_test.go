@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRedact_NestedSecrets(t *testing.T) {
+	in := []byte(`{
+		"quote_id": "q-1",
+		"token": "USDC",
+		"api_key": "k",
+		"auth": {"client_secret": "s", "access_token": "t", "X-Api-Key": "x"},
+		"legs": [{"signature": "sig", "amount": "10"}]
+	}`)
+
+	var got map[string]any
+	if err := json.Unmarshal(Redact(in), &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got["quote_id"] != "q-1" || got["token"] != "USDC" {
+		t.Errorf("non-sensitive fields must be kept, got %v", got)
+	}
+	if got["api_key"] != Redacted {
+		t.Errorf("expected api_key redacted, got %v", got["api_key"])
+	}
+	auth := got["auth"].(map[string]any)
+	for _, k := range []string{"client_secret", "access_token", "X-Api-Key"} {
+		if auth[k] != Redacted {
+			t.Errorf("expected %s redacted, got %v", k, auth[k])
+		}
+	}
+	leg := got["legs"].([]any)[0].(map[string]any)
+	if leg["signature"] != Redacted || leg["amount"] != "10" {
+		t.Errorf("expected only signature redacted in array element, got %v", leg)
+	}
+}
+
+func TestRedact_Struct(t *testing.T) {
+	payload := struct {
+		Password string `json:"password"`
+		Pair     string `json:"pair"`
+	}{"hunter2", "USD/BRL"}
+
+	if got := string(Redact(payload)); got != `{"pair":"USD/BRL","password":"[REDACTED]"}` {
+		t.Errorf("unexpected redaction: %s", got)
+	}
+}
+
+func TestRedact_NonJSONAndEmpty(t *testing.T) {
+	if got := string(Redact([]byte("Bad Gateway"))); got != `"Bad Gateway"` {
+		t.Errorf("expected non-JSON body kept as a string, got %s", got)
+	}
+	if Redact(nil) != nil || Redact([]byte{}) != nil {
+		t.Error("expected empty payloads to yield nil")
+	}
+}
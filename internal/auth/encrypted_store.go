@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// sealedValue is how an EncryptedStore persists a value: AES-GCM ciphertext
+// with the nonce prepended.
+type sealedValue struct {
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedStore wraps a TokenStore so tokens are encrypted at rest. Values
+// that cannot be decrypted, e.g. after a key rotation, read as missing and
+// are fetched again.
+type EncryptedStore struct {
+	store TokenStore
+	aead  cipher.AEAD
+}
+
+// NewEncryptedStore wraps store with AES-256-GCM. key is the base64 encoding
+// of 32 random bytes (AUTH_TOKEN_ENCRYPTION_KEY).
+func NewEncryptedStore(store TokenStore, key string) (*EncryptedStore, error) {
+	if key == "" {
+		return nil, errors.New("auth: token encryption key is required to persist tokens")
+	}
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("auth: decode token encryption key: %w", err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("auth: token encryption key must be 32 bytes, got %d", len(raw))
+	}
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedStore{store: store, aead: aead}, nil
+}
+
+// SetJSON encrypts the JSON encoding of value and stores it under key.
+func (s *EncryptedStore) SetJSON(ctx context.Context, key string, value any, ttl time.Duration) error {
+	plain, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	// The store key is bound as additional data, so a value copied to
+	// another key does not decrypt.
+	sealed := s.aead.Seal(nonce, nonce, plain, []byte(key))
+	return s.store.SetJSON(ctx, key, sealedValue{Ciphertext: sealed}, ttl)
+}
+
+// GetJSON reads and decrypts the value stored under key into dest.
+func (s *EncryptedStore) GetJSON(ctx context.Context, key string, dest any) error {
+	var sealed sealedValue
+	if err := s.store.GetJSON(ctx, key, &sealed); err != nil {
+		return err
+	}
+	n := s.aead.NonceSize()
+	if len(sealed.Ciphertext) < n {
+		return errors.New("auth: stored token is not encrypted")
+	}
+	plain, err := s.aead.Open(nil, sealed.Ciphertext[:n], sealed.Ciphertext[n:], []byte(key))
+	if err != nil {
+		return fmt.Errorf("auth: decrypt stored token: %w", err)
+	}
+	return json.Unmarshal(plain, dest)
+}
@@ -0,0 +1,306 @@
+// Package auth provides a venue-agnostic bearer token cache shared by the
+// adapters. Each venue supplies a FetchFunc that obtains a token for a key
+// (client ID, API key, ...); the TokenManager caches it, refreshes it before
+// expiry and, optionally, persists it so replicas share one token per key.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Checker-Finance/adapters/internal/metrics"
+)
+
+const (
+	// fetchTimeout bounds a single token fetch. Fetches are detached from the
+	// caller's context because other callers may be waiting on the same one.
+	fetchTimeout = 30 * time.Second
+
+	// invalidatedTTL is how long an invalidated token is tombstoned in the
+	// store, so replicas stop serving it.
+	invalidatedTTL = time.Minute
+)
+
+// Token is a bearer token and the time it expires.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// FetchFunc obtains a new token. current is the token being replaced, or the
+// zero Token if there is none, so venues with refresh tokens can use it.
+// The returned token must have ExpiresAt set.
+type FetchFunc func(ctx context.Context, current Token) (Token, error)
+
+// TokenStore persists tokens across replicas and restarts. store.Store
+// (Redis) satisfies it.
+type TokenStore interface {
+	SetJSON(ctx context.Context, key string, value any, ttl time.Duration) error
+	GetJSON(ctx context.Context, key string, dest any) error
+}
+
+// entry is a cached token and the function that last fetched it, used for
+// background refresh.
+type entry struct {
+	token Token
+	fetch FetchFunc
+}
+
+// TokenManager caches tokens per key. Concurrent requests for the same key
+// share a single fetch; different keys never wait on each other.
+type TokenManager struct {
+	venue         string
+	refreshBefore time.Duration
+	store         TokenStore
+
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// NewTokenManager constructs a TokenManager for venue. Tokens are treated as
+// expired refreshBefore ahead of their actual expiry.
+func NewTokenManager(venue string, refreshBefore time.Duration) *TokenManager {
+	return &TokenManager{
+		venue:         venue,
+		refreshBefore: refreshBefore,
+		entries:       make(map[string]*entry),
+	}
+}
+
+// SetStore enables persistence of tokens in store. Without a store tokens
+// are held in memory only.
+func (m *TokenManager) SetStore(store TokenStore) {
+	m.store = store
+}
+
+// Token returns a valid access token for key, calling fetch if the cached
+// one is missing or about to expire. fetch is remembered for Run.
+func (m *TokenManager) Token(ctx context.Context, key string, fetch FetchFunc) (string, error) {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	if !ok {
+		e = &entry{}
+		m.entries[key] = e
+	}
+	e.fetch = fetch
+	tok := e.token
+	m.mu.Unlock()
+
+	if m.fresh(tok, time.Now()) {
+		metrics.IncAuthToken(m.venue, "hit")
+		return tok.AccessToken, nil
+	}
+
+	tok, err := m.load(ctx, key, fetch, 0)
+	if err != nil {
+		return "", err
+	}
+	return tok.AccessToken, nil
+}
+
+// Set caches tok for key without fetching, e.g. to seed a known token.
+func (m *TokenManager) Set(key string, tok Token) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.entries[key]; ok {
+		e.token = tok
+		return
+	}
+	m.entries[key] = &entry{token: tok}
+}
+
+// Invalidate discards key's token if it is still accessToken, e.g. after the
+// venue rejected it with 401. A token already replaced by a concurrent
+// refresh is left alone. An empty accessToken discards unconditionally.
+func (m *TokenManager) Invalidate(ctx context.Context, key, accessToken string) {
+	m.mu.Lock()
+	e, ok := m.entries[key]
+	if ok && (accessToken == "" || e.token.AccessToken == accessToken) {
+		e.token = Token{}
+	}
+	m.mu.Unlock()
+
+	metrics.IncAuthTokenInvalidation(m.venue)
+	slog.Debug("auth.token_invalidated", "venue", m.venue)
+
+	if m.store == nil {
+		return
+	}
+	var persisted Token
+	if err := m.store.GetJSON(ctx, m.storeKey(key), &persisted); err != nil {
+		return
+	}
+	if accessToken == "" || persisted.AccessToken == accessToken {
+		if err := m.store.SetJSON(ctx, m.storeKey(key), Token{}, invalidatedTTL); err != nil {
+			slog.Warn("auth.token_store_failed",
+				"venue", m.venue,
+				"error", err)
+		}
+	}
+}
+
+// Forget drops key's token and stops refreshing it in the background. The
+// persisted copy, if any, is left for other replicas.
+func (m *TokenManager) Forget(key string) {
+	m.mu.Lock()
+	delete(m.entries, key)
+	m.mu.Unlock()
+}
+
+// Retain forgets every key not in keys, e.g. the tokens of clients removed
+// from Secrets Manager, and returns how many were forgotten.
+func (m *TokenManager) Retain(keys []string) int {
+	keep := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		keep[k] = true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var forgotten int
+	for key := range m.entries {
+		if !keep[key] {
+			delete(m.entries, key)
+			forgotten++
+		}
+	}
+	return forgotten
+}
+
+// Run refreshes tokens every interval until ctx is cancelled, so requests
+// rarely wait on a fetch.
+func (m *TokenManager) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.RefreshExpiring(ctx, interval)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RefreshExpiring fetches a new token for every key whose token would need
+// refreshing within horizon.
+func (m *TokenManager) RefreshExpiring(ctx context.Context, horizon time.Duration) {
+	type due struct {
+		key   string
+		fetch FetchFunc
+	}
+	var refresh []due
+	at := time.Now().Add(horizon)
+
+	m.mu.Lock()
+	for key, e := range m.entries {
+		if e.fetch != nil && !m.fresh(e.token, at) {
+			refresh = append(refresh, due{key, e.fetch})
+		}
+	}
+	m.mu.Unlock()
+
+	for _, d := range refresh {
+		if _, err := m.load(ctx, d.key, d.fetch, horizon); err != nil {
+			slog.Warn("auth.token_refresh_failed",
+				"venue", m.venue,
+				"error", err)
+		}
+	}
+}
+
+// load returns a token for key that is fresh horizon from now, from the
+// cache, the store or fetch, in that order. Concurrent loads of one key
+// share a single flight.
+func (m *TokenManager) load(ctx context.Context, key string, fetch FetchFunc, horizon time.Duration) (Token, error) {
+	fetchCtx := context.WithoutCancel(ctx)
+	ch := m.group.DoChan(key, func() (any, error) {
+		return m.loadOnce(fetchCtx, key, fetch, horizon)
+	})
+
+	select {
+	case res := <-ch:
+		if res.Err != nil {
+			return Token{}, res.Err
+		}
+		return res.Val.(Token), nil
+	case <-ctx.Done():
+		return Token{}, ctx.Err()
+	}
+}
+
+func (m *TokenManager) loadOnce(ctx context.Context, key string, fetch FetchFunc, horizon time.Duration) (Token, error) {
+	at := time.Now().Add(horizon)
+
+	// A flight that finished just before this one may have refreshed it.
+	m.mu.Lock()
+	var current Token
+	if e, ok := m.entries[key]; ok {
+		current = e.token
+	}
+	m.mu.Unlock()
+	if m.fresh(current, at) {
+		metrics.IncAuthToken(m.venue, "hit")
+		return current, nil
+	}
+
+	if m.store != nil {
+		var persisted Token
+		if err := m.store.GetJSON(ctx, m.storeKey(key), &persisted); err == nil && m.fresh(persisted, at) {
+			m.Set(key, persisted)
+			metrics.IncAuthToken(m.venue, "store_hit")
+			return persisted, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	start := time.Now()
+	tok, err := fetch(ctx, current)
+	metrics.ObserveDuration(metrics.AuthTokenFetchLatency, start, m.venue)
+	if err != nil {
+		metrics.IncAuthToken(m.venue, "error")
+		return Token{}, err
+	}
+	if tok.AccessToken == "" {
+		metrics.IncAuthToken(m.venue, "error")
+		return Token{}, fmt.Errorf("%s auth: empty access token", m.venue)
+	}
+	metrics.IncAuthToken(m.venue, "fetched")
+
+	m.Set(key, tok)
+	if m.store != nil {
+		if err := m.store.SetJSON(ctx, m.storeKey(key), tok, time.Until(tok.ExpiresAt)); err != nil {
+			slog.Warn("auth.token_store_failed",
+				"venue", m.venue,
+				"error", err)
+		}
+	}
+
+	slog.Info("auth.token_refreshed",
+		"venue", m.venue,
+		"expires_at", tok.ExpiresAt)
+	return tok, nil
+}
+
+// fresh reports whether tok can still be used at t.
+func (m *TokenManager) fresh(tok Token, t time.Time) bool {
+	return tok.AccessToken != "" && t.Add(m.refreshBefore).Before(tok.ExpiresAt)
+}
+
+// storeKey returns the persistence key for key. Keys may be API keys, so
+// they are hashed rather than stored in the clear.
+func (m *TokenManager) storeKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("auth:token:%s:%s", m.venue, hex.EncodeToString(sum[:16]))
+}
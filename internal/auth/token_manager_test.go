@@ -0,0 +1,221 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore is an in-memory TokenStore shared between managers in a test.
+type memStore struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newMemStore() *memStore { return &memStore{data: make(map[string][]byte)} }
+
+func (s *memStore) SetJSON(_ context.Context, key string, value any, _ time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[key] = b
+	return nil
+}
+
+func (s *memStore) GetJSON(_ context.Context, key string, dest any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.data[key]
+	if !ok {
+		return errors.New("not found")
+	}
+	return json.Unmarshal(b, dest)
+}
+
+// countingFetch returns a FetchFunc issuing tok-1, tok-2, ... valid for ttl.
+func countingFetch(calls *atomic.Int32, ttl time.Duration) FetchFunc {
+	return func(context.Context, Token) (Token, error) {
+		n := calls.Add(1)
+		return Token{AccessToken: fmt.Sprintf("tok-%d", n), ExpiresAt: time.Now().Add(ttl)}, nil
+	}
+}
+
+func TestTokenManager_ConcurrentCallersShareOneFetch(t *testing.T) {
+	m := NewTokenManager("test", time.Minute)
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, cur Token) (Token, error) {
+		<-release
+		return countingFetch(&calls, time.Hour)(ctx, cur)
+	}
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 10)
+	for i := range tokens {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens[i], _ = m.Token(context.Background(), "c1", fetch)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+	for _, tok := range tokens {
+		assert.Equal(t, "tok-1", tok)
+	}
+}
+
+func TestTokenManager_SlowFetchDoesNotBlockOtherKeys(t *testing.T) {
+	m := NewTokenManager("test", time.Minute)
+	block := make(chan struct{})
+	defer close(block)
+
+	go func() {
+		_, _ = m.Token(context.Background(), "slow", func(ctx context.Context, _ Token) (Token, error) {
+			<-block
+			return Token{}, ctx.Err()
+		})
+	}()
+
+	var calls atomic.Int32
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	tok, err := m.Token(ctx, "fast", countingFetch(&calls, time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "tok-1", tok)
+}
+
+func TestTokenManager_RefreshesBeforeExpiry(t *testing.T) {
+	m := NewTokenManager("test", 5*time.Minute)
+	m.Set("c1", Token{AccessToken: "old", ExpiresAt: time.Now().Add(4 * time.Minute)})
+
+	var calls atomic.Int32
+	tok, err := m.Token(context.Background(), "c1", countingFetch(&calls, time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "tok-1", tok, "a token inside the refresh window is replaced")
+}
+
+func TestTokenManager_InvalidateIgnoresReplacedToken(t *testing.T) {
+	m := NewTokenManager("test", time.Minute)
+	var calls atomic.Int32
+	fetch := countingFetch(&calls, time.Hour)
+	ctx := context.Background()
+
+	tok, _ := m.Token(ctx, "c1", fetch)
+	m.Invalidate(ctx, "c1", tok)
+	tok2, _ := m.Token(ctx, "c1", fetch)
+	assert.Equal(t, "tok-2", tok2)
+
+	// A late 401 for the first token must not discard the second.
+	m.Invalidate(ctx, "c1", tok)
+	tok3, _ := m.Token(ctx, "c1", fetch)
+	assert.Equal(t, "tok-2", tok3)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestTokenManager_StoreSharesTokensBetweenReplicas(t *testing.T) {
+	store := newMemStore()
+	a := NewTokenManager("test", time.Minute)
+	a.SetStore(store)
+	b := NewTokenManager("test", time.Minute)
+	b.SetStore(store)
+
+	var calls atomic.Int32
+	fetch := countingFetch(&calls, time.Hour)
+	ctx := context.Background()
+
+	tokA, err := a.Token(ctx, "c1", fetch)
+	require.NoError(t, err)
+	tokB, err := b.Token(ctx, "c1", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, tokA, tokB)
+	assert.EqualValues(t, 1, calls.Load(), "second replica should reuse the stored token")
+
+	// Invalidation on one replica is seen by the other's next store lookup.
+	a.Invalidate(ctx, "c1", tokA)
+	b.Invalidate(ctx, "c1", tokB)
+	tokB, err = b.Token(ctx, "c1", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "tok-2", tokB)
+}
+
+func TestTokenManager_RefreshExpiring(t *testing.T) {
+	m := NewTokenManager("test", time.Minute)
+	var calls atomic.Int32
+	ctx := context.Background()
+
+	_, err := m.Token(ctx, "c1", countingFetch(&calls, 90*time.Second))
+	require.NoError(t, err)
+
+	m.RefreshExpiring(ctx, 10*time.Second)
+	assert.EqualValues(t, 1, calls.Load(), "token fresh beyond the horizon is kept")
+
+	m.RefreshExpiring(ctx, time.Minute)
+	assert.EqualValues(t, 2, calls.Load(), "token going stale within the horizon is refreshed")
+
+	m.Forget("c1")
+	m.RefreshExpiring(ctx, time.Hour)
+	assert.EqualValues(t, 2, calls.Load(), "forgotten keys are not refreshed")
+}
+
+func TestTokenManager_FetchErrorIsReturned(t *testing.T) {
+	m := NewTokenManager("test", time.Minute)
+	_, err := m.Token(context.Background(), "c1", func(context.Context, Token) (Token, error) {
+		return Token{}, errors.New("auth0 down")
+	})
+	require.EqualError(t, err, "auth0 down")
+}
+
+func TestTokenManager_RetainForgetsOtherKeys(t *testing.T) {
+	m := NewTokenManager("test", time.Minute)
+	var calls atomic.Int32
+	ctx := context.Background()
+	for _, key := range []string{"c1", "c2", "c3"} {
+		_, err := m.Token(ctx, key, countingFetch(&calls, time.Second))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, m.Retain([]string{"c2"}))
+	m.RefreshExpiring(ctx, time.Hour)
+	assert.EqualValues(t, 4, calls.Load(), "only the retained key is refreshed")
+}
+
+func TestEncryptedStore(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	inner := newMemStore()
+	store, err := NewEncryptedStore(inner, key)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	tok := Token{AccessToken: "secret-token", ExpiresAt: time.Now().Add(time.Hour).UTC()}
+	require.NoError(t, store.SetJSON(ctx, "auth:token:test:k", tok, time.Hour))
+	assert.NotContains(t, string(inner.data["auth:token:test:k"]), "secret-token")
+
+	var got Token
+	require.NoError(t, store.GetJSON(ctx, "auth:token:test:k", &got))
+	assert.Equal(t, tok.AccessToken, got.AccessToken)
+
+	// A value moved to another key must not decrypt.
+	inner.data["auth:token:test:other"] = inner.data["auth:token:test:k"]
+	assert.Error(t, store.GetJSON(ctx, "auth:token:test:other", &got))
+
+	_, err = NewEncryptedStore(inner, "")
+	assert.Error(t, err)
+	_, err = NewEncryptedStore(inner, base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+}
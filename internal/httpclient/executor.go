@@ -9,7 +9,12 @@ import (
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/rate"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 )
 
 // Backoff returns the retry sleep duration for the given attempt number.
@@ -24,6 +29,43 @@ func Backoff(attempt int) time.Duration {
 	}
 }
 
+type endpointKey struct{}
+
+// WithEndpoint labels requests made with ctx by endpoint in the venue request
+// metrics. Clients should pass the path template (e.g. "/v1/orders/{id}")
+// whenever the request path embeds identifiers; otherwise the raw path is used.
+func WithEndpoint(ctx context.Context, endpoint string) context.Context {
+	return context.WithValue(ctx, endpointKey{}, endpoint)
+}
+
+func endpointOf(ctx context.Context, req *http.Request) string {
+	if endpoint, ok := ctx.Value(endpointKey{}).(string); ok && endpoint != "" {
+		return endpoint
+	}
+	return req.URL.Path
+}
+
+type captureKey struct{}
+
+// Response is the raw venue response captured by CaptureResponse.
+type Response struct {
+	Status int
+	Body   []byte
+}
+
+// CaptureResponse returns a context under which DoJSON stores the raw status
+// and body of the last response it receives, error responses included, so
+// callers can keep the venue's exact payload.
+func CaptureResponse(ctx context.Context) (context.Context, *Response) {
+	r := &Response{}
+	return context.WithValue(ctx, captureKey{}, r), r
+}
+
+func captureOf(ctx context.Context) *Response {
+	r, _ := ctx.Value(captureKey{}).(*Response)
+	return r
+}
+
 // Executor handles rate-limited, retrying HTTP execution with JSON decoding.
 type Executor struct {
 	rateMgr      *rate.Manager
@@ -52,8 +94,22 @@ func New(
 }
 
 // DoJSON executes req with rate limiting and retries, then JSON-decodes the response into out.
-// rateLimitKey scopes the rate limiter per client/venue.
-func (e *Executor) DoJSON(ctx context.Context, req *http.Request, rateLimitKey string, out any) error {
+// rateLimitKey scopes the rate limiter per client/venue. The call, retries included, is one
+// client span whose trace context is sent to the venue; each attempt is recorded in the
+// venue request metrics.
+func (e *Executor) DoJSON(ctx context.Context, req *http.Request, rateLimitKey string, out any) (err error) {
+	ctx, span := tracing.Start(ctx, e.venueTag+" "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+			attribute.String("url.path", req.URL.Path),
+		))
+	defer func() { tracing.End(span, err) }()
+	tracing.InjectHTTP(ctx, req.Header)
+	endpoint := endpointOf(ctx, req)
+	captured := captureOf(ctx)
+
 	if e.rateMgr != nil {
 		if err := e.rateMgr.Wait(ctx, rateLimitKey); err != nil {
 			return fmt.Errorf("rate limit wait: %w", err)
@@ -75,6 +131,7 @@ func (e *Executor) DoJSON(ctx context.Context, req *http.Request, rateLimitKey s
 		start := time.Now()
 		resp, err := e.http.Do(req)
 		if err != nil {
+			metrics.ObserveVenueRequest(e.venueTag, endpoint, req.Method, 0, start)
 			lastErr = err
 			slog.Warn(e.venueTag+".http_failed",
 				"url", req.URL.String(),
@@ -87,6 +144,14 @@ func (e *Executor) DoJSON(ctx context.Context, req *http.Request, rateLimitKey s
 		body, _ := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
 		elapsed := time.Since(start)
+		metrics.ObserveVenueRequest(e.venueTag, endpoint, req.Method, resp.StatusCode, start)
+		if captured != nil {
+			captured.Status, captured.Body = resp.StatusCode, body
+		}
+		span.SetAttributes(
+			attribute.Int("http.response.status_code", resp.StatusCode),
+			attribute.Int("http.request.resend_count", attempt),
+		)
 
 		if resp.StatusCode >= 500 {
 			slog.Warn(e.venueTag+".server_error",
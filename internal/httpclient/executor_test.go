@@ -11,8 +11,11 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/internal/metrics"
 )
 
 func newExec(retryMax int, client *http.Client) *Executor {
@@ -201,3 +204,52 @@ func TestDoJSON_TwoFailuresThenSuccess(t *testing.T) {
 	assert.EqualValues(t, 3, count.Load(), "expected 3 total attempts")
 	assert.Equal(t, 1, out["v"])
 }
+
+// ─── Venue request metrics ───────────────────────────────────────────────────
+
+func TestDoJSON_RecordsEachAttemptByEndpoint(t *testing.T) {
+	h, _ := countingHandler(1, http.StatusServiceUnavailable, []byte(`{}`))
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	exec := New(nil, srv.Client(), 2, "metrics_test", nil)
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/v1/orders/abc", nil)
+
+	ctx := WithEndpoint(context.Background(), "/v1/orders/{id}")
+	require.NoError(t, exec.DoJSON(ctx, req, "k", nil))
+
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.VenueRequests.WithLabelValues("metrics_test", "/v1/orders/{id}", "GET", "503")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.VenueRequests.WithLabelValues("metrics_test", "/v1/orders/{id}", "GET", "200")), 0)
+}
+
+// ─── Raw response capture ────────────────────────────────────────────────────
+
+func TestDoJSON_CapturesLastResponse(t *testing.T) {
+	h, _ := countingHandler(1, http.StatusBadGateway, []byte(`{"id":"q-1"}`))
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	exec := newExec(2, srv.Client())
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL, nil)
+
+	ctx, captured := CaptureResponse(context.Background())
+	require.NoError(t, exec.DoJSON(ctx, req, "k", nil))
+	assert.Equal(t, http.StatusOK, captured.Status)
+	assert.JSONEq(t, `{"id":"q-1"}`, string(captured.Body))
+}
+
+func TestDoJSON_CapturesErrorResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"code":"QUOTE_EXPIRED"}`))
+	}))
+	defer srv.Close()
+
+	exec := newExec(0, srv.Client())
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL, nil)
+
+	ctx, captured := CaptureResponse(context.Background())
+	require.Error(t, exec.DoJSON(ctx, req, "k", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, captured.Status)
+	assert.Contains(t, string(captured.Body), "QUOTE_EXPIRED")
+}
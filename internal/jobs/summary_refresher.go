@@ -26,6 +26,9 @@ type DBExecutor interface {
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
 }
 
+// SubjectSummaryRefreshed is published after each refresh of the balance summary.
+const SubjectSummaryRefreshed = "evt.balance.summary.refreshed.v1"
+
 // NewSummaryRefresher constructs a background job that runs periodically.
 func NewSummaryRefresher(nc *nats.Conn, db DBExecutor, pub *publisher.Publisher, interval time.Duration) *SummaryRefresher {
 	return &SummaryRefresher{
@@ -76,11 +79,11 @@ func (r *SummaryRefresher) runOnce(ctx context.Context) {
 
 	// Emit event for downstream analytics systems
 	event := map[string]any{
-		"event":       "evt.balance.summary.refreshed.v1",
+		"event":       SubjectSummaryRefreshed,
 		"timestamp":   time.Now().UTC(),
 		"duration_ms": time.Since(start).Milliseconds(),
 	}
-	if err := r.publisher.Publish(ctx, "evt.balance.summary.refreshed.v1", event); err != nil {
+	if err := r.publisher.Publish(ctx, SubjectSummaryRefreshed, event); err != nil {
 		slog.Warn("summary_refresher.nats_publish_failed", "error", err)
 	}
 
@@ -80,3 +80,16 @@ func searchSubstring(s, sub string) bool {
 	}
 	return false
 }
+
+func TestTradeSyncWriter_LookupTrades_NoIDs(t *testing.T) {
+	writer := NewTradeSyncWriter(nil, "test-adapter")
+
+	// No IDs should not touch the database
+	synced, err := writer.LookupTrades(t.Context(), nil)
+	if err != nil {
+		t.Fatalf("expected nil error for no IDs, got: %v", err)
+	}
+	if len(synced) != 0 {
+		t.Errorf("expected empty result, got %d rows", len(synced))
+	}
+}
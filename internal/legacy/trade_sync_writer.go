@@ -2,10 +2,15 @@ package legacy
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
@@ -30,6 +35,85 @@ func (w *TradeSyncWriter) SyncTradeUpsert(ctx context.Context, trade *model.Trad
 		return nil
 	}
 
+	if err := w.upsert(ctx, w.db, trade); err != nil {
+		slog.Error("legacy.trade_sync_failed",
+			"trade_id", trade.TradeID,
+			"client_id", trade.ClientID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("legacy.trade_sync_upsert",
+		"trade_id", trade.TradeID,
+		"status", trade.Status,
+		"client_id", trade.ClientID,
+		"venue", trade.Venue,
+		"executed_at", trade.ExecutedAt,
+	)
+
+	return nil
+}
+
+// SyncTradeWithEvent upserts the trade and enqueues the event announcing it
+// in one transaction, so the activity.t_order row and the event are either
+// both recorded or neither is. The outbox relay publishes the event under
+// msgID (see publisher.TradeEventID).
+func (w *TradeSyncWriter) SyncTradeWithEvent(ctx context.Context, trade *model.TradeConfirmation, subject, msgID string, payload any) error {
+	if trade == nil {
+		return nil
+	}
+
+	err := w.withTx(ctx, func(tx pgx.Tx) error {
+		if err := w.upsert(ctx, tx, trade); err != nil {
+			return fmt.Errorf("upsert trade: %w", err)
+		}
+		if err := outbox.Enqueue(ctx, tx, w.source, subject, msgID, payload); err != nil {
+			return fmt.Errorf("enqueue event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		slog.Error("legacy.trade_sync_failed",
+			"trade_id", trade.TradeID,
+			"client_id", trade.ClientID,
+			"subject", subject,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("legacy.trade_sync_upsert",
+		"trade_id", trade.TradeID,
+		"status", trade.Status,
+		"client_id", trade.ClientID,
+		"venue", trade.Venue,
+		"executed_at", trade.ExecutedAt,
+		"subject", subject,
+	)
+
+	return nil
+}
+
+func (w *TradeSyncWriter) withTx(ctx context.Context, fn func(pgx.Tx) error) error {
+	tx, err := w.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// execer is satisfied by both *pgxpool.Pool and pgx.Tx.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+func (w *TradeSyncWriter) upsert(ctx context.Context, db execer, trade *model.TradeConfirmation) error {
 	const query = `
 		INSERT INTO activity.t_order (
 			s_id_order,
@@ -67,7 +151,7 @@ func (w *TradeSyncWriter) SyncTradeUpsert(ctx context.Context, trade *model.Trad
 			s_id_rfq_external = EXCLUDED.s_id_rfq_external;
 	`
 
-	_, err := w.db.Exec(ctx, query,
+	_, err := db.Exec(ctx, query,
 		trade.TradeID,         // s_id_order
 		trade.Instrument,      // s_instrument_pair
 		trade.Price,           // dec_price
@@ -85,22 +169,79 @@ func (w *TradeSyncWriter) SyncTradeUpsert(ctx context.Context, trade *model.Trad
 		trade.ProviderOrderID, // s_id_order_external
 		trade.ProviderRFQID,   // s_id_rfq_external
 	)
+	return err
+}
+
+// SyncedTrade is the subset of an activity.t_order row used to reconcile it
+// against the venue.
+type SyncedTrade struct {
+	TradeID    string
+	ClientID   string
+	Instrument string
+	Side       string
+	Status     string
+	Price      float64
+	Quantity   float64
+	ExecutedAt time.Time
+}
+
+const syncedTradeColumns = `
+	s_id_order, COALESCE(s_id_client, ''), COALESCE(s_instrument_pair, ''), COALESCE(s_side, ''),
+	COALESCE(s_status, ''), COALESCE(dec_price, 0), COALESCE(dec_quantity, 0), dt_order`
+
+// LookupTrades returns the synced rows for tradeIDs, keyed by trade ID.
+// Trades that were never synced are absent from the result.
+func (w *TradeSyncWriter) LookupTrades(ctx context.Context, tradeIDs []string) (map[string]SyncedTrade, error) {
+	out := make(map[string]SyncedTrade, len(tradeIDs))
+	if len(tradeIDs) == 0 {
+		return out, nil
+	}
+
+	trades, err := w.queryTrades(ctx, `
+		SELECT `+syncedTradeColumns+`
+		FROM activity.t_order
+		WHERE s_id_order = ANY($1);
+	`, tradeIDs)
 	if err != nil {
-		slog.Error("legacy.trade_sync_failed",
-			"trade_id", trade.TradeID,
-			"client_id", trade.ClientID,
-			"error", err,
-		)
-		return err
+		return nil, err
 	}
+	for _, t := range trades {
+		out[t.TradeID] = t
+	}
+	return out, nil
+}
 
-	slog.Info("legacy.trade_sync_upsert",
-		"trade_id", trade.TradeID,
-		"status", trade.Status,
-		"client_id", trade.ClientID,
-		"venue", trade.Venue,
-		"executed_at", trade.ExecutedAt,
-	)
+// TradesBetween returns the client's trades written by this writer's source
+// with an order time in [from, to).
+func (w *TradeSyncWriter) TradesBetween(ctx context.Context, clientID string, from, to time.Time) ([]SyncedTrade, error) {
+	return w.queryTrades(ctx, `
+		SELECT `+syncedTradeColumns+`
+		FROM activity.t_order
+		WHERE s_source = $1 AND s_id_client = $2
+		  AND dt_order >= $3 AND dt_order < $4
+		ORDER BY dt_order;
+	`, w.source, clientID, from, to)
+}
 
-	return nil
+func (w *TradeSyncWriter) queryTrades(ctx context.Context, query string, args ...any) ([]SyncedTrade, error) {
+	rows, err := w.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []SyncedTrade
+	for rows.Next() {
+		var t SyncedTrade
+		var executedAt *time.Time
+		if err := rows.Scan(&t.TradeID, &t.ClientID, &t.Instrument, &t.Side,
+			&t.Status, &t.Price, &t.Quantity, &executedAt); err != nil {
+			return nil, err
+		}
+		if executedAt != nil {
+			t.ExecutedAt = *executedAt
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
 }
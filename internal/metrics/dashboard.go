@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+//go:generate go run ./gendashboard ../../docs/grafana/adapters-dashboard.json
+
+// DashboardUID is the stable Grafana UID of the generated adapters dashboard.
+const DashboardUID = "checker-adapters"
+
+// panel describes one dashboard panel in terms of the venue metrics above.
+type panel struct {
+	title string
+	unit  string
+	exprs []target
+}
+
+type target struct {
+	expr   string
+	legend string
+}
+
+// dashboardRows groups the panels by the component emitting them. Queries use
+// the metric names from venue.go, filtered by the $venue template variable.
+var dashboardRows = []struct {
+	title  string
+	panels []panel
+}{
+	{"Venue API", []panel{
+		{"Requests / s by endpoint", "reqps", []target{
+			{`sum by (venue, endpoint, method) (rate(venue_api_requests_total{venue=~"$venue"}[5m]))`, "{{venue}} {{method}} {{endpoint}}"},
+		}},
+		{"Error ratio by endpoint", "percentunit", []target{
+			{`sum by (venue, endpoint) (rate(venue_api_requests_total{venue=~"$venue", status!~"2.."}[5m])) / sum by (venue, endpoint) (rate(venue_api_requests_total{venue=~"$venue"}[5m]))`, "{{venue}} {{endpoint}}"},
+		}},
+		{"Responses by status", "reqps", []target{
+			{`sum by (venue, status) (rate(venue_api_requests_total{venue=~"$venue"}[5m]))`, "{{venue}} {{status}}"},
+		}},
+		{"Request latency p95", "s", []target{
+			{`histogram_quantile(0.95, sum by (le, venue, endpoint) (rate(venue_api_request_duration_seconds_bucket{venue=~"$venue"}[5m])))`, "{{venue}} {{endpoint}}"},
+		}},
+	}},
+	{"Pollers", []panel{
+		{"Active polled trades", "short", []target{
+			{`sum by (venue) (adapter_poller_active_trades{venue=~"$venue"})`, "{{venue}}"},
+		}},
+		{"Poll latency p95", "s", []target{
+			{`histogram_quantile(0.95, sum by (le, venue) (rate(adapter_poll_duration_seconds_bucket{venue=~"$venue"}[5m])))`, "{{venue}}"},
+		}},
+		{"Time to terminal p50 / p95", "s", []target{
+			{`histogram_quantile(0.5, sum by (le, venue) (rate(adapter_trade_time_to_terminal_seconds_bucket{venue=~"$venue"}[15m])))`, "{{venue}} p50"},
+			{`histogram_quantile(0.95, sum by (le, venue) (rate(adapter_trade_time_to_terminal_seconds_bucket{venue=~"$venue"}[15m])))`, "{{venue}} p95"},
+		}},
+		{"Terminal trades by status", "short", []target{
+			{`sum by (venue, status) (increase(adapter_trade_time_to_terminal_seconds_count{venue=~"$venue"}[1h]))`, "{{venue}} {{status}}"},
+		}},
+	}},
+	{"Webhooks", []panel{
+		{"Webhooks by result", "reqps", []target{
+			{`sum by (venue, result) (rate(adapter_webhook_events_total{venue=~"$venue"}[5m]))`, "{{venue}} {{result}}"},
+		}},
+	}},
+	{"Commands", []panel{
+		{"Commands / s by result", "ops", []target{
+			{`sum by (venue, command, result) (rate(adapter_commands_total{venue=~"$venue"}[5m]))`, "{{venue}} {{command}} {{result}}"},
+		}},
+		{"Command latency p95", "s", []target{
+			{`histogram_quantile(0.95, sum by (le, venue, command) (rate(adapter_command_duration_seconds_bucket{venue=~"$venue"}[5m])))`, "{{venue}} {{command}}"},
+		}},
+	}},
+	{"Execution", []panel{
+		{"Quote to fill p50 / p95", "s", []target{
+			{`histogram_quantile(0.5, sum by (le, venue) (rate(adapter_quote_to_fill_seconds_bucket{venue=~"$venue"}[15m])))`, "{{venue}} p50"},
+			{`histogram_quantile(0.95, sum by (le, venue) (rate(adapter_quote_to_fill_seconds_bucket{venue=~"$venue"}[15m])))`, "{{venue}} p95"},
+		}},
+		{"NATS publish errors", "short", []target{
+			{`sum by (venue, subject) (increase(nats_publish_errors_total{venue=~"$venue"}[5m]))`, "{{venue}} {{subject}}"},
+		}},
+		{"WebSocket reconnects", "short", []target{
+			{`sum by (venue) (increase(adapter_ws_reconnects_total{venue=~"$venue"}[15m]))`, "{{venue}}"},
+		}},
+	}},
+}
+
+// Dashboard renders the Grafana dashboard for the shared venue metrics. The
+// committed copy under docs/grafana is regenerated with `go generate`.
+func Dashboard() ([]byte, error) {
+	const width, height = 8, 8
+
+	var panels []map[string]any
+	id, y := 1, 0
+	for _, row := range dashboardRows {
+		panels = append(panels, map[string]any{
+			"id":        id,
+			"type":      "row",
+			"title":     row.title,
+			"collapsed": false,
+			"gridPos":   map[string]int{"h": 1, "w": 24, "x": 0, "y": y},
+			"panels":    []any{},
+		})
+		id++
+		y++
+		for i, p := range row.panels {
+			var targets []map[string]any
+			for j, t := range p.exprs {
+				targets = append(targets, map[string]any{
+					"datasource":   map[string]string{"type": "prometheus", "uid": "${datasource}"},
+					"expr":         t.expr,
+					"legendFormat": t.legend,
+					"refId":        string(rune('A' + j)),
+				})
+			}
+			panels = append(panels, map[string]any{
+				"id":         id,
+				"type":       "timeseries",
+				"title":      p.title,
+				"datasource": map[string]string{"type": "prometheus", "uid": "${datasource}"},
+				"fieldConfig": map[string]any{
+					"defaults":  map[string]any{"unit": p.unit},
+					"overrides": []any{},
+				},
+				"gridPos": map[string]int{"h": height, "w": width, "x": (i % 3) * width, "y": y + (i/3)*height},
+				"targets": targets,
+			})
+			id++
+		}
+		y += ((len(row.panels) + 2) / 3) * height
+	}
+
+	dashboard := map[string]any{
+		"uid":           DashboardUID,
+		"title":         "Checker Adapters",
+		"tags":          []string{"adapters", "venues"},
+		"timezone":      "utc",
+		"schemaVersion": 39,
+		"refresh":       "30s",
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"templating": map[string]any{
+			"list": []map[string]any{
+				{
+					"name":  "datasource",
+					"type":  "datasource",
+					"query": "prometheus",
+				},
+				{
+					"name":       "venue",
+					"type":       "query",
+					"datasource": map[string]string{"type": "prometheus", "uid": "${datasource}"},
+					"query":      "label_values(venue_api_requests_total, venue)",
+					"refresh":    2,
+					"multi":      true,
+					"includeAll": true,
+					"allValue":   ".*",
+					"current":    map[string]any{"text": "All", "value": "$__all"},
+				},
+			},
+		},
+		"panels": panels,
+	}
+
+	out, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal dashboard: %w", err)
+	}
+	return append(out, '\n'), nil
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDashboard_UpToDate(t *testing.T) {
+	want, err := Dashboard()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := os.ReadFile("../../docs/grafana/adapters-dashboard.json")
+	if err != nil {
+		t.Fatalf("read committed dashboard: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("docs/grafana/adapters-dashboard.json is stale; run `go generate ./internal/metrics`")
+	}
+}
+
+func TestQuoteClock_ObservesOnce(t *testing.T) {
+	q := &quoteClock{issued: make(map[string]time.Time)}
+	issued := time.Now()
+	q.record("capa", "q-1", issued)
+
+	if _, ok := q.take("rio", "q-1", issued.Add(time.Second)); ok {
+		t.Error("quote must not match another venue")
+	}
+	d, ok := q.take("capa", "q-1", issued.Add(3*time.Second))
+	if !ok || d != 3*time.Second {
+		t.Fatalf("expected 3s, got %v (ok=%v)", d, ok)
+	}
+	if _, ok := q.take("capa", "q-1", issued.Add(4*time.Second)); ok {
+		t.Error("a quote must be observed at most once")
+	}
+}
+
+func TestQuoteClock_Expires(t *testing.T) {
+	q := &quoteClock{issued: make(map[string]time.Time)}
+	issued := time.Now()
+	q.record("capa", "old", issued)
+
+	if _, ok := q.take("capa", "old", issued.Add(quoteTTL+time.Second)); ok {
+		t.Error("fills past the TTL must not be observed")
+	}
+
+	q.record("capa", "old", issued)
+	q.record("capa", "new", issued.Add(quoteTTL+2*time.Minute))
+	if _, ok := q.issued["capa|old"]; ok {
+		t.Error("expected expired quote to be pruned")
+	}
+}
@@ -0,0 +1,27 @@
+// Command gendashboard writes the Grafana dashboard for the shared venue
+// metrics to the path given as its only argument.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Checker-Finance/adapters/internal/metrics"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gendashboard <output.json>")
+		os.Exit(2)
+	}
+
+	out, err := metrics.Dashboard()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(os.Args[1], out, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -44,6 +44,40 @@ var (
 		[]string{"component", "reason"},
 	)
 
+	// Tracks token lookups by venue and where the token came from.
+	AuthTokenRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_token_requests_total",
+			Help: "Token lookups by venue and result.",
+		},
+		[]string{"venue", "result"}, // hit | store_hit | fetched | error
+	)
+
+	AuthTokenFetchLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "auth_token_fetch_latency_seconds",
+			Help:    "Time taken to fetch a token from the venue.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"venue"},
+	)
+
+	AuthTokenInvalidations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "auth_token_invalidations_total",
+			Help: "Tokens discarded after the venue rejected them.",
+		},
+		[]string{"venue"},
+	)
+
+	OutboxRelayed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "outbox_events_relayed_total",
+			Help: "Outbox events the relay tried to publish, by result (sent, failed).",
+		},
+		[]string{"result"},
+	)
+
 	// Gauges the last successful poll time (seconds since epoch).
 	LastPollTimestamp = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -83,3 +117,15 @@ func IncError(component, reason string) {
 func SetLastPoll(component string, t time.Time) {
 	LastPollTimestamp.WithLabelValues(component).Set(float64(t.Unix()))
 }
+
+func IncAuthToken(venue, result string) {
+	AuthTokenRequests.WithLabelValues(venue, result).Inc()
+}
+
+func IncAuthTokenInvalidation(venue string) {
+	AuthTokenInvalidations.WithLabelValues(venue).Inc()
+}
+
+func IncOutboxRelayed(result string) {
+	OutboxRelayed.WithLabelValues(result).Inc()
+}
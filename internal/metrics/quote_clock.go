@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// quoteTTL bounds how long an issued quote is remembered for quote-to-fill
+// timing; fills of older quotes are not observed.
+const quoteTTL = time.Hour
+
+// quoteClock remembers when quotes were issued so fills can be timed against
+// them. It is per process: a fill handled by a different replica than the
+// quote is not observed.
+type quoteClock struct {
+	mu        sync.Mutex
+	issued    map[string]time.Time // venue + "|" + quote ID → issue time
+	lastPrune time.Time
+}
+
+var quotes = &quoteClock{issued: make(map[string]time.Time)}
+
+// RecordQuote notes that venue issued quoteID at t.
+func RecordQuote(venue, quoteID string, t time.Time) {
+	quotes.record(venue, quoteID, t)
+}
+
+// ObserveQuoteFill observes the quote-to-fill latency for quoteID, filled at t.
+// Each quote is observed at most once, so a poller and a webhook reporting the
+// same fill are counted once.
+func ObserveQuoteFill(venue, quoteID string, t time.Time) {
+	if d, ok := quotes.take(venue, quoteID, t); ok {
+		QuoteToFill.WithLabelValues(venue).Observe(d.Seconds())
+	}
+}
+
+func (q *quoteClock) record(venue, quoteID string, t time.Time) {
+	if quoteID == "" {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.issued[venue+"|"+quoteID] = t
+	if t.Sub(q.lastPrune) > time.Minute {
+		for k, at := range q.issued {
+			if t.Sub(at) > quoteTTL {
+				delete(q.issued, k)
+			}
+		}
+		q.lastPrune = t
+	}
+}
+
+func (q *quoteClock) take(venue, quoteID string, t time.Time) (time.Duration, bool) {
+	if quoteID == "" {
+		return 0, false
+	}
+	key := venue + "|" + quoteID
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	at, ok := q.issued[key]
+	if !ok {
+		return 0, false
+	}
+	delete(q.issued, key)
+	if t.Sub(at) > quoteTTL {
+		return 0, false
+	}
+	return t.Sub(at), true
+}
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Venue metrics are shared by every adapter and labelled by venue (lowercase,
+// e.g. "capa"), so one dashboard covers all of them.
+var (
+	// VenueRequests counts HTTP attempts against a venue API, retries included.
+	// status is the HTTP status code, or "error" when no response was received.
+	VenueRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "venue_api_requests_total",
+			Help: "Venue API requests by venue, endpoint, method and status.",
+		},
+		[]string{"venue", "endpoint", "method", "status"},
+	)
+
+	VenueRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "venue_api_request_duration_seconds",
+			Help:    "Duration of venue API requests in seconds.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15), // 1ms → ~16s
+		},
+		[]string{"venue", "endpoint", "method"},
+	)
+
+	PollerActiveTrades = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "adapter_poller_active_trades",
+			Help: "Trades whose status is currently being polled.",
+		},
+		[]string{"venue"},
+	)
+
+	PollDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "adapter_poll_duration_seconds",
+			Help:    "Time taken to fetch a trade's status from the venue while polling.",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 12), // 10ms → ~20s
+		},
+		[]string{"venue"},
+	)
+
+	// TimeToTerminal measures from the start of polling (trade submission) to
+	// the poller seeing a terminal status.
+	TimeToTerminal = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "adapter_trade_time_to_terminal_seconds",
+			Help:    "Time from trade submission to a terminal status, by final status.",
+			Buckets: []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600, 7200, 21600, 86400},
+		},
+		[]string{"venue", "status"},
+	)
+
+	WebhookEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "adapter_webhook_events_total",
+			Help: "Venue webhooks by result (received, verified, invalid_signature, duplicate).",
+		},
+		[]string{"venue", "result"},
+	)
+
+	CommandsHandled = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "adapter_commands_total",
+			Help: "NATS commands handled by venue, command and result (ok, error).",
+		},
+		[]string{"venue", "command", "result"},
+	)
+
+	CommandDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "adapter_command_duration_seconds",
+			Help:    "Time taken to handle a NATS command.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"venue", "command"},
+	)
+
+	QuoteToFill = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "adapter_quote_to_fill_seconds",
+			Help:    "Time from a quote being issued to its trade filling.",
+			Buckets: []float64{0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+		[]string{"venue"},
+	)
+
+	NATSPublishErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nats_publish_errors_total",
+			Help: "Number of NATS publish failures by venue and subject.",
+		},
+		[]string{"venue", "subject"},
+	)
+
+	WSReconnects = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "adapter_ws_reconnects_total",
+			Help: "Venue WebSocket reconnects by venue and client ID.",
+		},
+		[]string{"venue", "client_id"},
+	)
+)
+
+// ObserveVenueRequest records one venue API attempt. status is the HTTP status
+// code, or 0 when the request failed before a response.
+func ObserveVenueRequest(venue, endpoint, method string, status int, start time.Time) {
+	label := "error"
+	if status > 0 {
+		label = strconv.Itoa(status)
+	}
+	VenueRequests.WithLabelValues(venue, endpoint, method, label).Inc()
+	VenueRequestDuration.WithLabelValues(venue, endpoint, method).Observe(time.Since(start).Seconds())
+}
+
+// TrackPolledTrade marks a trade as polled and returns a func that unmarks it.
+func TrackPolledTrade(venue string) func() {
+	g := PollerActiveTrades.WithLabelValues(venue)
+	g.Inc()
+	return g.Dec
+}
+
+func ObservePoll(venue string, start time.Time) {
+	PollDuration.WithLabelValues(venue).Observe(time.Since(start).Seconds())
+}
+
+func ObserveTimeToTerminal(venue, status string, submitted time.Time) {
+	TimeToTerminal.WithLabelValues(venue, status).Observe(time.Since(submitted).Seconds())
+}
+
+func IncWebhook(venue, result string) {
+	WebhookEvents.WithLabelValues(venue, result).Inc()
+}
+
+// ObserveCommand records the outcome and latency of a handled NATS command.
+func ObserveCommand(venue, command string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	CommandsHandled.WithLabelValues(venue, command, result).Inc()
+	CommandDuration.WithLabelValues(venue, command).Observe(time.Since(start).Seconds())
+}
+
+func IncNATSPublishError(venue, subject string) {
+	NATSPublishErrors.WithLabelValues(venue, subject).Inc()
+}
+
+func IncWSReconnect(venue, clientID string) {
+	WSReconnects.WithLabelValues(venue, clientID).Inc()
+}
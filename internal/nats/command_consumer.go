@@ -10,6 +10,8 @@ import (
 
 	natsio "github.com/nats-io/nats.go"
 
+	"github.com/Checker-Finance/adapters/internal/metrics"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
@@ -42,21 +44,30 @@ func (c *CommandConsumer) Subscribe(ctx context.Context, quoteSubject, tradeSubj
 		if ctx.Err() != nil {
 			return
 		}
+		start := time.Now()
+		msgCtx, span := tracing.StartConsumer(context.Background(), msg)
+		var err error
+		defer func() {
+			tracing.End(span, err)
+			metrics.ObserveCommand(c.venue, "quote_request", start, err)
+		}()
+
 		var env model.Envelope
-		if err := json.Unmarshal(msg.Data, &env); err != nil {
+		if err = json.Unmarshal(msg.Data, &env); err != nil {
 			slog.Error(c.venue+".cmd.quote_request.unmarshal_failed", "error", err)
 			return
 		}
 		var req model.QuoteRequest
-		if err := json.Unmarshal(env.Payload, &req); err != nil {
+		if err = json.Unmarshal(env.Payload, &req); err != nil {
 			slog.Error(c.venue+".cmd.quote_request.payload_failed",
 				"client", env.ClientID,
 				"error", err)
 			return
 		}
-		msgCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		msgCtx = tracing.WithEnvelopeCorrelation(msgCtx, span, env.CorrelationID)
+		msgCtx, cancel := context.WithTimeout(msgCtx, 3*time.Second)
 		defer cancel()
-		if err := c.svc.HandleQuoteRequest(msgCtx, env, req); err != nil {
+		if err = c.svc.HandleQuoteRequest(msgCtx, env, req); err != nil {
 			slog.Error(c.venue+".cmd.quote_request.handle_failed",
 				"client", env.ClientID,
 				"error", err)
@@ -71,21 +82,30 @@ func (c *CommandConsumer) Subscribe(ctx context.Context, quoteSubject, tradeSubj
 		if ctx.Err() != nil {
 			return
 		}
+		start := time.Now()
+		msgCtx, span := tracing.StartConsumer(context.Background(), msg)
+		var err error
+		defer func() {
+			tracing.End(span, err)
+			metrics.ObserveCommand(c.venue, "trade_execute", start, err)
+		}()
+
 		var env model.Envelope
-		if err := json.Unmarshal(msg.Data, &env); err != nil {
+		if err = json.Unmarshal(msg.Data, &env); err != nil {
 			slog.Error(c.venue+".cmd.trade_execute.unmarshal_failed", "error", err)
 			return
 		}
 		var cmd model.TradeCommand
-		if err := json.Unmarshal(env.Payload, &cmd); err != nil {
+		if err = json.Unmarshal(env.Payload, &cmd); err != nil {
 			slog.Error(c.venue+".cmd.trade_execute.payload_failed",
 				"client", env.ClientID,
 				"error", err)
 			return
 		}
-		msgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		msgCtx = tracing.WithEnvelopeCorrelation(msgCtx, span, env.CorrelationID)
+		msgCtx, cancel := context.WithTimeout(msgCtx, 5*time.Second)
 		defer cancel()
-		if err := c.svc.HandleTradeExecute(msgCtx, env, cmd); err != nil {
+		if err = c.svc.HandleTradeExecute(msgCtx, env, cmd); err != nil {
 			slog.Error(c.venue+".cmd.trade_execute.handle_failed",
 				"client", env.ClientID,
 				"error", err)
@@ -0,0 +1,131 @@
+// Package outbox implements a transactional outbox: events are written to
+// activity.t_event_outbox in the same transaction as the state change they
+// announce, and a Relay publishes them to JetStream afterwards.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Message is an event waiting in the outbox.
+type Message struct {
+	ID       int64
+	MsgID    string // JetStream Nats-Msg-Id; also unique in the outbox
+	Subject  string
+	Payload  json.RawMessage
+	Attempts int
+}
+
+// Enqueue writes an event into the outbox within tx. An event whose message
+// ID is already in the outbox is ignored, so the same event enqueued twice
+// (e.g. by a webhook and a poller) is published once.
+func Enqueue(ctx context.Context, tx pgx.Tx, source, subject, msgID string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal outbox payload: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO activity.t_event_outbox (msg_id, subject, payload, source)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (msg_id) DO NOTHING;
+	`, msgID, subject, data, source)
+	return err
+}
+
+// Store is the relay's view of the outbox table.
+type Store interface {
+	// Claim leases up to limit unsent messages, oldest first, so other relay
+	// replicas skip them until the lease expires.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+	MarkSent(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, cause error) error
+	// PurgeSent deletes messages sent more than retention ago and returns
+	// how many were deleted.
+	PurgeSent(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// PGStore is the Postgres-backed outbox Store. It only sees the rows of one
+// source, since every adapter shares the table but publishes its own events.
+type PGStore struct {
+	db     *pgxpool.Pool
+	source string
+}
+
+// NewPGStore constructs a Store over source's rows of activity.t_event_outbox.
+// source must match the one the adapter enqueues with (e.g. "rio-adapter").
+func NewPGStore(db *pgxpool.Pool, source string) *PGStore {
+	return &PGStore{db: db, source: source}
+}
+
+// Claim leases unsent messages whose previous lease, if any, has expired.
+func (s *PGStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error) {
+	rows, err := s.db.Query(ctx, `
+		UPDATE activity.t_event_outbox o
+		SET locked_until = now() + $2::interval
+		WHERE o.id IN (
+			SELECT id FROM activity.t_event_outbox
+			WHERE sent_at IS NULL
+			  AND source = $3
+			  AND (locked_until IS NULL OR locked_until < now())
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING o.id, o.msg_id, o.subject, o.payload, o.attempts;
+	`, limit, lease.String(), s.source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Message
+	for rows.Next() {
+		var m Message
+		if err := rows.Scan(&m.ID, &m.MsgID, &m.Subject, &m.Payload, &m.Attempts); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// MarkSent records that a message was published.
+func (s *PGStore) MarkSent(ctx context.Context, id int64) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE activity.t_event_outbox
+		SET sent_at = now(), locked_until = NULL
+		WHERE id = $1;
+	`, id)
+	return err
+}
+
+// MarkFailed records a failed publish and releases the lease so the message
+// is retried on the next pass.
+func (s *PGStore) MarkFailed(ctx context.Context, id int64, cause error) error {
+	_, err := s.db.Exec(ctx, `
+		UPDATE activity.t_event_outbox
+		SET attempts = attempts + 1, last_error = $2, locked_until = NULL
+		WHERE id = $1;
+	`, id, cause.Error())
+	return err
+}
+
+// PurgeSent deletes messages sent more than retention ago. By then JetStream's
+// duplicate window has long passed, so the rows no longer serve dedup.
+func (s *PGStore) PurgeSent(ctx context.Context, retention time.Duration) (int64, error) {
+	tag, err := s.db.Exec(ctx, `
+		DELETE FROM activity.t_event_outbox
+		WHERE source = $1
+		  AND sent_at < now() - $2::interval;
+	`, s.source, retention.String())
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
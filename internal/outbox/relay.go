@@ -0,0 +1,123 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Checker-Finance/adapters/internal/metrics"
+)
+
+// Sender publishes a message under a JetStream message ID.
+// *publisher.Publisher satisfies it.
+type Sender interface {
+	PublishWithID(ctx context.Context, subject, msgID string, data []byte) error
+}
+
+// RelayConfig tunes the relay loop.
+type RelayConfig struct {
+	Interval  time.Duration // Pause between passes that found nothing to send (default 1s)
+	BatchSize int           // Messages claimed per pass (default 100)
+	Lease     time.Duration // How long a claim hides messages from other replicas (default 30s)
+
+	Retention     time.Duration // How long sent messages are kept (default 7 days)
+	PurgeInterval time.Duration // Pause between purges of sent messages (default 1h)
+}
+
+// Relay publishes outbox messages and marks them sent. Delivery is
+// at-least-once; JetStream drops re-sends within its duplicate window by
+// message ID.
+type Relay struct {
+	store  Store
+	sender Sender
+	cfg    RelayConfig
+}
+
+// NewRelay constructs a Relay, applying defaults to unset config fields.
+func NewRelay(store Store, sender Sender, cfg RelayConfig) *Relay {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 30 * time.Second
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = 7 * 24 * time.Hour
+	}
+	if cfg.PurgeInterval <= 0 {
+		cfg.PurgeInterval = time.Hour
+	}
+	return &Relay{store: store, sender: sender, cfg: cfg}
+}
+
+// Start relays until ctx is done. Full batches are followed immediately by
+// another pass so a backlog drains without waiting for the interval. Sent
+// messages older than the retention are purged every PurgeInterval.
+func (r *Relay) Start(ctx context.Context) {
+	var lastPurge time.Time
+	for {
+		if time.Since(lastPurge) >= r.cfg.PurgeInterval {
+			r.PurgeOnce(ctx)
+			lastPurge = time.Now()
+		}
+
+		n, err := r.RelayOnce(ctx)
+		if err != nil {
+			slog.Warn("outbox.relay_failed", "error", err)
+		}
+		if n == r.cfg.BatchSize && err == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.cfg.Interval):
+		}
+	}
+}
+
+// RelayOnce claims one batch and publishes it, returning how many messages
+// were claimed. A message that fails to publish is released for the next
+// pass; the rest of the batch is still attempted.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	msgs, err := r.store.Claim(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, m := range msgs {
+		if err := r.sender.PublishWithID(ctx, m.Subject, m.MsgID, m.Payload); err != nil {
+			metrics.IncOutboxRelayed("failed")
+			slog.Warn("outbox.publish_failed",
+				"msg_id", m.MsgID,
+				"subject", m.Subject,
+				"attempts", m.Attempts+1,
+				"error", err)
+			if err := r.store.MarkFailed(ctx, m.ID, err); err != nil {
+				slog.Warn("outbox.mark_failed_failed", "msg_id", m.MsgID, "error", err)
+			}
+			continue
+		}
+		metrics.IncOutboxRelayed("sent")
+		if err := r.store.MarkSent(ctx, m.ID); err != nil {
+			// The lease expires and the message is re-sent; JetStream dedups it.
+			slog.Warn("outbox.mark_sent_failed", "msg_id", m.MsgID, "error", err)
+		}
+	}
+	return len(msgs), nil
+}
+
+// PurgeOnce deletes sent messages older than the retention.
+func (r *Relay) PurgeOnce(ctx context.Context) {
+	n, err := r.store.PurgeSent(ctx, r.cfg.Retention)
+	if err != nil {
+		slog.Warn("outbox.purge_failed", "error", err)
+		return
+	}
+	if n > 0 {
+		slog.Info("outbox.purged", "deleted", n, "retention", r.cfg.Retention)
+	}
+}
@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// memStore is an in-memory Store.
+type memStore struct {
+	pending []Message
+	sent    []int64
+	failed  map[int64]int
+
+	purgedWith []time.Duration
+}
+
+func (s *memStore) Claim(_ context.Context, limit int, _ time.Duration) ([]Message, error) {
+	var out []Message
+	for _, m := range s.pending {
+		if len(out) == limit {
+			break
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (s *memStore) MarkSent(_ context.Context, id int64) error {
+	s.sent = append(s.sent, id)
+	kept := s.pending[:0]
+	for _, m := range s.pending {
+		if m.ID != id {
+			kept = append(kept, m)
+		}
+	}
+	s.pending = kept
+	return nil
+}
+
+func (s *memStore) PurgeSent(_ context.Context, retention time.Duration) (int64, error) {
+	s.purgedWith = append(s.purgedWith, retention)
+	return 0, nil
+}
+
+func (s *memStore) MarkFailed(_ context.Context, id int64, _ error) error {
+	if s.failed == nil {
+		s.failed = make(map[int64]int)
+	}
+	s.failed[id]++
+	return nil
+}
+
+// fakeSender records published message IDs and fails subjects in failFor.
+type fakeSender struct {
+	msgIDs  []string
+	failFor map[string]bool
+}
+
+func (f *fakeSender) PublishWithID(_ context.Context, subject, msgID string, _ []byte) error {
+	if f.failFor[subject] {
+		return errors.New("nats unavailable")
+	}
+	f.msgIDs = append(f.msgIDs, msgID)
+	return nil
+}
+
+func TestRelayOnce_PublishesWithMessageIDAndMarksSent(t *testing.T) {
+	st := &memStore{pending: []Message{
+		{ID: 1, MsgID: "trade:RIO:ord-1:filled", Subject: "evt.trade.filled.v1.RIO"},
+		{ID: 2, MsgID: "trade:RIO:ord-2:cancelled", Subject: "evt.trade.cancelled.v1.RIO"},
+	}}
+	sender := &fakeSender{}
+
+	n, err := NewRelay(st, sender, RelayConfig{}).RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 claimed, got %d", n)
+	}
+	if len(sender.msgIDs) != 2 || sender.msgIDs[0] != "trade:RIO:ord-1:filled" {
+		t.Errorf("unexpected message IDs: %v", sender.msgIDs)
+	}
+	if len(st.sent) != 2 || len(st.pending) != 0 {
+		t.Errorf("expected both messages marked sent, sent=%v pending=%d", st.sent, len(st.pending))
+	}
+}
+
+func TestRelayOnce_FailedPublishIsRetried(t *testing.T) {
+	st := &memStore{pending: []Message{
+		{ID: 1, MsgID: "a", Subject: "evt.trade.filled.v1.CAPA"},
+		{ID: 2, MsgID: "b", Subject: "evt.trade.filled.v1.XFX"},
+	}}
+	sender := &fakeSender{failFor: map[string]bool{"evt.trade.filled.v1.CAPA": true}}
+	relay := NewRelay(st, sender, RelayConfig{})
+
+	if _, err := relay.RelayOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.failed[1] != 1 {
+		t.Errorf("expected message 1 marked failed once, got %d", st.failed[1])
+	}
+	if len(st.sent) != 1 || st.sent[0] != 2 {
+		t.Errorf("a failure must not block the rest of the batch, sent=%v", st.sent)
+	}
+
+	sender.failFor = nil
+	if _, err := relay.RelayOnce(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(st.pending) != 0 {
+		t.Errorf("expected the failed message to be sent on the next pass, pending=%d", len(st.pending))
+	}
+}
+
+func TestRelayOnce_RespectsBatchSize(t *testing.T) {
+	st := &memStore{}
+	for i := range 5 {
+		st.pending = append(st.pending, Message{ID: int64(i + 1), MsgID: string(rune('a' + i)), Subject: "evt.trade.filled.v1.ZODIA"})
+	}
+
+	n, err := NewRelay(st, &fakeSender{}, RelayConfig{BatchSize: 2}).RelayOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 || len(st.pending) != 3 {
+		t.Errorf("expected 2 relayed and 3 pending, got %d relayed and %d pending", n, len(st.pending))
+	}
+}
+
+func TestRelayStart_PurgesSentWithRetention(t *testing.T) {
+	st := &memStore{}
+	relay := NewRelay(st, &fakeSender{}, RelayConfig{Retention: 48 * time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	relay.Start(ctx)
+
+	if len(st.purgedWith) != 1 || st.purgedWith[0] != 48*time.Hour {
+		t.Errorf("expected one purge with 48h retention, got %v", st.purgedWith)
+	}
+}
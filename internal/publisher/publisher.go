@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/Checker-Finance/adapters/internal/metrics"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/model"
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Publisher wraps a NATS connection and provides helpers for publishing canonical events.
@@ -18,11 +22,36 @@ type Publisher struct {
 	js      nats.JetStreamContext
 	subject string
 	service string
+	opts    Options
 }
 
-// New creates a new Publisher with JetStream enabled if available.
+// Options tunes how a Publisher waits for JetStream acks.
+type Options struct {
+	// Async makes Publish and PublishEnvelope return once the message is
+	// handed to JetStream; acks are awaited in the background.
+	Async bool
+	// MaxPending bounds the async publishes awaiting an ack. Further
+	// publishes block until acks arrive (default 256).
+	MaxPending int
+	// OnFailure is called when an async publish is not acknowledged.
+	OnFailure func(msg *nats.Msg, err error)
+}
+
+// tradeEventIDPrefix marks message IDs built by TradeEventID.
+const tradeEventIDPrefix = "trade:"
+
+// New creates a new Publisher with JetStream enabled if available. Publishes
+// block until JetStream acknowledges them.
 func New(nc *nats.Conn, subject, service string) (*Publisher, error) {
-	js, err := nc.JetStream()
+	return NewWithOptions(nc, subject, service, Options{})
+}
+
+// NewWithOptions creates a Publisher, optionally publishing asynchronously.
+func NewWithOptions(nc *nats.Conn, subject, service string, opts Options) (*Publisher, error) {
+	if opts.MaxPending <= 0 {
+		opts.MaxPending = 256
+	}
+	js, err := nc.JetStream(nats.PublishAsyncMaxPending(opts.MaxPending))
 	if err != nil {
 		return nil, err
 	}
@@ -31,11 +60,41 @@ func New(nc *nats.Conn, subject, service string) (*Publisher, error) {
 		js:      js,
 		subject: subject,
 		service: service,
+		opts:    opts,
 	}, nil
 }
 
+// PublishOption customizes a single publish.
+type PublishOption func(*nats.Msg)
+
+// WithMsgID sets the JetStream message ID, so a re-publish of the same event
+// within the stream's duplicate window is discarded.
+func WithMsgID(id string) PublishOption {
+	return func(msg *nats.Msg) {
+		if id != "" {
+			msg.Header.Set(nats.MsgIdHdr, id)
+		}
+	}
+}
+
+// TradeEventID is the message ID of the event announcing that a trade reached
+// status on venue. Every path emitting that event (poller, webhook, service,
+// outbox) derives the same ID, so JetStream keeps only the first. Events
+// carrying such an ID are always published synchronously, even on an async
+// Publisher, since a lost trade status is not re-sent.
+func TradeEventID(venue, tradeID, status string) string {
+	return tradeEventIDPrefix + strings.ToUpper(venue) + ":" + tradeID + ":" + strings.ToLower(status)
+}
+
 // PublishEnvelope serializes and publishes a canonical event envelope to NATS.
-func (p *Publisher) PublishEnvelope(ctx context.Context, subject string, env *model.Envelope) error {
+// The envelope ID is used as the JetStream message ID unless overridden. An
+// envelope without a correlation ID takes the one carried by ctx.
+func (p *Publisher) PublishEnvelope(ctx context.Context, subject string, env *model.Envelope, opts ...PublishOption) error {
+	if env.CorrelationID == uuid.Nil {
+		if id, ok := tracing.CorrelationID(ctx); ok {
+			env.CorrelationID = id
+		}
+	}
 	data, err := json.Marshal(env)
 	if err != nil {
 		slog.Error("publisher.marshal_failed",
@@ -63,19 +122,20 @@ func (p *Publisher) PublishEnvelope(ctx context.Context, subject string, env *mo
 			"client_id":      []string{env.ClientID},
 		},
 	}
+	if env.ID != uuid.Nil {
+		msg.Header.Set(nats.MsgIdHdr, env.ID.String())
+	}
+	for _, opt := range opts {
+		opt(msg)
+	}
 
-	start := time.Now()
-	_, err = p.js.PublishMsg(msg)
-	metrics.ObserveDuration(metrics.NATSMessageLatency, start, subject)
-
-	if err != nil {
+	if err := p.send(ctx, msg, p.async(msg)); err != nil {
 		slog.Error("publisher.publish_failed",
 			"subject", subject,
 			"event_type", env.EventType,
 			"client_id", env.ClientID,
 			"error", err,
 		)
-		metrics.IncNATSMessage(subject, "error")
 		return err
 	}
 
@@ -84,16 +144,15 @@ func (p *Publisher) PublishEnvelope(ctx context.Context, subject string, env *mo
 		"event_type", env.EventType,
 		"client_id", env.ClientID,
 	)
-
-	metrics.IncNATSMessage(subject, "ok")
 	return nil
 }
 
 // PublishBalanceUpdated emits canonical balance.updated events.
 func (p *Publisher) PublishBalanceUpdated(ctx context.Context, bal model.Balance, tenantID, clientID string) error {
+	_, correlationID := tracing.EnsureCorrelationID(ctx)
 	env := &model.Envelope{
 		ID:            uuid.New(),
-		CorrelationID: uuid.New(),
+		CorrelationID: correlationID,
 		TenantID:      tenantID,
 		ClientID:      clientID,
 		Topic:         "evt.balance.updated.v1",
@@ -109,7 +168,7 @@ func (p *Publisher) PublishBalanceUpdated(ctx context.Context, bal model.Balance
 }
 
 // Publish publishes raw JSON payloads (for non-canonical internal events).
-func (p *Publisher) Publish(ctx context.Context, subject string, payload any) error {
+func (p *Publisher) Publish(ctx context.Context, subject string, payload any, opts ...PublishOption) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		metrics.IncError("publisher", "marshal_failed")
@@ -121,21 +180,110 @@ func (p *Publisher) Publish(ctx context.Context, subject string, payload any) er
 		Data:    data,
 		Header:  nats.Header{"source": []string{p.service}},
 	}
+	for _, opt := range opts {
+		opt(msg)
+	}
+	return p.send(ctx, msg, p.async(msg))
+}
+
+// PublishWithID publishes pre-serialized JSON under a JetStream message ID,
+// so a re-send within the stream's duplicate window is discarded. It always
+// waits for the ack, since callers such as the outbox relay act on it.
+func (p *Publisher) PublishWithID(ctx context.Context, subject, msgID string, data []byte) error {
+	msg := &nats.Msg{
+		Subject: subject,
+		Data:    data,
+		Header:  nats.Header{"source": []string{p.service}},
+	}
+	WithMsgID(msgID)(msg)
+	return p.send(ctx, msg, false)
+}
+
+// async reports whether msg may be published without waiting for its ack.
+// Trade events always wait, so a failure reaches the caller.
+func (p *Publisher) async(msg *nats.Msg) bool {
+	return p.opts.Async && !strings.HasPrefix(msg.Header.Get(nats.MsgIdHdr), tradeEventIDPrefix)
+}
+
+// send publishes msg, waiting for the ack unless async is set. The trace
+// context and correlation ID of ctx travel in the message headers.
+func (p *Publisher) send(ctx context.Context, msg *nats.Msg, async bool) error {
+	ctx, span := tracing.Start(ctx, "publish "+msg.Subject,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination.name", msg.Subject),
+			attribute.String("messaging.message.id", msg.Header.Get(nats.MsgIdHdr)),
+		))
+	tracing.InjectNATS(ctx, msg.Header)
 
 	start := time.Now()
-	_, err = p.js.PublishMsg(msg)
-	metrics.ObserveDuration(metrics.NATSMessageLatency, start, subject)
+	if async {
+		future, err := p.js.PublishMsgAsync(msg)
+		if err != nil {
+			metrics.IncNATSMessage(msg.Subject, "error")
+			tracing.End(span, err)
+			return err
+		}
+		go p.awaitAck(future, start, span)
+		return nil
+	}
 
+	_, err := p.js.PublishMsg(msg, nats.Context(ctx))
+	metrics.ObserveDuration(metrics.NATSMessageLatency, start, msg.Subject)
+	tracing.End(span, err)
 	if err != nil {
-		metrics.IncNATSMessage(subject, "error")
+		metrics.IncNATSMessage(msg.Subject, "error")
 		return err
 	}
-
-	metrics.IncNATSMessage(subject, "ok")
+	metrics.IncNATSMessage(msg.Subject, "ok")
 	return nil
 }
 
+// awaitAck records the outcome of an async publish and ends its span.
+func (p *Publisher) awaitAck(future nats.PubAckFuture, start time.Time, span trace.Span) {
+	msg := future.Msg()
+	select {
+	case <-future.Ok():
+		metrics.ObserveDuration(metrics.NATSMessageLatency, start, msg.Subject)
+		metrics.IncNATSMessage(msg.Subject, "ok")
+		tracing.End(span, nil)
+	case err := <-future.Err():
+		metrics.ObserveDuration(metrics.NATSMessageLatency, start, msg.Subject)
+		metrics.IncNATSMessage(msg.Subject, "error")
+		tracing.End(span, err)
+		slog.Error("publisher.async_publish_failed",
+			"subject", msg.Subject,
+			"msg_id", msg.Header.Get(nats.MsgIdHdr),
+			"error", err,
+		)
+		if p.opts.OnFailure != nil {
+			p.opts.OnFailure(msg, err)
+		}
+	}
+}
+
+// Flush waits until every async publish has been acknowledged or ctx is done.
+func (p *Publisher) Flush(ctx context.Context) error {
+	select {
+	case <-p.js.PublishAsyncComplete():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close waits briefly for pending async acks and closes the connection.
 func (p *Publisher) Close() {
+	if p.opts.Async {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := p.Flush(ctx); err != nil {
+			slog.Warn("publisher.flush_incomplete",
+				"pending", p.js.PublishAsyncPending(),
+				"error", err)
+		}
+	}
 	if p.nc != nil && p.nc.IsConnected() {
 		p.nc.Close()
 	}
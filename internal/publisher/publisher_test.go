@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,10 +18,25 @@ import (
 
 // mockJetStream implements a minimal JetStreamContext for testing
 type mockJetStream struct {
-	published []*nats.Msg
-	fail      bool
+	published  []*nats.Msg
+	asyncCalls int
+	fail       bool
+	ackErr     error // async publishes are nacked with ackErr when set
+	streams    map[string]*nats.StreamInfo
+	updated    []*nats.StreamConfig
 }
 
+// mockPubAckFuture is an already-resolved async publish.
+type mockPubAckFuture struct {
+	msg *nats.Msg
+	ok  chan *nats.PubAck
+	err chan error
+}
+
+func (f *mockPubAckFuture) Ok() <-chan *nats.PubAck { return f.ok }
+func (f *mockPubAckFuture) Err() <-chan error       { return f.err }
+func (f *mockPubAckFuture) Msg() *nats.Msg          { return f.msg }
+
 func (m *mockJetStream) PublishMsg(msg *nats.Msg, opts ...nats.PubOpt) (*nats.PubAck, error) {
 	if m.fail {
 		return nil, errors.New("mock publish error")
@@ -37,7 +53,18 @@ func (m *mockJetStream) PublishAsync(subj string, data []byte, opts ...nats.PubO
 	return nil, nil
 }
 func (m *mockJetStream) PublishMsgAsync(msg *nats.Msg, opts ...nats.PubOpt) (nats.PubAckFuture, error) {
-	return nil, nil
+	m.asyncCalls++
+	if m.fail {
+		return nil, errors.New("mock publish error")
+	}
+	m.published = append(m.published, msg)
+	f := &mockPubAckFuture{msg: msg, ok: make(chan *nats.PubAck, 1), err: make(chan error, 1)}
+	if m.ackErr != nil {
+		f.err <- m.ackErr
+	} else {
+		f.ok <- &nats.PubAck{Stream: "mock-stream"}
+	}
+	return f, nil
 }
 func (m *mockJetStream) PublishAsyncPending() int { return 0 }
 func (m *mockJetStream) PublishAsyncComplete() <-chan struct{} {
@@ -71,11 +98,15 @@ func (m *mockJetStream) AddStream(cfg *nats.StreamConfig, opts ...nats.JSOpt) (*
 	return nil, nil
 }
 func (m *mockJetStream) UpdateStream(cfg *nats.StreamConfig, opts ...nats.JSOpt) (*nats.StreamInfo, error) {
-	return nil, nil
+	m.updated = append(m.updated, cfg)
+	return &nats.StreamInfo{Config: *cfg}, nil
 }
 func (m *mockJetStream) DeleteStream(name string, opts ...nats.JSOpt) error { return nil }
 func (m *mockJetStream) StreamInfo(stream string, opts ...nats.JSOpt) (*nats.StreamInfo, error) {
-	return nil, nil
+	if info, ok := m.streams[stream]; ok {
+		return info, nil
+	}
+	return nil, nats.ErrStreamNotFound
 }
 func (m *mockJetStream) Streams(opts ...nats.JSOpt) <-chan *nats.StreamInfo {
 	ch := make(chan *nats.StreamInfo)
@@ -129,7 +160,16 @@ func (m *mockJetStream) ConsumerNames(stream string, opts ...nats.JSOpt) <-chan
 	return ch
 }
 func (m *mockJetStream) AccountInfo(opts ...nats.JSOpt) (*nats.AccountInfo, error) { return nil, nil }
-func (m *mockJetStream) StreamNameBySubject(string, ...nats.JSOpt) (string, error) { return "", nil }
+func (m *mockJetStream) StreamNameBySubject(subj string, _ ...nats.JSOpt) (string, error) {
+	for name, info := range m.streams {
+		for _, filter := range info.Config.Subjects {
+			if subjectCovers(filter, subj) {
+				return name, nil
+			}
+		}
+	}
+	return "", nats.ErrNoMatchingStream
+}
 func (m *mockJetStream) KeyValue(bucket string) (nats.KeyValue, error)             { return nil, nil }
 func (m *mockJetStream) CreateKeyValue(cfg *nats.KeyValueConfig) (nats.KeyValue, error) {
 	return nil, nil
@@ -267,3 +307,182 @@ func TestPublishBalanceUpdated(t *testing.T) {
 		t.Errorf("expected event_type=balance.updated, got %s", env.EventType)
 	}
 }
+
+func TestPublishWithID_SetsMsgIDHeader(t *testing.T) {
+	pub := newTestPublisher(false)
+
+	err := pub.PublishWithID(context.Background(), "evt.trade.filled.v1.RIO", "evt.trade.filled.v1.RIO:ord-1", []byte(`{"status":"filled"}`))
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	msg := pub.js.(*mockJetStream).published[0]
+	if got := msg.Header.Get(nats.MsgIdHdr); got != "evt.trade.filled.v1.RIO:ord-1" {
+		t.Errorf("expected Nats-Msg-Id header, got %q", got)
+	}
+	if string(msg.Data) != `{"status":"filled"}` {
+		t.Errorf("payload must be sent as-is, got %s", msg.Data)
+	}
+}
+
+func TestPublish_WithMsgID(t *testing.T) {
+	pub := newTestPublisher(false)
+	id := TradeEventID("rio", "ord-1", "FILLED")
+	if id != "trade:RIO:ord-1:filled" {
+		t.Fatalf("unexpected trade event ID: %s", id)
+	}
+
+	if err := pub.Publish(context.Background(), "evt.trade.filled.v1.RIO", map[string]any{"order_id": "ord-1"}, WithMsgID(id)); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if err := pub.Publish(context.Background(), "evt.trade.status_changed.v1.RIO", map[string]any{}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	js := pub.js.(*mockJetStream)
+	if got := js.published[0].Header.Get(nats.MsgIdHdr); got != id {
+		t.Errorf("expected Nats-Msg-Id %q, got %q", id, got)
+	}
+	if got := js.published[1].Header.Get(nats.MsgIdHdr); got != "" {
+		t.Errorf("expected no Nats-Msg-Id without WithMsgID, got %q", got)
+	}
+}
+
+func TestPublishEnvelope_UsesEnvelopeIDAsMsgID(t *testing.T) {
+	pub := newTestPublisher(false)
+	env := &model.Envelope{ID: uuid.New(), EventType: "balance.updated"}
+
+	if err := pub.PublishEnvelope(context.Background(), "evt.balance.updated.v1", env); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	msg := pub.js.(*mockJetStream).published[0]
+	if got := msg.Header.Get(nats.MsgIdHdr); got != env.ID.String() {
+		t.Errorf("expected Nats-Msg-Id %s, got %q", env.ID, got)
+	}
+}
+
+func TestPublish_AsyncReportsFailedAcks(t *testing.T) {
+	failed := make(chan *nats.Msg, 1)
+	js := &mockJetStream{ackErr: errors.New("no responders")}
+	pub := &Publisher{
+		js:      js,
+		service: "rio-adapter",
+		opts: Options{Async: true, OnFailure: func(msg *nats.Msg, _ error) {
+			failed <- msg
+		}},
+	}
+
+	if err := pub.Publish(context.Background(), "evt.trade.filled.v1.RIO", map[string]any{}, WithMsgID("m-1")); err != nil {
+		t.Fatalf("async publish must not wait for the ack, got error: %v", err)
+	}
+	select {
+	case msg := <-failed:
+		if msg.Header.Get(nats.MsgIdHdr) != "m-1" {
+			t.Errorf("unexpected failed message: %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected OnFailure to be called for the nacked publish")
+	}
+}
+
+func TestEnsureStreams(t *testing.T) {
+	js := &mockJetStream{streams: map[string]*nats.StreamInfo{
+		"RIO_EVENTS": {Config: nats.StreamConfig{
+			Name:       "RIO_EVENTS",
+			Subjects:   []string{"evt.trade.*.v1.RIO", "evt.balance.>"},
+			Duplicates: 2 * time.Minute,
+		}},
+	}}
+	pub := &Publisher{js: js}
+
+	err := pub.EnsureStreams(
+		StreamSpec{
+			Name:       "RIO_EVENTS",
+			Subjects:   []string{"evt.trade.*.v1.RIO", "evt.balance.updated.v1"},
+			Duplicates: 2 * time.Minute,
+		},
+		StreamSpec{Subjects: []string{"evt.balance.update.v1"}},
+	)
+	if err != nil {
+		t.Fatalf("expected streams to check out, got: %v", err)
+	}
+	if len(js.updated) != 0 {
+		t.Errorf("expected the stream to be left unchanged, got %+v", js.updated)
+	}
+
+	err = pub.EnsureStreams(StreamSpec{Name: "RIO_EVENTS", Duplicates: 10 * time.Minute})
+	if err == nil || !strings.Contains(err.Error(), "duplicate window") {
+		t.Errorf("expected a duplicate window mismatch to fail, got %v", err)
+	}
+
+	err = pub.EnsureStreams(
+		StreamSpec{Name: "RIO_EVENTS", Subjects: []string{"evt.recon.discrepancy.v1"}},
+		StreamSpec{Subjects: []string{"evt.lp.quote_expired.v1.XFX"}},
+		StreamSpec{Name: "MISSING"},
+	)
+	if err == nil {
+		t.Fatal("expected uncaptured subjects and missing stream to fail")
+	}
+	if !errors.Is(err, nats.ErrStreamNotFound) {
+		t.Errorf("expected ErrStreamNotFound in %v", err)
+	}
+	if !strings.Contains(err.Error(), "evt.lp.quote_expired.v1.XFX") {
+		t.Errorf("expected the subject no stream captures in %v", err)
+	}
+}
+
+func TestSubjectCovers(t *testing.T) {
+	tests := []struct {
+		filter, subj string
+		want         bool
+	}{
+		{"evt.trade.*.v1.RIO", "evt.trade.filled.v1.RIO", true},
+		{"evt.trade.*.v1.RIO", "evt.trade.*.v1.RIO", true},
+		{"evt.trade.*.v1.RIO", "evt.trade.filled.v1.CAPA", false},
+		{"evt.>", "evt.trade.*.v1.RIO", true},
+		{"evt.>", "evt", false},
+		{"evt.trade.*", "evt.trade.>", false},
+		{"evt.trade.filled.v1.RIO", "evt.trade.*.v1.RIO", false},
+	}
+	for _, tt := range tests {
+		if got := subjectCovers(tt.filter, tt.subj); got != tt.want {
+			t.Errorf("subjectCovers(%q, %q) = %v, want %v", tt.filter, tt.subj, got, tt.want)
+		}
+	}
+}
+
+func TestPublishWithID_WaitsForAckWhenAsync(t *testing.T) {
+	js := &mockJetStream{}
+	pub := &Publisher{js: js, opts: Options{Async: true}}
+
+	if err := pub.PublishWithID(context.Background(), "evt.trade.filled.v1.RIO", "m-1", []byte(`{}`)); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if js.asyncCalls != 0 || len(js.published) != 1 {
+		t.Errorf("expected a synchronous publish, got %d async calls", js.asyncCalls)
+	}
+}
+
+func TestPublish_TradeEventsWaitForAckWhenAsync(t *testing.T) {
+	js := &mockJetStream{}
+	pub := &Publisher{js: js, service: "rio-adapter", opts: Options{Async: true}}
+
+	if err := pub.Publish(context.Background(), "evt.trade.filled.v1.RIO", map[string]any{}, WithMsgID(TradeEventID("RIO", "ord-1", "filled"))); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if js.asyncCalls != 0 {
+		t.Errorf("expected trade event to be published synchronously, got %d async calls", js.asyncCalls)
+	}
+
+	if err := pub.Publish(context.Background(), "evt.trade.status_changed.v1.RIO", map[string]any{}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if js.asyncCalls != 1 {
+		t.Errorf("expected other events to stay async, got %d async calls", js.asyncCalls)
+	}
+
+	js.fail = true
+	if err := pub.Publish(context.Background(), "evt.trade.cancelled.v1.RIO", map[string]any{}, WithMsgID(TradeEventID("RIO", "ord-2", "cancelled"))); err == nil {
+		t.Fatal("expected a failed trade event publish to be returned to the caller")
+	}
+}
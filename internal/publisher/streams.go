@@ -0,0 +1,96 @@
+package publisher
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StreamSpec describes a JetStream stream an adapter publishes into.
+type StreamSpec struct {
+	// Name is the stream that must capture Subjects. Empty accepts any
+	// stream, for subjects shared by several adapters (e.g.
+	// evt.balance.update.v1), which only one stream can capture.
+	Name     string
+	Subjects []string // Subjects the adapter publishes; the stream must capture each
+	// Duplicates is the dedup window the stream must keep. Zero skips the
+	// check.
+	Duplicates time.Duration
+}
+
+// EnsureStreams checks that each stream exists, captures its expected
+// subjects and keeps the expected duplicate window. Streams are never created
+// or modified: they belong to the deployment, so a mismatch is an error.
+func (p *Publisher) EnsureStreams(specs ...StreamSpec) error {
+	var errs []error
+	for _, spec := range specs {
+		if err := p.ensureStream(spec); err != nil {
+			errs = append(errs, fmt.Errorf("stream %s: %w", spec.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *Publisher) ensureStream(spec StreamSpec) error {
+	if spec.Name == "" {
+		return p.ensureCaptured(spec.Subjects)
+	}
+
+	info, err := p.js.StreamInfo(spec.Name)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, subj := range spec.Subjects {
+		if !slices.ContainsFunc(info.Config.Subjects, func(filter string) bool {
+			return subjectCovers(filter, subj)
+		}) {
+			missing = append(missing, subj)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("subjects %v not captured by %v", missing, info.Config.Subjects)
+	}
+
+	// With a shorter window Nats-Msg-Id dedup silently lets re-sends through.
+	if spec.Duplicates > 0 && info.Config.Duplicates != spec.Duplicates {
+		return fmt.Errorf("duplicate window is %s, expected %s", info.Config.Duplicates, spec.Duplicates)
+	}
+	return nil
+}
+
+// ensureCaptured checks that some stream captures each subject.
+func (p *Publisher) ensureCaptured(subjects []string) error {
+	var missing []string
+	for _, subj := range subjects {
+		if _, err := p.js.StreamNameBySubject(subj); err != nil {
+			missing = append(missing, subj)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("subjects %v not captured by any stream", missing)
+	}
+	return nil
+}
+
+// subjectCovers reports whether the stream subject filter captures every
+// subject matching subj, which may itself contain wildcards.
+func subjectCovers(filter, subj string) bool {
+	ft := strings.Split(filter, ".")
+	st := strings.Split(subj, ".")
+	for i, f := range ft {
+		if f == ">" {
+			return i < len(st)
+		}
+		if i >= len(st) || st[i] == ">" {
+			return false
+		}
+		if f != "*" && f != st[i] {
+			return false
+		}
+	}
+	return len(ft) == len(st)
+}
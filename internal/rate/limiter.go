@@ -2,6 +2,7 @@ package rate
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 )
@@ -73,11 +74,30 @@ func (l *Limiter) Wait(ctx context.Context) error {
 	}
 }
 
+// refund returns a token taken by Allow or Wait that went unused.
+func (l *Limiter) refund() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = min(l.tokens+1, l.burst)
+}
+
+// keySeparator separates the parent and child parts of a hierarchical key.
+const keySeparator = "|"
+
+// Key builds a hierarchical limiter key. On a Manager created with
+// NewHierarchicalManager, a request under Key(parent, child) takes a token
+// from child's own bucket and from the ceiling shared by all of parent's
+// children.
+func Key(parent, child string) string {
+	return parent + keySeparator + child
+}
+
 // Manager holds per-client limiters.
 type Manager struct {
 	mu       sync.RWMutex
 	limiters map[string]*Limiter
 	defaults Config
+	parent   *Manager // shared ceilings for hierarchical keys, if any
 }
 
 func NewManager(defaults Config) *Manager {
@@ -87,6 +107,15 @@ func NewManager(defaults Config) *Manager {
 	}
 }
 
+// NewHierarchicalManager creates a Manager whose keys built with Key are
+// limited per child by child and, across all children of the same parent,
+// by parent. Plain keys are limited by child alone.
+func NewHierarchicalManager(child, parent Config) *Manager {
+	m := NewManager(child)
+	m.parent = NewManager(parent)
+	return m
+}
+
 func (m *Manager) GetLimiter(clientKey string) *Limiter {
 	m.mu.RLock()
 	if lim, ok := m.limiters[clientKey]; ok {
@@ -106,7 +135,22 @@ func (m *Manager) GetLimiter(clientKey string) *Limiter {
 }
 
 // Wait ensures rate limit compliance for a given key.
+// For hierarchical keys the child's bucket is drawn first, so a client
+// exhausting its own budget does not hold back the shared ceiling. If the
+// wait on the shared ceiling fails, the child's token is returned.
 func (m *Manager) Wait(ctx context.Context, key string) error {
 	lim := m.GetLimiter(key)
-	return lim.Wait(ctx)
+	if err := lim.Wait(ctx); err != nil {
+		return err
+	}
+	if m.parent == nil {
+		return nil
+	}
+	if parent, _, ok := strings.Cut(key, keySeparator); ok {
+		if err := m.parent.Wait(ctx, parent); err != nil {
+			lim.refund()
+			return err
+		}
+	}
+	return nil
 }
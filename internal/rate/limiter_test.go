@@ -202,3 +202,72 @@ func TestManager_ConcurrentGetLimiter(t *testing.T) {
 		}
 	}
 }
+
+func TestHierarchicalManager_ChildAndSharedCeiling(t *testing.T) {
+	mgr := NewHierarchicalManager(
+		Config{RequestsPerSecond: 1, Burst: 2},
+		Config{RequestsPerSecond: 1, Burst: 3},
+	)
+
+	wait := func(key string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		return mgr.Wait(ctx, key)
+	}
+
+	a := Key("api.example.com", "client-a")
+	b := Key("api.example.com", "client-b")
+
+	for i := 0; i < 2; i++ {
+		if err := wait(a); err != nil {
+			t.Fatalf("client-a request %d: %v", i, err)
+		}
+	}
+	if err := wait(a); err == nil {
+		t.Fatal("expected client-a to be throttled by its own bucket")
+	}
+
+	// client-a's throttling leaves the shared ceiling's last token for client-b.
+	if err := wait(b); err != nil {
+		t.Fatalf("client-b first request: %v", err)
+	}
+	if err := wait(b); err == nil {
+		t.Fatal("expected client-b to be throttled by the shared ceiling")
+	}
+
+	// Another parent has its own ceiling.
+	if err := wait(Key("other.example.com", "client-b")); err != nil {
+		t.Fatalf("other parent: %v", err)
+	}
+}
+
+func TestHierarchicalManager_RefundsChildTokenOnSharedCeilingFailure(t *testing.T) {
+	mgr := NewHierarchicalManager(
+		Config{RequestsPerSecond: 1, Burst: 2},
+		Config{RequestsPerSecond: 1, Burst: 1},
+	)
+
+	wait := func(key string) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		return mgr.Wait(ctx, key)
+	}
+
+	a := Key("api.example.com", "client-a")
+	b := Key("api.example.com", "client-b")
+
+	if err := wait(a); err != nil {
+		t.Fatalf("client-a request: %v", err)
+	}
+	if err := wait(b); err == nil {
+		t.Fatal("expected client-b to be throttled by the shared ceiling")
+	}
+
+	// The failed wait must not have spent client-b's own tokens.
+	lim := mgr.GetLimiter(b)
+	for i := 0; i < 2; i++ {
+		if !lim.Allow() {
+			t.Fatalf("client-b token %d was not refunded", i)
+		}
+	}
+}
@@ -84,6 +84,12 @@ func (r *AWSResolver[T]) Resolve(ctx context.Context, clientID string, parse fun
 	return cfg, nil
 }
 
+// Invalidate drops the cached config for a client so the next Resolve reads
+// the secret from AWS Secrets Manager again (e.g., after a credential rotation).
+func (r *AWSResolver[T]) Invalidate(clientID string) {
+	r.cache.Bust(r.cacheKey(clientID))
+}
+
 // DiscoverClients lists all client IDs that have secrets configured in AWS Secrets Manager.
 // It searches for secrets matching the prefix "{env}/" and ending with "/{venue}",
 // then extracts client IDs from the middle segment.
@@ -45,6 +45,35 @@ type PGPoolConfig struct {
 	HealthCheckPeriod time.Duration
 }
 
+// NewPGPool connects a Postgres pool, applying the non-zero settings of
+// pgPoolConfig over the URL's.
+func NewPGPool(ctx context.Context, pgURL string, pgPoolConfig PGPoolConfig) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(pgURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pg config: %w", err)
+	}
+	if pgPoolConfig.MaxConns > 0 {
+		cfg.MaxConns = pgPoolConfig.MaxConns
+	}
+	if pgPoolConfig.MinConns > 0 {
+		cfg.MinConns = pgPoolConfig.MinConns
+	}
+	if pgPoolConfig.MaxConnLifetime > 0 {
+		cfg.MaxConnLifetime = pgPoolConfig.MaxConnLifetime
+	}
+	if pgPoolConfig.MaxConnIdleTime > 0 {
+		cfg.MaxConnIdleTime = pgPoolConfig.MaxConnIdleTime
+	}
+	if pgPoolConfig.HealthCheckPeriod > 0 {
+		cfg.HealthCheckPeriod = pgPoolConfig.HealthCheckPeriod
+	}
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+	}
+	return pool, nil
+}
+
 // NewHybrid creates a Redis-first, Postgres-backed store.
 // redisURL must be a valid Redis URL, e.g. redis://localhost:6379 or redis://:password@host:6379/1
 func NewHybrid(redisURL string, pgURL string, pgPoolConfig PGPoolConfig) (Store, error) {
@@ -62,28 +91,8 @@ func NewHybrid(redisURL string, pgURL string, pgPoolConfig PGPoolConfig) (Store,
 
 	var pgPool *pgxpool.Pool
 	if pgURL != "" {
-		cfg, err := pgxpool.ParseConfig(pgURL)
-		if err != nil {
-			return nil, fmt.Errorf("invalid pg config: %w", err)
-		}
-		if pgPoolConfig.MaxConns > 0 {
-			cfg.MaxConns = pgPoolConfig.MaxConns
-		}
-		if pgPoolConfig.MinConns > 0 {
-			cfg.MinConns = pgPoolConfig.MinConns
-		}
-		if pgPoolConfig.MaxConnLifetime > 0 {
-			cfg.MaxConnLifetime = pgPoolConfig.MaxConnLifetime
-		}
-		if pgPoolConfig.MaxConnIdleTime > 0 {
-			cfg.MaxConnIdleTime = pgPoolConfig.MaxConnIdleTime
-		}
-		if pgPoolConfig.HealthCheckPeriod > 0 {
-			cfg.HealthCheckPeriod = pgPoolConfig.HealthCheckPeriod
-		}
-		pgPool, err = pgxpool.NewWithConfig(ctx, cfg)
-		if err != nil {
-			return nil, fmt.Errorf("failed to connect to postgres: %w", err)
+		if pgPool, err = NewPGPool(ctx, pgURL, pgPoolConfig); err != nil {
+			return nil, err
 		}
 	}
 
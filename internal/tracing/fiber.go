@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fiberHeaderCarrier adapts a Fiber request's headers to a TextMapCarrier.
+type fiberHeaderCarrier struct{ c *fiber.Ctx }
+
+func (f fiberHeaderCarrier) Get(key string) string { return f.c.Get(key) }
+func (f fiberHeaderCarrier) Set(key, val string)   { f.c.Request().Header.Set(key, val) }
+func (f fiberHeaderCarrier) Keys() []string {
+	var keys []string
+	f.c.Request().Header.VisitAll(func(k, _ []byte) {
+		keys = append(keys, string(k))
+	})
+	return keys
+}
+
+// FiberMiddleware starts a server span per request, continuing the caller's
+// trace context. The request's X-Correlation-ID (or a new one) is attached
+// to the handler context, available via c.UserContext(), and echoed on the
+// response.
+func FiberMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), fiberHeaderCarrier{c})
+
+		id, err := uuid.Parse(c.Get(CorrelationHTTPHeader))
+		if err != nil {
+			id = uuid.New()
+		}
+		ctx = WithCorrelationID(ctx, id)
+		c.Set(CorrelationHTTPHeader, id.String())
+
+		ctx, span := Start(ctx, c.Method()+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Method()),
+				attribute.String("url.path", c.Path()),
+			))
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err = c.Next()
+
+		// Name the span after the matched route to keep cardinality bounded.
+		span.SetName(c.Method() + " " + c.Route().Path)
+		status := c.Response().StatusCode()
+		if fe, ok := err.(*fiber.Error); ok {
+			status = fe.Code
+		}
+		span.SetAttributes(
+			attribute.String("http.route", c.Route().Path),
+			attribute.Int("http.response.status_code", status),
+		)
+		if err != nil {
+			span.RecordError(err)
+		}
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+		return err
+	}
+}
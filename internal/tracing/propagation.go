@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// CorrelationHeader carries the correlation ID on NATS messages.
+	CorrelationHeader = "correlation_id"
+	// CorrelationHTTPHeader carries the correlation ID on HTTP requests and responses.
+	CorrelationHTTPHeader = "X-Correlation-ID"
+
+	correlationAttr = "correlation_id"
+)
+
+type correlationKey struct{}
+
+// WithCorrelationID returns ctx carrying id. A nil id leaves ctx unchanged.
+func WithCorrelationID(ctx context.Context, id uuid.UUID) context.Context {
+	if id == uuid.Nil {
+		return ctx
+	}
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx.
+func CorrelationID(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(correlationKey{}).(uuid.UUID)
+	return id, ok
+}
+
+// CarryCorrelationID returns dst carrying src's correlation ID, if any. It is
+// for background work, such as status polling, started on behalf of a request
+// but outliving it.
+func CarryCorrelationID(dst, src context.Context) context.Context {
+	id, _ := CorrelationID(src)
+	return WithCorrelationID(dst, id)
+}
+
+// EnsureCorrelationID returns ctx's correlation ID, attaching a new one if it
+// has none.
+func EnsureCorrelationID(ctx context.Context) (context.Context, uuid.UUID) {
+	if id, ok := CorrelationID(ctx); ok {
+		return ctx, id
+	}
+	id := uuid.New()
+	return WithCorrelationID(ctx, id), id
+}
+
+// natsHeaderCarrier adapts nats.Header to a TextMapCarrier. Keys are kept
+// as-is (traceparent, tracestate) rather than canonicalized as in HTTP.
+type natsHeaderCarrier nats.Header
+
+func (c natsHeaderCarrier) Get(key string) string { return nats.Header(c).Get(key) }
+func (c natsHeaderCarrier) Set(key, val string)   { nats.Header(c).Set(key, val) }
+func (c natsHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// InjectNATS writes ctx's trace context and correlation ID into h.
+func InjectNATS(ctx context.Context, h nats.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, natsHeaderCarrier(h))
+	if id, ok := CorrelationID(ctx); ok && h.Get(CorrelationHeader) == "" {
+		h.Set(CorrelationHeader, id.String())
+	}
+}
+
+// ExtractNATS returns ctx extended with the trace context and correlation ID
+// found in h.
+func ExtractNATS(ctx context.Context, h nats.Header) context.Context {
+	if h == nil {
+		return ctx
+	}
+	ctx = otel.GetTextMapPropagator().Extract(ctx, natsHeaderCarrier(h))
+	if id, err := uuid.Parse(h.Get(CorrelationHeader)); err == nil {
+		ctx = WithCorrelationID(ctx, id)
+	}
+	return ctx
+}
+
+// InjectHTTP writes ctx's trace context into outbound request headers.
+func InjectHTTP(ctx context.Context, h http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+// StartConsumer starts a consumer span for msg, continuing the trace of its
+// publisher. The returned context also carries the message's correlation ID.
+func StartConsumer(ctx context.Context, msg *nats.Msg) (context.Context, trace.Span) {
+	ctx = ExtractNATS(ctx, msg.Header)
+	return Start(ctx, "process "+msg.Subject,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "nats"),
+			attribute.String("messaging.destination.name", msg.Subject),
+		))
+}
+
+// WithEnvelopeCorrelation attaches an envelope's correlation ID to ctx and
+// span unless ctx already carries one from the message headers.
+func WithEnvelopeCorrelation(ctx context.Context, span trace.Span, id uuid.UUID) context.Context {
+	if _, ok := CorrelationID(ctx); ok || id == uuid.Nil {
+		return ctx
+	}
+	span.SetAttributes(attribute.String(correlationAttr, id.String()))
+	return WithCorrelationID(ctx, id)
+}
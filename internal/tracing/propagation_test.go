@@ -0,0 +1,147 @@
+package tracing
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// remoteSpanContext returns ctx carrying a sampled remote span, standing in
+// for an upstream caller when no SDK is installed.
+func remoteSpanContext(t *testing.T) (context.Context, trace.SpanContext) {
+	t.Helper()
+	tid, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	sid, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	return trace.ContextWithRemoteSpanContext(context.Background(), sc), sc
+}
+
+func TestNATSRoundTrip(t *testing.T) {
+	ctx, sc := remoteSpanContext(t)
+	id := uuid.New()
+	ctx = WithCorrelationID(ctx, id)
+
+	h := nats.Header{}
+	InjectNATS(ctx, h)
+	if h.Get("traceparent") == "" {
+		t.Fatalf("expected traceparent header, got %v", h)
+	}
+	if h.Get(CorrelationHeader) != id.String() {
+		t.Errorf("expected correlation header %s, got %q", id, h.Get(CorrelationHeader))
+	}
+
+	out := ExtractNATS(context.Background(), h)
+	if got := trace.SpanContextFromContext(out); got.TraceID() != sc.TraceID() {
+		t.Errorf("expected trace %s, got %s", sc.TraceID(), got.TraceID())
+	}
+	if got, ok := CorrelationID(out); !ok || got != id {
+		t.Errorf("expected correlation ID %s, got %s (ok=%v)", id, got, ok)
+	}
+}
+
+func TestInjectNATS_KeepsExistingCorrelationHeader(t *testing.T) {
+	h := nats.Header{}
+	h.Set(CorrelationHeader, "explicit")
+	InjectNATS(WithCorrelationID(context.Background(), uuid.New()), h)
+	if h.Get(CorrelationHeader) != "explicit" {
+		t.Errorf("expected existing correlation header to be kept, got %q", h.Get(CorrelationHeader))
+	}
+}
+
+func TestWithEnvelopeCorrelation(t *testing.T) {
+	span := trace.SpanFromContext(context.Background())
+	envID := uuid.New()
+
+	ctx := WithEnvelopeCorrelation(context.Background(), span, envID)
+	if got, _ := CorrelationID(ctx); got != envID {
+		t.Errorf("expected envelope correlation ID, got %s", got)
+	}
+
+	headerID := uuid.New()
+	ctx = WithEnvelopeCorrelation(WithCorrelationID(context.Background(), headerID), span, envID)
+	if got, _ := CorrelationID(ctx); got != headerID {
+		t.Errorf("header correlation ID must win over the envelope's, got %s", got)
+	}
+}
+
+func TestEnsureCorrelationID(t *testing.T) {
+	ctx, id := EnsureCorrelationID(context.Background())
+	if id == uuid.Nil {
+		t.Fatal("expected a new correlation ID")
+	}
+	if _, again := EnsureCorrelationID(ctx); again != id {
+		t.Errorf("expected existing ID %s to be reused, got %s", id, again)
+	}
+}
+
+func TestCarryCorrelationID(t *testing.T) {
+	type key struct{}
+	dst := context.WithValue(context.Background(), key{}, "service")
+	id := uuid.New()
+
+	ctx := CarryCorrelationID(dst, WithCorrelationID(context.Background(), id))
+	if got, _ := CorrelationID(ctx); got != id {
+		t.Errorf("expected correlation ID %s, got %s", id, got)
+	}
+	if ctx.Value(key{}) != "service" {
+		t.Error("expected dst's values to be kept")
+	}
+	if CarryCorrelationID(dst, context.Background()) != dst {
+		t.Error("expected dst unchanged when src has no correlation ID")
+	}
+}
+
+func TestFiberMiddleware_CorrelationID(t *testing.T) {
+	app := fiber.New()
+	app.Use(FiberMiddleware())
+
+	var seen uuid.UUID
+	var seenTrace trace.TraceID
+	app.Get("/ping", func(c *fiber.Ctx) error {
+		seen, _ = CorrelationID(c.UserContext())
+		seenTrace = trace.SpanContextFromContext(c.UserContext()).TraceID()
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	id := uuid.New()
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set(CorrelationHTTPHeader, id.String())
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seen != id {
+		t.Errorf("expected handler to see correlation ID %s, got %s", id, seen)
+	}
+	if resp.Header.Get(CorrelationHTTPHeader) != id.String() {
+		t.Errorf("expected correlation ID echoed, got %q", resp.Header.Get(CorrelationHTTPHeader))
+	}
+	if seenTrace.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected caller's trace to be continued, got %s", seenTrace)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/ping", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := uuid.Parse(resp.Header.Get(CorrelationHTTPHeader)); err != nil {
+		t.Errorf("expected a generated correlation ID, got %q", resp.Header.Get(CorrelationHTTPHeader))
+	}
+}
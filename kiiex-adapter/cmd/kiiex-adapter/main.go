@@ -12,7 +12,11 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/nats-io/nats.go"
 
+	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/internal/publisher"
+	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	kiiexapi "github.com/Checker-Finance/adapters/kiiex-adapter/internal/api"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/config"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/instruments"
@@ -38,6 +42,12 @@ func main() {
 	pkglogger.Init("kiiex-adapter", cfg.Profile, cfg.LogLevel)
 	defer pkglogger.Sync()
 
+	shutdownTracing, err := tracing.Init(ctx, "kiiex-adapter")
+	if err != nil {
+		slog.Error("Failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("Starting kiiex-adapter", "version", Version)
 	slog.Info("Configuration loaded",
 		"serverPort", cfg.ServerPort,
@@ -82,6 +92,14 @@ func main() {
 	// --- Create order service (sessions are created on demand, one per client) ---
 	orderService := order.NewService(resolver, instrumentMaster, eventBus, cfg.WebSocketURL)
 
+	// --- Session supervisor (heartbeat, re-login, idle eviction, credential rotation) ---
+	supervisor := order.NewSessionSupervisor(orderService, resolver, order.SupervisorConfig{
+		HeartbeatInterval:       cfg.SessionHeartbeatInterval,
+		IdleTimeout:             cfg.SessionIdleTimeout,
+		CredentialCheckInterval: cfg.CredentialCheckInterval,
+	})
+	go supervisor.Start(ctx)
+
 	// --- Create trade status service ---
 	tradeStatusService := tracking.NewTradeStatusService(orderService, eventBus)
 	go tradeStatusService.Start(ctx)
@@ -95,14 +113,42 @@ func main() {
 	defer nc.Close()
 
 	// --- Publisher ---
-	pub, err := publisher.New(nc, "evt.kiiex", "KIIEX_EVENTS")
+	pub, err := publisher.NewWithOptions(nc, "evt.kiiex", "KIIEX_EVENTS", publisher.Options{
+		Async:      cfg.NATSPublishAsync,
+		MaxPending: cfg.NATSPublishMaxPending,
+	})
 	if err != nil {
 		slog.Error("Failed to init NATS publisher", "error", err)
 		os.Exit(1)
 	}
+	if err := pub.EnsureStreams(publisher.StreamSpec{
+		Name:       cfg.NATSStream,
+		Subjects:   []string{"evt.trade.*.v1.KIIEX"},
+		Duplicates: cfg.NATSDedupWindow,
+	}); err != nil {
+		slog.Error("Failed to verify JetStream streams", "error", err)
+		os.Exit(1)
+	}
 
 	// --- NATS publisher (subscribes to eventbus, forwards to NATS) ---
-	_ = kiinats.NewNATSPublisher(pub, eventBus)
+	natsPublisher := kiinats.NewNATSPublisher(pub, eventBus)
+
+	// --- Postgres (optional): terminal trades with their outbox events ---
+	if cfg.DatabaseURL != "" {
+		pg, err := store.NewPGPool(ctx, cfg.DatabaseURL, store.PGPoolConfig{MaxConns: int32(cfg.PGMaxConns)})
+		if err != nil {
+			slog.Error("Failed to connect to postgres", "error", err)
+			os.Exit(1)
+		}
+		defer pg.Close()
+		natsPublisher.SetTradeSync(legacy.NewTradeSyncWriter(pg, "kiiex-adapter"))
+
+		// --- Outbox relay: publishes terminal trade events queued with their t_order rows ---
+		outboxRelay := outbox.NewRelay(outbox.NewPGStore(pg, "kiiex-adapter"), pub, outbox.RelayConfig{})
+		go outboxRelay.Start(ctx)
+	} else {
+		slog.Warn("DATABASE_URL not set; trade events are published directly and no trades are written to activity.t_order")
+	}
 
 	// --- NATS command consumer ---
 	consumer := kiinats.NewCommandConsumer(nc, orderService)
@@ -114,8 +160,9 @@ func main() {
 
 	// --- Fiber HTTP server ---
 	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(tracing.FiberMiddleware())
 	handler := kiiexapi.NewKiiexHandler(orderService)
-	kiiexapi.RegisterRoutes(app, handler, nc)
+	kiiexapi.RegisterRoutes(app, handler, nc, orderService)
 
 	go func() {
 		if err := app.Listen(fmt.Sprintf(":%d", cfg.ServerPort)); err != nil {
@@ -140,9 +187,13 @@ func main() {
 	}
 
 	tradeStatusService.Stop()
+	supervisor.Stop()
 	if err := orderService.Close(); err != nil {
 		slog.Error("failed to close order service sessions", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("tracing shutdown error", "error", err)
+	}
 
 	select {
 	case <-shutdownCtx.Done():
@@ -3,29 +3,52 @@ package alphapoint
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/Checker-Finance/adapters/internal/tracing"
 )
 
 // MessageHandler is called when a message is received
 type MessageHandler func(response *Response)
 
+// ErrDisconnected is returned to callers awaiting a response when the socket drops.
+var ErrDisconnected = errors.New("alphapoint: connection lost before response")
+
+// defaultCallTimeout bounds Call when the caller's context carries no deadline.
+const defaultCallTimeout = 10 * time.Second
+
 // Client is a WebSocket client for AlphaPoint
 type Client struct {
 	url            string
 	conn           *websocket.Conn
+	connMu         sync.RWMutex
+	writeMu        sync.Mutex
 	sequence       int64
 	handlers       []MessageHandler
 	handlersMu     sync.RWMutex
 	connected      bool
 	connectedMu    sync.RWMutex
 	done           chan struct{}
+	closeOnce      sync.Once
 	reconnectDelay time.Duration
+	reconnectGroup singleflight.Group // serializes Reconnect and the scheduled reconnect
+	onReconnect    func()
+	lastMessageAt  atomic.Int64
+	reconnects     atomic.Int64
+	pending        map[int]chan *Response
+	pendingMu      sync.Mutex
+	callTimeout    time.Duration
 }
 
 // NewClient creates a new AlphaPoint WebSocket client
@@ -35,40 +58,53 @@ func NewClient(url string) *Client {
 		handlers:       make([]MessageHandler, 0),
 		done:           make(chan struct{}),
 		reconnectDelay: 5 * time.Second,
+		pending:        make(map[int]chan *Response),
+		callTimeout:    defaultCallTimeout,
 	}
 }
 
 // Connect establishes a WebSocket connection
-func (c *Client) Connect(ctx context.Context) error {
+func (c *Client) Connect(ctx context.Context) (err error) {
 	slog.Info("Connecting to WebSocket", "url", c.url)
+	ctx, span := tracing.Start(ctx, "alphapoint.ws.connect", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
 
 	dialer := websocket.Dialer{
 		HandshakeTimeout: 10 * time.Second,
 	}
 
-	conn, _, err := dialer.DialContext(ctx, c.url, nil)
+	header := http.Header{}
+	tracing.InjectHTTP(ctx, header)
+	conn, _, err := dialer.DialContext(ctx, c.url, header)
 	if err != nil {
 		return fmt.Errorf("failed to connect to WebSocket: %w", err)
 	}
 
+	c.connMu.Lock()
 	c.conn = conn
+	c.connMu.Unlock()
+	c.lastMessageAt.Store(time.Now().UnixNano())
 	c.setConnected(true)
 	slog.Info("Connected to WebSocket")
 
 	// Start read loop
-	go c.readLoop()
+	go c.readLoop(conn)
 
 	return nil
 }
 
-// Close closes the WebSocket connection
+// Close closes the WebSocket connection. It is safe to call more than once.
 func (c *Client) Close() error {
-	close(c.done)
-	c.setConnected(false)
-	if c.conn != nil {
-		return c.conn.Close()
-	}
-	return nil
+	var err error
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.setConnected(false)
+		c.failPending()
+		if conn := c.currentConn(); conn != nil {
+			err = conn.Close()
+		}
+	})
+	return err
 }
 
 // IsConnected returns whether the client is connected
@@ -84,6 +120,39 @@ func (c *Client) setConnected(connected bool) {
 	c.connected = connected
 }
 
+func (c *Client) currentConn() *websocket.Conn {
+	c.connMu.RLock()
+	defer c.connMu.RUnlock()
+	return c.conn
+}
+
+func (c *Client) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// LastMessageAt returns the time the last frame was received from AlphaPoint.
+func (c *Client) LastMessageAt() time.Time {
+	return time.Unix(0, c.lastMessageAt.Load())
+}
+
+// Reconnects returns the number of successful reconnects since the client was created.
+func (c *Client) Reconnects() int64 {
+	return c.reconnects.Load()
+}
+
+// OnReconnect sets a callback invoked after every successful reconnect.
+// The callback runs on its own goroutine and must not block indefinitely.
+func (c *Client) OnReconnect(fn func()) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.onReconnect = fn
+}
+
 // AddHandler adds a message handler
 func (c *Client) AddHandler(handler MessageHandler) {
 	c.handlersMu.Lock()
@@ -91,21 +160,65 @@ func (c *Client) AddHandler(handler MessageHandler) {
 	c.handlers = append(c.handlers, handler)
 }
 
-// SendMessage sends a message to AlphaPoint
+// SendMessage sends a message to AlphaPoint without waiting for its response.
+// Any response is delivered to the registered handlers.
 func (c *Client) SendMessage(ctx context.Context, operationName string, payload interface{}) error {
+	_, err := c.send(ctx, operationName, payload, nil)
+	return err
+}
+
+// Call sends a request and waits for the response carrying the same sequence
+// number. The response is returned to the caller only; it is not passed to the
+// registered handlers. If ctx has no deadline the client's call timeout applies.
+func (c *Client) Call(ctx context.Context, operationName string, payload interface{}) (_ *Response, err error) {
+	ctx, span := tracing.Start(ctx, "alphapoint "+operationName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("alphapoint.operation", operationName)))
+	defer func() { tracing.End(span, err) }()
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.callTimeout)
+		defer cancel()
+	}
+
+	ch := make(chan *Response, 1)
+	seq, err := c.send(ctx, operationName, payload, ch)
+	if err != nil {
+		return nil, err
+	}
+	defer c.removePending(seq)
+
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, fmt.Errorf("%s (sequence %d): %w", operationName, seq, ErrDisconnected)
+		}
+		if resp.M == int(MessageTypeError) {
+			return resp, fmt.Errorf("alphapoint error for %s (sequence %d): %s", operationName, seq, resp.O)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("await %s response (sequence %d): %w", operationName, seq, ctx.Err())
+	}
+}
+
+// send assigns the next sequence number, registers reply (if non-nil) as the
+// waiter for that sequence and writes the request frame.
+func (c *Client) send(ctx context.Context, operationName string, payload interface{}, reply chan *Response) (int, error) {
 	if !c.IsConnected() {
-		return fmt.Errorf("not connected to WebSocket")
+		return 0, fmt.Errorf("not connected to WebSocket")
 	}
 
 	seq := int(atomic.AddInt64(&c.sequence, 2))
 	request, err := NewRequest(MessageTypeRequest, seq, operationName, payload)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	data, err := json.Marshal(request)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	slog.Debug("Sending message",
@@ -113,26 +226,93 @@ func (c *Client) SendMessage(ctx context.Context, operationName string, payload
 		"sequence", seq,
 	)
 
-	if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
-		return fmt.Errorf("failed to send message: %w", err)
+	conn := c.currentConn()
+	if conn == nil {
+		return 0, fmt.Errorf("not connected to WebSocket")
 	}
 
-	return nil
+	// Register before writing so a fast response cannot race the registration.
+	if reply != nil {
+		c.pendingMu.Lock()
+		c.pending[seq] = reply
+		c.pendingMu.Unlock()
+	}
+
+	// gorilla/websocket supports a single concurrent writer per connection.
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetWriteDeadline(deadline)
+		defer func() { _ = conn.SetWriteDeadline(time.Time{}) }()
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		if reply != nil {
+			c.removePending(seq)
+		}
+		return 0, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return seq, nil
 }
 
-func (c *Client) readLoop() {
-	defer func() {
-		c.setConnected(false)
-		slog.Info("WebSocket read loop exited")
-	}()
+func (c *Client) removePending(seq int) {
+	c.pendingMu.Lock()
+	delete(c.pending, seq)
+	c.pendingMu.Unlock()
+}
+
+// resolvePending hands response to the Call waiting on its sequence number.
+// It reports false when nobody is waiting, so the response goes to the handlers.
+func (c *Client) resolvePending(response *Response) bool {
+	if response.M != int(MessageTypeResponse) && response.M != int(MessageTypeError) {
+		return false
+	}
+
+	c.pendingMu.Lock()
+	ch, ok := c.pending[response.I]
+	if ok {
+		delete(c.pending, response.I)
+	}
+	c.pendingMu.Unlock()
+
+	if ok {
+		ch <- response
+	}
+	return ok
+}
+
+// failPending fails every in-flight Call with ErrDisconnected.
+func (c *Client) failPending() {
+	c.pendingMu.Lock()
+	pending := c.pending
+	c.pending = make(map[int]chan *Response)
+	c.pendingMu.Unlock()
+
+	for _, ch := range pending {
+		close(ch)
+	}
+}
+
+// readLoop reads frames from conn until it errors. A loop whose connection has
+// been replaced by Reconnect exits quietly instead of scheduling another reconnect.
+func (c *Client) readLoop(conn *websocket.Conn) {
+	defer slog.Info("WebSocket read loop exited")
 
 	for {
 		select {
 		case <-c.done:
 			return
 		default:
-			_, message, err := c.conn.ReadMessage()
+			_, message, err := conn.ReadMessage()
 			if err != nil {
+				if c.currentConn() != conn {
+					return
+				}
+				c.setConnected(false)
+				c.failPending()
+				if c.isClosed() {
+					return
+				}
 				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
 					slog.Info("WebSocket closed normally")
 					return
@@ -142,6 +322,7 @@ func (c *Client) readLoop() {
 				return
 			}
 
+			c.lastMessageAt.Store(time.Now().UnixNano())
 			slog.Debug("Received message", "payload", string(message))
 
 			var response Response
@@ -150,6 +331,9 @@ func (c *Client) readLoop() {
 				continue
 			}
 
+			if c.resolvePending(&response) {
+				continue
+			}
 			c.notifyHandlers(&response)
 		}
 	}
@@ -164,24 +348,76 @@ func (c *Client) notifyHandlers(response *Response) {
 	}
 }
 
+func (c *Client) notifyReconnected() {
+	c.reconnects.Add(1)
+
+	c.handlersMu.RLock()
+	fn := c.onReconnect
+	c.handlersMu.RUnlock()
+
+	if fn != nil {
+		go fn()
+	}
+}
+
+// scheduleReconnect reconnects after reconnectDelay unless a Reconnect has
+// restored the connection in the meantime.
 func (c *Client) scheduleReconnect() {
 	slog.Info("Scheduling reconnection", "delay", c.reconnectDelay)
 
 	time.AfterFunc(c.reconnectDelay, func() {
+		if c.isClosed() || c.IsConnected() {
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		if err := c.Connect(ctx); err != nil {
+		if err := c.Reconnect(ctx); err != nil {
 			slog.Error("Reconnection failed", "error", err)
 			c.scheduleReconnect()
 		}
 	})
 }
 
-// Reconnect attempts to reconnect to the WebSocket
+// Reconnect replaces the WebSocket connection. Concurrent calls, including the
+// client's own scheduled reconnect, share one attempt, so only one new
+// connection is dialled and OnReconnect fires once.
 func (c *Client) Reconnect(ctx context.Context) error {
-	if c.conn != nil {
-		_ = c.conn.Close()
+	if c.isClosed() {
+		return fmt.Errorf("client is closed")
+	}
+
+	ch := c.reconnectGroup.DoChan("reconnect", func() (any, error) {
+		return nil, c.replaceConn(context.WithoutCancel(ctx))
+	})
+	select {
+	case res := <-ch:
+		return res.Err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// replaceConn closes the current connection and dials a new one.
+func (c *Client) replaceConn(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	c.connMu.Lock()
+	old := c.conn
+	c.conn = nil
+	c.connMu.Unlock()
+
+	c.setConnected(false)
+	c.failPending()
+	if old != nil {
+		_ = old.Close()
 	}
-	return c.Connect(ctx)
+
+	if err := c.Connect(ctx); err != nil {
+		return err
+	}
+	c.notifyReconnected()
+	return nil
 }
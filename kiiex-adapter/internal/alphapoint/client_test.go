@@ -0,0 +1,225 @@
+package alphapoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer starts a WebSocket server that passes every request frame to respond.
+func newTestServer(t *testing.T, respond func(conn *websocket.Conn, req Request)) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req Request
+			if err := json.Unmarshal(data, &req); err != nil {
+				continue
+			}
+			respond(conn, req)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func writeResponse(conn *websocket.Conn, m MessageType, seq int, op, payload string) {
+	data, _ := json.Marshal(Response{M: int(m), I: seq, N: op, O: payload})
+	_ = conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func connectTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+	c := NewClient(url)
+	require.NoError(t, c.Connect(context.Background()))
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestClient_Call_CorrelatesOutOfOrderResponses(t *testing.T) {
+	held := make(chan Request, 1)
+	url := newTestServer(t, func(conn *websocket.Conn, req Request) {
+		// Hold the first request and answer both once the second arrives, in reverse order.
+		select {
+		case first := <-held:
+			writeResponse(conn, MessageTypeResponse, req.I, req.N, `{"which":"second"}`)
+			writeResponse(conn, MessageTypeResponse, first.I, first.N, `{"which":"first"}`)
+		default:
+			held <- req
+		}
+	})
+	c := connectTestClient(t, url)
+
+	type result struct {
+		Which string `json:"which"`
+	}
+	firstDone := make(chan *Response, 1)
+	go func() {
+		resp, err := c.Call(context.Background(), "SendOrder", map[string]int{"n": 1})
+		assert.NoError(t, err)
+		firstDone <- resp
+	}()
+	require.Eventually(t, func() bool { return len(held) == 1 }, time.Second, 5*time.Millisecond)
+
+	resp, err := c.Call(context.Background(), "SendOrder", map[string]int{"n": 2})
+	require.NoError(t, err)
+	var got result
+	require.NoError(t, resp.ParsePayload(&got))
+	assert.Equal(t, "second", got.Which)
+
+	resp = <-firstDone
+	require.NoError(t, resp.ParsePayload(&got))
+	assert.Equal(t, "first", got.Which)
+}
+
+func TestClient_Call_DoesNotNotifyHandlers(t *testing.T) {
+	url := newTestServer(t, func(conn *websocket.Conn, req Request) {
+		writeResponse(conn, MessageTypeResponse, req.I, req.N, `{}`)
+		writeResponse(conn, MessageTypeEvent, 0, "OrderStateEvent", `{}`)
+	})
+	c := connectTestClient(t, url)
+
+	events := make(chan string, 4)
+	c.AddHandler(func(r *Response) { events <- r.N })
+
+	_, err := c.Call(context.Background(), "Ping", struct{}{})
+	require.NoError(t, err)
+
+	select {
+	case op := <-events:
+		assert.Equal(t, "OrderStateEvent", op)
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered to handlers")
+	}
+	assert.Empty(t, events)
+}
+
+func TestClient_Call_ErrorFrame(t *testing.T) {
+	url := newTestServer(t, func(conn *websocket.Conn, req Request) {
+		writeResponse(conn, MessageTypeError, req.I, req.N, `{"errormsg":"Endpoint Not Found"}`)
+	})
+	c := connectTestClient(t, url)
+
+	_, err := c.Call(context.Background(), "Unknown", struct{}{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Endpoint Not Found")
+}
+
+func TestSession_ExecuteOrder_ErrorFrameClearsLogin(t *testing.T) {
+	url := newTestServer(t, func(conn *websocket.Conn, req Request) {
+		writeResponse(conn, MessageTypeError, req.I, req.N, `{"result":false,"errormsg":"Not Authorized","errorcode":20}`)
+	})
+	sess := NewSession(connectTestClient(t, url))
+	sess.authenticated.Store(true)
+
+	_, err := sess.ExecuteOrder(context.Background(), &SendOrderRequest{ClientOrderID: 1})
+	require.Error(t, err)
+	assert.False(t, sess.authenticated.Load(), "an unauthorized error frame must force a new login")
+}
+
+func TestClient_Call_Timeout(t *testing.T) {
+	url := newTestServer(t, func(*websocket.Conn, Request) {})
+	c := connectTestClient(t, url)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.Call(ctx, "SendOrder", struct{}{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Empty(t, c.pending)
+}
+
+func TestClient_Call_FailsOnDisconnect(t *testing.T) {
+	url := newTestServer(t, func(conn *websocket.Conn, _ Request) {
+		_ = conn.Close()
+	})
+	c := connectTestClient(t, url)
+	c.reconnectDelay = time.Hour
+
+	_, err := c.Call(context.Background(), "SendOrder", struct{}{})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrDisconnected))
+}
+
+// newCountingServer starts a WebSocket server that counts connections. The
+// first connection is dropped after dropFirst if it is non-zero.
+func newCountingServer(t *testing.T, dials *atomic.Int32, dropFirst time.Duration) string {
+	t.Helper()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := dials.Add(1)
+		time.Sleep(20 * time.Millisecond) // widen the window for concurrent reconnects
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if n == 1 && dropFirst > 0 {
+			time.Sleep(dropFirst)
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+func TestClient_Reconnect_ConcurrentCallsShareOneDial(t *testing.T) {
+	var dials atomic.Int32
+	c := connectTestClient(t, newCountingServer(t, &dials, 0))
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, c.Reconnect(context.Background()))
+		}()
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 2, dials.Load(), "concurrent reconnects must dial once")
+	assert.EqualValues(t, 1, c.Reconnects())
+	assert.True(t, c.IsConnected())
+}
+
+func TestClient_ScheduledReconnectSkipsRestoredConnection(t *testing.T) {
+	var dials atomic.Int32
+	c := NewClient(newCountingServer(t, &dials, 10*time.Millisecond))
+	c.reconnectDelay = 200 * time.Millisecond
+	require.NoError(t, c.Connect(context.Background()))
+	t.Cleanup(func() { _ = c.Close() })
+
+	// The server drops the first connection; reconnect before the client's
+	// own scheduled attempt fires.
+	require.Eventually(t, func() bool { return !c.IsConnected() }, time.Second, 5*time.Millisecond)
+	require.NoError(t, c.Reconnect(context.Background()))
+
+	time.Sleep(400 * time.Millisecond)
+	assert.EqualValues(t, 2, dials.Load(), "the scheduled reconnect must not dial again")
+	assert.EqualValues(t, 1, c.Reconnects())
+}
@@ -65,6 +65,9 @@ type GetUserAccountsRequest struct {
 // LogOutRequest represents a logout request
 type LogOutRequest struct{}
 
+// PingRequest represents a heartbeat request
+type PingRequest struct{}
+
 // GetOpenOrdersRequest represents a request to get open orders
 type GetOpenOrdersRequest struct {
 	OmsID     int `json:"OMSId"`
@@ -7,6 +7,15 @@ type SendOrderResponse struct {
 	OrderID      int64  `json:"OrderId"`
 }
 
+// ErrorResponse represents the generic error payload AlphaPoint returns for a
+// rejected request
+type ErrorResponse struct {
+	Result    bool   `json:"result"`
+	ErrorMsg  string `json:"errormsg"`
+	ErrorCode int    `json:"errorcode"`
+	Details   string `json:"detail"`
+}
+
 // CancelOrderResponse represents the response from canceling an order
 type CancelOrderResponse struct {
 	Result    bool   `json:"result"`
@@ -2,16 +2,27 @@ package alphapoint
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// reloginTimeout bounds the AuthenticateUser call issued after a reconnect.
+const reloginTimeout = 10 * time.Second
+
+// errorCodeNotAuthorized is the AlphaPoint error code for a request made on a
+// session that is not, or no longer, authenticated.
+const errorCodeNotAuthorized = 20
+
 // Session manages the AlphaPoint WebSocket session
 type Session struct {
 	client          *Client
 	auth            *AuthenticateUserRequest
 	authMu          sync.RWMutex
+	authenticated   atomic.Bool
 	messageHandlers map[string]func(*Response)
 	handlersMu      sync.RWMutex
 }
@@ -23,8 +34,10 @@ func NewSession(client *Client) *Session {
 		messageHandlers: make(map[string]func(*Response)),
 	}
 
-	// Register ourselves as a handler for all messages
+	// Register ourselves as a handler for all messages. Operation handlers live
+	// on the session, so they survive reconnects of the underlying client.
 	client.AddHandler(s.handleMessage)
+	client.OnReconnect(s.relogin)
 
 	return s
 }
@@ -43,7 +56,7 @@ func (s *Session) GetAuth() *AuthenticateUserRequest {
 	return s.auth
 }
 
-// Login sends authentication to AlphaPoint
+// Login authenticates the session with AlphaPoint and waits for the result.
 func (s *Session) Login(ctx context.Context) error {
 	s.authMu.RLock()
 	auth := s.auth
@@ -55,15 +68,68 @@ func (s *Session) Login(ctx context.Context) error {
 	}
 
 	slog.Info("Logging in to AlphaPoint")
-	return s.client.SendMessage(ctx, "AuthenticateUser", auth)
+	resp, err := s.client.Call(ctx, "AuthenticateUser", auth)
+	if err != nil {
+		s.authenticated.Store(false)
+		return fmt.Errorf("authenticate user: %w", err)
+	}
+
+	s.handleAuthenticateResponse(resp)
+	if !s.IsAuthenticated() {
+		return fmt.Errorf("authenticate user: rejected by AlphaPoint")
+	}
+	return nil
 }
 
 // Logout sends logout request to AlphaPoint
 func (s *Session) Logout(ctx context.Context) error {
 	slog.Info("Logging out from AlphaPoint")
+	s.authenticated.Store(false)
 	return s.client.SendMessage(ctx, "LogOut", &LogOutRequest{})
 }
 
+// Ping sends a heartbeat to AlphaPoint and waits for the PONG reply.
+func (s *Session) Ping(ctx context.Context) error {
+	_, err := s.client.Call(ctx, "Ping", &PingRequest{})
+	return err
+}
+
+// IsAuthenticated reports whether AlphaPoint accepted the last AuthenticateUser
+// request on the current connection.
+func (s *Session) IsAuthenticated() bool {
+	return s.authenticated.Load()
+}
+
+// LastMessageAt returns the time the last frame was received on the session.
+func (s *Session) LastMessageAt() time.Time {
+	return s.client.LastMessageAt()
+}
+
+// Reconnects returns the number of reconnects of the underlying client.
+func (s *Session) Reconnects() int64 {
+	return s.client.Reconnects()
+}
+
+// Reconnect drops the current connection, dials a new one and re-authenticates.
+func (s *Session) Reconnect(ctx context.Context) error {
+	s.authenticated.Store(false)
+	// Client.Reconnect triggers relogin through the OnReconnect hook.
+	return s.client.Reconnect(ctx)
+}
+
+// relogin re-runs AuthenticateUser after the client reconnects; a fresh
+// connection carries no AlphaPoint session state.
+func (s *Session) relogin() {
+	s.authenticated.Store(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), reloginTimeout)
+	defer cancel()
+
+	if err := s.Login(ctx); err != nil {
+		slog.Error("Re-login after reconnect failed", "error", err)
+	}
+}
+
 // RegisterHandler registers a handler for a specific operation
 func (s *Session) RegisterHandler(operation string, handler func(*Response)) {
 	s.handlersMu.Lock()
@@ -72,6 +138,12 @@ func (s *Session) RegisterHandler(operation string, handler func(*Response)) {
 }
 
 func (s *Session) handleMessage(response *Response) {
+	if strings.EqualFold(response.N, "AuthenticateUser") {
+		s.handleAuthenticateResponse(response)
+	} else {
+		s.checkAuthorized(response)
+	}
+
 	s.handlersMu.RLock()
 	handler, ok := s.messageHandlers[strings.ToLower(response.N)]
 	s.handlersMu.RUnlock()
@@ -83,13 +155,53 @@ func (s *Session) handleMessage(response *Response) {
 	}
 }
 
-// ExecuteOrder sends an order to AlphaPoint
-func (s *Session) ExecuteOrder(ctx context.Context, order *SendOrderRequest) error {
+func (s *Session) handleAuthenticateResponse(response *Response) {
+	var authResp AuthenticationResponse
+	if err := response.ParsePayload(&authResp); err != nil {
+		slog.Error("Failed to parse AuthenticationResponse", "error", err)
+		s.authenticated.Store(false)
+		return
+	}
+
+	s.authenticated.Store(authResp.Authenticated)
+	if !authResp.Authenticated {
+		slog.Warn("AlphaPoint authentication rejected", "error", authResp.ErrorMsg)
+	}
+}
+
+// checkAuthorized marks the session unauthenticated when AlphaPoint rejects a
+// request as not authorized, so the next caller logs in again.
+func (s *Session) checkAuthorized(response *Response) {
+	var errResp ErrorResponse
+	if err := response.ParsePayload(&errResp); err != nil || errResp.ErrorCode != errorCodeNotAuthorized {
+		return
+	}
+	if s.authenticated.Swap(false) {
+		slog.Warn("AlphaPoint session no longer authorized", "operation", response.N, "error", errResp.ErrorMsg)
+	}
+}
+
+// ExecuteOrder sends an order to AlphaPoint and waits for the matching SendOrder response.
+func (s *Session) ExecuteOrder(ctx context.Context, order *SendOrderRequest) (*SendOrderResponse, error) {
 	slog.Info("Executing order",
 		"clientOrderId", order.ClientOrderID,
 		"instrumentId", order.InstrumentID,
 	)
-	return s.client.SendMessage(ctx, "SendOrder", order)
+
+	resp, err := s.client.Call(ctx, "SendOrder", order)
+	// Error frames come back with resp set; a lapsed login is only seen here.
+	if resp != nil {
+		s.checkAuthorized(resp)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sendOrderResp SendOrderResponse
+	if err := resp.ParsePayload(&sendOrderResp); err != nil {
+		return nil, fmt.Errorf("parse SendOrderResponse: %w", err)
+	}
+	return &sendOrderResp, nil
 }
 
 // CancelOrder sends a cancel request to AlphaPoint
@@ -140,5 +252,6 @@ func (s *Session) Connect(ctx context.Context) error {
 
 // Close closes the session
 func (s *Session) Close() error {
+	s.authenticated.Store(false)
 	return s.client.Close()
 }
@@ -2,17 +2,20 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/shopspring/decimal"
 
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/alphapoint"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/order"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // OrderService defines the service method used by the HTTP handler.
 type OrderService interface {
-	ExecuteOrder(ctx context.Context, cmd *order.SubmitOrderCommand) error
+	ExecuteOrder(ctx context.Context, cmd *order.SubmitOrderCommand) (*order.ExecutionResult, error)
 }
 
 // KiiexHandler handles HTTP API requests for Kiiex operations.
@@ -26,7 +29,8 @@ func NewKiiexHandler(service OrderService) *KiiexHandler {
 }
 
 // ExecuteOrderHandler handles POST /api/v1/orders.
-// Returns 202 Accepted immediately; order dispatch is async via WebSocket.
+// Waits for AlphaPoint's SendOrder response and returns the venue order ID;
+// a venue rejection is returned as 422 with the rejection reason.
 func (h *KiiexHandler) ExecuteOrderHandler(c *fiber.Ctx) error {
 	var req OrderExecuteRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -67,15 +71,30 @@ func (h *KiiexHandler) ExecuteOrderHandler(c *fiber.Ctx) error {
 		"side", req.Side,
 	)
 
-	if err := h.service.ExecuteOrder(c.Context(), cmd); err != nil {
+	result, err := h.service.ExecuteOrder(c.UserContext(), cmd)
+	if err != nil {
 		slog.Error("kiiex.execute_order.failed",
 			"client", req.ClientID,
 			"error", err)
+		if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, alphapoint.ErrDisconnected) {
+			return c.Status(fiber.StatusGatewayTimeout).JSON(fiber.Map{"error": err.Error()})
+		}
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": verr.Message, "errorCode": verr.Code})
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	return c.Status(fiber.StatusAccepted).JSON(OrderExecuteResponse{
-		OrderID: req.OrderID,
-		Status:  "accepted",
-	})
+	resp := OrderExecuteResponse{
+		OrderID:         req.OrderID,
+		ExternalOrderID: result.OrderID,
+		Status:          "accepted",
+	}
+	if result.Rejected() {
+		resp.Status = "rejected"
+		resp.RejectReason = result.RejectReason
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(resp)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
 }
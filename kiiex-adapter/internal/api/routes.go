@@ -7,10 +7,17 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/nats-io/nats.go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/order"
 )
 
+// SessionReporter exposes the state of the per-client AlphaPoint sessions.
+type SessionReporter interface {
+	SessionStatuses() []order.SessionStatus
+}
+
 // RegisterRoutes registers all HTTP routes on the Fiber app.
-func RegisterRoutes(app *fiber.App, h *KiiexHandler, nc *nats.Conn) {
+func RegisterRoutes(app *fiber.App, h *KiiexHandler, nc *nats.Conn, sessions SessionReporter) {
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -28,9 +35,25 @@ func RegisterRoutes(app *fiber.App, h *KiiexHandler, nc *nats.Conn) {
 			code = fiber.StatusServiceUnavailable
 		}
 
+		// Session state is informational: an unhealthy session is repaired by the
+		// supervisor, so it degrades the status without failing the probe.
+		var statuses []order.SessionStatus
+		if sessions != nil {
+			statuses = sessions.SessionStatuses()
+		}
+		checks["alphapoint"] = "ok"
+		for _, st := range statuses {
+			if !st.Connected || !st.Authenticated {
+				checks["alphapoint"] = "degraded"
+				status = "degraded"
+				break
+			}
+		}
+
 		return c.Status(code).JSON(fiber.Map{
-			"status": status,
-			"checks": checks,
+			"status":   status,
+			"checks":   checks,
+			"sessions": statuses,
 		})
 	})
 
@@ -32,8 +32,10 @@ func (r OrderExecuteRequest) Validate() error {
 	return nil
 }
 
-// OrderExecuteResponse is the response for POST /api/v1/orders (202 Accepted).
+// OrderExecuteResponse is the response for POST /api/v1/orders.
 type OrderExecuteResponse struct {
-	OrderID string `json:"orderId"`
-	Status  string `json:"status"` // always "accepted"
+	OrderID         string `json:"orderId"`
+	ExternalOrderID int64  `json:"externalOrderId,omitempty"` // AlphaPoint order ID
+	Status          string `json:"status"`                    // "accepted" | "rejected"
+	RejectReason    string `json:"rejectReason,omitempty"`
 }
@@ -27,6 +27,21 @@ type Config struct {
 	LogLevel          string
 	CheckerIssuer     string
 	SymbolMappingPath string
+
+	// AlphaPoint session supervision
+	SessionHeartbeatInterval time.Duration
+	SessionIdleTimeout       time.Duration
+	CredentialCheckInterval  time.Duration
+
+	// Postgres (optional): terminal trades are kept in activity.t_order with their events
+	DatabaseURL string
+	PGMaxConns  int
+
+	// JetStream publishing
+	NATSStream            string        // Stream that must capture the adapter's trade events
+	NATSDedupWindow       time.Duration // Duplicate window enforced on NATSStream
+	NATSPublishAsync      bool          // Publish without waiting for each ack
+	NATSPublishMaxPending int           // Async publishes awaiting an ack before publishing blocks
 }
 
 // Load creates a Config from environment variables with defaults
@@ -48,6 +63,18 @@ func Load(ctx context.Context) *Config {
 		LogLevel:          pkgconfig.GetEnv("LOG_LEVEL", "info"),
 		CheckerIssuer:     pkgconfig.GetEnv("CHECKER_ISSUER", ""),
 		SymbolMappingPath: pkgconfig.GetEnv("SYMBOL_MAPPING_PATH", "configs/symbol_mapping.json"),
+
+		SessionHeartbeatInterval: pkgconfig.GetEnvDuration("KIIEX_SESSION_HEARTBEAT_INTERVAL", 30*time.Second),
+		SessionIdleTimeout:       pkgconfig.GetEnvDuration("KIIEX_SESSION_IDLE_TIMEOUT", 30*time.Minute),
+		CredentialCheckInterval:  pkgconfig.GetEnvDuration("KIIEX_CREDENTIAL_CHECK_INTERVAL", 5*time.Minute),
+
+		DatabaseURL: pkgconfig.GetEnv("DATABASE_URL", ""),
+		PGMaxConns:  pkgconfig.GetEnvInt("PG_MAX_CONNS", 4),
+
+		NATSStream:            pkgconfig.GetEnv("NATS_STREAM", "KIIEX_EVENTS"),
+		NATSDedupWindow:       pkgconfig.GetEnvDuration("NATS_DEDUP_WINDOW", 2*time.Minute),
+		NATSPublishAsync:      pkgconfig.GetEnvBool("NATS_PUBLISH_ASYNC", false),
+		NATSPublishMaxPending: pkgconfig.GetEnvInt("NATS_PUBLISH_MAX_PENDING", 256),
 	}
 
 	secretPath := fmt.Sprintf("%s/%s", cfg.Env, cfg.ServiceName)
@@ -70,4 +97,7 @@ func (c *Config) applyServiceSecret(m map[string]string) {
 	if v := m["log_level"]; v != "" {
 		c.LogLevel = v
 	}
+	if v := m["database_url"]; v != "" {
+		c.DatabaseURL = v
+	}
 }
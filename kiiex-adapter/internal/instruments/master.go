@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"strconv"
 	"sync"
+
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // Master manages symbol to instrument ID mappings
@@ -83,3 +86,21 @@ func (m *Master) GetAllMappings() map[string]int {
 	}
 	return result
 }
+
+// Products returns the mapped symbols as products for RFQ validation. Kiiex
+// publishes no size limits, so only the pair is constrained.
+func (m *Master) Products() []model.Product {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	products := make([]model.Product, 0, len(m.symbolToInstrumentID))
+	for symbol, id := range m.symbolToInstrumentID {
+		products = append(products, model.Product{
+			VenueCode:        "KIIEX",
+			InstrumentSymbol: symbol,
+			ProductID:        strconv.Itoa(id),
+			ProductName:      symbol,
+		})
+	}
+	return products
+}
@@ -82,6 +82,18 @@ func TestMaster_AddMapping(t *testing.T) {
 	assert.Equal(t, "BTCUSD", symbol)
 }
 
+func TestMaster_Products(t *testing.T) {
+	master := NewMaster()
+	assert.Empty(t, master.Products())
+
+	master.AddMapping("BTCUSD", 1)
+	products := master.Products()
+	require.Len(t, products, 1)
+	assert.Equal(t, "KIIEX", products[0].VenueCode)
+	assert.Equal(t, "BTCUSD", products[0].InstrumentSymbol)
+	assert.Equal(t, "1", products[0].ProductID)
+}
+
 func TestMaster_GetAllMappings(t *testing.T) {
 	master := NewMaster()
 
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
+)
+
+var (
+	// KiiexSessionConnected reports whether each client's AlphaPoint socket is connected (1) or not (0).
+	KiiexSessionConnected = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiiex_session_connected",
+			Help: "Whether the AlphaPoint WebSocket for a client is connected (1) or not (0).",
+		},
+		[]string{"client_id"},
+	)
+
+	// KiiexSessionAuthenticated reports whether each client's AlphaPoint session is authenticated.
+	KiiexSessionAuthenticated = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kiiex_session_authenticated",
+			Help: "Whether the AlphaPoint session for a client is authenticated (1) or not (0).",
+		},
+		[]string{"client_id"},
+	)
+
+	// KiiexSessionHeartbeatFailures counts failed or stale heartbeats per client.
+	KiiexSessionHeartbeatFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kiiex_session_heartbeat_failures_total",
+			Help: "Number of failed or stale AlphaPoint heartbeats by client ID.",
+		},
+		[]string{"client_id"},
+	)
+
+	// KiiexSessionEvictions counts sessions removed by the supervisor, by reason.
+	KiiexSessionEvictions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kiiex_session_evictions_total",
+			Help: "Number of AlphaPoint sessions evicted, by reason (idle, credentials_rotated).",
+		},
+		[]string{"reason"},
+	)
+
+	// KiiexSessionsActive gauges the number of cached AlphaPoint sessions.
+	KiiexSessionsActive = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kiiex_sessions_active",
+			Help: "Number of AlphaPoint sessions currently held by the adapter.",
+		},
+	)
+)
+
+// SetSessionState records the connection and authentication state of a client's session.
+func SetSessionState(clientID string, connected, authenticated bool) {
+	KiiexSessionConnected.WithLabelValues(clientID).Set(boolToFloat(connected))
+	KiiexSessionAuthenticated.WithLabelValues(clientID).Set(boolToFloat(authenticated))
+}
+
+// DeleteSessionState removes per-client session gauges once a session is evicted.
+func DeleteSessionState(clientID string) {
+	KiiexSessionConnected.DeleteLabelValues(clientID)
+	KiiexSessionAuthenticated.DeleteLabelValues(clientID)
+}
+
+// IncHeartbeatFailure increments the heartbeat failure counter for a client.
+func IncHeartbeatFailure(clientID string) {
+	KiiexSessionHeartbeatFailures.WithLabelValues(clientID).Inc()
+}
+
+// IncReconnect increments the shared WebSocket reconnect counter for a client.
+func IncReconnect(clientID string) {
+	sharedmetrics.IncWSReconnect("kiiex", clientID)
+}
+
+// IncEviction increments the session eviction counter for the given reason.
+func IncEviction(reason string) {
+	KiiexSessionEvictions.WithLabelValues(reason).Inc()
+}
+
+// SetActiveSessions sets the number of cached sessions.
+func SetActiveSessions(n int) {
+	KiiexSessionsActive.Set(float64(n))
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
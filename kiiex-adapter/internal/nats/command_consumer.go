@@ -8,12 +8,14 @@ import (
 
 	"github.com/nats-io/nats.go"
 
+	"github.com/Checker-Finance/adapters/internal/metrics"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/order"
 )
 
 // OrderService defines the order service interface consumed by the NATS command consumer.
 type OrderService interface {
-	ExecuteOrder(ctx context.Context, cmd *order.SubmitOrderCommand) error
+	ExecuteOrder(ctx context.Context, cmd *order.SubmitOrderCommand) (*order.ExecutionResult, error)
 	CancelOrder(ctx context.Context, clientID, orderID string) error
 }
 
@@ -33,15 +35,31 @@ func NewCommandConsumer(nc *nats.Conn, orderService OrderService) *CommandConsum
 // Handlers inherit ctx so they respect shutdown cancellation.
 func (c *CommandConsumer) Subscribe(ctx context.Context, inboundSubject, cancelSubject string) error {
 	executeSub, err := c.nc.Subscribe(inboundSubject, func(msg *nats.Msg) {
+		start := time.Now()
+		msgCtx, span := tracing.StartConsumer(ctx, msg)
+		var err error
+		defer func() {
+			tracing.End(span, err)
+			metrics.ObserveCommand("kiiex", "execute", start, err)
+		}()
+
 		var cmd order.SubmitOrderCommand
-		if err := json.Unmarshal(msg.Data, &cmd); err != nil {
+		if err = json.Unmarshal(msg.Data, &cmd); err != nil {
 			slog.Error("kiiex.consumer.execute_unmarshal_failed", "error", err)
 			return
 		}
-		msgCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		msgCtx, cancel := context.WithTimeout(msgCtx, 10*time.Second)
 		defer cancel()
-		if err := c.orderService.ExecuteOrder(msgCtx, &cmd); err != nil {
+		var result *order.ExecutionResult
+		result, err = c.orderService.ExecuteOrder(msgCtx, &cmd)
+		if err != nil {
 			slog.Error("kiiex.consumer.execute_failed", "error", err)
+			return
+		}
+		if result.Rejected() {
+			slog.Warn("kiiex.consumer.execute_rejected",
+				"orderId", result.OrderID,
+				"reason", result.RejectReason)
 		}
 	})
 	if err != nil {
@@ -50,14 +68,22 @@ func (c *CommandConsumer) Subscribe(ctx context.Context, inboundSubject, cancelS
 	c.subs = append(c.subs, executeSub)
 
 	cancelSub, err := c.nc.Subscribe(cancelSubject, func(msg *nats.Msg) {
+		start := time.Now()
+		msgCtx, span := tracing.StartConsumer(ctx, msg)
+		var err error
+		defer func() {
+			tracing.End(span, err)
+			metrics.ObserveCommand("kiiex", "cancel", start, err)
+		}()
+
 		var cmd order.CancelOrderCommand
-		if err := json.Unmarshal(msg.Data, &cmd); err != nil {
+		if err = json.Unmarshal(msg.Data, &cmd); err != nil {
 			slog.Error("kiiex.consumer.cancel_unmarshal_failed", "error", err)
 			return
 		}
-		msgCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		msgCtx, cancel := context.WithTimeout(msgCtx, 5*time.Second)
 		defer cancel()
-		if err := c.orderService.CancelOrder(msgCtx, cmd.ClientID, cmd.OrderID); err != nil {
+		if err = c.orderService.CancelOrder(msgCtx, cmd.ClientID, cmd.OrderID); err != nil {
 			slog.Error("kiiex.consumer.cancel_failed", "error", err)
 		}
 	})
@@ -3,10 +3,16 @@ package nats
 import (
 	"context"
 	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
 
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/order"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/pkg/eventbus"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 const (
@@ -14,10 +20,17 @@ const (
 	subjectKiiexCancelled = "evt.trade.cancelled.v1.KIIEX"
 )
 
+// TradeSync records a terminal trade in activity.t_order and queues the event
+// announcing it in one transaction. *legacy.TradeSyncWriter satisfies it.
+type TradeSync interface {
+	SyncTradeWithEvent(ctx context.Context, trade *model.TradeConfirmation, subject, msgID string, payload any) error
+}
+
 // NATSPublisher subscribes to the in-process event bus and publishes fill/cancel events to NATS.
 type NATSPublisher struct {
-	pub      *publisher.Publisher
-	eventBus *eventbus.EventBus
+	pub       *publisher.Publisher
+	eventBus  *eventbus.EventBus
+	tradeSync TradeSync
 }
 
 // NewNATSPublisher creates a NATSPublisher that listens to eventBus and forwards events to NATS.
@@ -30,6 +43,12 @@ func NewNATSPublisher(pub *publisher.Publisher, eventBus *eventbus.EventBus) *NA
 	return p
 }
 
+// SetTradeSync makes terminal fills and cancels be written to activity.t_order
+// together with their event, which the outbox relay then publishes.
+func (p *NATSPublisher) SetTradeSync(ts TradeSync) {
+	p.tradeSync = ts
+}
+
 func (p *NATSPublisher) subscribeToEvents() {
 	p.eventBus.Subscribe(order.FillArrivedEvent{}, func(event interface{}) {
 		fill, ok := event.(*order.FillArrivedEvent)
@@ -66,7 +85,17 @@ func (p *NATSPublisher) publishFillArrived(event *order.FillArrivedEvent) {
 		"instrumentPair", event.InstrumentPair,
 		"status", event.Status,
 	)
-	if err := p.pub.Publish(context.Background(), subjectKiiexFilled, event); err != nil {
+	// Partial fills share the order ID; each fill is deduplicated by its own ID.
+	msgID := ""
+	if event.FillID != "" {
+		msgID = publisher.TradeEventID("KIIEX", event.FillID, "filled")
+	}
+	if leaves, err := decimal.NewFromString(event.QuantityLeaves); err == nil && leaves.IsZero() {
+		if p.syncTerminalTrade(fillTrade(event), subjectKiiexFilled, msgID, event) {
+			return
+		}
+	}
+	if err := p.pub.Publish(context.Background(), subjectKiiexFilled, event, publisher.WithMsgID(msgID)); err != nil {
 		slog.Error("kiiex.publisher.fill_failed", "error", err)
 	}
 }
@@ -79,7 +108,63 @@ func (p *NATSPublisher) publishOrderCanceled(event *order.OrderCanceledEvent) {
 	slog.Info("kiiex.publisher.cancel",
 		"orderId", event.OrderID,
 	)
-	if err := p.pub.Publish(context.Background(), subjectKiiexCancelled, event); err != nil {
+	msgID := publisher.TradeEventID("KIIEX", event.OrderID, "cancelled")
+	if p.syncTerminalTrade(cancelTrade(event), subjectKiiexCancelled, msgID, event) {
+		return
+	}
+	if err := p.pub.Publish(context.Background(), subjectKiiexCancelled, event, publisher.WithMsgID(msgID)); err != nil {
 		slog.Error("kiiex.publisher.cancel_failed", "error", err)
 	}
 }
+
+// syncTerminalTrade writes trade and queues its event in one transaction, and
+// reports whether it did. Without a database, or if the transaction fails,
+// the caller publishes the event directly under the same message ID.
+func (p *NATSPublisher) syncTerminalTrade(trade *model.TradeConfirmation, subject, msgID string, event any) bool {
+	if p.tradeSync == nil {
+		return false
+	}
+	if err := p.tradeSync.SyncTradeWithEvent(context.Background(), trade, subject, msgID, event); err != nil {
+		slog.Warn("kiiex.trade_sync_failed",
+			"orderId", trade.TradeID,
+			"status", trade.Status,
+			"error", err)
+		return false
+	}
+	return true
+}
+
+// fillTrade returns the last fill of an order as a filled trade.
+func fillTrade(event *order.FillArrivedEvent) *model.TradeConfirmation {
+	price, _ := strconv.ParseFloat(event.Price, 64)
+	quantity, _ := strconv.ParseFloat(event.QuantityCumulative, 64)
+	if quantity == 0 {
+		quantity, _ = strconv.ParseFloat(event.QuantityFilled, 64)
+	}
+	return &model.TradeConfirmation{
+		TradeID:         event.OrderID,
+		ClientID:        event.ClientID,
+		OrderID:         event.OrderID,
+		Instrument:      event.InstrumentPair,
+		Side:            strings.ToUpper(event.Side),
+		Quantity:        quantity,
+		Price:           price,
+		Venue:           "KIIEX",
+		Status:          model.StatusFilled,
+		ExecutedAt:      time.Now(),
+		RFQID:           event.RequestForQuoteID,
+		ProviderOrderID: event.ExternalOrderID,
+	}
+}
+
+// cancelTrade returns a cancelled order as a cancelled trade.
+func cancelTrade(event *order.OrderCanceledEvent) *model.TradeConfirmation {
+	return &model.TradeConfirmation{
+		TradeID:    event.OrderID,
+		ClientID:   event.ClientID,
+		OrderID:    event.OrderID,
+		Venue:      "KIIEX",
+		Status:     model.StatusCancelled,
+		ExecutedAt: time.Now(),
+	}
+}
@@ -0,0 +1,51 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/order"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/pkg/eventbus"
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+type syncedTrade struct {
+	trade   *model.TradeConfirmation
+	subject string
+	msgID   string
+}
+
+type recordingTradeSync struct {
+	synced []syncedTrade
+}
+
+func (r *recordingTradeSync) SyncTradeWithEvent(_ context.Context, trade *model.TradeConfirmation, subject, msgID string, _ any) error {
+	r.synced = append(r.synced, syncedTrade{trade: trade, subject: subject, msgID: msgID})
+	return nil
+}
+
+func TestNATSPublisher_TerminalEventsGoThroughOutbox(t *testing.T) {
+	ts := &recordingTradeSync{}
+	p := NewNATSPublisher(nil, eventbus.New())
+	p.SetTradeSync(ts)
+
+	p.publishFillArrived(&order.FillArrivedEvent{
+		OrderID: "ord-1", FillID: "901", ClientID: "client-a",
+		QuantityFilled: "2", QuantityLeaves: "0", Price: "100", Side: "buy",
+	})
+	p.publishOrderCanceled(&order.OrderCanceledEvent{OrderID: "ord-2", ClientID: "client-a"})
+
+	require.Len(t, ts.synced, 2)
+	fill, cancel := ts.synced[0], ts.synced[1]
+	assert.Equal(t, subjectKiiexFilled, fill.subject)
+	assert.Equal(t, "trade:KIIEX:901:filled", fill.msgID)
+	assert.Equal(t, model.StatusFilled, fill.trade.Status)
+	assert.Equal(t, 2.0, fill.trade.Quantity)
+	assert.Equal(t, subjectKiiexCancelled, cancel.subject)
+	assert.Equal(t, "trade:KIIEX:ord-2:cancelled", cancel.msgID)
+	assert.Equal(t, "client-a", cancel.trade.ClientID)
+	assert.Equal(t, model.StatusCancelled, cancel.trade.Status)
+}
@@ -39,7 +39,8 @@ func NewFillArrivedEvent() *FillArrivedEvent {
 
 // OrderCanceledEvent is published when an order is canceled
 type OrderCanceledEvent struct {
-	OrderID string `json:"orderId"`
+	OrderID  string `json:"orderId"`
+	ClientID string `json:"clientId,omitempty"`
 }
 
 // AttemptedCancelEvent is published when a cancel attempt is made
@@ -5,14 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/alphapoint"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/instruments"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/metrics"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/security"
 	"github.com/Checker-Finance/adapters/kiiex-adapter/pkg/eventbus"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // ConfigResolver resolves per-client Kiiex credentials from a secret store.
@@ -22,14 +27,35 @@ type ConfigResolver interface {
 
 // sessionEntry pairs an AlphaPoint session with the credentials used to create it.
 type sessionEntry struct {
-	session *alphapoint.Session
-	auth    *security.Auth
+	session   *alphapoint.Session
+	auth      *security.Auth
+	createdAt time.Time
+	lastUsed  atomic.Int64 // unix nanos of the last order operation on the session
+}
+
+func (e *sessionEntry) touch() {
+	e.lastUsed.Store(time.Now().UnixNano())
+}
+
+func (e *sessionEntry) lastUsedAt() time.Time {
+	return time.Unix(0, e.lastUsed.Load())
+}
+
+// SessionStatus is a point-in-time view of one client's AlphaPoint session.
+type SessionStatus struct {
+	ClientID      string    `json:"clientId"`
+	Connected     bool      `json:"connected"`
+	Authenticated bool      `json:"authenticated"`
+	CreatedAt     time.Time `json:"createdAt"`
+	LastUsedAt    time.Time `json:"lastUsedAt"`
+	LastMessageAt time.Time `json:"lastMessageAt"`
+	Reconnects    int64     `json:"reconnects"`
 }
 
 // Service handles order operations
 type Service struct {
 	mu               sync.RWMutex
-	sessions         map[string]sessionEntry
+	sessions         map[string]*sessionEntry
 	resolver         ConfigResolver
 	instrumentMaster *instruments.Master
 	eventBus         *eventbus.EventBus
@@ -44,7 +70,7 @@ func NewService(
 	wsURL string,
 ) *Service {
 	return &Service{
-		sessions:         make(map[string]sessionEntry),
+		sessions:         make(map[string]*sessionEntry),
 		resolver:         resolver,
 		instrumentMaster: instrumentMaster,
 		eventBus:         eventBus,
@@ -54,7 +80,9 @@ func NewService(
 
 // getOrCreateSession returns an existing session entry for clientID or creates and connects a new one.
 // Auth is resolved from the secret store only when a new session needs to be created.
-func (s *Service) getOrCreateSession(ctx context.Context, clientID string) (sessionEntry, error) {
+// Only order and cancel activity marks an existing session as used, so a
+// session that is merely polled for status still goes idle.
+func (s *Service) getOrCreateSession(ctx context.Context, clientID string) (*sessionEntry, error) {
 	s.mu.RLock()
 	entry, ok := s.sessions[clientID]
 	s.mu.RUnlock()
@@ -70,12 +98,26 @@ func (s *Service) getOrCreateSession(ctx context.Context, clientID string) (sess
 
 	auth, err := s.resolver.Resolve(ctx, clientID)
 	if err != nil {
-		return sessionEntry{}, fmt.Errorf("resolve credentials for client %q: %w", clientID, err)
+		return nil, fmt.Errorf("resolve credentials for client %q: %w", clientID, err)
 	}
 
+	entry, err = s.newSession(ctx, clientID, auth)
+	if err != nil {
+		return nil, err
+	}
+	entry.touch()
+
+	s.sessions[clientID] = entry
+	metrics.SetActiveSessions(len(s.sessions))
+	slog.Info("AlphaPoint session created", "clientID", clientID)
+	return entry, nil
+}
+
+// newSession dials AlphaPoint and wires the order handlers for a client.
+func (s *Service) newSession(ctx context.Context, clientID string, auth *security.Auth) (*sessionEntry, error) {
 	client := alphapoint.NewClient(s.wsURL)
 	if err := client.Connect(ctx); err != nil {
-		return sessionEntry{}, fmt.Errorf("connect to AlphaPoint for client %q: %w", clientID, err)
+		return nil, fmt.Errorf("connect to AlphaPoint for client %q: %w", clientID, err)
 	}
 
 	sess := alphapoint.NewSession(client)
@@ -89,24 +131,137 @@ func (s *Service) getOrCreateSession(ctx context.Context, clientID string) (sess
 	sess.RegisterHandler("cancelorder", s.handleCancelOrderResponse)
 	sess.RegisterHandler("getorderstatus", s.handleGetOrderStatusResponse)
 
-	entry = sessionEntry{session: sess, auth: auth}
+	return &sessionEntry{session: sess, auth: auth, createdAt: time.Now()}, nil
+}
+
+// replaceSession swaps the session for clientID with one built from auth, closing the old one.
+// It is used when credentials rotate in the secret store.
+func (s *Service) replaceSession(ctx context.Context, clientID string, auth *security.Auth) error {
+	entry, err := s.newSession(ctx, clientID, auth)
+	if err != nil {
+		return err
+	}
+	if err := entry.session.Login(ctx); err != nil {
+		slog.Warn("Login on replacement session failed", "clientID", clientID, "error", err)
+	}
+
+	s.mu.Lock()
+	old, ok := s.sessions[clientID]
+	if ok {
+		entry.lastUsed.Store(old.lastUsed.Load())
+	} else {
+		entry.touch()
+	}
 	s.sessions[clientID] = entry
-	slog.Info("AlphaPoint session created", "clientID", clientID)
-	return entry, nil
+	s.mu.Unlock()
+
+	if ok {
+		closeSession(ctx, clientID, old)
+	}
+	return nil
+}
+
+// evictSession removes the session for clientID if it is still the given entry, and closes it.
+func (s *Service) evictSession(ctx context.Context, clientID string, entry *sessionEntry) bool {
+	s.mu.Lock()
+	current, ok := s.sessions[clientID]
+	if !ok || current != entry {
+		s.mu.Unlock()
+		return false
+	}
+	delete(s.sessions, clientID)
+	metrics.SetActiveSessions(len(s.sessions))
+	s.mu.Unlock()
+
+	closeSession(ctx, clientID, entry)
+	metrics.DeleteSessionState(clientID)
+	return true
+}
+
+// closeSession logs out (best effort) and closes an AlphaPoint session.
+func closeSession(ctx context.Context, clientID string, entry *sessionEntry) {
+	if entry.session.IsConnected() {
+		if err := entry.session.Logout(ctx); err != nil {
+			slog.Debug("Logout before close failed", "clientID", clientID, "error", err)
+		}
+	}
+	if err := entry.session.Close(); err != nil {
+		slog.Warn("failed to close session", "clientID", clientID, "error", err)
+	}
 }
 
-// ExecuteOrder submits an order to AlphaPoint for the client identified in the command.
-func (s *Service) ExecuteOrder(ctx context.Context, cmd *SubmitOrderCommand) error {
+// snapshotSessions returns a copy of the current session map.
+func (s *Service) snapshotSessions() map[string]*sessionEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make(map[string]*sessionEntry, len(s.sessions))
+	for clientID, entry := range s.sessions {
+		out[clientID] = entry
+	}
+	return out
+}
+
+// SessionStatuses reports the state of every cached AlphaPoint session, sorted by client ID.
+func (s *Service) SessionStatuses() []SessionStatus {
+	sessions := s.snapshotSessions()
+	out := make([]SessionStatus, 0, len(sessions))
+	for clientID, entry := range sessions {
+		out = append(out, SessionStatus{
+			ClientID:      clientID,
+			Connected:     entry.session.IsConnected(),
+			Authenticated: entry.session.IsAuthenticated(),
+			CreatedAt:     entry.createdAt,
+			LastUsedAt:    entry.lastUsedAt(),
+			LastMessageAt: entry.session.LastMessageAt(),
+			Reconnects:    entry.session.Reconnects(),
+		})
+	}
+	slices.SortFunc(out, func(a, b SessionStatus) int {
+		return strings.Compare(a.ClientID, b.ClientID)
+	})
+	return out
+}
+
+// ExecutionResult is AlphaPoint's synchronous answer to a SendOrder request.
+type ExecutionResult struct {
+	OrderID      int64  // AlphaPoint order ID
+	Status       string // AlphaPoint status, e.g. "Accepted" or "Rejected"
+	RejectReason string
+}
+
+// Rejected reports whether AlphaPoint rejected the order.
+func (r *ExecutionResult) Rejected() bool {
+	return strings.EqualFold(r.Status, "rejected")
+}
+
+// ExecuteOrder submits an order to AlphaPoint for the client identified in the command
+// and waits for AlphaPoint's SendOrder response correlated by sequence number.
+func (s *Service) ExecuteOrder(ctx context.Context, cmd *SubmitOrderCommand) (*ExecutionResult, error) {
 	slog.Info("SubmitOrderCommand received", "command", cmd)
 
+	if err := model.ValidateRFQ(s.instrumentMaster.Products(), model.RFQRequest{
+		ClientID:     cmd.ClientID,
+		CurrencyPair: cmd.InstrumentPair,
+		Side:         cmd.Side,
+		Amount:       cmd.Quantity.InexactFloat64(),
+	}); err != nil {
+		slog.Warn("kiiex.execute_order.rejected",
+			"client", cmd.ClientID,
+			"pair", cmd.InstrumentPair,
+			"quantity", cmd.Quantity.String(),
+			"error", err)
+		return nil, err
+	}
+
 	entry, err := s.getOrCreateSession(ctx, cmd.ClientID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	entry.touch()
 
 	instrumentID, ok := s.instrumentMaster.GetInstrumentID(cmd.InstrumentPair)
 	if !ok {
-		return fmt.Errorf("unknown instrument pair: %s", cmd.InstrumentPair)
+		return nil, fmt.Errorf("unknown instrument pair: %s", cmd.InstrumentPair)
 	}
 
 	tradeInfo := TradeInfo{
@@ -128,14 +283,23 @@ func (s *Service) ExecuteOrder(ctx context.Context, cmd *SubmitOrderCommand) err
 		TimeInForce:        TimeInForceFOK.ToInt(),
 	}
 
-	if err := entry.session.Login(ctx); err != nil {
-		slog.Error("Failed to login", "error", err)
-		return err
+	if err := ensureLoggedIn(ctx, entry); err != nil {
+		return nil, err
 	}
 
-	if err := entry.session.ExecuteOrder(ctx, orderReq); err != nil {
+	resp, err := entry.session.ExecuteOrder(ctx, orderReq)
+	if err != nil {
 		slog.Error("Failed to execute order", "error", err)
-		return err
+		return nil, err
+	}
+
+	result := &ExecutionResult{
+		OrderID:      resp.OrderID,
+		Status:       resp.Status,
+		RejectReason: resp.ErrorMessage,
+	}
+	if result.OrderID != 0 {
+		tradeInfo.OrderID = int(result.OrderID)
 	}
 
 	s.eventBus.Publish(&OrderSubmittedEvent{
@@ -143,7 +307,14 @@ func (s *Service) ExecuteOrder(ctx context.Context, cmd *SubmitOrderCommand) err
 		OrderID:   cmd.ClientOrderID,
 	})
 
-	return nil
+	if result.Rejected() {
+		slog.Warn("Order rejected", "orderId", result.OrderID, "error", result.RejectReason)
+		s.eventBus.Publish(&AttemptedCancelEvent{
+			OrderID: int(result.OrderID),
+		})
+	}
+
+	return result, nil
 }
 
 // CancelOrder cancels an order for a specific client.
@@ -159,6 +330,7 @@ func (s *Service) CancelOrder(ctx context.Context, clientID, orderID string) err
 	if err != nil {
 		return err
 	}
+	entry.touch()
 
 	cancel := &alphapoint.CancelOrderRequest{
 		OmsID:     entry.auth.OmsID,
@@ -166,8 +338,7 @@ func (s *Service) CancelOrder(ctx context.Context, clientID, orderID string) err
 		OrderID:   orderIDInt,
 	}
 
-	if err := entry.session.Login(ctx); err != nil {
-		slog.Error("Failed to login", "error", err)
+	if err := ensureLoggedIn(ctx, entry); err != nil {
 		return err
 	}
 
@@ -177,12 +348,26 @@ func (s *Service) CancelOrder(ctx context.Context, clientID, orderID string) err
 	}
 
 	s.eventBus.Publish(&OrderCanceledEvent{
-		OrderID: orderID,
+		OrderID:  orderID,
+		ClientID: clientID,
 	})
 
 	return nil
 }
 
+// ensureLoggedIn authenticates entry's session unless it already is. A session
+// whose login lapsed is marked unauthenticated when AlphaPoint rejects a request.
+func ensureLoggedIn(ctx context.Context, entry *sessionEntry) error {
+	if entry.session.IsAuthenticated() {
+		return nil
+	}
+	if err := entry.session.Login(ctx); err != nil {
+		slog.Error("Failed to login", "error", err)
+		return err
+	}
+	return nil
+}
+
 // GetTradeStatus requests the status of a trade via the client's own session.
 func (s *Service) GetTradeStatus(ctx context.Context, tradeInfo TradeInfo) error {
 	slog.Info("GetTradeStatus", "tradeInfo", tradeInfo)
@@ -216,6 +401,8 @@ func (s *Service) Close() error {
 	return errors.Join(errs...)
 }
 
+// handleSendOrderResponse handles SendOrder responses that arrive after their
+// ExecuteOrder call stopped waiting (e.g., on timeout).
 func (s *Service) handleSendOrderResponse(response *alphapoint.Response) {
 	slog.Info("Order submitted response", "payload", response.O)
 
@@ -0,0 +1,141 @@
+package order
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/instruments"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/security"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/pkg/eventbus"
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+func newOrderTestService(t *testing.T, accountID int) (*Service, *eventbus.EventBus) {
+	t.Helper()
+	fake := newFakeAlphaPoint(t)
+	master := instruments.NewMaster()
+	master.AddMapping("BTCUSD", 1)
+	bus := eventbus.New()
+	resolver := &stubResolver{auth: security.Auth{APIKey: "key", UserID: 1, Nonce: "n", OmsID: 1, AccountID: accountID}}
+	svc := NewService(resolver, master, bus, fake.url())
+	t.Cleanup(func() { _ = svc.Close() })
+	return svc, bus
+}
+
+func TestService_ExecuteOrder_ReturnsVenueOrderID(t *testing.T) {
+	svc, bus := newOrderTestService(t, 7)
+
+	submitted := make(chan *OrderSubmittedEvent, 2)
+	bus.Subscribe(OrderSubmittedEvent{}, func(event interface{}) {
+		if e, ok := event.(OrderSubmittedEvent); ok {
+			submitted <- &e
+		}
+	})
+
+	cmds := []*SubmitOrderCommand{
+		{ID: 1, ClientOrderID: "c-1", ClientID: "client-a", InstrumentPair: "BTCUSD", Quantity: decimal.NewFromInt(1), Side: "Buy", Type: "Market"},
+		{ID: 2, ClientOrderID: "c-2", ClientID: "client-a", InstrumentPair: "BTCUSD", Quantity: decimal.NewFromInt(2), Side: "Sell", Type: "Market"},
+	}
+
+	results := make([]*ExecutionResult, len(cmds))
+	errs := make([]error, len(cmds))
+	var wg sync.WaitGroup
+	for i, cmd := range cmds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = svc.ExecuteOrder(context.Background(), cmd)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int64(5001), results[0].OrderID)
+	assert.Equal(t, int64(5002), results[1].OrderID)
+	assert.False(t, results[0].Rejected())
+	for range cmds {
+		select {
+		case e := <-submitted:
+			assert.Contains(t, []int{5001, 5002}, e.TradeInfo.OrderID)
+		case <-time.After(time.Second):
+			t.Fatal("OrderSubmittedEvent not published")
+		}
+	}
+}
+
+func TestService_ExecuteOrder_ValidatesBeforeSending(t *testing.T) {
+	svc, _ := newOrderTestService(t, 7)
+
+	tests := []struct {
+		name string
+		cmd  *SubmitOrderCommand
+		code string
+	}{
+		{"unmapped pair", &SubmitOrderCommand{ID: 3, ClientID: "client-a", InstrumentPair: "DOGEUSD", Quantity: decimal.NewFromInt(1), Side: "Buy", Type: "Market"}, model.RFQErrUnsupportedPair},
+		{"zero quantity", &SubmitOrderCommand{ID: 4, ClientID: "client-a", InstrumentPair: "BTCUSD", Quantity: decimal.Zero, Side: "Buy", Type: "Market"}, model.RFQErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ExecuteOrder(context.Background(), tt.cmd)
+			verr, ok := model.AsRFQValidationError(err)
+			require.True(t, ok, "expected a validation error, got %v", err)
+			assert.Equal(t, tt.code, verr.Code)
+		})
+	}
+	assert.Empty(t, svc.SessionStatuses(), "rejected orders must not open a session")
+}
+
+func TestService_ExecuteOrder_ReturnsRejectReason(t *testing.T) {
+	svc, bus := newOrderTestService(t, 99)
+
+	attempted := make(chan *AttemptedCancelEvent, 1)
+	bus.Subscribe(AttemptedCancelEvent{}, func(event interface{}) {
+		if e, ok := event.(AttemptedCancelEvent); ok {
+			attempted <- &e
+		}
+	})
+
+	res, err := svc.ExecuteOrder(context.Background(), &SubmitOrderCommand{
+		ID: 3, ClientID: "client-a", InstrumentPair: "BTCUSD", Quantity: decimal.NewFromInt(1), Side: "Buy", Type: "Market",
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Rejected())
+	assert.Equal(t, "Not_Enough_Funds", res.RejectReason)
+	select {
+	case <-attempted:
+	case <-time.After(time.Second):
+		t.Fatal("AttemptedCancelEvent not published")
+	}
+}
+
+func TestService_CancelOrder_ReusesSessionUntilUnauthorized(t *testing.T) {
+	fake := newFakeAlphaPoint(t)
+	resolver := &stubResolver{auth: security.Auth{APIKey: "key", UserID: 1, Nonce: "n", OmsID: 1, AccountID: 7}}
+	svc := NewService(resolver, instruments.NewMaster(), eventbus.New(), fake.url())
+	t.Cleanup(func() { _ = svc.Close() })
+	ctx := context.Background()
+
+	require.NoError(t, svc.CancelOrder(ctx, "client-a", "1"))
+	require.NoError(t, svc.CancelOrder(ctx, "client-a", "2"))
+	require.Eventually(t, func() bool { return fake.cancels.Load() == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), fake.logins.Load(), "authenticated session should be reused")
+
+	// The venue drops the login: the rejection marks the session
+	// unauthenticated and the next cancel logs in again.
+	fake.expired.Store(true)
+	require.NoError(t, svc.CancelOrder(ctx, "client-a", "3"))
+	entry, err := svc.getOrCreateSession(ctx, "client-a")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return !entry.session.IsAuthenticated() }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, svc.CancelOrder(ctx, "client-a", "4"))
+	assert.Equal(t, int32(2), fake.logins.Load())
+}
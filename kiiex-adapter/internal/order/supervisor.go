@@ -0,0 +1,196 @@
+package order
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/metrics"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/security"
+)
+
+// CredentialRefresher re-reads a client's credentials from the secret store,
+// bypassing any local cache, so rotated secrets are picked up.
+type CredentialRefresher interface {
+	Refresh(ctx context.Context, clientID string) (*security.Auth, error)
+}
+
+// SupervisorConfig tunes the session supervisor.
+type SupervisorConfig struct {
+	// HeartbeatInterval is how often each session is pinged.
+	HeartbeatInterval time.Duration
+	// StaleAfter is how long a connected session may go without receiving any
+	// frame before it is forcibly reconnected.
+	StaleAfter time.Duration
+	// IdleTimeout evicts sessions that have not been used for an order operation.
+	IdleTimeout time.Duration
+	// CredentialCheckInterval is how often credentials are re-read from the secret store.
+	CredentialCheckInterval time.Duration
+}
+
+// SessionSupervisor keeps the per-client AlphaPoint sessions held by Service healthy:
+// it heartbeats each session, reconnects stale ones (the client re-authenticates on
+// reconnect), evicts idle sessions and recreates sessions whose credentials rotated.
+type SessionSupervisor struct {
+	service   *Service
+	refresher CredentialRefresher
+	cfg       SupervisorConfig
+	done      chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewSessionSupervisor creates a supervisor for the sessions held by service.
+// refresher may be nil, in which case credential rotation is not checked.
+func NewSessionSupervisor(service *Service, refresher CredentialRefresher, cfg SupervisorConfig) *SessionSupervisor {
+	if cfg.HeartbeatInterval <= 0 {
+		cfg.HeartbeatInterval = 30 * time.Second
+	}
+	if cfg.StaleAfter <= 0 {
+		cfg.StaleAfter = 3 * cfg.HeartbeatInterval
+	}
+	if cfg.CredentialCheckInterval <= 0 {
+		cfg.CredentialCheckInterval = 5 * time.Minute
+	}
+	return &SessionSupervisor{
+		service:   service,
+		refresher: refresher,
+		cfg:       cfg,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start runs the supervision loops until ctx is cancelled or Stop is called.
+func (s *SessionSupervisor) Start(ctx context.Context) {
+	slog.Info("Starting session supervisor",
+		"heartbeatInterval", s.cfg.HeartbeatInterval,
+		"idleTimeout", s.cfg.IdleTimeout,
+		"credentialCheckInterval", s.cfg.CredentialCheckInterval,
+	)
+
+	heartbeat := time.NewTicker(s.cfg.HeartbeatInterval)
+	defer heartbeat.Stop()
+	credentials := time.NewTicker(s.cfg.CredentialCheckInterval)
+	defer credentials.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case <-heartbeat.C:
+			s.evictIdle(ctx)
+			s.heartbeat(ctx)
+		case <-credentials.C:
+			s.checkCredentials(ctx)
+		}
+	}
+}
+
+// Stop stops the supervisor.
+func (s *SessionSupervisor) Stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// heartbeat pings every session and reconnects those that are down or stale.
+func (s *SessionSupervisor) heartbeat(ctx context.Context) {
+	for clientID, entry := range s.service.snapshotSessions() {
+		s.checkSession(ctx, clientID, entry)
+	}
+}
+
+func (s *SessionSupervisor) checkSession(ctx context.Context, clientID string, entry *sessionEntry) {
+	sess := entry.session
+	stale := time.Since(sess.LastMessageAt()) >= s.cfg.StaleAfter
+
+	var pingErr error
+	if sess.IsConnected() && !stale {
+		pingCtx, cancel := context.WithTimeout(ctx, s.cfg.HeartbeatInterval)
+		pingErr = sess.Ping(pingCtx)
+		cancel()
+		if pingErr != nil {
+			slog.Warn("AlphaPoint heartbeat failed", "clientID", clientID, "error", pingErr)
+		}
+	}
+
+	if !sess.IsConnected() || stale || pingErr != nil {
+		metrics.IncHeartbeatFailure(clientID)
+	}
+
+	// A recently dropped socket is left to the client's own reconnect loop;
+	// the supervisor only steps in once the session has been silent too long
+	// or the socket is up but unwritable.
+	if stale || pingErr != nil {
+		slog.Warn("AlphaPoint session unhealthy, reconnecting",
+			"clientID", clientID,
+			"connected", sess.IsConnected(),
+			"lastMessageAt", sess.LastMessageAt(),
+		)
+		reconnectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		err := sess.Reconnect(reconnectCtx)
+		cancel()
+		if err != nil {
+			slog.Error("AlphaPoint reconnect failed", "clientID", clientID, "error", err)
+		} else {
+			metrics.IncReconnect(clientID)
+		}
+	}
+
+	metrics.SetSessionState(clientID, sess.IsConnected(), sess.IsAuthenticated())
+}
+
+// evictIdle closes sessions that have not been used within IdleTimeout.
+func (s *SessionSupervisor) evictIdle(ctx context.Context) {
+	if s.cfg.IdleTimeout <= 0 {
+		return
+	}
+	for clientID, entry := range s.service.snapshotSessions() {
+		idle := time.Since(entry.lastUsedAt())
+		if idle < s.cfg.IdleTimeout {
+			continue
+		}
+		if s.service.evictSession(ctx, clientID, entry) {
+			metrics.IncEviction("idle")
+			slog.Info("AlphaPoint session evicted", "clientID", clientID, "reason", "idle", "idleFor", idle)
+		}
+	}
+}
+
+// checkCredentials re-reads every session's credentials and recreates the
+// session when they no longer match the ones it was created with.
+func (s *SessionSupervisor) checkCredentials(ctx context.Context) {
+	if s.refresher == nil {
+		return
+	}
+	for clientID, entry := range s.service.snapshotSessions() {
+		auth, err := s.refresher.Refresh(ctx, clientID)
+		if err != nil {
+			slog.Warn("Credential refresh failed", "clientID", clientID, "error", err)
+			continue
+		}
+		if sameCredentials(entry.auth, auth) {
+			continue
+		}
+
+		slog.Info("AlphaPoint credentials rotated, recreating session", "clientID", clientID)
+		if err := s.service.replaceSession(ctx, clientID, auth); err != nil {
+			slog.Error("Failed to recreate session after credential rotation", "clientID", clientID, "error", err)
+			continue
+		}
+		metrics.IncEviction("credentials_rotated")
+	}
+}
+
+// sameCredentials reports whether two credential sets would authenticate the same way.
+func sameCredentials(a, b *security.Auth) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.APIKey == b.APIKey &&
+		a.Signature == b.Signature &&
+		a.UserID == b.UserID &&
+		a.Nonce == b.Nonce &&
+		a.OmsID == b.OmsID &&
+		a.AccountID == b.AccountID
+}
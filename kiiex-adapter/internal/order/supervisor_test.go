@@ -0,0 +1,207 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/alphapoint"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/instruments"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/internal/security"
+	"github.com/Checker-Finance/adapters/kiiex-adapter/pkg/eventbus"
+)
+
+// fakeAlphaPoint is a minimal AlphaPoint gateway that answers AuthenticateUser, Ping,
+// SendOrder and CancelOrder. Orders for account 99 are rejected. While expired is
+// set, cancels are rejected as not authorized until the next login.
+type fakeAlphaPoint struct {
+	srv     *httptest.Server
+	logins  atomic.Int32
+	pings   atomic.Int32
+	cancels atomic.Int32
+	expired atomic.Bool
+}
+
+func newFakeAlphaPoint(t *testing.T) *fakeAlphaPoint {
+	t.Helper()
+	f := &fakeAlphaPoint{}
+	upgrader := websocket.Upgrader{}
+	f.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var req alphapoint.Request
+			if err := json.Unmarshal(data, &req); err != nil {
+				continue
+			}
+			var payload string
+			switch req.N {
+			case "AuthenticateUser":
+				f.logins.Add(1)
+				f.expired.Store(false)
+				payload = `{"Authenticated":true}`
+			case "Ping":
+				f.pings.Add(1)
+				payload = `{"msg":"PONG"}`
+			case "SendOrder":
+				var order alphapoint.SendOrderRequest
+				_ = json.Unmarshal([]byte(req.O), &order)
+				if order.AccountID == 99 {
+					payload = `{"status":"Rejected","errormsg":"Not_Enough_Funds","OrderId":0}`
+				} else {
+					payload = fmt.Sprintf(`{"status":"Accepted","errormsg":"","OrderId":%d}`, 5000+order.ClientOrderID)
+				}
+			case "CancelOrder":
+				f.cancels.Add(1)
+				if f.expired.Load() {
+					payload = `{"result":false,"errormsg":"Not Authorized","errorcode":20}`
+				} else {
+					payload = `{"result":true,"errormsg":null,"errorcode":0}`
+				}
+			default:
+				continue
+			}
+			resp, _ := json.Marshal(alphapoint.Response{M: 1, I: req.I, N: req.N, O: payload})
+			if err := conn.WriteMessage(websocket.TextMessage, resp); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+func (f *fakeAlphaPoint) url() string {
+	return "ws" + strings.TrimPrefix(f.srv.URL, "http")
+}
+
+// stubResolver returns the current credentials for every client.
+type stubResolver struct {
+	mu   sync.Mutex
+	auth security.Auth
+}
+
+func (r *stubResolver) Resolve(_ context.Context, _ string) (*security.Auth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a := r.auth
+	return &a, nil
+}
+
+func (r *stubResolver) Refresh(ctx context.Context, clientID string) (*security.Auth, error) {
+	return r.Resolve(ctx, clientID)
+}
+
+func (r *stubResolver) rotate(apiKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.auth.APIKey = apiKey
+}
+
+func newSupervisedService(t *testing.T) (*Service, *stubResolver, *fakeAlphaPoint) {
+	t.Helper()
+	fake := newFakeAlphaPoint(t)
+	resolver := &stubResolver{auth: security.Auth{APIKey: "key-1", UserID: 1, Nonce: "n", OmsID: 1, AccountID: 7}}
+	svc := NewService(resolver, instruments.NewMaster(), eventbus.New(), fake.url())
+	t.Cleanup(func() { _ = svc.Close() })
+	return svc, resolver, fake
+}
+
+func TestSupervisor_EvictsIdleSessions(t *testing.T) {
+	svc, resolver, _ := newSupervisedService(t)
+	ctx := context.Background()
+
+	entry, err := svc.getOrCreateSession(ctx, "client-a")
+	require.NoError(t, err)
+	entry.lastUsed.Store(time.Now().Add(-2 * time.Hour).UnixNano())
+
+	// Status polling alone does not keep a session alive.
+	require.NoError(t, svc.GetTradeStatus(ctx, TradeInfo{ClientID: "client-a", OrderID: 1}))
+
+	_, err = svc.getOrCreateSession(ctx, "client-b")
+	require.NoError(t, err)
+
+	sup := NewSessionSupervisor(svc, resolver, SupervisorConfig{IdleTimeout: time.Hour})
+	sup.evictIdle(ctx)
+
+	statuses := svc.SessionStatuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "client-b", statuses[0].ClientID)
+	assert.False(t, entry.session.IsConnected())
+}
+
+func TestSupervisor_RecreatesSessionOnCredentialRotation(t *testing.T) {
+	svc, resolver, fake := newSupervisedService(t)
+	ctx := context.Background()
+
+	old, err := svc.getOrCreateSession(ctx, "client-a")
+	require.NoError(t, err)
+
+	sup := NewSessionSupervisor(svc, resolver, SupervisorConfig{})
+
+	sup.checkCredentials(ctx)
+	same, err := svc.getOrCreateSession(ctx, "client-a")
+	require.NoError(t, err)
+	assert.Same(t, old, same, "unchanged credentials must keep the session")
+
+	resolver.rotate("key-2")
+	sup.checkCredentials(ctx)
+
+	fresh, err := svc.getOrCreateSession(ctx, "client-a")
+	require.NoError(t, err)
+	assert.NotSame(t, old, fresh)
+	assert.Equal(t, "key-2", fresh.auth.APIKey)
+	assert.False(t, old.session.IsConnected())
+	assert.Eventually(t, func() bool { return fake.logins.Load() >= 1 }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, fresh.session.IsAuthenticated, time.Second, 10*time.Millisecond)
+}
+
+func TestSupervisor_HeartbeatPingsHealthySession(t *testing.T) {
+	svc, resolver, fake := newSupervisedService(t)
+	ctx := context.Background()
+
+	entry, err := svc.getOrCreateSession(ctx, "client-a")
+	require.NoError(t, err)
+
+	sup := NewSessionSupervisor(svc, resolver, SupervisorConfig{HeartbeatInterval: time.Second})
+	sup.heartbeat(ctx)
+
+	assert.Eventually(t, func() bool { return fake.pings.Load() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(0), entry.session.Reconnects())
+}
+
+func TestSupervisor_HeartbeatReconnectsAndReauthenticatesStaleSession(t *testing.T) {
+	svc, resolver, fake := newSupervisedService(t)
+	ctx := context.Background()
+
+	entry, err := svc.getOrCreateSession(ctx, "client-a")
+	require.NoError(t, err)
+
+	// A zero StaleAfter is replaced by the default, so use a tiny positive value
+	// to make the freshly connected session count as silent.
+	sup := NewSessionSupervisor(svc, resolver, SupervisorConfig{HeartbeatInterval: time.Second, StaleAfter: time.Nanosecond})
+	sup.heartbeat(ctx)
+
+	assert.Equal(t, int64(1), entry.session.Reconnects())
+	assert.True(t, entry.session.IsConnected())
+	assert.Eventually(t, func() bool { return fake.logins.Load() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, entry.session.IsAuthenticated, time.Second, 10*time.Millisecond)
+}
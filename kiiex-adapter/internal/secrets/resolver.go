@@ -38,6 +38,13 @@ func (r *AWSResolver) Resolve(ctx context.Context, clientID string) (*security.A
 	return &cfg, nil
 }
 
+// Refresh re-reads the Auth for a client from AWS Secrets Manager, bypassing the cache,
+// so the session supervisor can detect rotated credentials.
+func (r *AWSResolver) Refresh(ctx context.Context, clientID string) (*security.Auth, error) {
+	r.inner.Invalidate(clientID)
+	return r.Resolve(ctx, clientID)
+}
+
 // DiscoverClients lists all client IDs that have Kiiex secrets configured in AWS Secrets Manager.
 func (r *AWSResolver) DiscoverClients(ctx context.Context) ([]string, error) {
 	return r.inner.DiscoverClients(ctx)
@@ -87,9 +87,10 @@ func (s *TradeStatusService) handleAttemptedCancel(event *order.AttemptedCancelE
 	slog.Info("Attempted cancel", "orderId", event.OrderID)
 
 	if event.OrderID != 0 {
-		if tradeID, ok := s.markFilled(event.OrderID); ok {
+		if tradeID, info, ok := s.markFilled(event.OrderID); ok {
 			s.eventBus.Publish(&order.OrderCanceledEvent{
-				OrderID: tradeID,
+				OrderID:  tradeID,
+				ClientID: info.ClientID,
 			})
 		}
 	}
@@ -114,7 +115,7 @@ func (s *TradeStatusService) handleFillArrived(event *order.FillArrivedEvent) {
 	}
 }
 
-func (s *TradeStatusService) markFilled(tradeID int) (string, bool) {
+func (s *TradeStatusService) markFilled(tradeID int) (string, order.TradeInfo, bool) {
 	slog.Info("MarkTradeFilled", "tradeId", tradeID)
 
 	s.mu.Lock()
@@ -123,11 +124,11 @@ func (s *TradeStatusService) markFilled(tradeID int) (string, bool) {
 	for key, tradeInfo := range s.tradeMap {
 		if tradeInfo.OrderID == tradeID {
 			delete(s.tradeMap, key)
-			return key, true
+			return key, tradeInfo, true
 		}
 	}
 
-	return "", false
+	return "", order.TradeInfo{}, false
 }
 
 // Start starts the polling goroutine
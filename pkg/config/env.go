@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,45 @@ func GetEnvInt(key string, def int) int {
 	return def
 }
 
+// GetEnvFloat returns the environment variable value for key parsed as float64, or def if unset or invalid.
+func GetEnvFloat(key string, def float64) float64 {
+	if val := os.Getenv(key); val != "" {
+		if f, err := strconv.ParseFloat(val, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
+// GetEnvBool returns the environment variable value for key parsed as bool, or def if unset or invalid.
+func GetEnvBool(key string, def bool) bool {
+	if val := os.Getenv(key); val != "" {
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return def
+}
+
+// GetEnvList returns the comma-separated environment variable value for key as a slice
+// of trimmed, non-empty items, or def if unset or empty.
+func GetEnvList(key string, def []string) []string {
+	val := os.Getenv(key)
+	if val == "" {
+		return def
+	}
+	var out []string
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	if len(out) == 0 {
+		return def
+	}
+	return out
+}
+
 // GetEnvDuration returns the environment variable value for key parsed as time.Duration, or def if unset or invalid.
 func GetEnvDuration(key string, def time.Duration) time.Duration {
 	if val := os.Getenv(key); val != "" {
@@ -61,6 +61,9 @@ type RFQRequest struct {
 	Amount         float64 `json:"amount"`        // requested notional or quantity
 	ProductID      int     `json:"product_id,omitempty"`
 	CurrencyAmount string  `json:"currency_amount,omitempty"` // optional: amount in source currency
+	Destination    string  `json:"destination,omitempty"`     // optional: venue settlement destination (e.g. wallet alias)
+	Country        string  `json:"country,omitempty"`         // optional: venue jurisdiction to quote in (e.g. "MX")
+	PaymentMethod  string  `json:"payment_method,omitempty"`  // optional: venue funding method (e.g. "wire")
 
 	// Context
 	Source      string    `json:"source,omitempty"`       // e.g. "SLACK", "WHATSAPP"
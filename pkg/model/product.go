@@ -4,12 +4,20 @@ import "time"
 
 // Product represents a normalized venue-specific product definition
 // stored in reference.venue_products and shared across the platform.
+//
+// Size limits and the increment are expressed in NotionalCurrency; zero
+// means the venue imposes no limit.
 type Product struct {
-	VenueCode        string    `json:"venue_code"`           // e.g. "RIO"
-	InstrumentSymbol string    `json:"instrument_symbol"`    // e.g. "USDC/BRL"
-	ProductID        string    `json:"product_id"`           // 190
-	SecondaryID      string    `json:"product_secondary_id"` // 50396
-	ProductName      string    `json:"product_name"`         // "CRYPTO D0/D0"
-	IsBlocked        bool      `json:"is_blocked"`           // true if blocked
-	AsOf             time.Time `json:"as_of"`                // last update
+	VenueCode        string    `json:"venue_code"`                  // e.g. "RIO"
+	InstrumentSymbol string    `json:"instrument_symbol"`           // e.g. "USDC/BRL"
+	ProductID        string    `json:"product_id"`                  // 190
+	SecondaryID      string    `json:"product_secondary_id"`        // 50396
+	ProductName      string    `json:"product_name"`                // "CRYPTO D0/D0"
+	IsBlocked        bool      `json:"is_blocked"`                  // true if blocked
+	MinSize          float64   `json:"min_size,omitempty"`          // e.g. 100000
+	MaxSize          float64   `json:"max_size,omitempty"`          // e.g. 5000000
+	SizeIncrement    float64   `json:"size_increment,omitempty"`    // e.g. 0.01
+	PricePrecision   int       `json:"price_precision,omitempty"`   // decimal places in quoted prices
+	NotionalCurrency string    `json:"notional_currency,omitempty"` // e.g. "USD"
+	AsOf             time.Time `json:"as_of"`                       // last update
 }
@@ -0,0 +1,149 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// RFQ validation error codes, returned to callers in RFQValidationError.Code.
+const (
+	RFQErrInvalidAmount        = "INVALID_AMOUNT"
+	RFQErrUnsupportedPair      = "UNSUPPORTED_PAIR"
+	RFQErrProductBlocked       = "PRODUCT_BLOCKED"
+	RFQErrBelowMinSize         = "BELOW_MIN_SIZE"
+	RFQErrAboveMaxSize         = "ABOVE_MAX_SIZE"
+	RFQErrInvalidIncrement     = "INVALID_SIZE_INCREMENT"
+	RFQErrUnsupportedCountry   = "UNSUPPORTED_COUNTRY"
+	RFQErrInvalidPaymentMethod = "INVALID_PAYMENT_METHOD"
+)
+
+// RFQValidationError reports an RFQ rejected before it reached the venue.
+type RFQValidationError struct {
+	Code    string
+	Message string
+}
+
+func (e *RFQValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// AsRFQValidationError returns the RFQValidationError wrapped in err, if any.
+func AsRFQValidationError(err error) (*RFQValidationError, bool) {
+	var verr *RFQValidationError
+	if errors.As(err, &verr) {
+		return verr, true
+	}
+	return nil, false
+}
+
+// compactPair strips separators so "USD/MXN", "usd:mxn", "USD_MXN",
+// "USD.MXN" and "USDMXN" compare equal.
+func compactPair(pair string) string {
+	return strings.ToUpper(strings.NewReplacer("/", "", ":", "", "_", "", ".", "", "-", "").Replace(pair))
+}
+
+// pairBase returns the base currency of a separated pair, or "" if the pair
+// has no separator.
+func pairBase(pair string) string {
+	if i := strings.IndexAny(pair, "/:_.-"); i > 0 {
+		return strings.ToUpper(pair[:i])
+	}
+	return ""
+}
+
+// FindProduct returns the product for pair, ignoring separators and case.
+// When a venue lists several products for a pair, an unblocked one wins.
+func FindProduct(products []Product, pair string) (Product, bool) {
+	want := compactPair(pair)
+	var found *Product
+	for i := range products {
+		if compactPair(products[i].InstrumentSymbol) != want {
+			continue
+		}
+		if !products[i].IsBlocked {
+			return products[i], true
+		}
+		if found == nil {
+			found = &products[i]
+		}
+	}
+	if found == nil {
+		return Product{}, false
+	}
+	return *found, true
+}
+
+// ValidateAmount checks amount against the product's size limits. amountCurrency
+// is the currency the amount is denominated in; limits are only enforced when
+// it matches NotionalCurrency (or the product has none), since converting
+// between legs would need a price.
+func (p Product) ValidateAmount(amount float64, amountCurrency string) error {
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return &RFQValidationError{Code: RFQErrInvalidAmount, Message: fmt.Sprintf("amount %v must be positive", amount)}
+	}
+	if p.NotionalCurrency != "" && !strings.EqualFold(p.NotionalCurrency, amountCurrency) {
+		return nil
+	}
+
+	unit := p.NotionalCurrency
+	if unit == "" {
+		unit = strings.ToUpper(amountCurrency)
+	}
+	if p.MinSize > 0 && amount < p.MinSize {
+		return &RFQValidationError{
+			Code:    RFQErrBelowMinSize,
+			Message: fmt.Sprintf("%s amount %v is below the minimum of %v %s", p.InstrumentSymbol, amount, p.MinSize, unit),
+		}
+	}
+	if p.MaxSize > 0 && amount > p.MaxSize {
+		return &RFQValidationError{
+			Code:    RFQErrAboveMaxSize,
+			Message: fmt.Sprintf("%s amount %v exceeds the maximum of %v %s", p.InstrumentSymbol, amount, p.MaxSize, unit),
+		}
+	}
+	if p.SizeIncrement > 0 {
+		steps := amount / p.SizeIncrement
+		if math.Abs(steps-math.Round(steps)) > 1e-6 {
+			return &RFQValidationError{
+				Code:    RFQErrInvalidIncrement,
+				Message: fmt.Sprintf("%s amount %v is not a multiple of %v %s", p.InstrumentSymbol, amount, p.SizeIncrement, unit),
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateRFQ checks an RFQ against a venue's product list: the pair must be
+// listed and unblocked and the amount within the product's limits. The amount
+// is taken to be in req.CurrencyAmount, or the pair's base currency if unset.
+// An empty product list means the catalogue is unknown; only the amount's
+// sign is checked.
+func ValidateRFQ(products []Product, req RFQRequest) error {
+	amountCurrency := req.CurrencyAmount
+	if amountCurrency == "" {
+		amountCurrency = pairBase(req.CurrencyPair)
+	}
+	if len(products) == 0 {
+		return Product{}.ValidateAmount(req.Amount, amountCurrency)
+	}
+
+	p, ok := FindProduct(products, req.CurrencyPair)
+	if !ok {
+		return &RFQValidationError{
+			Code:    RFQErrUnsupportedPair,
+			Message: fmt.Sprintf("pair %q is not supported", req.CurrencyPair),
+		}
+	}
+	if p.IsBlocked {
+		return &RFQValidationError{
+			Code:    RFQErrProductBlocked,
+			Message: fmt.Sprintf("pair %q is currently blocked", req.CurrencyPair),
+		}
+	}
+	if amountCurrency == "" {
+		amountCurrency = pairBase(p.InstrumentSymbol)
+	}
+	return p.ValidateAmount(req.Amount, amountCurrency)
+}
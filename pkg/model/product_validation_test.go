@@ -0,0 +1,128 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestProduct_ValidateAmount(t *testing.T) {
+	usd := Product{
+		InstrumentSymbol: "USD/MXN",
+		MinSize:          1000,
+		MaxSize:          5_000_000,
+		SizeIncrement:    0.01,
+		NotionalCurrency: "USD",
+	}
+	tests := []struct {
+		name     string
+		product  Product
+		amount   float64
+		currency string
+		wantCode string // "" means valid
+	}{
+		{"within limits", usd, 2500.5, "USD", ""},
+		{"at minimum", usd, 1000, "USD", ""},
+		{"at maximum", usd, 5_000_000, "USD", ""},
+		{"below minimum", usd, 999.99, "USD", RFQErrBelowMinSize},
+		{"above maximum", usd, 5_000_000.01, "USD", RFQErrAboveMaxSize},
+		{"off increment", usd, 1000.005, "USD", RFQErrInvalidIncrement},
+		{"currency case ignored", usd, 999, "usd", RFQErrBelowMinSize},
+		{"other leg not checked", usd, 1, "MXN", ""},
+		{"zero", usd, 0, "USD", RFQErrInvalidAmount},
+		{"negative", usd, -5, "USD", RFQErrInvalidAmount},
+		{"NaN", usd, math.NaN(), "USD", RFQErrInvalidAmount},
+		{"infinite", usd, math.Inf(1), "USD", RFQErrInvalidAmount},
+		{"invalid even in other leg", usd, 0, "MXN", RFQErrInvalidAmount},
+		{"no limits", Product{InstrumentSymbol: "BTC/USD"}, 0.00000001, "BTC", ""},
+		{"limits without notional apply to any leg", Product{MinSize: 10}, 5, "BTC", RFQErrBelowMinSize},
+
+		// Increments are compared with a tolerance, so binary rounding of
+		// decimal amounts does not reject valid sizes.
+		{"decimal step 0.1", Product{SizeIncrement: 0.1}, 0.3, "BTC", ""},
+		{"decimal step 0.01", Product{SizeIncrement: 0.01}, 1000.07, "USD", ""},
+		{"fine step", Product{SizeIncrement: 0.00000001}, 0.12345678, "BTC", ""},
+		{"fine step off", Product{SizeIncrement: 0.0001}, 0.12345, "BTC", RFQErrInvalidIncrement},
+		{"large amount on cent step", Product{SizeIncrement: 0.01}, 123_456_789.01, "USD", ""},
+		{"whole step", Product{SizeIncrement: 1000}, 25_000, "USD", ""},
+		{"whole step off", Product{SizeIncrement: 1000}, 25_500, "USD", RFQErrInvalidIncrement},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.product.ValidateAmount(tt.amount, tt.currency)
+			assertRFQCode(t, err, tt.wantCode)
+		})
+	}
+}
+
+func TestValidateRFQ(t *testing.T) {
+	products := []Product{
+		{InstrumentSymbol: "USDC/BRL", MinSize: 100, NotionalCurrency: "USDC"},
+		{InstrumentSymbol: "BTC/USD", IsBlocked: true},
+		{InstrumentSymbol: "ETH/USD", IsBlocked: true, ProductID: "blocked"},
+		{InstrumentSymbol: "ETH/USD", MaxSize: 50, NotionalCurrency: "ETH", ProductID: "open"},
+		{InstrumentSymbol: "USDMXN", SizeIncrement: 1},
+	}
+	tests := []struct {
+		name     string
+		products []Product
+		req      RFQRequest
+		wantCode string
+	}{
+		{"listed pair", products, RFQRequest{CurrencyPair: "USDC/BRL", Amount: 500}, ""},
+		{"separators and case ignored", products, RFQRequest{CurrencyPair: "usdc:brl", Amount: 500}, ""},
+		{"underscore separator", products, RFQRequest{CurrencyPair: "USDC_BRL", Amount: 500}, ""},
+		{"unlisted pair", products, RFQRequest{CurrencyPair: "EUR/BRL", Amount: 500}, RFQErrUnsupportedPair},
+		{"blocked pair", products, RFQRequest{CurrencyPair: "BTC/USD", Amount: 1}, RFQErrProductBlocked},
+		{"unblocked duplicate wins", products, RFQRequest{CurrencyPair: "ETH/USD", Amount: 10}, ""},
+		{"unblocked duplicate limits apply", products, RFQRequest{CurrencyPair: "ETH/USD", Amount: 51}, RFQErrAboveMaxSize},
+		{"amount defaults to base currency", products, RFQRequest{CurrencyPair: "USDC/BRL", Amount: 50}, RFQErrBelowMinSize},
+		{"amount in quote currency skips limits", products, RFQRequest{CurrencyPair: "USDC/BRL", Amount: 50, CurrencyAmount: "BRL"}, ""},
+		{"unseparated request pair uses product base", products, RFQRequest{CurrencyPair: "USDCBRL", Amount: 50}, RFQErrBelowMinSize},
+		{"unseparated product", products, RFQRequest{CurrencyPair: "USD/MXN", Amount: 10.5}, RFQErrInvalidIncrement},
+		{"invalid amount", products, RFQRequest{CurrencyPair: "USDC/BRL", Amount: -1}, RFQErrInvalidAmount},
+		{"unknown catalogue allows any pair", nil, RFQRequest{CurrencyPair: "EUR/BRL", Amount: 1}, ""},
+		{"unknown catalogue still checks amount", nil, RFQRequest{CurrencyPair: "EUR/BRL", Amount: 0}, RFQErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRFQCode(t, ValidateRFQ(tt.products, tt.req), tt.wantCode)
+		})
+	}
+}
+
+func TestAsRFQValidationError(t *testing.T) {
+	verr := &RFQValidationError{Code: RFQErrBelowMinSize, Message: "too small"}
+	if verr.Error() != "BELOW_MIN_SIZE: too small" {
+		t.Errorf("unexpected message %q", verr.Error())
+	}
+
+	got, ok := AsRFQValidationError(fmt.Errorf("create rfq: %w", verr))
+	if !ok || got != verr {
+		t.Errorf("expected the wrapped validation error, got %v, %v", got, ok)
+	}
+	if _, ok := AsRFQValidationError(errors.New("venue down")); ok {
+		t.Error("expected a plain error not to match")
+	}
+	if _, ok := AsRFQValidationError(nil); ok {
+		t.Error("expected nil not to match")
+	}
+}
+
+func assertRFQCode(t *testing.T, err error, want string) {
+	t.Helper()
+	if want == "" {
+		if err != nil {
+			t.Fatalf("expected valid, got %v", err)
+		}
+		return
+	}
+	verr, ok := AsRFQValidationError(err)
+	if !ok {
+		t.Fatalf("expected %s, got %v", want, err)
+	}
+	if verr.Code != want {
+		t.Errorf("expected code %s, got %s (%s)", want, verr.Code, verr.Message)
+	}
+}
@@ -18,6 +18,7 @@ const (
 	SettlementTypeFORWARD SettlementType = "FORWARD" // Any settlement beyond spot
 	SettlementTypeNDF     SettlementType = "NDF"     // Non-Deliverable Forward
 	SettlementTypeSWAP    SettlementType = "SWAP"    // FX swap or related leg
+	SettlementTypeCFD     SettlementType = "CFD"     // Contract for difference, cash-settled, no value date
 )
 
 // Valid returns true if the settlement type is one of the known constants.
@@ -28,7 +29,8 @@ func (t SettlementType) Valid() bool {
 		SettlementTypeSPOT,
 		SettlementTypeFORWARD,
 		SettlementTypeNDF,
-		SettlementTypeSWAP:
+		SettlementTypeSWAP,
+		SettlementTypeCFD:
 		return true
 	default:
 		return false
@@ -44,26 +46,23 @@ func (t *SettlementType) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &s); err != nil {
 		return err
 	}
-	s = strings.ToUpper(strings.TrimSpace(s))
-	switch s {
-	case "TOD":
-		*t = SettlementTypeTOD
-	case "TOM":
-		*t = SettlementTypeTOM
-	case "SPOT":
-		*t = SettlementTypeSPOT
-	case "FORWARD":
-		*t = SettlementTypeFORWARD
-	case "NDF":
-		*t = SettlementTypeNDF
-	case "SWAP":
-		*t = SettlementTypeSWAP
-	default:
-		return fmt.Errorf("invalid settlement type: %s", s)
+	parsed, err := ParseSettlementType(s)
+	if err != nil {
+		return err
 	}
+	*t = parsed
 	return nil
 }
 
+// ParseSettlementType normalizes s (case-insensitive) to a known SettlementType.
+func ParseSettlementType(s string) (SettlementType, error) {
+	t := SettlementType(strings.ToUpper(strings.TrimSpace(s)))
+	if !t.Valid() {
+		return "", fmt.Errorf("invalid settlement type: %s", t)
+	}
+	return t, nil
+}
+
 func (t SettlementType) String() string {
 	return string(t)
 }
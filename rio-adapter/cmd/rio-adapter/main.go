@@ -9,10 +9,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/rate"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/logger"
 	"github.com/Checker-Finance/adapters/pkg/secrets"
 	"github.com/Checker-Finance/adapters/pkg/utils"
@@ -34,6 +37,13 @@ func main() {
 	cfg.Venue = "rio"
 
 	logger.Init(cfg.ServiceName, cfg.Env, cfg.LogLevel)
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.ServiceName)
+	if err != nil {
+		slog.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("starting [rio-adapter]...")
 	slog.Info("connection to DSN", "dsn", utils.MaskDSN(cfg.DatabaseURL))
 
@@ -71,18 +81,36 @@ func main() {
 	}
 
 	// --- Publisher ---
-	pub, err := publisher.New(nc, "evt.rio", "RIO_EVENTS")
+	pub, err := publisher.NewWithOptions(nc, "evt.rio", "RIO_EVENTS", publisher.Options{
+		Async:      cfg.NATSPublishAsync,
+		MaxPending: cfg.NATSPublishMaxPending,
+	})
 	if err != nil {
 		slog.Error("failed to init publisher", "error", err)
 		os.Exit(1)
 	}
+	if err := pub.EnsureStreams(publisher.StreamSpec{
+		Name:       cfg.NATSStream,
+		Subjects:   []string{"evt.trade.*.v1.RIO"},
+		Duplicates: cfg.NATSDedupWindow,
+	}); err != nil {
+		slog.Error("failed to verify jetstream streams", "error", err)
+		os.Exit(1)
+	}
 
-	// --- Rate limiter ---
-	rateMgr := rate.NewManager(rate.Config{
-		RequestsPerSecond: 10, // Rio may have different rate limits
-		Burst:             20,
-		Cooldown:          1 * time.Second,
-	})
+	// --- Rate limiter: per API key, under a shared ceiling per Rio base URL ---
+	rateMgr := rate.NewHierarchicalManager(
+		rate.Config{
+			RequestsPerSecond: 5,
+			Burst:             10,
+			Cooldown:          1 * time.Second,
+		},
+		rate.Config{
+			RequestsPerSecond: 10, // Rio may have different rate limits
+			Burst:             20,
+			Cooldown:          1 * time.Second,
+		},
+	)
 
 	// --- Store (Redis + Postgres hybrid) ---
 	st, err := store.NewHybrid(cfg.RedisURL, cfg.DatabaseURL, store.PGPoolConfig{
@@ -100,6 +128,13 @@ func main() {
 	// --- Legacy trade sync writer ---
 	tradeSyncWriter := legacy.NewTradeSyncWriter(st.(*store.HybridStore).PG, "rio-adapter")
 
+	// --- Outbox relay: publishes terminal trade events queued with their t_order rows ---
+	outboxRelay := outbox.NewRelay(outbox.NewPGStore(st.(*store.HybridStore).PG, "rio-adapter"), pub, outbox.RelayConfig{})
+	go outboxRelay.Start(ctx)
+
+	// --- Audit trail: append-only record of each RFQ from request to final status ---
+	auditStore := audit.NewPGStore(st.(*store.HybridStore).PG)
+
 	// --- Rio HTTP Client (config supplied per-request) ---
 	rioClient := rio.NewClient(
 		rateMgr,
@@ -127,6 +162,9 @@ func main() {
 		tradeSyncWriter,
 	)
 	rioSvc.SetPoller(poller)
+	go rioSvc.StartLifecycleSweep(ctx)
+	auditRecorder := audit.NewRecorder(auditStore, "rio")
+	rioSvc.SetAuditRecorder(auditRecorder)
 
 	// --- Rio Webhook Handler ---
 	webhookHandler := rio.NewWebhookHandler(
@@ -145,10 +183,12 @@ func main() {
 		IdleTimeout:  cfg.HTTPIdleTimeout,
 		BodyLimit:    cfg.HTTPBodyLimit,
 	})
+	app.Use(tracing.FiberMiddleware())
 
 	// Rio API Handler (with client validation via config resolver)
 	clientValidator := api.NewResolverValidator(resolver)
 	rioHandler := api.NewRioHandler(rioSvc, clientValidator)
+	orderHandler := api.NewOrderHandler(rioSvc, clientValidator)
 
 	// Order resolve handler
 	orderResolveHandler := &api.OrderResolveHandler{
@@ -159,7 +199,10 @@ func main() {
 
 	productsHandler := api.NewProductsHandler(st, cfg.Venue)
 	balanceHandler := api.NewBalanceHandler(st)
-	api.RegisterRoutes(app, nc, st, rioHandler, orderResolveHandler, webhookHandler, productsHandler, balanceHandler)
+	webhookRegistrar := rio.NewWebhookRegistrar(rioClient, resolver, cfg.RioWebhookSyncInterval)
+	webhooksHandler := api.NewWebhooksHandler(webhookRegistrar)
+	auditHandler := audit.NewHandler(auditStore, "rio")
+	api.RegisterRoutes(app, nc, st, rioHandler, orderHandler, orderResolveHandler, webhookHandler, productsHandler, balanceHandler, webhooksHandler, auditHandler)
 
 	// Start HTTP server
 	serverReady := make(chan struct{})
@@ -172,12 +215,10 @@ func main() {
 		}
 	}()
 
-	// --- Register webhooks with Rio for each configured client ---
+	// --- Keep Rio webhook registrations in line with client configs ---
 	go func() {
 		<-serverReady // wait for HTTP server to start
-		if err := rioSvc.RegisterOrderWebhook(ctx); err != nil {
-			slog.Warn("failed to register webhooks with Rio", "error", err)
-		}
+		webhookRegistrar.Start(ctx)
 	}()
 
 	// --- Main process stays alive until interrupted ---
@@ -197,10 +238,19 @@ func main() {
 	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
 		slog.Warn("fiber.shutdown_failed", "error", err)
 	}
+	if err := pub.Flush(shutdownCtx); err != nil {
+		slog.Warn("nats.flush_incomplete", "error", err)
+	}
 	if err := nc.Drain(); err != nil {
 		slog.Warn("nats.drain_failed", "error", err)
 	}
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		slog.Warn("audit.flush_incomplete", "error", err)
+	}
 	if err := st.Close(); err != nil {
 		slog.Warn("store.close_failed", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("tracing.shutdown_failed", "error", err)
+	}
 }
@@ -27,7 +27,7 @@ func (h *BalanceHandler) GetBalances(c *fiber.Ctx) error {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "missing client_id"})
 	}
 
-	ctx, cancel := context.WithTimeout(c.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 3*time.Second)
 	defer cancel()
 
 	balances, err := h.store.GetClientBalances(ctx, clientID)
@@ -45,21 +45,26 @@ func (h *RioHandler) CreateRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
 	r := toRFQRequest(req)
 
-	quote, err := h.service.CreateRFQ(c.Context(), r)
+	quote, err := h.service.CreateRFQ(c.UserContext(), r)
 	if err != nil {
 		slog.Error("rio.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(RFQResponse{
+		resp := RFQResponse{
 			QuoteID:  req.ID,
 			ErrorMsg: err.Error(),
-		})
+		}
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			resp.ErrorCode = verr.Code
+			resp.ErrorMsg = verr.Message
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(resp)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(RFQResponse{
@@ -80,7 +85,7 @@ func (h *RioHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
@@ -93,7 +98,7 @@ func (h *RioHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 		"client", req.ClientID,
 		"quote_id", quoteID)
 
-	trade, err := h.service.ExecuteRFQ(c.Context(), req.ClientID, quoteID)
+	trade, err := h.service.ExecuteRFQ(c.UserContext(), req.ClientID, quoteID)
 	if err != nil {
 		slog.Error("rio.execute_rfq.failed",
 			"client", req.ClientID,
@@ -122,5 +127,7 @@ func toRFQRequest(req RFQCreateRequest) model.RFQRequest {
 		CurrencyPair:   req.CurrencyPair,
 		Amount:         req.Amount,
 		CurrencyAmount: req.AmountDenomination,
+		Country:        req.Country,
+		PaymentMethod:  req.BankTransferMethod,
 	}
 }
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Checker-Finance/adapters/rio-adapter/internal/rio"
+)
+
+// OrderLifecycleService is satisfied by rio.Service.
+type OrderLifecycleService interface {
+	GetOrderLifecycle(ctx context.Context, clientID, orderID string) (*rio.OrderLifecycle, error)
+}
+
+// OrderHandler serves the settlement lifecycle of Rio orders.
+type OrderHandler struct {
+	service   OrderLifecycleService
+	validator ClientValidator
+}
+
+// NewOrderHandler creates a new OrderHandler.
+// validator is optional — if nil, client validation is skipped.
+func NewOrderHandler(service OrderLifecycleService, validator ClientValidator) *OrderHandler {
+	return &OrderHandler{
+		service:   service,
+		validator: validator,
+	}
+}
+
+// GetOrder handles GET /api/v1/orders/:id?clientId=...
+func (h *OrderHandler) GetOrder(c *fiber.Ctx) error {
+	orderID := c.Params("id")
+	clientID := c.Query("clientId")
+	if clientID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "clientId is required"})
+	}
+
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), clientID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
+	}
+
+	lc, err := h.service.GetOrderLifecycle(c.UserContext(), clientID, orderID)
+	if errors.Is(err, rio.ErrOrderNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "order not found"})
+	}
+	if err != nil {
+		slog.Error("rio.get_order.failed",
+			"client", clientID,
+			"order_id", orderID,
+			"error", err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(lc)
+}
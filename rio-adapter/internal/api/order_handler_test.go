@@ -0,0 +1,77 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/rio-adapter/internal/rio"
+)
+
+type mockLifecycleService struct {
+	lifecycles map[string]*rio.OrderLifecycle
+}
+
+func (m *mockLifecycleService) GetOrderLifecycle(_ context.Context, clientID, orderID string) (*rio.OrderLifecycle, error) {
+	lc, ok := m.lifecycles[orderID]
+	if !ok || lc.ClientID != clientID {
+		return nil, rio.ErrOrderNotFound
+	}
+	return lc, nil
+}
+
+func newOrderTestApp(svc OrderLifecycleService, validator ClientValidator) *fiber.App {
+	app := fiber.New()
+	handler := NewOrderHandler(svc, validator)
+	app.Get("/api/v1/orders/:id", handler.GetOrder)
+	return app
+}
+
+func TestOrderHandler_GetOrder(t *testing.T) {
+	svc := &mockLifecycleService{lifecycles: map[string]*rio.OrderLifecycle{
+		"ord-001": {
+			OrderID:  "ord-001",
+			ClientID: "client-001",
+			Stage:    rio.StageAwaitingFunding,
+			PaymentInstructions: &rio.RioPaymentInstructions{
+				CLABE:     "646180157000000004",
+				Reference: "RIO-XYZ",
+			},
+		},
+	}}
+	validator := &mockValidator{known: map[string]bool{"client-001": true, "client-002": true}}
+	app := newOrderTestApp(svc, validator)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{"found", "/api/v1/orders/ord-001?clientId=client-001", http.StatusOK},
+		{"missing clientId", "/api/v1/orders/ord-001", http.StatusBadRequest},
+		{"unknown client", "/api/v1/orders/ord-001?clientId=client-999", http.StatusForbidden},
+		{"other client's order", "/api/v1/orders/ord-001?clientId=client-002", http.StatusNotFound},
+		{"unknown order", "/api/v1/orders/ord-404?clientId=client-001", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, resp.StatusCode)
+
+			if tt.status == http.StatusOK {
+				var body map[string]any
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				assert.Equal(t, "awaiting_funding", body["stage"])
+				instructions := body["payment_instructions"].(map[string]any)
+				assert.Equal(t, "RIO-XYZ", instructions["reference"])
+			}
+		})
+	}
+}
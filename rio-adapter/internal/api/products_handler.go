@@ -23,7 +23,7 @@ func NewProductsHandler(st store.Store, venue string) *ProductsHandler {
 
 // ListProducts returns the products catalog for the configured venue.
 func (h *ProductsHandler) ListProducts(c *fiber.Ctx) error {
-	ctx, cancel := context.WithTimeout(c.Context(), 3*time.Second)
+	ctx, cancel := context.WithTimeout(c.UserContext(), 3*time.Second)
 	defer cancel()
 
 	products, err := h.store.ListProducts(ctx, h.venue)
@@ -8,6 +8,8 @@ type RFQCreateRequest struct {
 	AmountDenomination string  `json:"amountDenomination" example:"BRL"`
 	Side               string  `json:"orderSide" example:"buy"`
 	Amount             float64 `json:"quantity" example:"1000.00"`
+	Country            string  `json:"country,omitempty" example:"MX"`
+	BankTransferMethod string  `json:"usBankTransferMethod,omitempty" example:"wire"`
 }
 
 // ResolveQuoteID returns the quote ID from QuoteID, falling back to ProviderQuoteID.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/store"
 	"github.com/Checker-Finance/adapters/rio-adapter/internal/rio"
 	"github.com/gofiber/fiber/v2"
@@ -14,10 +15,13 @@ import (
 
 func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store,
 	rioHandler *RioHandler,
+	orderHandler *OrderHandler,
 	orderResolveHandler *OrderResolveHandler,
 	webhookHandler *rio.WebhookHandler,
 	productsHandler *ProductsHandler,
 	balanceHandler *BalanceHandler,
+	webhooksHandler *WebhooksHandler,
+	auditHandler *audit.Handler,
 ) {
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
@@ -60,7 +64,10 @@ func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store,
 	v1.Get("/balances/:client_id", balanceHandler.GetBalances)
 	v1.Post("/quotes", rioHandler.CreateRFQHandler)
 	v1.Post("/orders", rioHandler.ExecuteRFQHandler)
+	v1.Get("/orders/:id", orderHandler.GetOrder)
 	v1.Post("/resolve-order/:quoteId", orderResolveHandler.ResolveOrder)
+	v1.Get("/admin/webhooks", webhooksHandler.ListWebhooks)
+	v1.Get("/audit/:id", auditHandler.Timeline)
 
 	// Webhook route
 	app.Post("/webhooks/rio/orders", webhookHandler.HandleOrderWebhook)
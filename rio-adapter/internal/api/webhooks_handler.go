@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/Checker-Finance/adapters/rio-adapter/internal/rio"
+)
+
+// WebhookStateSource is satisfied by rio.WebhookRegistrar.
+type WebhookStateSource interface {
+	States() []rio.WebhookState
+}
+
+// WebhooksHandler reports the webhook registration state of each client.
+type WebhooksHandler struct {
+	source WebhookStateSource
+}
+
+// NewWebhooksHandler creates a new WebhooksHandler.
+func NewWebhooksHandler(source WebhookStateSource) *WebhooksHandler {
+	return &WebhooksHandler{source: source}
+}
+
+// ListWebhooks handles GET /api/v1/admin/webhooks.
+func (h *WebhooksHandler) ListWebhooks(c *fiber.Ctx) error {
+	states := h.source.States()
+	return c.JSON(fiber.Map{
+		"count":   len(states),
+		"clients": states,
+	})
+}
@@ -70,6 +70,7 @@ func (c *Client) CreateOrder(ctx context.Context, cfg *RioClientConfig, req *Rio
 // GetOrder retrieves an order by ID.
 // GET /api/orders/{id}
 func (c *Client) GetOrder(ctx context.Context, cfg *RioClientConfig, orderID string) (*RioOrderResponse, error) {
+	ctx = httpclient.WithEndpoint(ctx, "/api/orders/{id}")
 	var resp RioOrderResponse
 	if err := c.getJSON(ctx, cfg, "/api/orders/"+orderID, &resp); err != nil {
 		return nil, err
@@ -92,6 +93,30 @@ func (c *Client) RegisterWebhook(ctx context.Context, cfg *RioClientConfig, call
 	return &resp, nil
 }
 
+// ListWebhooks lists the webhooks registered for the API key.
+// GET /api/webhooks
+func (c *Client) ListWebhooks(ctx context.Context, cfg *RioClientConfig) ([]RioWebhookRegistrationResponse, error) {
+	var resp []RioWebhookRegistrationResponse
+	if err := c.getJSON(ctx, cfg, "/api/webhooks", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DeleteWebhook removes a webhook registration.
+// DELETE /api/webhooks/{id}
+func (c *Client) DeleteWebhook(ctx context.Context, cfg *RioClientConfig, webhookID string) error {
+	url := fmt.Sprintf("%s/api/webhooks/%s", cfg.BaseURL, webhookID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	setHeaders(req, cfg.APIKey)
+
+	ctx = httpclient.WithEndpoint(ctx, "/api/webhooks/{id}")
+	return c.exec.DoJSON(ctx, req, cfg.rateLimitKey(), nil)
+}
+
 // getJSON performs an authenticated GET request and decodes the JSON response.
 func (c *Client) getJSON(ctx context.Context, cfg *RioClientConfig, path string, out any) error {
 	url := fmt.Sprintf("%s%s", cfg.BaseURL, path)
@@ -0,0 +1,425 @@
+package rio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+// subjectSettlementProgress carries every lifecycle stage change of a Rio order.
+const subjectSettlementProgress = "evt.trade.settlement_progress.v1.RIO"
+
+// lifecycleTTL is how long an order's lifecycle stays queryable after its
+// last update.
+const lifecycleTTL = 30 * 24 * time.Hour
+
+// lifecycleSweepInterval is how often in-memory lifecycles older than
+// lifecycleTTL are dropped.
+const lifecycleSweepInterval = time.Hour
+
+// ErrOrderNotFound is returned when an order is unknown or belongs to
+// another client.
+var ErrOrderNotFound = errors.New("rio: order not found")
+
+// SettlementStage is where the money of a Rio order is. It is finer-grained
+// than the canonical trade status, which only says whether the order is done.
+type SettlementStage string
+
+const (
+	StageAwaitingFunding SettlementStage = "awaiting_funding" // Waiting for the payer's transfer
+	StageFunded          SettlementStage = "funded"           // Rio received the payer's funds
+	StageProcessing      SettlementStage = "processing"       // Sourcing liquidity, compliance, transfer in flight
+	StageCryptoSent      SettlementStage = "crypto_sent"      // Onramp: crypto sent on-chain (see TxHash)
+	StageFiatPaid        SettlementStage = "fiat_paid"        // Offramp: fiat payout sent (see PayoutReference)
+	StageCompleted       SettlementStage = "completed"
+	StageCancelled       SettlementStage = "cancelled"
+	StageFailed          SettlementStage = "failed"
+	StageRefunding       SettlementStage = "refunding"
+	StageRefunded        SettlementStage = "refunded"
+)
+
+// SettlementStageFor maps a raw Rio order status to its settlement stage.
+func SettlementStageFor(status string) SettlementStage {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "created",
+		"awaiting_payment",
+		"awaitingpayment",
+		"payment_pending",
+		"paymentpending",
+		"awaiting_transfer",
+		"awaitingtransfer",
+		"transfer_pending",
+		"transferpending":
+		return StageAwaitingFunding
+	case "funded":
+		return StageFunded
+	case "crypto_sent", "cryptosent":
+		return StageCryptoSent
+	case "fiat_paid", "fiatpaid":
+		return StageFiatPaid
+	}
+
+	switch NormalizeRioStatus(status) {
+	case "filled":
+		return StageCompleted
+	case "cancelled":
+		return StageCancelled
+	case "rejected":
+		return StageFailed
+	case "refunding":
+		return StageRefunding
+	case "refunded":
+		return StageRefunded
+	default:
+		return StageProcessing
+	}
+}
+
+// OrderLifecycle is the settlement picture of a Rio order: where the payer
+// must send funds, how far the order has got, and the on-chain and bank
+// references of the payout.
+type OrderLifecycle struct {
+	OrderID             string                  `json:"order_id"`
+	QuoteID             string                  `json:"quote_id"`
+	ClientID            string                  `json:"client_id"`
+	Type                string                  `json:"type"` // onramp, offramp
+	Side                string                  `json:"side"`
+	Instrument          string                  `json:"instrument"`
+	AmountFiat          float64                 `json:"amount_fiat"`
+	AmountCrypto        float64                 `json:"amount_crypto"`
+	Status              string                  `json:"status"` // Canonical status
+	RawStatus           string                  `json:"raw_status"`
+	Stage               SettlementStage         `json:"stage"`
+	PaymentInstructions *RioPaymentInstructions `json:"payment_instructions,omitempty"`
+	TxHash              string                  `json:"tx_hash,omitempty"`
+	PayoutReference     string                  `json:"payout_reference,omitempty"`
+	Fees                RioFees                 `json:"fees"`
+	History             []StageTransition       `json:"history"`
+	VenueUpdatedAt      time.Time               `json:"venue_updated_at,omitempty"` // Rio's updatedAt of the last applied snapshot
+	UpdatedAt           time.Time               `json:"updated_at"`
+}
+
+// StageTransition records when an order entered a stage and who reported it.
+type StageTransition struct {
+	Stage     SettlementStage `json:"stage"`
+	RawStatus string          `json:"raw_status"`
+	Source    string          `json:"source"` // execute, poller, webhook, api
+	At        time.Time       `json:"at"`
+}
+
+// Final reports whether the order has reached a terminal stage.
+func (l *OrderLifecycle) Final() bool {
+	return model.IsTerminal(l.Status)
+}
+
+// apply merges a Rio order snapshot into the lifecycle and reports whether
+// anything worth announcing changed: the stage, the tx hash or the payout
+// reference. Fields Rio omits from a snapshot keep their previous value.
+//
+// Snapshots that arrive out of order are not applied and accepted is false:
+// one whose updatedAt is older than the last applied snapshot's, or one that
+// would move a final order anywhere but into a refund.
+func (l *OrderLifecycle) apply(order *RioOrderResponse, source string, now time.Time) (changed, accepted bool) {
+	venueAt, _ := time.Parse(time.RFC3339, order.UpdatedAt)
+	if !venueAt.IsZero() && venueAt.Before(l.VenueUpdatedAt) {
+		return false, false
+	}
+	if l.Final() && NormalizeRioStatus(order.Status) != l.Status {
+		if stage := SettlementStageFor(order.Status); stage != StageRefunding && stage != StageRefunded {
+			return false, false
+		}
+	}
+	if !venueAt.IsZero() {
+		l.VenueUpdatedAt = venueAt
+	}
+
+	l.OrderID = order.ID
+	if order.QuoteID != "" {
+		l.QuoteID = order.QuoteID
+	}
+	if order.Type != "" {
+		l.Type = order.Type
+	}
+	if order.Side != "" {
+		l.Side = strings.ToUpper(order.Side)
+	}
+	if order.Crypto != "" || order.Fiat != "" {
+		l.Instrument = formatPair(order.Crypto, order.Fiat)
+	}
+	if order.AmountFiat != 0 {
+		l.AmountFiat = order.AmountFiat
+	}
+	if order.AmountCrypto != 0 {
+		l.AmountCrypto = order.AmountCrypto
+	}
+	if order.Fees != (RioFees{}) {
+		l.Fees = order.Fees
+	}
+	if order.PaymentInstructions != nil {
+		l.PaymentInstructions = order.PaymentInstructions
+	}
+
+	if order.TxHash != "" && order.TxHash != l.TxHash {
+		l.TxHash = order.TxHash
+		changed = true
+	}
+	if order.PayoutReference != "" && order.PayoutReference != l.PayoutReference {
+		l.PayoutReference = order.PayoutReference
+		changed = true
+	}
+
+	l.Status = NormalizeRioStatus(order.Status)
+	l.RawStatus = order.Status
+	if stage := SettlementStageFor(order.Status); stage != l.Stage {
+		l.Stage = stage
+		l.History = append(l.History, StageTransition{
+			Stage:     stage,
+			RawStatus: order.Status,
+			Source:    source,
+			At:        now,
+		})
+		changed = true
+	}
+	l.UpdatedAt = now
+	return changed, true
+}
+
+// lifecycleKey is the store key of an order's lifecycle.
+func lifecycleKey(orderID string) string {
+	return "rio:order:" + orderID + ":lifecycle"
+}
+
+// RecordOrderProgress merges an order snapshot from GetOrder or a webhook
+// into the stored lifecycle and publishes a settlement progress event when
+// the stage, tx hash or payout reference changed. Every change of Rio status
+// is appended to the audit trail.
+func (s *Service) RecordOrderProgress(ctx context.Context, clientID string, order *RioOrderResponse, source string) *OrderLifecycle {
+	if order == nil || order.ID == "" {
+		return nil
+	}
+
+	unlock := s.lockOrder(order.ID)
+	lc := s.loadLifecycle(ctx, order.ID)
+	if lc == nil {
+		lc = &OrderLifecycle{ClientID: clientID}
+	}
+	prevStatus, prevRaw := lc.Status, lc.RawStatus
+	changed, accepted := lc.apply(order, source, time.Now().UTC())
+	if accepted {
+		s.saveLifecycle(ctx, lc)
+	}
+	snapshot := *lc
+	unlock()
+
+	if !accepted {
+		slog.Warn("rio.lifecycle.stale_snapshot_ignored",
+			"order_id", order.ID,
+			"client", snapshot.ClientID,
+			"status", snapshot.RawStatus,
+			"snapshot_status", order.Status,
+			"snapshot_updated_at", order.UpdatedAt,
+			"source", source)
+		return &snapshot
+	}
+
+	if order.Status != prevRaw {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:       audit.KindStatusTransition,
+			ClientID:   snapshot.ClientID,
+			QuoteID:    snapshot.QuoteID,
+			TradeID:    snapshot.OrderID,
+			PrevStatus: prevStatus,
+			Status:     snapshot.Status,
+			Source:     source,
+		}, order)
+	}
+
+	if changed {
+		slog.Info("rio.lifecycle.progress",
+			"order_id", lc.OrderID,
+			"client", lc.ClientID,
+			"stage", string(lc.Stage),
+			"raw_status", lc.RawStatus,
+			"source", source)
+		s.publishProgress(ctx, &snapshot, source)
+	}
+	return &snapshot
+}
+
+// GetOrderLifecycle returns the lifecycle of a client's order, refreshed from
+// Rio unless it is already final. If Rio cannot be reached the last stored
+// lifecycle is returned.
+func (s *Service) GetOrderLifecycle(ctx context.Context, clientID, orderID string) (*OrderLifecycle, error) {
+	unlock := s.lockOrder(orderID)
+	stored := s.loadLifecycle(ctx, orderID)
+	unlock()
+
+	if stored != nil {
+		if stored.ClientID != clientID {
+			return nil, ErrOrderNotFound
+		}
+		if stored.Final() {
+			return stored, nil
+		}
+	}
+
+	order, err := s.FetchTradeStatus(ctx, clientID, orderID)
+	if err != nil {
+		if stored != nil {
+			slog.Warn("rio.lifecycle.refresh_failed",
+				"order_id", orderID,
+				"client", clientID,
+				"error", err)
+			return stored, nil
+		}
+		return nil, fmt.Errorf("fetch rio order %q: %w", orderID, err)
+	}
+	if stored == nil && order.ClientReferenceID != "" && order.ClientReferenceID != clientID {
+		return nil, ErrOrderNotFound
+	}
+	return s.RecordOrderProgress(ctx, clientID, order, "api"), nil
+}
+
+// loadLifecycle reads an order's lifecycle from the store, which every
+// replica writes, and from memory, and returns the more recently updated of
+// the two so a stale local copy never overwrites a newer one. Callers hold
+// the order's lock.
+func (s *Service) loadLifecycle(ctx context.Context, orderID string) *OrderLifecycle {
+	var local *OrderLifecycle
+	if v, ok := s.lifecycles.Load(orderID); ok {
+		lc := *v.(*OrderLifecycle)
+		lc.History = slices.Clone(lc.History)
+		local = &lc
+	}
+	if s.store == nil {
+		return local
+	}
+	var stored OrderLifecycle
+	if err := s.store.GetJSON(ctx, lifecycleKey(orderID), &stored); err != nil || stored.OrderID == "" {
+		return local
+	}
+	if local != nil && local.UpdatedAt.After(stored.UpdatedAt) {
+		return local
+	}
+	return &stored
+}
+
+// saveLifecycle keeps the lifecycle in the store, and in memory until it is
+// final. Without a store it stays in memory until SweepLifecycles drops it.
+// Callers hold the order's lock.
+func (s *Service) saveLifecycle(ctx context.Context, lc *OrderLifecycle) {
+	if s.store == nil {
+		cp := *lc
+		s.lifecycles.Store(lc.OrderID, &cp)
+		return
+	}
+	if lc.Final() {
+		s.lifecycles.Delete(lc.OrderID)
+	} else {
+		cp := *lc
+		s.lifecycles.Store(lc.OrderID, &cp)
+	}
+	if err := s.store.SetJSON(ctx, lifecycleKey(lc.OrderID), lc, lifecycleTTL); err != nil {
+		slog.Warn("rio.lifecycle.persist_failed",
+			"order_id", lc.OrderID,
+			"error", err)
+	}
+}
+
+// orderLock serializes lifecycle updates of one order; refs counts the
+// callers holding or waiting for it.
+type orderLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lockOrder locks orderID's lifecycle so that slow store calls for one order
+// do not hold up others, and returns the unlock function.
+func (s *Service) lockOrder(orderID string) (unlock func()) {
+	s.lifecycleMu.Lock()
+	if s.orderLocks == nil {
+		s.orderLocks = make(map[string]*orderLock)
+	}
+	l := s.orderLocks[orderID]
+	if l == nil {
+		l = &orderLock{}
+		s.orderLocks[orderID] = l
+	}
+	l.refs++
+	s.lifecycleMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		s.lifecycleMu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(s.orderLocks, orderID)
+		}
+		s.lifecycleMu.Unlock()
+	}
+}
+
+// StartLifecycleSweep drops stale in-memory lifecycles every
+// lifecycleSweepInterval until ctx is cancelled.
+func (s *Service) StartLifecycleSweep(ctx context.Context) {
+	ticker := time.NewTicker(lifecycleSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.SweepLifecycles(now)
+		}
+	}
+}
+
+// SweepLifecycles drops in-memory lifecycles not updated within lifecycleTTL,
+// matching the expiry of stored ones, and returns how many it dropped.
+func (s *Service) SweepLifecycles(now time.Time) int {
+	cutoff := now.Add(-lifecycleTTL)
+	dropped := 0
+	s.lifecycles.Range(func(key, value any) bool {
+		if value.(*OrderLifecycle).UpdatedAt.Before(cutoff) && s.lifecycles.CompareAndDelete(key, value) {
+			dropped++
+		}
+		return true
+	})
+	if dropped > 0 {
+		slog.Debug("rio.lifecycle.swept", "dropped", dropped)
+	}
+	return dropped
+}
+
+func (s *Service) publishProgress(ctx context.Context, lc *OrderLifecycle, source string) {
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.Publish(ctx, subjectSettlementProgress, map[string]any{
+		"client_id":            lc.ClientID,
+		"order_id":             lc.OrderID,
+		"quote_id":             lc.QuoteID,
+		"status":               lc.Status,
+		"raw_status":           lc.RawStatus,
+		"stage":                lc.Stage,
+		"payment_instructions": lc.PaymentInstructions,
+		"tx_hash":              lc.TxHash,
+		"payout_reference":     lc.PayoutReference,
+		"fees":                 lc.Fees,
+		"source":               source,
+		"timestamp":            lc.UpdatedAt,
+	}); err != nil {
+		slog.Warn("rio.publish_failed",
+			"subject", subjectSettlementProgress,
+			"error", err)
+	}
+}
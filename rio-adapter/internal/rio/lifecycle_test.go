@@ -0,0 +1,326 @@
+package rio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/internal/store"
+)
+
+// memJSONStore keeps SetJSON values in memory; other Store methods are unused.
+type memJSONStore struct {
+	store.Store
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (m *memJSONStore) SetJSON(_ context.Context, key string, value any, _ time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.data == nil {
+		m.data = make(map[string][]byte)
+	}
+	m.data[key] = b
+	return nil
+}
+
+func (m *memJSONStore) GetJSON(_ context.Context, key string, dest any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	b, ok := m.data[key]
+	if !ok {
+		return errors.New("not found")
+	}
+	return json.Unmarshal(b, dest)
+}
+
+func onrampOrder(status string) *RioOrderResponse {
+	return &RioOrderResponse{
+		ID:                "ord-lc-001",
+		QuoteID:           "qt-lc-001",
+		Status:            status,
+		Side:              "buy",
+		Type:              "onramp",
+		Crypto:            "USDC",
+		Fiat:              "USD",
+		AmountFiat:        1000,
+		AmountCrypto:      995,
+		ClientReferenceID: "client-001",
+	}
+}
+
+func TestSettlementStageFor(t *testing.T) {
+	tests := []struct {
+		status string
+		want   SettlementStage
+	}{
+		{"created", StageAwaitingFunding},
+		{"awaitingPayment", StageAwaitingFunding},
+		{"funded", StageFunded},
+		{"sourcingLiquidity", StageProcessing},
+		{"cryptoSent", StageCryptoSent},
+		{"fiat_paid", StageFiatPaid},
+		{"completed", StageCompleted},
+		{"expired", StageCancelled},
+		{"kyc_failed", StageFailed},
+		{"refund_pending", StageRefunding},
+		{"refunded", StageRefunded},
+		{"some_new_status", StageProcessing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			assert.Equal(t, tt.want, SettlementStageFor(tt.status))
+		})
+	}
+}
+
+func TestService_RecordOrderProgress_TracksStages(t *testing.T) {
+	svc := newTestService(t, "http://unused")
+	ctx := context.Background()
+
+	created := onrampOrder("awaiting_payment")
+	created.Fees = RioFees{ProcessingFeeFiat: 2.5}
+	created.PaymentInstructions = &RioPaymentInstructions{
+		Amount:        1000,
+		Currency:      "USD",
+		BankName:      "Lead Bank",
+		AccountNumber: "123456789",
+		RoutingNumber: "101019644",
+		Reference:     "RIO-ABC123",
+	}
+	svc.RecordOrderProgress(ctx, "client-001", created, "execute")
+
+	// Repeated snapshots in the same stage add no history.
+	svc.RecordOrderProgress(ctx, "client-001", onrampOrder("awaiting_payment"), "poller")
+	svc.RecordOrderProgress(ctx, "client-001", onrampOrder("funded"), "webhook")
+
+	sent := onrampOrder("crypto_sent")
+	sent.TxHash = "0xdeadbeef"
+	svc.RecordOrderProgress(ctx, "client-001", sent, "webhook")
+
+	lc := svc.RecordOrderProgress(ctx, "client-001", onrampOrder("completed"), "poller")
+	require.NotNil(t, lc)
+
+	var stages []SettlementStage
+	for _, h := range lc.History {
+		stages = append(stages, h.Stage)
+	}
+	assert.Equal(t, []SettlementStage{StageAwaitingFunding, StageFunded, StageCryptoSent, StageCompleted}, stages)
+	assert.Equal(t, "filled", lc.Status)
+	assert.True(t, lc.Final())
+	assert.Equal(t, "0xdeadbeef", lc.TxHash)
+	require.NotNil(t, lc.PaymentInstructions, "instructions must survive snapshots that omit them")
+	assert.Equal(t, "RIO-ABC123", lc.PaymentInstructions.Reference)
+	assert.Equal(t, 2.5, lc.Fees.ProcessingFeeFiat)
+	assert.Equal(t, "USDC/USD", lc.Instrument)
+}
+
+func TestService_GetOrderLifecycle(t *testing.T) {
+	st := &memJSONStore{}
+	ctx := context.Background()
+
+	// Another replica recorded the order while it awaited funding.
+	writer := newTestService(t, "http://unused")
+	writer.store = st
+	writer.RecordOrderProgress(ctx, "client-001", onrampOrder("awaiting_payment"), "webhook")
+
+	paid := onrampOrder("fiat_paid")
+	paid.PayoutReference = "SPEI-987"
+	srv := mockRioServer(t, nil, nil, paid)
+	defer srv.Close()
+	reader := newTestService(t, srv.URL)
+	reader.store = st
+
+	lc, err := reader.GetOrderLifecycle(ctx, "client-001", "ord-lc-001")
+	require.NoError(t, err)
+	assert.Equal(t, StageFiatPaid, lc.Stage)
+	assert.Equal(t, "SPEI-987", lc.PayoutReference)
+	assert.Len(t, lc.History, 2)
+
+	_, err = reader.GetOrderLifecycle(ctx, "client-002", "ord-lc-001")
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+
+	// Rio unreachable: the stored lifecycle is served.
+	srv.Close()
+	lc, err = reader.GetOrderLifecycle(ctx, "client-001", "ord-lc-001")
+	require.NoError(t, err)
+	assert.Equal(t, StageFiatPaid, lc.Stage)
+}
+
+func TestWebhookHandler_RecordsLifecycle(t *testing.T) {
+	svc := newTestService(t, "http://unused")
+	handler := NewWebhookHandler(nil, nil, nil, nil, svc, nil)
+	app := fiber.New()
+	app.Post("/webhooks/rio/orders", handler.HandleOrderWebhook)
+
+	order := onrampOrder("crypto_sent")
+	order.TxHash = "0xfeed"
+	body, err := json.Marshal(RioOrderWebhookEvent{Event: "order.status_changed", Data: *order})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/rio/orders", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	lc := svc.loadLifecycle(context.Background(), "ord-lc-001")
+	require.NotNil(t, lc)
+	assert.Equal(t, StageCryptoSent, lc.Stage)
+	assert.Equal(t, "0xfeed", lc.TxHash)
+	assert.Equal(t, "webhook", lc.History[0].Source)
+}
+
+func TestService_RecordOrderProgress_IgnoresOutOfOrderSnapshots(t *testing.T) {
+	svc := newTestService(t, "http://unused")
+	ctx := context.Background()
+	at := func(status, updatedAt string) *RioOrderResponse {
+		o := onrampOrder(status)
+		o.UpdatedAt = updatedAt
+		return o
+	}
+
+	svc.RecordOrderProgress(ctx, "client-001", at("funded", "2026-01-01T10:05:00Z"), "webhook")
+
+	// An older snapshot delivered late does not roll the stage back.
+	lc := svc.RecordOrderProgress(ctx, "client-001", at("awaiting_payment", "2026-01-01T10:00:00Z"), "poller")
+	assert.Equal(t, StageFunded, lc.Stage)
+
+	lc = svc.RecordOrderProgress(ctx, "client-001", at("completed", "2026-01-01T10:10:00Z"), "webhook")
+	assert.Equal(t, StageCompleted, lc.Stage)
+
+	// A final order leaves its state only for a refund, even without timestamps.
+	lc = svc.RecordOrderProgress(ctx, "client-001", onrampOrder("processing"), "poller")
+	assert.Equal(t, StageCompleted, lc.Stage)
+	lc = svc.RecordOrderProgress(ctx, "client-001", at("refund_pending", "2026-01-01T10:20:00Z"), "webhook")
+	assert.Equal(t, StageRefunding, lc.Stage)
+
+	var stages []SettlementStage
+	for _, h := range lc.History {
+		stages = append(stages, h.Stage)
+	}
+	assert.Equal(t, []SettlementStage{StageFunded, StageCompleted, StageRefunding}, stages)
+}
+
+func TestService_LoadLifecycle_PrefersNewerStoredCopy(t *testing.T) {
+	st := &memJSONStore{}
+	ctx := context.Background()
+
+	stale := newTestService(t, "http://unused")
+	stale.store = st
+	stale.RecordOrderProgress(ctx, "client-001", onrampOrder("awaiting_payment"), "execute")
+
+	// Another replica moves the order on; stale still holds the old copy in memory.
+	other := newTestService(t, "http://unused")
+	other.store = st
+	other.RecordOrderProgress(ctx, "client-001", onrampOrder("funded"), "webhook")
+
+	lc := stale.RecordOrderProgress(ctx, "client-001", onrampOrder("crypto_sent"), "poller")
+	require.NotNil(t, lc)
+	var stages []SettlementStage
+	for _, h := range lc.History {
+		stages = append(stages, h.Stage)
+	}
+	assert.Equal(t, []SettlementStage{StageAwaitingFunding, StageFunded, StageCryptoSent}, stages)
+}
+
+func TestWebhookHandler_IgnoresOrderWithoutClientReference(t *testing.T) {
+	svc := newTestService(t, "http://unused")
+	handler := NewWebhookHandler(nil, nil, nil, nil, svc, nil)
+	app := fiber.New()
+	app.Post("/webhooks/rio/orders", handler.HandleOrderWebhook)
+
+	order := onrampOrder("completed")
+	order.ClientReferenceID = ""
+	order.UserID = "rio-user-1"
+	body, err := json.Marshal(RioOrderWebhookEvent{Event: "order.status_changed", Data: *order})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/rio/orders", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Nil(t, svc.loadLifecycle(context.Background(), "ord-lc-001"), "unattributed events must not be recorded")
+}
+
+func TestService_SweepLifecycles_DropsExpiredEntries(t *testing.T) {
+	svc := newTestService(t, "http://unused")
+	ctx := context.Background()
+
+	svc.RecordOrderProgress(ctx, "client-001", onrampOrder("completed"), "poller")
+	recent := onrampOrder("funded")
+	recent.ID = "ord-lc-002"
+	svc.RecordOrderProgress(ctx, "client-001", recent, "webhook")
+
+	assert.Zero(t, svc.SweepLifecycles(time.Now()), "fresh lifecycles are kept, final or not")
+	assert.Equal(t, 2, svc.SweepLifecycles(time.Now().Add(lifecycleTTL+time.Minute)))
+	assert.Nil(t, svc.loadLifecycle(ctx, "ord-lc-001"))
+	assert.Nil(t, svc.loadLifecycle(ctx, "ord-lc-002"))
+}
+
+// blockingJSONStore holds GetJSON for one key until release is closed.
+type blockingJSONStore struct {
+	memJSONStore
+	blockKey string
+	entered  chan struct{}
+	release  chan struct{}
+}
+
+func (b *blockingJSONStore) GetJSON(ctx context.Context, key string, dest any) error {
+	if key == b.blockKey {
+		close(b.entered)
+		<-b.release
+	}
+	return b.memJSONStore.GetJSON(ctx, key, dest)
+}
+
+func TestService_RecordOrderProgress_LocksPerOrder(t *testing.T) {
+	st := &blockingJSONStore{
+		blockKey: lifecycleKey("ord-lc-001"),
+		entered:  make(chan struct{}),
+		release:  make(chan struct{}),
+	}
+	svc := newTestService(t, "http://unused")
+	svc.store = st
+	ctx := context.Background()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.RecordOrderProgress(ctx, "client-001", onrampOrder("funded"), "webhook")
+	}()
+	<-st.entered
+
+	// A slow store call for one order does not hold up another order.
+	other := onrampOrder("funded")
+	other.ID = "ord-lc-002"
+	recorded := make(chan *OrderLifecycle, 1)
+	go func() { recorded <- svc.RecordOrderProgress(ctx, "client-001", other, "poller") }()
+	select {
+	case lc := <-recorded:
+		require.NotNil(t, lc)
+		assert.Equal(t, StageFunded, lc.Stage)
+	case <-time.After(2 * time.Second):
+		t.Fatal("second order blocked behind the first")
+	}
+
+	close(st.release)
+	<-done
+	assert.Empty(t, svc.orderLocks, "order locks are released once unused")
+}
@@ -191,7 +191,12 @@ func NormalizeRioStatus(status string) string {
 		"manual_review",
 		"manualreview",
 		"awaiting_confirmation",
-		"awaitingconfirmation":
+		"awaitingconfirmation",
+		"funded",
+		"crypto_sent",
+		"cryptosent",
+		"fiat_paid",
+		"fiatpaid":
 		return "submitted"
 
 	// ─── Filled / Completed ───
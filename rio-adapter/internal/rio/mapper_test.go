@@ -26,6 +26,9 @@ func TestNormalizeRioStatus(t *testing.T) {
 		{"verifying", "verifying", "submitted"},
 		{"manual_review", "manual_review", "submitted"},
 		{"compliance_review", "complianceReview", "submitted"},
+		{"funded", "funded", "submitted"},
+		{"crypto_sent", "cryptoSent", "submitted"},
+		{"fiat_paid", "fiat_paid", "submitted"},
 
 		// Filled (completed)
 		{"paid", "paid", "filled"},
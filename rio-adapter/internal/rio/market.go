@@ -0,0 +1,75 @@
+package rio
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+// fiatCountries maps the fiat currency of a pair to the Rio country that
+// settles it.
+var fiatCountries = map[string]string{
+	"USD": "US",
+	"MXN": "MX",
+	"PEN": "PE",
+}
+
+// usBankTransferMethods are the funding methods Rio accepts for US quotes.
+var usBankTransferMethods = map[string]bool{
+	"ach_push": true,
+	"wire":     true,
+}
+
+// Market is the Rio jurisdiction and funding method an RFQ is quoted in.
+type Market struct {
+	Country              string
+	USBankTransferMethod string // US only
+}
+
+// resolveMarket picks the country for an RFQ: the one named in the request,
+// else the one settling the pair's fiat currency if the client has it, else
+// the client's default. US quotes use the requested bank transfer method or
+// the client's default one.
+func resolveMarket(cfg *RioClientConfig, req model.RFQRequest) (Market, error) {
+	country := strings.ToUpper(strings.TrimSpace(req.Country))
+	switch {
+	case country != "":
+		if !cfg.SupportsCountry(country) {
+			return Market{}, &model.RFQValidationError{
+				Code:    model.RFQErrUnsupportedCountry,
+				Message: fmt.Sprintf("country %s is not enabled for this client", country),
+			}
+		}
+	default:
+		_, fiat := parsePair(req.CurrencyPair)
+		if c, ok := fiatCountries[fiat]; ok && cfg.SupportsCountry(c) {
+			country = c
+		} else {
+			country = strings.ToUpper(cfg.Country)
+		}
+	}
+
+	m := Market{Country: country}
+	method := strings.ToLower(strings.TrimSpace(req.PaymentMethod))
+	if country != "US" {
+		if method != "" {
+			return Market{}, &model.RFQValidationError{
+				Code:    model.RFQErrInvalidPaymentMethod,
+				Message: fmt.Sprintf("bank transfer method applies only to US quotes, not %s", country),
+			}
+		}
+		return m, nil
+	}
+	if method == "" {
+		method = strings.ToLower(cfg.USBankTransferMethod)
+	}
+	if method != "" && !usBankTransferMethods[method] {
+		return Market{}, &model.RFQValidationError{
+			Code:    model.RFQErrInvalidPaymentMethod,
+			Message: fmt.Sprintf("bank transfer method %q must be ach_push or wire", method),
+		}
+	}
+	m.USBankTransferMethod = method
+	return m, nil
+}
@@ -0,0 +1,84 @@
+package rio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/pkg/model"
+)
+
+func TestResolveMarket(t *testing.T) {
+	cfg := &RioClientConfig{Country: "US", Countries: []string{"MX"}, USBankTransferMethod: "ach_push"}
+
+	tests := []struct {
+		name    string
+		req     model.RFQRequest
+		want    Market
+		errCode string
+	}{
+		{"fiat picks country", model.RFQRequest{CurrencyPair: "USDC/MXN"}, Market{Country: "MX"}, ""},
+		{"US gets default method", model.RFQRequest{CurrencyPair: "USDC/USD"}, Market{Country: "US", USBankTransferMethod: "ach_push"}, ""},
+		{"explicit method", model.RFQRequest{CurrencyPair: "USDC/USD", PaymentMethod: "WIRE"}, Market{Country: "US", USBankTransferMethod: "wire"}, ""},
+		{"explicit country wins", model.RFQRequest{CurrencyPair: "USDC/USD", Country: "mx"}, Market{Country: "MX"}, ""},
+		{"unconfigured fiat country falls back", model.RFQRequest{CurrencyPair: "USDC/PEN"}, Market{Country: "US", USBankTransferMethod: "ach_push"}, ""},
+		{"explicit country not enabled", model.RFQRequest{CurrencyPair: "USDC/PEN", Country: "PE"}, Market{}, model.RFQErrUnsupportedCountry},
+		{"unknown method", model.RFQRequest{CurrencyPair: "USDC/USD", PaymentMethod: "rtp"}, Market{}, model.RFQErrInvalidPaymentMethod},
+		{"method outside US", model.RFQRequest{CurrencyPair: "USDC/MXN", PaymentMethod: "wire"}, Market{}, model.RFQErrInvalidPaymentMethod},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveMarket(cfg, tt.req)
+			if tt.errCode != "" {
+				verr, ok := model.AsRFQValidationError(err)
+				require.True(t, ok, "got %v", err)
+				assert.Equal(t, tt.errCode, verr.Code)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_CreateRFQ_SendsResolvedMarket(t *testing.T) {
+	var sent RioQuoteRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = RioQuoteRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&sent)
+		writeJSON(w, RioQuoteResponse{ID: "qt-1", Crypto: sent.Crypto, Fiat: sent.Fiat, AmountFiat: 1000, AmountCrypto: 1000})
+	}))
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+	cfg := svc.configResolver.(*mockConfigResolver).cfg
+	cfg.Countries = []string{"MX"}
+
+	_, err := svc.CreateRFQ(context.Background(), model.RFQRequest{
+		ClientID: "client-001", CurrencyPair: "USDC/USD", Side: "buy", Amount: 1000, PaymentMethod: "wire",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "US", sent.Country)
+	assert.Equal(t, "wire", sent.USBankTransferMethod)
+
+	_, err = svc.CreateRFQ(context.Background(), model.RFQRequest{
+		ClientID: "client-001", CurrencyPair: "USDC/MXN", Side: "buy", Amount: 1000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "MX", sent.Country)
+	assert.Empty(t, sent.USBankTransferMethod)
+}
+
+func TestRateLimitKey_PerAPIKeyUnderBaseURL(t *testing.T) {
+	a := &RioClientConfig{BaseURL: "https://rio.example.com", APIKey: "key-a"}
+	b := &RioClientConfig{BaseURL: "https://rio.example.com", APIKey: "key-b"}
+
+	assert.NotEqual(t, a.rateLimitKey(), b.rateLimitKey())
+	assert.NotContains(t, a.rateLimitKey(), "key-a")
+	assert.Contains(t, a.rateLimitKey(), "rio_api:https://rio.example.com|")
+}
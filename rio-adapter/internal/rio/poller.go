@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/pkg/model"
 	"github.com/Checker-Finance/adapters/rio-adapter/pkg/config"
 )
 
@@ -72,11 +74,14 @@ func (p *Poller) PollTradeStatus(
 	// Create dedicated child context for this poller
 	ctx, cancel := context.WithCancel(parentCtx)
 	p.activeTrades.Store(orderID, cancel)
+	submitted := time.Now()
+	untrack := metrics.TrackPolledTrade("rio")
 
 	go func() {
 		defer func() {
 			p.activeTrades.Delete(orderID)
 			cancel()
+			untrack()
 		}()
 
 		// Use longer interval since webhooks are primary
@@ -101,7 +106,9 @@ func (p *Poller) PollTradeStatus(
 				return
 
 			case <-ticker.C:
+				pollStart := time.Now()
 				order, err := p.service.FetchTradeStatus(ctx, clientID, orderID)
+				metrics.ObservePoll("rio", pollStart)
 				if err != nil {
 					slog.Warn("rio.trade_poll_error",
 						"order_id", orderID,
@@ -110,6 +117,8 @@ func (p *Poller) PollTradeStatus(
 					continue
 				}
 
+				p.service.RecordOrderProgress(ctx, clientID, order, "poller")
+
 				rawStatus := order.Status
 				status := NormalizeRioStatus(rawStatus)
 
@@ -144,6 +153,10 @@ func (p *Poller) PollTradeStatus(
 
 				// Handle terminal status
 				if IsTerminalStatus(status) {
+					metrics.ObserveTimeToTerminal("rio", status, submitted)
+					if status == model.StatusFilled {
+						metrics.ObserveQuoteFill("rio", quoteID, time.Now())
+					}
 					p.handleTerminalStatus(ctx, clientID, orderID, quoteID, order, status)
 					return
 				}
@@ -178,16 +191,29 @@ func (p *Poller) handleTerminalStatus(
 	order *RioOrderResponse,
 	status string,
 ) {
-	// 1. Sync into legacy database
+	finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.RIO"
+	msgID := publisher.TradeEventID("RIO", orderID, status)
+	event := map[string]any{
+		"client_id": clientID,
+		"order_id":  orderID,
+		"quote_id":  quoteID,
+		"status":    status,
+		"final":     true,
+		"timestamp": time.Now().UTC(),
+	}
+
+	// 1. Sync into legacy database, queueing the final event in the same transaction
+	queued := false
 	if p.tradeSync != nil {
 		trade := p.service.BuildTradeConfirmationFromOrder(clientID, orderID, order)
 		if trade != nil {
-			if err := p.tradeSync.SyncTradeUpsert(ctx, trade); err != nil {
+			if err := p.tradeSync.SyncTradeWithEvent(ctx, trade, finalSubject, msgID, event); err != nil {
 				slog.Warn("legacy.trade_sync_failed",
 					"order_id", orderID,
 					"client", clientID,
 					"error", err)
 			} else {
+				queued = true
 				slog.Info("legacy.trade_sync_upsert",
 					"order_id", orderID,
 					"client", clientID,
@@ -197,17 +223,9 @@ func (p *Poller) handleTerminalStatus(
 		}
 	}
 
-	// 2. Emit final event
-	if p.publisher != nil {
-		finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.RIO"
-		if err := p.publisher.Publish(ctx, finalSubject, map[string]any{
-			"client_id": clientID,
-			"order_id":  orderID,
-			"quote_id":  quoteID,
-			"status":    status,
-			"final":     true,
-			"timestamp": time.Now().UTC(),
-		}); err != nil {
+	// 2. Emit final event directly when it could not be queued
+	if !queued && p.publisher != nil {
+		if err := p.publisher.Publish(ctx, finalSubject, event, publisher.WithMsgID(msgID)); err != nil {
 			slog.Debug("nats.publish_failed",
 				"subject", finalSubject,
 				"error", err)
@@ -4,15 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"log/slog"
 
 	"github.com/nats-io/nats.go"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/model"
 	"github.com/Checker-Finance/adapters/rio-adapter/pkg/config"
 )
@@ -30,6 +35,11 @@ type Service struct {
 	mapper          *Mapper
 	tradeSyncWriter *legacy.TradeSyncWriter
 	poller          *Poller
+	auditor         *audit.Recorder
+
+	lifecycleMu sync.Mutex            // guards orderLocks
+	orderLocks  map[string]*orderLock // order_id -> lock serializing its lifecycle updates
+	lifecycles  sync.Map              // order_id -> *OrderLifecycle
 }
 
 // NewService constructs a fully wired Rio adapter service.
@@ -61,6 +71,11 @@ func (s *Service) SetPoller(p *Poller) {
 	s.poller = p
 }
 
+// SetAuditRecorder sets the recorder for the quote-to-trade audit trail.
+func (s *Service) SetAuditRecorder(r *audit.Recorder) {
+	s.auditor = r
+}
+
 // resolveConfig resolves the per-client Rio configuration, returning an error if not found.
 func (s *Service) resolveConfig(ctx context.Context, clientID string) (*RioClientConfig, error) {
 	cfg, err := s.configResolver.Resolve(ctx, clientID)
@@ -82,21 +97,39 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 		"amount", req.Amount,
 	)
 
+	// Rio publishes no product catalogue, so only the amount can be checked
+	if err := model.ValidateRFQ(nil, req); err != nil {
+		return nil, err
+	}
+
 	// Resolve per-client configuration
 	clientCfg, err := s.resolveConfig(ctx, req.ClientID)
 	if err != nil {
 		return nil, err
 	}
 
+	market, err := resolveMarket(clientCfg, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert to Rio request format
-	rioReq := s.mapper.ToRioQuoteRequest(req, clientCfg.Country)
+	rioReq := s.mapper.ToRioQuoteRequest(req, market.Country)
+	rioReq.USBankTransferMethod = market.USBankTransferMethod
 
 	slog.Debug("rio.rfq_request",
 		"json", pretty(rioReq))
+	s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: req.ClientID}, rioReq)
 
 	// Call Rio API with per-client config
+	ctx, raw := httpclient.CaptureResponse(ctx)
 	rioResp, err := s.client.CreateQuote(ctx, clientCfg, rioReq)
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindQuoteResponse,
+			ClientID: req.ClientID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Error("rio.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
@@ -105,6 +138,12 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 
 	// Convert to canonical quote
 	quote := s.mapper.FromRioQuote(rioResp, req.ClientID)
+	metrics.RecordQuote("rio", quote.ID, time.Now())
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindQuoteResponse,
+		ClientID: req.ClientID,
+		QuoteID:  quote.ID,
+	}, raw.Body)
 
 	slog.Info("rio.rfq_created",
 		"client", req.ClientID,
@@ -135,9 +174,22 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 		ClientReferenceID: clientID,
 	}
 
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteRequest,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+	}, orderReq)
+
 	// Call Rio API with per-client config
+	ctx, raw := httpclient.CaptureResponse(ctx)
 	orderResp, err := s.client.CreateOrder(ctx, clientCfg, orderReq)
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Error("rio.execute_rfq.failed",
 			"client", clientID,
 			"quote_id", quoteID,
@@ -147,6 +199,13 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 
 	// Convert to canonical trade confirmation
 	trade := s.mapper.FromRioOrder(orderResp, clientID)
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteResponse,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+		TradeID:  trade.TradeID,
+		Status:   trade.Status,
+	}, raw.Body)
 
 	slog.Info("rio.order_created",
 		"client", clientID,
@@ -155,6 +214,9 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 		"status", trade.Status,
 	)
 
+	// Capture payment instructions before the payer needs them.
+	s.RecordOrderProgress(ctx, clientID, orderResp, "execute")
+
 	// Start async polling if not in terminal state.
 	// Use the service-level context (s.ctx) — not the HTTP request context —
 	// so polling survives after the HTTP response is sent.
@@ -162,9 +224,12 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 		slog.Info("rio.starting_status_poll",
 			"order_id", trade.TradeID,
 			"client", clientID)
-		go s.poller.PollTradeStatus(s.ctx, clientID, quoteID, trade.TradeID)
+		go s.poller.PollTradeStatus(tracing.CarryCorrelationID(s.ctx, ctx), clientID, quoteID, trade.TradeID)
 	} else if IsTerminalStatus(orderResp.Status) {
 		// Immediately sync terminal trades
+		if trade.Status == model.StatusFilled {
+			metrics.ObserveQuoteFill("rio", quoteID, time.Now())
+		}
 		s.syncTerminalTrade(ctx, trade)
 	}
 
@@ -200,69 +265,21 @@ func (s *Service) BuildTradeConfirmationFromOrder(clientID, orderID string, orde
 	return s.mapper.FromRioOrder(order, clientID)
 }
 
-// RegisterOrderWebhook registers a webhook for order status changes for all discovered clients.
-// Each client's callback URL is taken from its per-client config (WebhookURL field).
-// Clients without a WebhookURL configured are skipped.
-func (s *Service) RegisterOrderWebhook(ctx context.Context) error {
-	clients, err := s.configResolver.DiscoverClients(ctx)
-	if err != nil || len(clients) == 0 {
-		slog.Warn("rio.register_webhook.no_clients",
-			"error", err)
-		return fmt.Errorf("no client configs available for webhook registration")
-	}
-
-	var lastErr error
-	registered := 0
-	for _, clientID := range clients {
-		clientCfg, err := s.configResolver.Resolve(ctx, clientID)
-		if err != nil {
-			slog.Error("rio.register_webhook.resolve_failed",
-				"client", clientID,
-				"error", err)
-			lastErr = err
-			continue
-		}
-
-		if clientCfg.WebhookURL == "" {
-			slog.Debug("rio.register_webhook.skipped_no_url",
-				"client", clientID)
-			continue
-		}
-
-		resp, err := s.client.RegisterWebhook(ctx, clientCfg, clientCfg.WebhookURL, true)
-		if err != nil {
-			slog.Error("rio.register_webhook.failed",
-				"client", clientID,
-				"callback_url", clientCfg.WebhookURL,
-				"error", err)
-			lastErr = err
-			continue
-		}
-
-		registered++
-		slog.Info("rio.webhook_registered",
-			"client", clientID,
-			"webhook_id", resp.ID,
-			"url", resp.URL,
-			"type", resp.Type)
-	}
-
-	if registered == 0 {
-		return fmt.Errorf("webhook registration failed for all %d clients: %w", len(clients), lastErr)
-	}
-
-	slog.Info("rio.webhooks_registered",
-		"registered", registered,
-		"total", len(clients))
-
-	return nil
-}
-
 // syncTerminalTrade syncs a terminal trade to the legacy database and publishes events.
 func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfirmation) {
-	// Sync to legacy database
+	subject := "evt.trade." + trade.Status + ".v1.RIO"
+	msgID := publisher.TradeEventID("RIO", trade.TradeID, trade.Status)
+	event := map[string]any{
+		"client_id": trade.ClientID,
+		"order_id":  trade.TradeID,
+		"status":    trade.Status,
+		"final":     true,
+		"timestamp": time.Now().UTC(),
+	}
+
+	// Sync to legacy database, queueing the final event in the same transaction
 	if s.tradeSyncWriter != nil {
-		if err := s.tradeSyncWriter.SyncTradeUpsert(ctx, trade); err != nil {
+		if err := s.tradeSyncWriter.SyncTradeWithEvent(ctx, trade, subject, msgID, event); err != nil {
 			slog.Warn("rio.trade_sync_failed",
 				"order_id", trade.TradeID,
 				"client", trade.ClientID,
@@ -272,21 +289,15 @@ func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfi
 				"order_id", trade.TradeID,
 				"client", trade.ClientID,
 				"status", trade.Status)
+			return
 		}
 	}
 
-	// Publish final event
+	// Publish final event directly when it could not be queued
 	if s.publisher == nil {
 		return
 	}
-	subject := "evt.trade." + trade.Status + ".v1.RIO"
-	if err := s.publisher.Publish(ctx, subject, map[string]any{
-		"client_id": trade.ClientID,
-		"order_id":  trade.TradeID,
-		"status":    trade.Status,
-		"final":     true,
-		"timestamp": time.Now().UTC(),
-	}); err != nil {
+	if err := s.publisher.Publish(ctx, subject, event, publisher.WithMsgID(msgID)); err != nil {
 		slog.Warn("rio.publish_failed",
 			"subject", subject,
 			"error", err)
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/rio-adapter/pkg/config"
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
@@ -262,6 +263,74 @@ func TestService_ExecuteRFQ_ClientError(t *testing.T) {
 	assert.Contains(t, err.Error(), "rio order creation failed")
 }
 
+// auditLog is an audit.Store collecting appended events.
+type auditLog struct {
+	events []audit.Event
+}
+
+func (l *auditLog) Append(_ context.Context, e audit.Event) error {
+	l.events = append(l.events, e)
+	return nil
+}
+
+func (l *auditLog) Timeline(_ context.Context, _, _ string) ([]audit.Event, error) {
+	return l.events, nil
+}
+
+func TestService_RecordsAuditTrail(t *testing.T) {
+	quoteResp := &RioQuoteResponse{
+		ID:           "qt-abc-123",
+		Crypto:       "USDC",
+		Fiat:         "BRL",
+		Side:         "buy",
+		AmountFiat:   5000,
+		AmountCrypto: 1000,
+		ExpiresAt:    time.Now().Add(5 * time.Minute).UTC().Format(time.RFC3339),
+	}
+	orderResp := &RioOrderResponse{
+		ID:        "ord-xyz-789",
+		QuoteID:   "qt-abc-123",
+		Status:    "completed",
+		Side:      "buy",
+		Crypto:    "USDC",
+		Fiat:      "BRL",
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+
+	server := mockRioServer(t, quoteResp, orderResp, nil)
+	defer server.Close()
+
+	log := &auditLog{}
+	svc := newTestService(t, server.URL)
+	recorder := audit.NewRecorder(log, "rio")
+	svc.SetAuditRecorder(recorder)
+
+	_, err := svc.CreateRFQ(context.Background(), model.RFQRequest{
+		ClientID: "client-001", Side: "buy", CurrencyPair: "USDC/BRL", Amount: 5000,
+	})
+	require.NoError(t, err)
+	_, err = svc.ExecuteRFQ(context.Background(), "client-001", "qt-abc-123")
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close(context.Background()))
+
+	var kinds []audit.Kind
+	for _, e := range log.events {
+		kinds = append(kinds, e.Kind)
+		assert.Equal(t, "rio", e.Venue)
+		assert.Equal(t, "client-001", e.ClientID)
+	}
+	require.Equal(t, []audit.Kind{
+		audit.KindRFQRequest,
+		audit.KindQuoteResponse,
+		audit.KindExecuteRequest,
+		audit.KindExecuteResponse,
+	}, kinds[:4])
+
+	assert.Equal(t, "qt-abc-123", log.events[1].QuoteID)
+	assert.Contains(t, string(log.events[1].Payload), "qt-abc-123", "quote response should carry the raw venue body")
+	assert.Equal(t, "ord-xyz-789", log.events[3].TradeID)
+}
+
 func TestService_FetchTradeStatus_Success(t *testing.T) {
 	getResp := &RioOrderResponse{
 		ID:     "ord-xyz-789",
@@ -331,16 +400,6 @@ func TestService_BuildTradeConfirmationFromOrder_NilOrder(t *testing.T) {
 	assert.Nil(t, trade)
 }
 
-func TestService_RegisterOrderWebhook_Success(t *testing.T) {
-	server := mockRioServer(t, nil, nil, nil)
-	defer server.Close()
-
-	svc := newTestService(t, server.URL)
-
-	err := svc.RegisterOrderWebhook(context.Background())
-	require.NoError(t, err)
-}
-
 func TestService_ExecuteRFQ_UsesServiceContext(t *testing.T) {
 	// Verify that the poller receives the service-level context (s.ctx),
 	// not the HTTP request context, so polling survives after response.
@@ -1,6 +1,14 @@
 package rio
 
-import "context"
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+
+	"github.com/Checker-Finance/adapters/internal/rate"
+)
 
 //
 // ────────────────────────────────────────────────
@@ -9,20 +17,30 @@ import "context"
 //
 
 // RioClientConfig holds per-client Rio API configuration resolved from AWS Secrets Manager.
-// Secret format: {"api_key": "...", "base_url": "https://...", "country": "US", "webhook_url": "...", "webhook_secret": "...", "webhook_sig_header": "X-Rio-Signature"}
+// Secret format: {"api_key": "...", "base_url": "https://...", "country": "US", "countries": "US,MX", "us_bank_transfer_method": "wire", "webhook_url": "...", "webhook_secret": "...", "webhook_sig_header": "X-Rio-Signature"}
 type RioClientConfig struct {
-	BaseURL          string // Rio API base URL (e.g. "https://app.sandbox.rio.trade")
-	APIKey           string // Rio API key for x-api-key header
-	Country          string // Country code for Rio operations (US, MX, PE)
-	WebhookURL       string // Callback URL to register with Rio for this client (optional)
-	WebhookSecret    string // HMAC secret for validating Rio webhook signatures (optional)
-	WebhookSigHeader string // Header name carrying the webhook signature (default: X-Rio-Signature)
+	BaseURL              string   // Rio API base URL (e.g. "https://app.sandbox.rio.trade")
+	APIKey               string   // Rio API key for x-api-key header
+	Country              string   // Default country code for Rio operations (US, MX, PE)
+	Countries            []string // Other countries the client may quote in (optional)
+	USBankTransferMethod string   // Default US bank transfer method: ach_push or wire (optional)
+	WebhookURL           string   // Callback URL to register with Rio for this client (optional)
+	WebhookSecret        string   // HMAC secret for validating Rio webhook signatures (optional)
+	WebhookSigHeader     string   // Header name carrying the webhook signature (default: X-Rio-Signature)
 }
 
-// rateLimitKey returns a key that isolates rate limits per client,
-// derived from the base URL so each Rio endpoint gets its own bucket.
+// rateLimitKey returns a hierarchical key: each API key gets its own bucket,
+// under a ceiling shared by every client of the same Rio base URL. The API
+// key is hashed so it never appears in limiter state.
 func (c *RioClientConfig) rateLimitKey() string {
-	return "rio_api:" + c.BaseURL
+	sum := sha256.Sum256([]byte(c.APIKey))
+	return rate.Key("rio_api:"+c.BaseURL, "rio_key:"+hex.EncodeToString(sum[:8]))
+}
+
+// SupportsCountry reports whether the client may quote in country.
+func (c *RioClientConfig) SupportsCountry(country string) bool {
+	return strings.EqualFold(c.Country, country) ||
+		slices.ContainsFunc(c.Countries, func(s string) bool { return strings.EqualFold(s, country) })
 }
 
 // ConfigResolver resolves per-client Rio configuration.
@@ -134,6 +152,28 @@ type RioOrderResponse struct {
 	CompletedAt         string  `json:"completedAt,omitempty"` // When completed
 	CreatedAt           string  `json:"createdAt"`
 	UpdatedAt           string  `json:"updatedAt"`
+
+	PaymentInstructions *RioPaymentInstructions `json:"paymentInstructions,omitempty"` // Where the payer sends funds
+	PayoutReference     string                  `json:"payoutReference,omitempty"`     // Bank reference of the fiat payout (offramp)
+	FundedAt            string                  `json:"fundedAt,omitempty"`            // When Rio received the payer's funds
+}
+
+// RioPaymentInstructions tells the payer where to send funds for an order:
+// bank details for an onramp, a deposit address for an offramp.
+type RioPaymentInstructions struct {
+	Amount         float64 `json:"amount,omitempty"`
+	Currency       string  `json:"currency,omitempty"`
+	BankName       string  `json:"bankName,omitempty"`
+	AccountHolder  string  `json:"accountHolder,omitempty"`
+	AccountNumber  string  `json:"accountNumber,omitempty"`
+	RoutingNumber  string  `json:"routingNumber,omitempty"` // US
+	CLABE          string  `json:"clabe,omitempty"`         // MX
+	CCI            string  `json:"cci,omitempty"`           // PE
+	Reference      string  `json:"reference,omitempty"`     // Must accompany the transfer
+	DepositAddress string  `json:"depositAddress,omitempty"`
+	Network        string  `json:"network,omitempty"`
+	Memo           string  `json:"memo,omitempty"`
+	ExpiresAt      string  `json:"expiresAt,omitempty"`
 }
 
 //
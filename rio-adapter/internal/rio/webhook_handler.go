@@ -9,9 +9,11 @@ import (
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
 	"github.com/Checker-Finance/adapters/internal/webhooks"
+	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
 // WebhookHandler handles incoming webhook events from Rio.
@@ -46,6 +48,8 @@ func NewWebhookHandler(
 // HandleOrderWebhook processes order status change webhooks from Rio.
 // POST /webhooks/rio/orders
 func (h *WebhookHandler) HandleOrderWebhook(c *fiber.Ctx) error {
+	metrics.IncWebhook("rio", "received")
+
 	// Parse body first so we can identify the client for per-client signature validation.
 	var event RioOrderWebhookEvent
 	if err := c.BodyParser(&event); err != nil {
@@ -57,25 +61,33 @@ func (h *WebhookHandler) HandleOrderWebhook(c *fiber.Ctx) error {
 		})
 	}
 
+	// Orders are attributed to clients only by the client reference set at
+	// execution; Rio's user ID is not a client ID.
+	clientID := event.Data.ClientReferenceID
+	if clientID == "" {
+		metrics.IncWebhook("rio", "unattributed")
+		slog.Warn("rio.webhook.missing_client_reference",
+			"event", event.Event,
+			"order_id", event.Data.ID,
+			"status", event.Data.Status)
+		return c.SendStatus(fiber.StatusOK)
+	}
+
 	// Validate HMAC signature using the per-client webhook secret (if configured).
 	if h.resolver != nil {
-		clientID := event.Data.ClientReferenceID
-		if clientID == "" {
-			clientID = event.Data.UserID
-		}
-		if clientID != "" {
-			if clientCfg, err := h.resolver.Resolve(c.UserContext(), clientID); err == nil && clientCfg.WebhookSecret != "" {
-				sigHeader := clientCfg.WebhookSigHeader
-				signature := c.Get(sigHeader)
-				if signature == "" || !webhooks.ValidateHMACSHA256(clientCfg.WebhookSecret, signature, c.Body()) {
-					slog.Warn("rio.webhook.invalid_signature",
-						"client", clientID,
-						"header", sigHeader)
-					return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-						"error": "invalid signature",
-					})
-				}
+		if clientCfg, err := h.resolver.Resolve(c.UserContext(), clientID); err == nil && clientCfg.WebhookSecret != "" {
+			sigHeader := clientCfg.WebhookSigHeader
+			signature := c.Get(sigHeader)
+			if signature == "" || !webhooks.ValidateHMACSHA256(clientCfg.WebhookSecret, signature, c.Body()) {
+				metrics.IncWebhook("rio", "invalid_signature")
+				slog.Warn("rio.webhook.invalid_signature",
+					"client", clientID,
+					"header", sigHeader)
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "invalid signature",
+				})
 			}
+			metrics.IncWebhook("rio", "verified")
 		}
 	}
 
@@ -93,13 +105,18 @@ func (h *WebhookHandler) HandleOrderWebhook(c *fiber.Ctx) error {
 		h.poller.CancelPolling(order.ID)
 	}
 
+	// Record settlement progress (payment instructions, tx hash, payout reference)
+	if h.service != nil {
+		h.service.RecordOrderProgress(ctx, clientID, &order, "webhook")
+	}
+
 	// Normalize the status
 	normalizedStatus := NormalizeRioStatus(order.Status)
 
 	// Publish status change event
 	if h.publisher != nil {
 		statusEvent := map[string]any{
-			"client_id":  order.ClientReferenceID,
+			"client_id":  clientID,
 			"order_id":   order.ID,
 			"quote_id":   order.QuoteID,
 			"status":     normalizedStatus,
@@ -118,7 +135,10 @@ func (h *WebhookHandler) HandleOrderWebhook(c *fiber.Ctx) error {
 
 	// Handle terminal statuses
 	if IsTerminalStatus(order.Status) {
-		h.handleTerminalWebhook(ctx, &order, normalizedStatus)
+		if normalizedStatus == model.StatusFilled {
+			metrics.ObserveQuoteFill("rio", order.QuoteID, time.Now())
+		}
+		h.handleTerminalWebhook(ctx, clientID, &order, normalizedStatus)
 	}
 
 	return c.SendStatus(fiber.StatusOK)
@@ -126,22 +146,31 @@ func (h *WebhookHandler) HandleOrderWebhook(c *fiber.Ctx) error {
 
 
 // handleTerminalWebhook processes a terminal order status from webhook.
-func (h *WebhookHandler) handleTerminalWebhook(ctx context.Context, order *RioOrderResponse, status string) {
-	clientID := order.ClientReferenceID
-	if clientID == "" {
-		clientID = order.UserID
+func (h *WebhookHandler) handleTerminalWebhook(ctx context.Context, clientID string, order *RioOrderResponse, status string) {
+	finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.RIO"
+	msgID := publisher.TradeEventID("RIO", order.ID, status)
+	event := map[string]any{
+		"client_id": clientID,
+		"order_id":  order.ID,
+		"quote_id":  order.QuoteID,
+		"status":    status,
+		"final":     true,
+		"source":    "webhook",
+		"timestamp": time.Now().UTC(),
 	}
 
-	// Sync to legacy database
+	// Sync to legacy database, queueing the final event in the same transaction
+	queued := false
 	if h.tradeSync != nil && h.service != nil {
 		trade := h.service.BuildTradeConfirmationFromOrder(clientID, order.ID, order)
 		if trade != nil {
-			if err := h.tradeSync.SyncTradeUpsert(ctx, trade); err != nil {
+			if err := h.tradeSync.SyncTradeWithEvent(ctx, trade, finalSubject, msgID, event); err != nil {
 				slog.Warn("rio.webhook.trade_sync_failed",
 					"order_id", order.ID,
 					"client", clientID,
 					"error", err)
 			} else {
+				queued = true
 				slog.Info("rio.webhook.trade_synced",
 					"order_id", order.ID,
 					"client", clientID,
@@ -150,18 +179,9 @@ func (h *WebhookHandler) handleTerminalWebhook(ctx context.Context, order *RioOr
 		}
 	}
 
-	// Publish final event
-	if h.publisher != nil {
-		finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.RIO"
-		if err := h.publisher.Publish(ctx, finalSubject, map[string]any{
-			"client_id": clientID,
-			"order_id":  order.ID,
-			"quote_id":  order.QuoteID,
-			"status":    status,
-			"final":     true,
-			"source":    "webhook",
-			"timestamp": time.Now().UTC(),
-		}); err != nil {
+	// Publish final event directly when it could not be queued
+	if !queued && h.publisher != nil {
+		if err := h.publisher.Publish(ctx, finalSubject, event, publisher.WithMsgID(msgID)); err != nil {
 			slog.Warn("rio.webhook.publish_final_failed",
 				"subject", finalSubject,
 				"error", err)
@@ -0,0 +1,284 @@
+package rio
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WebhookStatus is the outcome of the last registration check for a client.
+type WebhookStatus string
+
+const (
+	WebhookRegistered    WebhookStatus = "registered"     // Rio has the client's callback URL
+	WebhookNotConfigured WebhookStatus = "not_configured" // The client has no webhook_url
+	WebhookFailed        WebhookStatus = "failed"         // Rio could not be listed or updated
+)
+
+// WebhookState is the registration state of one client's order webhook.
+type WebhookState struct {
+	ClientID  string        `json:"client_id"`
+	Status    WebhookStatus `json:"status"`
+	URL       string        `json:"url,omitempty"`
+	WebhookID string        `json:"webhook_id,omitempty"`
+	Removed   []string      `json:"removed,omitempty"` // Obsolete registrations deleted in the last check
+	Error     string        `json:"error,omitempty"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// WebhookRegistrar keeps Rio's order webhook registrations in line with the
+// clients' configured callback URLs. Rio scopes webhooks to an API key, so
+// clients sharing a key are reconciled together.
+type WebhookRegistrar struct {
+	client   *Client
+	resolver ConfigResolver
+	interval time.Duration
+
+	mu     sync.RWMutex
+	states map[string]WebhookState
+
+	// owned holds, per account, the callback endpoints this registrar has
+	// registered, so registrations are removed once no client wants them,
+	// including after a client is removed or clears its webhook_url. Only
+	// Reconcile touches it.
+	owned map[string]ownedEndpoints
+}
+
+// ownedEndpoints is an account's config and the callback endpoints
+// (scheme, host and path) registered for it.
+type ownedEndpoints struct {
+	cfg       *RioClientConfig
+	endpoints map[string]bool
+}
+
+// NewWebhookRegistrar constructs a registrar that re-checks every interval.
+func NewWebhookRegistrar(client *Client, resolver ConfigResolver, interval time.Duration) *WebhookRegistrar {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &WebhookRegistrar{
+		client:   client,
+		resolver: resolver,
+		interval: interval,
+		states:   make(map[string]WebhookState),
+		owned:    make(map[string]ownedEndpoints),
+	}
+}
+
+// Start reconciles immediately and then on every interval until ctx is done,
+// picking up newly discovered clients and changed URLs.
+func (r *WebhookRegistrar) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Reconcile(ctx); err != nil {
+			slog.Warn("rio.webhooks.reconcile_failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// States returns the registration state of every discovered client, ordered
+// by client ID.
+func (r *WebhookRegistrar) States() []WebhookState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]WebhookState, 0, len(r.states))
+	for _, s := range r.states {
+		out = append(out, s)
+	}
+	slices.SortFunc(out, func(a, b WebhookState) int {
+		return cmp.Compare(a.ClientID, b.ClientID)
+	})
+	return out
+}
+
+// webhookAccount is the clients sharing one Rio API key.
+type webhookAccount struct {
+	cfg     *RioClientConfig
+	clients map[string]*RioClientConfig // Clients with a webhook_url
+	owned   map[string]bool             // Endpoints registered by this adapter
+}
+
+// Reconcile lists each account's registrations, registers missing callback
+// URLs and deletes registrations that no client wants any more, including
+// duplicates. A registration is only deleted if its scheme, host and path
+// match a callback endpoint this adapter serves or has registered for the
+// account; others are left alone. Accounts whose clients were all removed
+// are reconciled from the last known config, unless a client failed to
+// resolve and might still want its registration.
+func (r *WebhookRegistrar) Reconcile(ctx context.Context) error {
+	clientIDs, err := r.resolver.DiscoverClients(ctx)
+	if err != nil {
+		return fmt.Errorf("discover clients: %w", err)
+	}
+
+	now := time.Now().UTC()
+	states := make(map[string]WebhookState, len(clientIDs))
+	accounts := make(map[string]*webhookAccount)
+	account := func(key string, cfg *RioClientConfig) *webhookAccount {
+		acct, ok := accounts[key]
+		if !ok {
+			acct = &webhookAccount{cfg: cfg, clients: make(map[string]*RioClientConfig), owned: make(map[string]bool)}
+			for e := range r.owned[key].endpoints {
+				acct.owned[e] = true
+			}
+			accounts[key] = acct
+		}
+		return acct
+	}
+	resolveFailed := false
+	for _, clientID := range clientIDs {
+		cfg, err := r.resolver.Resolve(ctx, clientID)
+		if err != nil {
+			resolveFailed = true
+			states[clientID] = WebhookState{ClientID: clientID, Status: WebhookFailed, Error: err.Error(), CheckedAt: now}
+			continue
+		}
+		acct := account(webhookAccountKey(cfg), cfg)
+		if cfg.WebhookURL == "" {
+			states[clientID] = WebhookState{ClientID: clientID, Status: WebhookNotConfigured, CheckedAt: now}
+			continue
+		}
+		acct.clients[clientID] = cfg
+		acct.owned[callbackEndpoint(cfg.WebhookURL)] = true
+	}
+
+	owned := make(map[string]ownedEndpoints)
+	for key, prev := range r.owned {
+		if _, ok := accounts[key]; !ok {
+			if resolveFailed {
+				owned[key] = prev
+				continue
+			}
+			account(key, prev.cfg)
+		}
+	}
+
+	for key, acct := range accounts {
+		if remaining := r.reconcileAccount(ctx, acct, now, states); len(remaining) > 0 {
+			owned[key] = ownedEndpoints{cfg: acct.cfg, endpoints: remaining}
+		}
+	}
+	r.owned = owned
+
+	r.mu.Lock()
+	r.states = states
+	r.mu.Unlock()
+	return nil
+}
+
+// reconcileAccount brings one account's registrations in line with its
+// clients' callback URLs and returns the endpoints it still owns: those of
+// the wanted URLs, plus any whose stale registrations could not be deleted.
+func (r *WebhookRegistrar) reconcileAccount(ctx context.Context, acct *webhookAccount, now time.Time, states map[string]WebhookState) map[string]bool {
+	fail := func(err error) {
+		for clientID, cfg := range acct.clients {
+			states[clientID] = WebhookState{ClientID: clientID, Status: WebhookFailed, URL: cfg.WebhookURL, Error: err.Error(), CheckedAt: now}
+		}
+	}
+
+	wanted := make(map[string]bool)
+	remaining := make(map[string]bool)
+	for _, cfg := range acct.clients {
+		wanted[cfg.WebhookURL] = true
+		remaining[callbackEndpoint(cfg.WebhookURL)] = true
+	}
+
+	existing, err := r.client.ListWebhooks(ctx, acct.cfg)
+	if err != nil {
+		fail(fmt.Errorf("list webhooks: %w", err))
+		return acct.owned
+	}
+
+	kept := make(map[string]string) // url -> webhook ID
+	var removed []string
+	for _, wh := range existing {
+		if wh.Type != "" && wh.Type != "orders" {
+			continue
+		}
+		if _, dup := kept[wh.URL]; wanted[wh.URL] && !dup {
+			kept[wh.URL] = wh.ID
+			continue
+		}
+		// Leave registrations that do not point at this adapter alone.
+		endpoint := callbackEndpoint(wh.URL)
+		if !wanted[wh.URL] && !acct.owned[endpoint] {
+			continue
+		}
+		if err := r.client.DeleteWebhook(ctx, acct.cfg, wh.ID); err != nil {
+			slog.Warn("rio.webhooks.delete_failed",
+				"webhook_id", wh.ID,
+				"url", wh.URL,
+				"error", err)
+			remaining[endpoint] = true
+			continue
+		}
+		removed = append(removed, wh.ID)
+		slog.Info("rio.webhooks.removed",
+			"webhook_id", wh.ID,
+			"url", wh.URL)
+	}
+
+	errs := make(map[string]error)
+	for u := range wanted {
+		if _, ok := kept[u]; ok {
+			continue
+		}
+		resp, err := r.client.RegisterWebhook(ctx, acct.cfg, u, true)
+		if err != nil {
+			slog.Error("rio.webhooks.register_failed",
+				"url", u,
+				"error", err)
+			errs[u] = err
+			continue
+		}
+		kept[u] = resp.ID
+		slog.Info("rio.webhooks.registered",
+			"webhook_id", resp.ID,
+			"url", u)
+	}
+
+	for clientID, cfg := range acct.clients {
+		state := WebhookState{
+			ClientID:  clientID,
+			Status:    WebhookRegistered,
+			URL:       cfg.WebhookURL,
+			WebhookID: kept[cfg.WebhookURL],
+			Removed:   removed,
+			CheckedAt: now,
+		}
+		if err := errs[cfg.WebhookURL]; err != nil {
+			state.Status = WebhookFailed
+			state.Error = err.Error()
+		}
+		states[clientID] = state
+	}
+	return remaining
+}
+
+// webhookAccountKey identifies the Rio account a client's API key belongs to.
+func webhookAccountKey(cfg *RioClientConfig) string {
+	return cfg.BaseURL + "|" + cfg.APIKey
+}
+
+// callbackEndpoint returns the scheme, host and path of a callback URL, or
+// the URL itself if it does not parse.
+func callbackEndpoint(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + u.Path
+}
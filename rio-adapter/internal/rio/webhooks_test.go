@@ -0,0 +1,182 @@
+package rio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookServer is a fake Rio holding webhook registrations.
+type webhookServer struct {
+	mu       sync.Mutex
+	hooks    []RioWebhookRegistrationResponse
+	nextID   int
+	deleted  []string
+	listFail bool
+}
+
+func (s *webhookServer) start(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/webhooks":
+			if s.listFail {
+				w.WriteHeader(http.StatusBadRequest)
+				writeJSON(w, RioErrorResponse{Error: "bad_request"})
+				return
+			}
+			writeJSON(w, s.hooks)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/webhooks/orders":
+			var req RioWebhookRegistration
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			s.nextID++
+			hook := RioWebhookRegistrationResponse{ID: fmt.Sprintf("wh-new-%d", s.nextID), URL: req.URL, Type: "orders"}
+			s.hooks = append(s.hooks, hook)
+			writeJSON(w, hook)
+		case r.Method == http.MethodDelete:
+			id := strings.TrimPrefix(r.URL.Path, "/api/webhooks/")
+			s.deleted = append(s.deleted, id)
+			for i, h := range s.hooks {
+				if h.ID == id {
+					s.hooks = append(s.hooks[:i], s.hooks[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// mapConfigResolver resolves each client to its own config.
+type mapConfigResolver map[string]*RioClientConfig
+
+func (m mapConfigResolver) Resolve(_ context.Context, clientID string) (*RioClientConfig, error) {
+	if cfg, ok := m[clientID]; ok {
+		return cfg, nil
+	}
+	return nil, fmt.Errorf("no config for %s", clientID)
+}
+
+func (m mapConfigResolver) DiscoverClients(_ context.Context) ([]string, error) {
+	var ids []string
+	for id := range m {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+const testCallbackURL = "https://adapter.example.com/webhooks/rio/orders"
+
+func TestWebhookRegistrar_RemovesDuplicatesAndStaleURLs(t *testing.T) {
+	fake := &webhookServer{hooks: []RioWebhookRegistrationResponse{
+		{ID: "wh-1", URL: testCallbackURL, Type: "orders"},
+		{ID: "wh-2", URL: testCallbackURL, Type: "orders"},
+		{ID: "wh-3", URL: "https://old-host.example.com/webhooks/rio/orders", Type: "orders"},
+		{ID: "wh-4", URL: "https://erp.example.com/rio-hooks", Type: "orders"},
+		{ID: "wh-5", URL: testCallbackURL, Type: "payments"},
+	}}
+	srv := fake.start(t)
+	reg := NewWebhookRegistrar(NewClient(nil), mapConfigResolver{
+		"client-001": {BaseURL: srv.URL, APIKey: "k", WebhookURL: testCallbackURL},
+	}, time.Minute)
+
+	require.NoError(t, reg.Reconcile(context.Background()))
+
+	assert.ElementsMatch(t, []string{"wh-2"}, fake.deleted, "other hosts, foreign URLs and other webhook types are kept")
+	assert.Zero(t, fake.nextID, "existing registration must be reused")
+
+	states := reg.States()
+	require.Len(t, states, 1)
+	assert.Equal(t, WebhookRegistered, states[0].Status)
+	assert.Equal(t, "wh-1", states[0].WebhookID)
+}
+
+func TestWebhookRegistrar_RegistersOnceAndFollowsURLChanges(t *testing.T) {
+	fake := &webhookServer{}
+	srv := fake.start(t)
+	resolver := mapConfigResolver{
+		"client-001": {BaseURL: srv.URL, APIKey: "k", WebhookURL: testCallbackURL},
+		"client-002": {BaseURL: srv.URL, APIKey: "k", WebhookURL: testCallbackURL},
+	}
+	reg := NewWebhookRegistrar(NewClient(nil), resolver, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, reg.Reconcile(ctx))
+	require.NoError(t, reg.Reconcile(ctx))
+	assert.Equal(t, 1, fake.nextID, "clients sharing an API key share one registration; restarts add none")
+
+	// The callback moves to a new host.
+	moved := "https://adapter-v2.example.com/webhooks/rio/orders"
+	resolver["client-001"].WebhookURL = moved
+	resolver["client-002"].WebhookURL = moved
+	require.NoError(t, reg.Reconcile(ctx))
+
+	assert.Equal(t, []string{"wh-new-1"}, fake.deleted)
+	require.Len(t, fake.hooks, 1)
+	assert.Equal(t, moved, fake.hooks[0].URL)
+	for _, s := range reg.States() {
+		assert.Equal(t, fake.hooks[0].ID, s.WebhookID, s.ClientID)
+	}
+}
+
+func TestWebhookRegistrar_States(t *testing.T) {
+	fake := &webhookServer{listFail: true}
+	srv := fake.start(t)
+	reg := NewWebhookRegistrar(NewClient(nil), mapConfigResolver{
+		"client-a": {BaseURL: srv.URL, APIKey: "k"},
+		"client-b": {BaseURL: srv.URL, APIKey: "k", WebhookURL: testCallbackURL},
+	}, time.Minute)
+
+	require.NoError(t, reg.Reconcile(context.Background()))
+
+	states := reg.States()
+	require.Len(t, states, 2)
+	assert.Equal(t, "client-a", states[0].ClientID)
+	assert.Equal(t, WebhookNotConfigured, states[0].Status)
+	assert.Equal(t, WebhookFailed, states[1].Status)
+	assert.Contains(t, states[1].Error, "list webhooks")
+}
+
+func TestWebhookRegistrar_RemovesRegistrationsNoClientWants(t *testing.T) {
+	fake := &webhookServer{}
+	srv := fake.start(t)
+	otherURL := "https://adapter-b.example.com/webhooks/rio/orders"
+	resolver := mapConfigResolver{
+		"client-001": {BaseURL: srv.URL, APIKey: "k1", WebhookURL: testCallbackURL},
+		"client-002": {BaseURL: srv.URL, APIKey: "k2", WebhookURL: otherURL},
+	}
+	reg := NewWebhookRegistrar(NewClient(nil), resolver, time.Minute)
+	ctx := context.Background()
+	require.NoError(t, reg.Reconcile(ctx))
+	require.Len(t, fake.hooks, 2)
+
+	// client-001 clears its webhook_url; client-002 is removed altogether.
+	resolver["client-001"].WebhookURL = ""
+	delete(resolver, "client-002")
+	require.NoError(t, reg.Reconcile(ctx))
+
+	assert.ElementsMatch(t, []string{"wh-new-1", "wh-new-2"}, fake.deleted)
+	assert.Empty(t, fake.hooks)
+	states := reg.States()
+	require.Len(t, states, 1)
+	assert.Equal(t, WebhookNotConfigured, states[0].Status)
+
+	// Nothing is left to own, so later runs delete nothing more.
+	require.NoError(t, reg.Reconcile(ctx))
+	assert.Len(t, fake.deleted, 2)
+}
@@ -3,6 +3,7 @@ package secrets
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	intsecrets "github.com/Checker-Finance/adapters/internal/secrets"
 	pkgsecrets "github.com/Checker-Finance/adapters/pkg/secrets"
@@ -14,7 +15,7 @@ import (
 // It is a thin wrapper over the generic intsecrets.AWSResolver[rio.RioClientConfig].
 //
 // Secret naming convention: {env}/{clientID}/rio
-// Secret JSON format:       {"api_key": "...", "base_url": "https://...", "country": "US"}
+// Secret JSON format:       {"api_key": "...", "base_url": "https://...", "country": "US", "countries": "US,MX,PE", "us_bank_transfer_method": "wire"}
 type AWSResolver struct {
 	inner *intsecrets.AWSResolver[rio.RioClientConfig]
 }
@@ -50,12 +51,14 @@ func parseRioConfig(m map[string]string) (rio.RioClientConfig, error) {
 		sigHeader = "X-Rio-Signature"
 	}
 	cfg := rio.RioClientConfig{
-		APIKey:           m["api_key"],
-		BaseURL:          m["base_url"],
-		Country:          m["country"],
-		WebhookURL:       m["webhook_url"],
-		WebhookSecret:    m["webhook_secret"],
-		WebhookSigHeader: sigHeader,
+		APIKey:               m["api_key"],
+		BaseURL:              m["base_url"],
+		Country:              m["country"],
+		Countries:            splitList(m["countries"]),
+		USBankTransferMethod: m["us_bank_transfer_method"],
+		WebhookURL:           m["webhook_url"],
+		WebhookSecret:        m["webhook_secret"],
+		WebhookSigHeader:     sigHeader,
 	}
 	if cfg.APIKey == "" {
 		return rio.RioClientConfig{}, fmt.Errorf("missing required field 'api_key'")
@@ -68,3 +71,14 @@ func parseRioConfig(m map[string]string) (rio.RioClientConfig, error) {
 	}
 	return cfg, nil
 }
+
+// splitList parses a comma-separated secret value, ignoring blanks.
+func splitList(v string) []string {
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.ToUpper(strings.TrimSpace(part)); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
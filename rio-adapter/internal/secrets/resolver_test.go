@@ -241,3 +241,18 @@ func TestAWSResolver_DiscoverClients_ProviderError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "list failed")
 }
+
+func TestParseRioConfig_MultiCountry(t *testing.T) {
+	cfg, err := parseRioConfig(map[string]string{
+		"api_key":                 "k",
+		"base_url":                "https://rio.example.com",
+		"country":                 "US",
+		"countries":               "mx, pe,,",
+		"us_bank_transfer_method": "wire",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MX", "PE"}, cfg.Countries)
+	assert.Equal(t, "wire", cfg.USBankTransferMethod)
+	assert.True(t, cfg.SupportsCountry("pe"))
+	assert.False(t, cfg.SupportsCountry("BR"))
+}
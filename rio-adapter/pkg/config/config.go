@@ -49,7 +49,14 @@ type Config struct {
 	// Rio-specific configuration
 	// Per-client config (api_key, base_url, country, webhook_url, webhook_secret, webhook_sig_header)
 	// is resolved from AWS Secrets Manager at runtime. See internal/secrets/resolver.go.
-	RioPollInterval time.Duration // Polling interval for Rio order status (fallback for webhooks)
+	RioPollInterval        time.Duration // Polling interval for Rio order status (fallback for webhooks)
+	RioWebhookSyncInterval time.Duration // How often webhook registrations are reconciled with client configs
+
+	// JetStream publishing
+	NATSStream            string        // Stream that must capture the adapter's trade events
+	NATSDedupWindow       time.Duration // Duplicate window enforced on NATSStream
+	NATSPublishAsync      bool          // Publish without waiting for each ack
+	NATSPublishMaxPending int           // Async publishes awaiting an ack before publishing blocks
 }
 
 // Load loads configuration from environment variables, then overlays any values
@@ -87,7 +94,13 @@ func Load(ctx context.Context) *Config {
 		PGHealthCheckPeriod: pkgconfig.GetEnvDuration("PG_HEALTH_CHECK_PERIOD", 1*time.Minute),
 
 		// Rio-specific configuration (per-client config resolved from AWS Secrets Manager)
-		RioPollInterval: pkgconfig.GetEnvDuration("RIO_POLL_INTERVAL", 30*time.Second),
+		RioPollInterval:        pkgconfig.GetEnvDuration("RIO_POLL_INTERVAL", 30*time.Second),
+		RioWebhookSyncInterval: pkgconfig.GetEnvDuration("RIO_WEBHOOK_SYNC_INTERVAL", 10*time.Minute),
+
+		NATSStream:            pkgconfig.GetEnv("NATS_STREAM", "RIO_EVENTS"),
+		NATSDedupWindow:       pkgconfig.GetEnvDuration("NATS_DEDUP_WINDOW", 2*time.Minute),
+		NATSPublishAsync:      pkgconfig.GetEnvBool("NATS_PUBLISH_ASYNC", false),
+		NATSPublishMaxPending: pkgconfig.GetEnvInt("NATS_PUBLISH_MAX_PENDING", 256),
 	}
 
 	secretPath := fmt.Sprintf("%s/%s", cfg.Env, cfg.ServiceName)
@@ -9,11 +9,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/auth"
 	"github.com/Checker-Finance/adapters/internal/jobs"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/rate"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/logger"
 	"github.com/Checker-Finance/adapters/pkg/secrets"
 	"github.com/Checker-Finance/adapters/pkg/utils"
@@ -35,6 +39,13 @@ func main() {
 	cfg.Venue = "xfx"
 
 	logger.Init(cfg.ServiceName, cfg.Env, cfg.LogLevel)
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.ServiceName)
+	if err != nil {
+		slog.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("starting [xfx-adapter]...")
 	slog.Info("connection to DSN", "dsn", utils.MaskDSN(cfg.DatabaseURL))
 
@@ -72,11 +83,26 @@ func main() {
 	}
 
 	// --- Publisher ---
-	pub, err := publisher.New(nc, "evt.xfx", "XFX_EVENTS")
+	pub, err := publisher.NewWithOptions(nc, "evt.xfx", "XFX_EVENTS", publisher.Options{
+		Async:      cfg.NATSPublishAsync,
+		MaxPending: cfg.NATSPublishMaxPending,
+	})
 	if err != nil {
 		slog.Error("failed to init publisher", "error", err)
 		os.Exit(1)
 	}
+	if err := pub.EnsureStreams(
+		publisher.StreamSpec{
+			Name:       cfg.NATSStream,
+			Subjects:   []string{"evt.trade.*.v1.XFX"},
+			Duplicates: cfg.NATSDedupWindow,
+		},
+		// Quote, balance and recon subjects may live in other streams, some shared by several adapters
+		publisher.StreamSpec{Subjects: []string{cfg.OutboundSubject, xfx.SubjectQuoteExpired, jobs.SubjectSummaryRefreshed}},
+	); err != nil {
+		slog.Error("failed to verify jetstream streams", "error", err)
+		os.Exit(1)
+	}
 
 	// --- Rate limiter ---
 	rateMgr := rate.NewManager(rate.Config{
@@ -101,6 +127,13 @@ func main() {
 	// --- Legacy trade sync writer ---
 	tradeSyncWriter := legacy.NewTradeSyncWriter(st.(*store.HybridStore).PG, "xfx-adapter")
 
+	// --- Outbox relay: publishes terminal trade events queued with their t_order rows ---
+	outboxRelay := outbox.NewRelay(outbox.NewPGStore(st.(*store.HybridStore).PG, "xfx-adapter"), pub, outbox.RelayConfig{})
+	go outboxRelay.Start(ctx)
+
+	// --- Audit trail: append-only record of each RFQ from request to final status ---
+	auditStore := audit.NewPGStore(st.(*store.HybridStore).PG)
+
 	// --- RFQ sweeper: expires stale open RFQs and quotes in the legacy DB ---
 	rfqSweeper := legacy.NewRFQSweeper(
 		st.(*store.HybridStore).PG,
@@ -120,6 +153,16 @@ func main() {
 
 	// --- XFX Auth token manager ---
 	tokenMgr := xfx.NewTokenManager()
+	if cfg.TokenPersist {
+		tokenStore, err := auth.NewEncryptedStore(st, cfg.TokenEncryptionKey)
+		if err != nil {
+			slog.Error("failed to init token store", "error", err)
+			os.Exit(1)
+		}
+		tokenMgr.SetStore(tokenStore)
+	}
+	go tokenMgr.Run(ctx, time.Minute)
+	go tokenMgr.RunClientPruning(ctx, resolver, cfg.DiscoveryInterval)
 
 	// --- XFX HTTP client ---
 	xfxClient := xfx.NewClient(rateMgr, tokenMgr)
@@ -146,6 +189,11 @@ func main() {
 		tradeSyncWriter,
 	)
 	xfxSvc.SetPoller(poller)
+	auditRecorder := audit.NewRecorder(auditStore, "xfx")
+	xfxSvc.SetAuditRecorder(auditRecorder)
+
+	// --- Report quotes that lapse unexecuted (XFX quotes live ~15s) ---
+	go xfxSvc.StartQuoteExpiry(ctx, time.Second)
 
 	// --- NATS command consumer: quote requests and trade execute commands ---
 	cmdConsumer := xfx.NewCommandConsumer(nc, xfxSvc)
@@ -161,14 +209,16 @@ func main() {
 		IdleTimeout:  cfg.HTTPIdleTimeout,
 		BodyLimit:    cfg.HTTPBodyLimit,
 	})
+	app.Use(tracing.FiberMiddleware())
 
 	clientValidator := api.NewResolverValidator(resolver)
 	xfxHandler := api.NewXFXHandler(xfxSvc, clientValidator)
 	resolveHandler := api.NewOrderResolveHandler(xfxSvc, st, tradeSyncWriter)
 	productsHandler := api.NewProductsHandler(xfxSvc)
 	balanceHandler := api.NewBalanceHandler(st)
+	auditHandler := audit.NewHandler(auditStore, "xfx")
 
-	api.RegisterRoutes(app, nc, st, xfxHandler, resolveHandler, productsHandler, balanceHandler)
+	api.RegisterRoutes(app, nc, st, xfxHandler, resolveHandler, productsHandler, balanceHandler, auditHandler)
 
 	// Start HTTP server
 	go func() {
@@ -196,10 +246,19 @@ func main() {
 	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
 		slog.Warn("fiber.shutdown_failed", "error", err)
 	}
+	if err := pub.Flush(shutdownCtx); err != nil {
+		slog.Warn("nats.flush_incomplete", "error", err)
+	}
 	if err := nc.Drain(); err != nil {
 		slog.Warn("nats.drain_failed", "error", err)
 	}
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		slog.Warn("audit.flush_incomplete", "error", err)
+	}
 	if err := st.Close(); err != nil {
 		slog.Warn("store.close_failed", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("tracing.shutdown_failed", "error", err)
+	}
 }
@@ -2,17 +2,20 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Checker-Finance/adapters/pkg/model"
+	"github.com/Checker-Finance/adapters/xfx-adapter/internal/xfx"
 )
 
 // RFQService defines the interface for RFQ operations used by the handler.
 type RFQService interface {
 	CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Quote, error)
 	ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*model.TradeConfirmation, error)
+	GetQuote(ctx context.Context, clientID, quoteID string) (*model.Quote, error)
 }
 
 // ClientValidator checks whether a client ID is configured and allowed.
@@ -44,21 +47,26 @@ func (h *XFXHandler) CreateRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
 	r := toRFQRequest(req)
 
-	quote, err := h.service.CreateRFQ(c.Context(), r)
+	quote, err := h.service.CreateRFQ(c.UserContext(), r)
 	if err != nil {
 		slog.Error("xfx.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(RFQResponse{
+		resp := RFQResponse{
 			QuoteID:  req.ID,
 			ErrorMsg: err.Error(),
-		})
+		}
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			resp.ErrorCode = verr.Code
+			resp.ErrorMsg = verr.Message
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(resp)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(RFQResponse{
@@ -79,7 +87,7 @@ func (h *XFXHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
@@ -92,13 +100,17 @@ func (h *XFXHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 		"client", req.ClientID,
 		"quote_id", quoteID)
 
-	trade, err := h.service.ExecuteRFQ(c.Context(), req.ClientID, quoteID)
+	trade, err := h.service.ExecuteRFQ(c.UserContext(), req.ClientID, quoteID)
 	if err != nil {
 		slog.Error("xfx.execute_rfq.failed",
 			"client", req.ClientID,
 			"quote_id", quoteID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(RFQExecutionResponse{
+		status := fiber.StatusBadRequest
+		if errors.Is(err, xfx.ErrQuoteExpired) {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(RFQExecutionResponse{
 			OrderID:  req.OrderID,
 			ErrorMsg: err.Error(),
 		})
@@ -113,6 +125,39 @@ func (h *XFXHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 	})
 }
 
+// GetQuoteHandler returns the venue's current view of a quote.
+// GET /api/v1/quotes/:id?clientId=...
+func (h *XFXHandler) GetQuoteHandler(c *fiber.Ctx) error {
+	quoteID := c.Params("id")
+	clientID := c.Query("clientId")
+	if clientID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "clientId is required"})
+	}
+
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), clientID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
+	}
+
+	quote, err := h.service.GetQuote(c.UserContext(), clientID, quoteID)
+	if err != nil {
+		slog.Warn("xfx.get_quote.failed",
+			"client", clientID,
+			"quote_id", quoteID,
+			"error", err)
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.JSON(QuoteStatusResponse{
+		ProviderQuoteID: quote.ID,
+		Instrument:      quote.Instrument,
+		Side:            quote.Side,
+		Quantity:        quote.Quantity,
+		Price:           quote.Price,
+		Status:          quote.Status,
+		ExpireAt:        quote.ExpiresAt.Unix(),
+	})
+}
+
 // toRFQRequest converts an API request to a canonical RFQRequest.
 func toRFQRequest(req RFQCreateRequest) model.RFQRequest {
 	return model.RFQRequest{
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Checker-Finance/adapters/pkg/model"
+	"github.com/Checker-Finance/adapters/xfx-adapter/internal/xfx"
 )
 
 // ─── Mock service ─────────────────────────────────────────────────────────────
@@ -22,6 +23,7 @@ import (
 type mockRFQService struct {
 	createRFQFn  func(ctx context.Context, req model.RFQRequest) (*model.Quote, error)
 	executeRFQFn func(ctx context.Context, clientID, quoteID string) (*model.TradeConfirmation, error)
+	getQuoteFn   func(ctx context.Context, clientID, quoteID string) (*model.Quote, error)
 }
 
 func (m *mockRFQService) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Quote, error) {
@@ -38,6 +40,13 @@ func (m *mockRFQService) ExecuteRFQ(ctx context.Context, clientID, quoteID strin
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (m *mockRFQService) GetQuote(ctx context.Context, clientID, quoteID string) (*model.Quote, error) {
+	if m.getQuoteFn != nil {
+		return m.getQuoteFn(ctx, clientID, quoteID)
+	}
+	return nil, fmt.Errorf("not implemented")
+}
+
 // ─── Mock validator ───────────────────────────────────────────────────────────
 
 type mockClientValidator struct {
@@ -56,6 +65,7 @@ func newTestApp(svc RFQService) *fiber.App {
 	v1 := app.Group("/api/v1")
 	v1.Post("/quotes", handler.CreateRFQHandler)
 	v1.Post("/orders", handler.ExecuteRFQHandler)
+	v1.Get("/quotes/:id", handler.GetQuoteHandler)
 	return app
 }
 
@@ -240,6 +250,29 @@ func TestCreateRFQHandler_KnownClient_Allowed(t *testing.T) {
 
 // ─── ExecuteRFQHandler ────────────────────────────────────────────────────────
 
+func TestCreateRFQHandler_ValidationErrorCode(t *testing.T) {
+	svc := &mockRFQService{
+		createRFQFn: func(_ context.Context, _ model.RFQRequest) (*model.Quote, error) {
+			return nil, &model.RFQValidationError{Code: model.RFQErrBelowMinSize, Message: "below the minimum"}
+		},
+	}
+	app := newTestApp(svc)
+
+	body := `{"quoteId": "q-small", "clientId": "client-001", "pair": "USD/MXN", "orderSide": "buy", "quantity": 10}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/quotes", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	var result RFQResponse
+	raw, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(raw, &result))
+	assert.Equal(t, model.RFQErrBelowMinSize, result.ErrorCode)
+	assert.Equal(t, "below the minimum", result.ErrorMsg)
+}
+
 func TestExecuteRFQHandler_Success_BodyQuoteID(t *testing.T) {
 	var receivedQuoteID string
 	svc := &mockRFQService{
@@ -374,6 +407,29 @@ func TestExecuteRFQHandler_ServiceError(t *testing.T) {
 	assert.Contains(t, result.ErrorMsg, "quote already executed")
 }
 
+func TestExecuteRFQHandler_QuoteExpired(t *testing.T) {
+	svc := &mockRFQService{
+		executeRFQFn: func(_ context.Context, _, quoteID string) (*model.TradeConfirmation, error) {
+			return nil, fmt.Errorf("%w: %s", xfx.ErrQuoteExpired, quoteID)
+		},
+	}
+	app := newTestApp(svc)
+
+	body := `{"clientId": "client-001", "orderId": "ord-exp", "quoteId": "qt-lapsed"}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+
+	var result RFQExecutionResponse
+	raw, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(raw, &result))
+	assert.Equal(t, "ord-exp", result.OrderID)
+	assert.Contains(t, result.ErrorMsg, "quote expired")
+}
+
 func TestExecuteRFQHandler_InvalidJSON(t *testing.T) {
 	app := newTestApp(&mockRFQService{})
 
@@ -385,6 +441,63 @@ func TestExecuteRFQHandler_InvalidJSON(t *testing.T) {
 	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
 }
 
+// ─── GetQuoteHandler ──────────────────────────────────────────────────────────
+
+func TestGetQuoteHandler_Success(t *testing.T) {
+	svc := &mockRFQService{
+		getQuoteFn: func(_ context.Context, clientID, quoteID string) (*model.Quote, error) {
+			assert.Equal(t, "client-001", clientID)
+			assert.Equal(t, "qt-001", quoteID)
+			return &model.Quote{
+				ID:         "qt-001",
+				Instrument: "USD/MXN",
+				Side:       "BUY",
+				Quantity:   1000,
+				Price:      17.25,
+				Status:     "EXPIRED",
+				ExpiresAt:  time.Date(2025, 6, 1, 12, 0, 30, 0, time.UTC),
+			}, nil
+		},
+	}
+	app := newTestApp(svc)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/quotes/qt-001?clientId=client-001", nil)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result QuoteStatusResponse
+	raw, _ := io.ReadAll(resp.Body)
+	require.NoError(t, json.Unmarshal(raw, &result))
+	assert.Equal(t, "qt-001", result.ProviderQuoteID)
+	assert.Equal(t, "EXPIRED", result.Status)
+	assert.Equal(t, 17.25, result.Price)
+	assert.Equal(t, int64(1748779230), result.ExpireAt)
+}
+
+func TestGetQuoteHandler_MissingClientID(t *testing.T) {
+	app := newTestApp(&mockRFQService{})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/quotes/qt-001", nil)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGetQuoteHandler_NotFound(t *testing.T) {
+	svc := &mockRFQService{
+		getQuoteFn: func(_ context.Context, _, _ string) (*model.Quote, error) {
+			return nil, fmt.Errorf("quote not found")
+		},
+	}
+	app := newTestApp(svc)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/quotes/qt-missing?clientId=client-001", nil)
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
 // ─── toRFQRequest ─────────────────────────────────────────────────────────────
 
 func TestToRFQRequest(t *testing.T) {
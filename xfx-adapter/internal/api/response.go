@@ -6,6 +6,7 @@ type RFQResponse struct {
 	ProviderQuoteId string  `json:"providerQuoteId"`
 	Price           float64 `json:"price"`
 	ExpireAt        int64   `json:"expireAt"`
+	ErrorCode       string  `json:"errorCode,omitempty"`
 	ErrorMsg        string  `json:"errorMessage,omitempty"`
 }
 
@@ -18,3 +19,14 @@ type RFQExecutionResponse struct {
 	ExecutedAt      int64   `json:"executedAt"`
 	ErrorMsg        string  `json:"errorMessage,omitempty"`
 }
+
+// QuoteStatusResponse represents the venue's current view of a quote.
+type QuoteStatusResponse struct {
+	ProviderQuoteID string  `json:"providerQuoteId"`
+	Instrument      string  `json:"pair"`
+	Side            string  `json:"orderSide"`
+	Quantity        float64 `json:"quantity"`
+	Price           float64 `json:"price"`
+	Status          string  `json:"status"`
+	ExpireAt        int64   `json:"expireAt"`
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/store"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
@@ -12,7 +13,7 @@ import (
 )
 
 // RegisterRoutes registers all HTTP routes on the Fiber app.
-func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store, xfxHandler *XFXHandler, resolveHandler *OrderResolveHandler, productsHandler *ProductsHandler, balanceHandler *BalanceHandler) {
+func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store, xfxHandler *XFXHandler, resolveHandler *OrderResolveHandler, productsHandler *ProductsHandler, balanceHandler *BalanceHandler, auditHandler *audit.Handler) {
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
 	// Health check
@@ -51,8 +52,10 @@ func RegisterRoutes(app *fiber.App, nc *nats.Conn, st store.Store, xfxHandler *X
 	// API routes
 	v1 := app.Group("/api/v1")
 	v1.Post("/quotes", xfxHandler.CreateRFQHandler)
+	v1.Get("/quotes/:id", xfxHandler.GetQuoteHandler)
 	v1.Post("/orders", xfxHandler.ExecuteRFQHandler)
 	v1.Post("/resolve-order/:quoteId", resolveHandler.ResolveOrder)
 	v1.Get("/products", productsHandler.ListProducts)
 	v1.Get("/balances/:client_id", balanceHandler.GetBalances)
+	v1.Get("/audit/:id", auditHandler.Timeline)
 }
@@ -3,6 +3,7 @@ package secrets
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	intsecrets "github.com/Checker-Finance/adapters/internal/secrets"
 	pkgsecrets "github.com/Checker-Finance/adapters/pkg/secrets"
@@ -67,5 +68,12 @@ func parseXFXConfig(m map[string]string) (xfx.XFXClientConfig, error) {
 	if cfg.Auth0Audience == "" {
 		return xfx.XFXClientConfig{}, fmt.Errorf("missing required field 'auth0_audience'")
 	}
+	if v := m["requote_tolerance"]; v != "" {
+		tolerance, err := strconv.ParseFloat(v, 64)
+		if err != nil || tolerance < 0 {
+			return xfx.XFXClientConfig{}, fmt.Errorf("invalid 'requote_tolerance' %q", v)
+		}
+		cfg.RequoteTolerance = tolerance
+	}
 	return cfg, nil
 }
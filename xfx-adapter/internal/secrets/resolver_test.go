@@ -108,3 +108,23 @@ func TestParseXFXConfig_ExtraFieldsIgnored(t *testing.T) {
 	assert.Equal(t, "my-client-id", cfg.ClientID)
 	assert.Equal(t, "https://dev-api.xfx.io", cfg.BaseURL)
 }
+
+func TestParseXFXConfig_RequoteTolerance(t *testing.T) {
+	m := map[string]string{
+		"client_id":         "my-client-id",
+		"client_secret":     "my-client-secret",
+		"base_url":          "https://dev-api.xfx.io",
+		"auth0_endpoint":    "https://dev.auth0.com/oauth/token",
+		"auth0_audience":    "https://api.xfx.io",
+		"requote_tolerance": "0.0015",
+	}
+
+	cfg, err := parseXFXConfig(m)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0015, cfg.RequoteTolerance)
+
+	m["requote_tolerance"] = "-1"
+	_, err = parseXFXConfig(m)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requote_tolerance")
+}
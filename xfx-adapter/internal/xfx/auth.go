@@ -7,8 +7,9 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"sync"
 	"time"
+
+	"github.com/Checker-Finance/adapters/internal/auth"
 )
 
 const (
@@ -16,57 +17,94 @@ const (
 	tokenExpiryBuffer = 5 * time.Minute
 )
 
-// tokenEntry caches a bearer token with its expiry time.
-type tokenEntry struct {
-	accessToken string
-	expiresAt   time.Time
-}
-
 // TokenManager fetches and caches OAuth2 client credentials tokens per client.
 // Each client ID maps to a separate cached token derived from its own client_id/client_secret.
 // Auth0 endpoint and audience are taken from the per-client XFXClientConfig.
 type TokenManager struct {
 	client *http.Client
-	mu     sync.Mutex
-	cache  map[string]tokenEntry // clientID → tokenEntry
+	tokens *auth.TokenManager
 }
 
 // NewTokenManager creates a new TokenManager.
 func NewTokenManager() *TokenManager {
 	return &TokenManager{
 		client: &http.Client{Timeout: 10 * time.Second},
-		cache:  make(map[string]tokenEntry),
+		tokens: auth.NewTokenManager("xfx", tokenExpiryBuffer),
 	}
 }
 
+// SetStore persists tokens in store so replicas share them.
+func (m *TokenManager) SetStore(store auth.TokenStore) {
+	m.tokens.SetStore(store)
+}
+
+// Run refreshes cached tokens in the background until ctx is cancelled.
+func (m *TokenManager) Run(ctx context.Context, interval time.Duration) {
+	m.tokens.Run(ctx, interval)
+}
+
 // GetToken returns a valid bearer token for the given client config.
 // Returns cached token if still valid; otherwise fetches a new one from Auth0.
 func (m *TokenManager) GetToken(ctx context.Context, cfg *XFXClientConfig) (string, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	if entry, ok := m.cache[cfg.ClientID]; ok {
-		if time.Now().Before(entry.expiresAt.Add(-tokenExpiryBuffer)) {
-			return entry.accessToken, nil
+	return m.tokens.Token(ctx, cfg.ClientID, func(ctx context.Context, _ auth.Token) (auth.Token, error) {
+		token, err := m.fetchToken(ctx, cfg)
+		if err != nil {
+			return auth.Token{}, fmt.Errorf("xfx auth: fetch token for client %q: %w", cfg.ClientID, err)
 		}
-	}
 
-	// Fetch new token from Auth0
-	token, err := m.fetchToken(ctx, cfg)
+		slog.Info("xfx.auth.token_refreshed",
+			"client_id", cfg.ClientID,
+			"expires_in_sec", token.ExpiresIn)
+
+		return auth.Token{
+			AccessToken: token.AccessToken,
+			ExpiresAt:   time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+		}, nil
+	})
+}
+
+// InvalidateToken discards token for the client after XFX rejected it, so
+// the next GetToken fetches a new one.
+func (m *TokenManager) InvalidateToken(ctx context.Context, cfg *XFXClientConfig, token string) {
+	m.tokens.Invalidate(ctx, cfg.ClientID, token)
+}
+
+// PruneClients forgets the tokens of clients no longer configured in Secrets
+// Manager, so they stop being refreshed. Nothing is forgotten if any
+// configured client fails to resolve, since its token key would be unknown.
+func (m *TokenManager) PruneClients(ctx context.Context, resolver ConfigResolver) error {
+	clientIDs, err := resolver.DiscoverClients(ctx)
 	if err != nil {
-		return "", fmt.Errorf("xfx auth: fetch token for client %q: %w", cfg.ClientID, err)
+		return err
 	}
-
-	m.cache[cfg.ClientID] = tokenEntry{
-		accessToken: token.AccessToken,
-		expiresAt:   time.Now().Add(time.Duration(token.ExpiresIn) * time.Second),
+	keys := make([]string, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		cfg, err := resolver.Resolve(ctx, clientID)
+		if err != nil {
+			return fmt.Errorf("resolve %q: %w", clientID, err)
+		}
+		keys = append(keys, cfg.ClientID)
 	}
+	if n := m.tokens.Retain(keys); n > 0 {
+		slog.Info("xfx.auth.tokens_forgotten", "count", n)
+	}
+	return nil
+}
 
-	slog.Info("xfx.auth.token_refreshed",
-		"client_id", cfg.ClientID,
-		"expires_in_sec", token.ExpiresIn)
-
-	return token.AccessToken, nil
+// RunClientPruning calls PruneClients every interval until ctx is cancelled.
+func (m *TokenManager) RunClientPruning(ctx context.Context, resolver ConfigResolver, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.PruneClients(ctx, resolver); err != nil {
+				slog.Warn("xfx.auth.prune_failed", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
 // fetchToken requests a new access token from Auth0.
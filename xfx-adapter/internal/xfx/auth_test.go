@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/internal/auth"
 )
 
 // mockTransport is an http.RoundTripper that delegates to a handler function.
@@ -87,12 +89,10 @@ func TestTokenManager_GetToken_ReturnsCachedToken(t *testing.T) {
 	})
 
 	// Pre-populate cache with a valid token
-	tm.mu.Lock()
-	tm.cache["client-b"] = tokenEntry{
-		accessToken: "cached-token",
-		expiresAt:   time.Now().Add(24 * time.Hour),
-	}
-	tm.mu.Unlock()
+	tm.tokens.Set("client-b", auth.Token{
+		AccessToken: "cached-token",
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+	})
 
 	token, err := tm.GetToken(context.Background(), testCfg("client-b"))
 	require.NoError(t, err)
@@ -115,12 +115,10 @@ func TestTokenManager_GetToken_RefreshesWhenNearExpiry(t *testing.T) {
 	})
 
 	// Token expires in 3 minutes — within the 5-minute buffer
-	tm.mu.Lock()
-	tm.cache["client-c"] = tokenEntry{
-		accessToken: "expiring-soon-token",
-		expiresAt:   time.Now().Add(3 * time.Minute),
-	}
-	tm.mu.Unlock()
+	tm.tokens.Set("client-c", auth.Token{
+		AccessToken: "expiring-soon-token",
+		ExpiresAt:   time.Now().Add(3 * time.Minute),
+	})
 
 	token, err := tm.GetToken(context.Background(), testCfg("client-c"))
 	require.NoError(t, err)
@@ -229,3 +227,33 @@ func TestTokenManager_GetToken_SendsCorrectPayload(t *testing.T) {
 	assert.Equal(t, testAuth0Audience, capturedPayload.Audience)
 	assert.Equal(t, "client_credentials", capturedPayload.GrantType)
 }
+
+// ─── PruneClients: tokens of removed clients are forgotten ───────────────────
+
+func TestTokenManager_PruneClients(t *testing.T) {
+	tokenResp, _ := json.Marshal(Auth0TokenResponse{AccessToken: "fetched-token", ExpiresIn: 86400})
+	callCount := 0
+	tm := newTokenManagerWithTransport(t, func(*http.Request) (*http.Response, error) {
+		callCount++
+		return auth0Response(http.StatusOK, string(tokenResp)), nil
+	})
+	seedToken(tm, "client-kept")
+	seedToken(tm, "client-removed")
+
+	// A resolve failure leaves every token in place.
+	err := tm.PruneClients(context.Background(), &mockConfigResolver{err: assert.AnError})
+	require.Error(t, err)
+
+	resolver := &mockConfigResolver{cfg: testCfg("client-kept"), clients: []string{"acme"}}
+	require.NoError(t, tm.PruneClients(context.Background(), resolver))
+
+	token, err := tm.GetToken(context.Background(), testCfg("client-kept"))
+	require.NoError(t, err)
+	assert.Equal(t, "test-bearer-token", token)
+	assert.Equal(t, 0, callCount)
+
+	token, err = tm.GetToken(context.Background(), testCfg("client-removed"))
+	require.NoError(t, err)
+	assert.Equal(t, "fetched-token", token, "a removed client's token must have been forgotten")
+	assert.Equal(t, 1, callCount)
+}
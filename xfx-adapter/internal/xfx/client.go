@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"strings"
@@ -12,9 +14,24 @@ import (
 
 	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/rate"
-	"github.com/Checker-Finance/adapters/xfx-adapter/internal/metrics"
 )
 
+// APIError is a 4xx response from XFX.
+type APIError struct {
+	Status  int
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("xfx returned %d: %s", e.Status, e.Message)
+}
+
+// isUnauthorized reports whether err is a 401 from XFX.
+func isUnauthorized(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized
+}
+
 // Client wraps low-level HTTP communication with the XFX API.
 // Configuration (base URL, credentials) is supplied per-request so that a
 // single Client instance can serve multiple tenants.
@@ -46,7 +63,7 @@ func NewClient(rateMgr *rate.Manager, tokens *TokenManager) *Client {
 		if len(errResp.Error.ValidationErrors) > 0 {
 			msg += ": " + strings.Join(errResp.Error.ValidationErrors, "; ")
 		}
-		return fmt.Errorf("xfx returned %d: %s", status, msg)
+		return &APIError{Status: status, Message: msg}
 	})
 	return &Client{
 		exec:   exec,
@@ -57,12 +74,9 @@ func NewClient(rateMgr *rate.Manager, tokens *TokenManager) *Client {
 // RequestQuote creates a new executable quote.
 // POST /v1/customer/quotes
 func (c *Client) RequestQuote(ctx context.Context, cfg *XFXClientConfig, req *XFXQuoteRequest) (*XFXQuoteResponse, error) {
-	const endpoint, method = "/v1/customer/quotes", http.MethodPost
-	start := time.Now()
+	const endpoint = "/v1/customer/quotes"
 	var resp XFXQuoteResponse
 	err := c.postJSON(ctx, cfg, endpoint, req, &resp)
-	metrics.IncXFXRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.XFXRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -75,12 +89,9 @@ func (c *Client) RequestQuote(ctx context.Context, cfg *XFXClientConfig, req *XF
 // GetQuote retrieves an existing quote by ID.
 // GET /v1/customer/quotes/{quoteId}
 func (c *Client) GetQuote(ctx context.Context, cfg *XFXClientConfig, quoteID string) (*XFXQuoteResponse, error) {
-	const endpoint, method = "/v1/customer/quotes/{id}", http.MethodGet
-	start := time.Now()
+	ctx = httpclient.WithEndpoint(ctx, "/v1/customer/quotes/{id}")
 	var resp XFXQuoteResponse
 	err := c.getJSON(ctx, cfg, "/v1/customer/quotes/"+quoteID, &resp)
-	metrics.IncXFXRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.XFXRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -93,12 +104,9 @@ func (c *Client) GetQuote(ctx context.Context, cfg *XFXClientConfig, quoteID str
 // ExecuteQuote executes an active quote, creating a transaction.
 // POST /v1/customer/quotes/{quoteId}/execute
 func (c *Client) ExecuteQuote(ctx context.Context, cfg *XFXClientConfig, quoteID string) (*XFXExecuteResponse, error) {
-	const endpoint, method = "/v1/customer/quotes/{id}/execute", http.MethodPost
-	start := time.Now()
+	ctx = httpclient.WithEndpoint(ctx, "/v1/customer/quotes/{id}/execute")
 	var resp XFXExecuteResponse
 	err := c.postJSON(ctx, cfg, "/v1/customer/quotes/"+quoteID+"/execute", nil, &resp)
-	metrics.IncXFXRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.XFXRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -111,12 +119,9 @@ func (c *Client) ExecuteQuote(ctx context.Context, cfg *XFXClientConfig, quoteID
 // GetTransaction retrieves a transaction by ID.
 // GET /v1/customer/transactions/{transactionId}
 func (c *Client) GetTransaction(ctx context.Context, cfg *XFXClientConfig, txID string) (*XFXTransactionResponse, error) {
-	const endpoint, method = "/v1/customer/transactions/{id}", http.MethodGet
-	start := time.Now()
+	ctx = httpclient.WithEndpoint(ctx, "/v1/customer/transactions/{id}")
 	var resp XFXTransactionResponse
 	err := c.getJSON(ctx, cfg, "/v1/customer/transactions/"+txID, &resp)
-	metrics.IncXFXRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.XFXRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -137,38 +142,13 @@ func xfxAPIError(success bool, message string) error {
 	return fmt.Errorf("xfx api error: %s", message)
 }
 
-// statusLabel returns "ok" or "error" for use as a Prometheus label.
-func statusLabel(err error) string {
-	if err != nil {
-		return "error"
-	}
-	return "ok"
-}
-
 // getJSON performs an authenticated GET request and decodes the JSON response.
 func (c *Client) getJSON(ctx context.Context, cfg *XFXClientConfig, path string, out any) error {
-	token, err := c.tokens.GetToken(ctx, cfg)
-	if err != nil {
-		return fmt.Errorf("xfx: get auth token: %w", err)
-	}
-
-	url := cfg.BaseURL + path
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return err
-	}
-	setHeaders(req, token)
-
-	return c.exec.DoJSON(ctx, req, cfg.ClientID, out)
+	return c.do(ctx, cfg, http.MethodGet, path, nil, out)
 }
 
 // postJSON performs an authenticated POST request with a JSON body.
 func (c *Client) postJSON(ctx context.Context, cfg *XFXClientConfig, path string, body any, out any) error {
-	token, err := c.tokens.GetToken(ctx, cfg)
-	if err != nil {
-		return fmt.Errorf("xfx: get auth token: %w", err)
-	}
-
 	var bodyBytes []byte
 	if body != nil {
 		var marshalErr error
@@ -177,15 +157,42 @@ func (c *Client) postJSON(ctx context.Context, cfg *XFXClientConfig, path string
 			return marshalErr
 		}
 	}
+	return c.do(ctx, cfg, http.MethodPost, path, bodyBytes, out)
+}
 
-	url := cfg.BaseURL + path
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bodyBytes))
+// do sends an authenticated request. A 401 invalidates the token and the
+// request is retried once with a fresh one.
+func (c *Client) do(ctx context.Context, cfg *XFXClientConfig, method, path string, body []byte, out any) error {
+	token, err := c.send(ctx, cfg, method, path, body, out)
+	if isUnauthorized(err) {
+		slog.Info("xfx.token_rejected",
+			"client_id", cfg.ClientID,
+			"path", path)
+		c.tokens.InvalidateToken(ctx, cfg, token)
+		_, err = c.send(ctx, cfg, method, path, body, out)
+	}
+	return err
+}
+
+// send builds one authenticated request and executes it, returning the
+// bearer token it used.
+func (c *Client) send(ctx context.Context, cfg *XFXClientConfig, method, path string, body []byte, out any) (string, error) {
+	token, err := c.tokens.GetToken(ctx, cfg)
+	if err != nil {
+		return "", fmt.Errorf("xfx: get auth token: %w", err)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, cfg.BaseURL+path, reader)
 	if err != nil {
-		return err
+		return token, err
 	}
 	setHeaders(req, token)
 
-	return c.exec.DoJSON(ctx, req, cfg.ClientID, out)
+	return token, c.exec.DoJSON(ctx, req, cfg.ClientID, out)
 }
 
 // setHeaders sets required headers for XFX API requests.
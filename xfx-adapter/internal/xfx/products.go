@@ -1,21 +1,45 @@
 package xfx
 
 import (
+	"strings"
 	"time"
 
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
+const (
+	// xfxMinSize and xfxMaxSize bound the quantity of a quote. Quantities are
+	// in the base currency, which is USD or a USD stablecoin for every pair,
+	// so XFX's $100,000 minimum applies as-is.
+	xfxMinSize = 100_000
+	xfxMaxSize = 10_000_000
+)
+
+// xfxProduct returns a supported XFX pair with the venue's size limits.
+func xfxProduct(symbol string, pricePrecision int) model.Product {
+	return model.Product{
+		VenueCode:        "XFX",
+		InstrumentSymbol: symbol,
+		ProductID:        symbol,
+		ProductName:      symbol,
+		MinSize:          xfxMinSize,
+		MaxSize:          xfxMaxSize,
+		SizeIncrement:    0.01,
+		PricePrecision:   pricePrecision,
+		NotionalCurrency: symbol[:strings.Index(symbol, "/")],
+		AsOf:             time.Time{},
+	}
+}
+
 // xfxSupportedProducts is the static list of XFX supported currency pairs.
 // XFX does not expose a products API; the supported pairs are fixed.
-// Minimum trade size: $100,000 USD per pair.
 var xfxSupportedProducts = []model.Product{
-	{VenueCode: "XFX", InstrumentSymbol: "USD/MXN", ProductID: "USD/MXN", ProductName: "USD/MXN", AsOf: time.Time{}},
-	{VenueCode: "XFX", InstrumentSymbol: "USDT/MXN", ProductID: "USDT/MXN", ProductName: "USDT/MXN", AsOf: time.Time{}},
-	{VenueCode: "XFX", InstrumentSymbol: "USDC/MXN", ProductID: "USDC/MXN", ProductName: "USDC/MXN", AsOf: time.Time{}},
-	{VenueCode: "XFX", InstrumentSymbol: "USD/COP", ProductID: "USD/COP", ProductName: "USD/COP", AsOf: time.Time{}},
-	{VenueCode: "XFX", InstrumentSymbol: "USDT/COP", ProductID: "USDT/COP", ProductName: "USDT/COP", AsOf: time.Time{}},
-	{VenueCode: "XFX", InstrumentSymbol: "USDC/COP", ProductID: "USDC/COP", ProductName: "USDC/COP", AsOf: time.Time{}},
-	{VenueCode: "XFX", InstrumentSymbol: "USD/USDT", ProductID: "USD/USDT", ProductName: "USD/USDT", AsOf: time.Time{}},
-	{VenueCode: "XFX", InstrumentSymbol: "USD/USDC", ProductID: "USD/USDC", ProductName: "USD/USDC", AsOf: time.Time{}},
+	xfxProduct("USD/MXN", 4),
+	xfxProduct("USDT/MXN", 4),
+	xfxProduct("USDC/MXN", 4),
+	xfxProduct("USD/COP", 2),
+	xfxProduct("USDT/COP", 2),
+	xfxProduct("USDC/COP", 2),
+	xfxProduct("USD/USDT", 6),
+	xfxProduct("USD/USDC", 6),
 }
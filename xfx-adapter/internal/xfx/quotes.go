@@ -0,0 +1,238 @@
+package xfx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
+	"github.com/Checker-Finance/adapters/xfx-adapter/internal/metrics"
+)
+
+// ErrQuoteExpired is returned by ExecuteRFQ when the quote has lapsed and
+// could not be replaced by a fresh quote within the client's tolerance.
+var ErrQuoteExpired = errors.New("xfx: quote expired")
+
+const (
+	// SubjectQuoteExpired is published for quotes that lapse unexecuted.
+	SubjectQuoteExpired = "evt.lp.quote_expired.v1.XFX"
+
+	// quoteExecutionMargin treats quotes this close to expiry as already
+	// expired, so the execute call does not race the venue's clock.
+	quoteExecutionMargin = 500 * time.Millisecond
+
+	// quoteTombstoneTTL keeps lapsed quotes after their expiry is reported,
+	// so a late execute is still rejected or requoted instead of passing
+	// through to XFX as an unknown quote.
+	quoteTombstoneTTL = 10 * time.Minute
+)
+
+// issuedQuote records the terms of a quote returned by CreateRFQ.
+type issuedQuote struct {
+	clientID   string
+	quoteID    string
+	symbol     string
+	side       string
+	quantity   float64
+	price      float64
+	validUntil time.Time
+
+	// reported is set once the quote's expiry has been published.
+	reported atomic.Bool
+}
+
+// expired reports whether the quote can no longer be executed at now.
+func (q *issuedQuote) expired(now time.Time) bool {
+	return !q.validUntil.IsZero() && !now.Add(quoteExecutionMargin).Before(q.validUntil)
+}
+
+// adverseMove returns the fractional move of current against quoted that is
+// unfavourable to the client: up for BUY, down for SELL. Favourable moves
+// return zero.
+func adverseMove(side string, quoted, current float64) float64 {
+	if quoted <= 0 {
+		return 0
+	}
+	move := (current - quoted) / quoted
+	if !strings.EqualFold(side, "BUY") {
+		move = -move
+	}
+	return max(move, 0)
+}
+
+// rememberQuote records an issued quote so ExecuteRFQ can check its expiry
+// and the expiry sweep can report it if it lapses.
+func (s *Service) rememberQuote(clientID string, q XFXQuote) {
+	validUntil, _ := time.Parse(time.RFC3339, q.ValidUntil)
+	s.quotes.Store(q.ID, &issuedQuote{
+		clientID:   clientID,
+		quoteID:    q.ID,
+		symbol:     q.Symbol,
+		side:       strings.ToUpper(q.Side),
+		quantity:   q.Quantity,
+		price:      q.Price,
+		validUntil: validUntil,
+	})
+}
+
+// lookupQuote returns the issued quote, or nil if it is unknown (e.g. issued
+// before a restart) or belongs to another client. The quote is kept until
+// forgetQuote is called after a successful execute.
+func (s *Service) lookupQuote(clientID, quoteID string) *issuedQuote {
+	v, ok := s.quotes.Load(quoteID)
+	if !ok {
+		return nil
+	}
+	issued := v.(*issuedQuote)
+	if issued.clientID != clientID {
+		return nil
+	}
+	return issued
+}
+
+// forgetQuote drops a quote the client has executed.
+func (s *Service) forgetQuote(clientID, quoteID string) {
+	if issued := s.lookupQuote(clientID, quoteID); issued != nil {
+		s.quotes.CompareAndDelete(quoteID, issued)
+	}
+}
+
+// prepareExecution returns the quote ID to send to XFX. Live or unknown quotes
+// pass through unchanged. An expired quote is replaced by a fresh one when the client
+// has a requote tolerance and the new price is within it; otherwise
+// ErrQuoteExpired is returned.
+func (s *Service) prepareExecution(ctx context.Context, clientCfg *XFXClientConfig, clientID, quoteID string) (string, error) {
+	issued := s.lookupQuote(clientID, quoteID)
+	if issued == nil || !issued.expired(time.Now()) {
+		return quoteID, nil
+	}
+	s.reportExpired(ctx, issued)
+
+	if clientCfg.RequoteTolerance <= 0 {
+		metrics.IncQuoteLifecycle("rejected")
+		return "", fmt.Errorf("%w: %s lapsed at %s", ErrQuoteExpired, quoteID, issued.validUntil.Format(time.RFC3339))
+	}
+
+	requote := &XFXQuoteRequest{
+		Symbol:   issued.symbol,
+		Side:     issued.side,
+		Quantity: issued.quantity,
+	}
+	s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: clientID, QuoteID: quoteID, Source: "requote"}, requote)
+
+	ctx, raw := httpclient.CaptureResponse(ctx)
+	resp, err := s.client.RequestQuote(ctx, clientCfg, requote)
+	requoted := audit.Event{Kind: audit.KindQuoteResponse, ClientID: clientID, Source: "requote"}
+	if err != nil {
+		requoted.Error = err.Error()
+	} else {
+		requoted.QuoteID = resp.Quote.ID
+	}
+	s.auditor.Record(ctx, requoted, raw.Body)
+	if err != nil {
+		metrics.IncQuoteLifecycle("rejected")
+		return "", fmt.Errorf("%w: requote %s failed: %v", ErrQuoteExpired, quoteID, err)
+	}
+
+	move := adverseMove(issued.side, issued.price, resp.Quote.Price)
+	if move > clientCfg.RequoteTolerance {
+		metrics.IncQuoteLifecycle("rejected")
+		slog.Warn("xfx.execute_rfq.requote_rejected",
+			"client", clientID,
+			"quote_id", quoteID,
+			"quoted_price", issued.price,
+			"requoted_price", resp.Quote.Price,
+			"move", move,
+			"tolerance", clientCfg.RequoteTolerance)
+		return "", fmt.Errorf("%w: requoted %v against %v (%.4f%% > %.4f%%)",
+			ErrQuoteExpired, resp.Quote.Price, issued.price, move*100, clientCfg.RequoteTolerance*100)
+	}
+
+	metrics.IncQuoteLifecycle("requoted")
+	slog.Info("xfx.execute_rfq.requoted",
+		"client", clientID,
+		"quote_id", quoteID,
+		"new_quote_id", resp.Quote.ID,
+		"quoted_price", issued.price,
+		"requoted_price", resp.Quote.Price)
+	return resp.Quote.ID, nil
+}
+
+// StartQuoteExpiry runs ExpireQuotes every interval until ctx is done.
+func (s *Service) StartQuoteExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			s.ExpireQuotes(ctx, now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// ExpireQuotes publishes evt.lp.quote_expired.v1.XFX once for each quote past
+// its validity and returns how many it reported. Lapsed quotes are kept for
+// quoteTombstoneTTL so executes against them are still caught.
+func (s *Service) ExpireQuotes(ctx context.Context, now time.Time) int {
+	n := 0
+	s.quotes.Range(func(key, value any) bool {
+		issued := value.(*issuedQuote)
+		if issued.validUntil.IsZero() || now.Before(issued.validUntil) {
+			return true
+		}
+		if s.reportExpired(ctx, issued) {
+			n++
+		}
+		if !now.Before(issued.validUntil.Add(quoteTombstoneTTL)) {
+			s.quotes.CompareAndDelete(key, issued)
+		}
+		return true
+	})
+	return n
+}
+
+// reportExpired publishes the quote's expiry unless it was already reported,
+// and reports whether it did.
+func (s *Service) reportExpired(ctx context.Context, issued *issuedQuote) bool {
+	if !issued.reported.CompareAndSwap(false, true) {
+		return false
+	}
+	s.publishQuoteExpired(ctx, issued)
+	return true
+}
+
+// publishQuoteExpired reports a quote that lapsed without being executed.
+func (s *Service) publishQuoteExpired(ctx context.Context, issued *issuedQuote) {
+	metrics.IncQuoteLifecycle("expired")
+	slog.Info("xfx.quote_expired",
+		"client", issued.clientID,
+		"quote_id", issued.quoteID,
+		"valid_until", issued.validUntil)
+
+	if s.publisher == nil {
+		return
+	}
+	if err := s.publisher.Publish(ctx, SubjectQuoteExpired, map[string]any{
+		"client_id":   issued.clientID,
+		"quote_id":    issued.quoteID,
+		"instrument":  issued.symbol,
+		"side":        issued.side,
+		"quantity":    issued.quantity,
+		"price":       issued.price,
+		"valid_until": issued.validUntil,
+		"venue":       "XFX",
+		"timestamp":   time.Now().UTC(),
+	}); err != nil {
+		metrics.IncNATSPublishError(SubjectQuoteExpired)
+		slog.Warn("xfx.publish_failed",
+			"subject", SubjectQuoteExpired,
+			"error", err)
+	}
+}
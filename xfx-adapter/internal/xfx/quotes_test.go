@@ -0,0 +1,207 @@
+package xfx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRequoteServer serves a fresh quote at requotePrice and records the quote
+// ID passed to the execute endpoint.
+func newRequoteServer(t *testing.T, requotePrice float64, executed *atomic.Value) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/customer/quotes":
+			writeJSON(w, XFXQuoteResponse{Success: true, Quote: XFXQuote{
+				ID:         "qt-fresh",
+				Symbol:     "USD/MXN",
+				Side:       "buy",
+				Quantity:   1000,
+				Price:      requotePrice,
+				ValidUntil: time.Now().Add(time.Minute).Format(time.RFC3339),
+				Status:     "ACTIVE",
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/execute"):
+			executed.Store(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/customer/quotes/"), "/execute"))
+			writeJSON(w, XFXExecuteResponse{Success: true, Transaction: XFXTransaction{
+				ID:      "tx-001",
+				QuoteID: "qt-fresh",
+				Status:  "PENDING",
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+// rememberExpired records a BUY quote at price 17.00 that lapsed a second ago.
+func rememberExpired(svc *Service, quoteID string) {
+	svc.rememberQuote("client-001", XFXQuote{
+		ID:         quoteID,
+		Symbol:     "USD/MXN",
+		Side:       "buy",
+		Quantity:   1000,
+		Price:      17.00,
+		ValidUntil: time.Now().Add(-time.Second).Format(time.RFC3339),
+	})
+}
+
+func TestExecuteRFQ_ExpiredQuote_RejectedWithoutTolerance(t *testing.T) {
+	var executed atomic.Value
+	srv := newRequoteServer(t, 17.00, &executed)
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+	rememberExpired(svc, "qt-old")
+
+	_, err := svc.ExecuteRFQ(context.Background(), "client-001", "qt-old")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrQuoteExpired))
+	assert.Nil(t, executed.Load(), "expired quote must not reach the execute endpoint")
+}
+
+func TestExecuteRFQ_ExpiredQuote_RequotedWithinTolerance(t *testing.T) {
+	var executed atomic.Value
+	srv := newRequoteServer(t, 17.01, &executed)
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+	svc.configResolver.(*mockConfigResolver).cfg.RequoteTolerance = 0.001
+	rememberExpired(svc, "qt-old")
+
+	trade, err := svc.ExecuteRFQ(context.Background(), "client-001", "qt-old")
+	require.NoError(t, err)
+	assert.Equal(t, "tx-001", trade.TradeID)
+	assert.Equal(t, "qt-fresh", executed.Load())
+	assert.Equal(t, "qt-old", trade.ProviderRFQID, "the trade keeps the caller's quote ID")
+}
+
+func TestExecuteRFQ_ExpiredQuote_RequoteBeyondTolerance(t *testing.T) {
+	var executed atomic.Value
+	srv := newRequoteServer(t, 17.50, &executed)
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+	svc.configResolver.(*mockConfigResolver).cfg.RequoteTolerance = 0.001
+	rememberExpired(svc, "qt-old")
+
+	_, err := svc.ExecuteRFQ(context.Background(), "client-001", "qt-old")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrQuoteExpired))
+	assert.Nil(t, executed.Load())
+}
+
+func TestExecuteRFQ_UnknownQuote_PassesThrough(t *testing.T) {
+	var executed atomic.Value
+	srv := newRequoteServer(t, 17.00, &executed)
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+
+	_, err := svc.ExecuteRFQ(context.Background(), "client-001", "qt-unknown")
+	require.NoError(t, err)
+	assert.Equal(t, "qt-unknown", executed.Load())
+}
+
+func TestExpireQuotes_ReportsOnceAndKeepsTombstone(t *testing.T) {
+	svc := newTestService(t, "http://unused")
+	now := time.Now()
+	svc.rememberQuote("client-001", XFXQuote{ID: "qt-live", ValidUntil: now.Add(time.Minute).Format(time.RFC3339)})
+	svc.rememberQuote("client-001", XFXQuote{ID: "qt-lapsed", ValidUntil: now.Add(-time.Minute).Format(time.RFC3339)})
+
+	assert.Equal(t, 1, svc.ExpireQuotes(context.Background(), now))
+	assert.Equal(t, 0, svc.ExpireQuotes(context.Background(), now), "expired quotes are reported once")
+
+	_, live := svc.quotes.Load("qt-live")
+	assert.True(t, live)
+	_, lapsed := svc.quotes.Load("qt-lapsed")
+	assert.True(t, lapsed, "lapsed quotes are kept as tombstones")
+
+	svc.ExpireQuotes(context.Background(), now.Add(quoteTombstoneTTL))
+	_, lapsed = svc.quotes.Load("qt-lapsed")
+	assert.False(t, lapsed, "tombstones are dropped after quoteTombstoneTTL")
+}
+
+func TestExecuteRFQ_AfterExpirySweep_Rejected(t *testing.T) {
+	var executed atomic.Value
+	srv := newRequoteServer(t, 17.00, &executed)
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+	rememberExpired(svc, "qt-old")
+	require.Equal(t, 1, svc.ExpireQuotes(context.Background(), time.Now()))
+
+	_, err := svc.ExecuteRFQ(context.Background(), "client-001", "qt-old")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrQuoteExpired))
+	assert.Nil(t, executed.Load(), "a swept quote must not reach the execute endpoint")
+}
+
+func TestExecuteRFQ_AfterExpirySweep_Requoted(t *testing.T) {
+	var executed atomic.Value
+	srv := newRequoteServer(t, 17.01, &executed)
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+	svc.configResolver.(*mockConfigResolver).cfg.RequoteTolerance = 0.001
+	rememberExpired(svc, "qt-old")
+	svc.ExpireQuotes(context.Background(), time.Now())
+
+	trade, err := svc.ExecuteRFQ(context.Background(), "client-001", "qt-old")
+	require.NoError(t, err)
+	assert.Equal(t, "qt-fresh", executed.Load())
+	assert.Equal(t, "qt-old", trade.ProviderRFQID)
+
+	_, kept := svc.quotes.Load("qt-old")
+	assert.False(t, kept, "executed quotes are forgotten")
+}
+
+func TestExecuteRFQ_OtherClientDoesNotEvictQuote(t *testing.T) {
+	var executed atomic.Value
+	srv := newRequoteServer(t, 17.00, &executed)
+	defer srv.Close()
+
+	svc := newTestService(t, srv.URL)
+	rememberExpired(svc, "qt-old")
+
+	_, err := svc.ExecuteRFQ(context.Background(), "client-002", "qt-old")
+	require.NoError(t, err)
+
+	_, kept := svc.quotes.Load("qt-old")
+	assert.True(t, kept, "another client's execute must not evict the quote")
+}
+
+func TestGetQuote_ReturnsVenueStatus(t *testing.T) {
+	quoteResp := &XFXQuoteResponse{Success: true, Quote: XFXQuote{
+		ID:     "qt-001",
+		Symbol: "USD/MXN",
+		Side:   "buy",
+		Price:  17.25,
+		Status: "expired",
+	}}
+	srv := newMockXFXServer(t, quoteResp, nil, nil)
+	defer srv.Close()
+
+	quote, err := newTestService(t, srv.URL).GetQuote(context.Background(), "client-001", "qt-001")
+	require.NoError(t, err)
+	assert.Equal(t, "EXPIRED", quote.Status)
+	assert.Equal(t, 17.25, quote.Price)
+}
+
+func TestAdverseMove(t *testing.T) {
+	assert.InDelta(t, 0.01, adverseMove("BUY", 100, 101), 1e-9)
+	assert.Zero(t, adverseMove("BUY", 100, 99))
+	assert.InDelta(t, 0.01, adverseMove("SELL", 100, 99), 1e-9)
+	assert.Zero(t, adverseMove("SELL", 100, 101))
+	assert.Zero(t, adverseMove("BUY", 0, 101))
+}
@@ -5,13 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/nats-io/nats.go"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/model"
 	"github.com/Checker-Finance/adapters/xfx-adapter/internal/metrics"
 	"github.com/Checker-Finance/adapters/xfx-adapter/pkg/config"
@@ -30,6 +36,9 @@ type Service struct {
 	mapper          *Mapper
 	tradeSyncWriter *legacy.TradeSyncWriter
 	poller          *Poller
+	auditor         *audit.Recorder
+
+	quotes sync.Map // quoteID -> *issuedQuote, until executed or expired
 }
 
 // NewService constructs a fully wired XFX adapter service.
@@ -61,6 +70,11 @@ func (s *Service) SetPoller(p *Poller) {
 	s.poller = p
 }
 
+// SetAuditRecorder sets the recorder for the quote-to-trade audit trail.
+func (s *Service) SetAuditRecorder(r *audit.Recorder) {
+	s.auditor = r
+}
+
 // resolveConfig resolves the per-client XFX configuration.
 func (s *Service) resolveConfig(ctx context.Context, clientID string) (*XFXClientConfig, error) {
 	cfg, err := s.configResolver.Resolve(ctx, clientID)
@@ -82,6 +96,15 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 		"amount", req.Amount,
 	)
 
+	if err := model.ValidateRFQ(xfxSupportedProducts, req); err != nil {
+		slog.Warn("xfx.create_rfq.rejected",
+			"client", req.ClientID,
+			"pair", req.CurrencyPair,
+			"amount", req.Amount,
+			"error", err)
+		return nil, err
+	}
+
 	clientCfg, err := s.resolveConfig(ctx, req.ClientID)
 	if err != nil {
 		return nil, err
@@ -90,9 +113,16 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 	xfxReq := s.mapper.ToXFXQuoteRequest(req)
 
 	slog.Debug("xfx.rfq_request", "json", pretty(xfxReq))
+	s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: req.ClientID}, xfxReq)
 
+	ctx, raw := httpclient.CaptureResponse(ctx)
 	xfxResp, err := s.client.RequestQuote(ctx, clientCfg, xfxReq)
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindQuoteResponse,
+			ClientID: req.ClientID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Error("xfx.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
@@ -100,6 +130,13 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 	}
 
 	quote := s.mapper.FromXFXQuote(xfxResp, req.ClientID)
+	sharedmetrics.RecordQuote("xfx", quote.ID, time.Now())
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindQuoteResponse,
+		ClientID: req.ClientID,
+		QuoteID:  quote.ID,
+	}, raw.Body)
+	s.rememberQuote(req.ClientID, xfxResp.Quote)
 
 	slog.Info("xfx.rfq_created",
 		"client", req.ClientID,
@@ -123,16 +160,45 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 		return nil, err
 	}
 
-	execResp, err := s.client.ExecuteQuote(ctx, clientCfg, quoteID)
+	// execQuoteID differs from quoteID when an expired quote was requoted;
+	// the caller's quoteID identifies the trade everywhere else.
+	execQuoteID, err := s.prepareExecution(ctx, clientCfg, clientID, quoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteRequest,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+	}, map[string]string{"quote_id": quoteID, "executed_quote_id": execQuoteID})
+
+	ctx, raw := httpclient.CaptureResponse(ctx)
+	execResp, err := s.client.ExecuteQuote(ctx, clientCfg, execQuoteID)
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			Error:    err.Error(),
+		}, raw.Body)
 		slog.Error("xfx.execute_rfq.failed",
 			"client", clientID,
 			"quote_id", quoteID,
+			"executed_quote_id", execQuoteID,
 			"error", err)
 		return nil, fmt.Errorf("xfx quote execution failed: %w", err)
 	}
+	s.forgetQuote(clientID, quoteID)
 
 	trade := s.mapper.FromXFXExecute(execResp, clientID, quoteID)
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteResponse,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+		TradeID:  trade.TradeID,
+		Status:   trade.Status,
+	}, raw.Body)
 
 	slog.Info("xfx.trade_created",
 		"client", clientID,
@@ -147,14 +213,40 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 		slog.Info("xfx.starting_status_poll",
 			"transaction_id", trade.TradeID,
 			"client", clientID)
-		go s.poller.PollTradeStatus(s.ctx, clientID, quoteID, trade.TradeID)
+		go s.poller.PollTradeStatus(tracing.CarryCorrelationID(s.ctx, ctx), clientID, quoteID, trade.TradeID)
 	} else if IsTerminalStatus(execResp.Transaction.Status) {
+		if trade.Status == model.StatusFilled {
+			sharedmetrics.ObserveQuoteFill("xfx", quoteID, time.Now())
+		}
 		s.syncTerminalTrade(ctx, trade)
 	}
 
 	return trade, nil
 }
 
+// GetQuote retrieves the current state of a quote from XFX.
+func (s *Service) GetQuote(ctx context.Context, clientID, quoteID string) (*model.Quote, error) {
+	clientCfg, err := s.resolveConfig(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.GetQuote(ctx, clientCfg, quoteID)
+	if err != nil {
+		slog.Warn("xfx.get_quote.failed",
+			"client", clientID,
+			"quote_id", quoteID,
+			"error", err)
+		return nil, err
+	}
+
+	quote := s.mapper.FromXFXQuote(resp, clientID)
+	if resp.Quote.Status != "" {
+		quote.Status = strings.ToUpper(resp.Quote.Status)
+	}
+	return quote, nil
+}
+
 // FetchTransactionStatus retrieves the latest transaction status from XFX.
 func (s *Service) FetchTransactionStatus(ctx context.Context, clientID, txID string) (*XFXTransaction, error) {
 	clientCfg, err := s.resolveConfig(ctx, clientID)
@@ -185,8 +277,18 @@ func (s *Service) BuildTradeConfirmationFromTx(clientID string, tx *XFXTransacti
 
 // syncTerminalTrade syncs a terminal trade to the legacy database and publishes final event.
 func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfirmation) {
+	subject := "evt.trade." + trade.Status + ".v1.XFX"
+	msgID := publisher.TradeEventID("XFX", trade.TradeID, trade.Status)
+	event := map[string]any{
+		"client_id": trade.ClientID,
+		"trade_id":  trade.TradeID,
+		"status":    trade.Status,
+		"final":     true,
+		"timestamp": time.Now().UTC(),
+	}
+
 	if s.tradeSyncWriter != nil {
-		if err := s.tradeSyncWriter.SyncTradeUpsert(ctx, trade); err != nil {
+		if err := s.tradeSyncWriter.SyncTradeWithEvent(ctx, trade, subject, msgID, event); err != nil {
 			slog.Warn("xfx.trade_sync_failed",
 				"trade_id", trade.TradeID,
 				"client", trade.ClientID,
@@ -196,20 +298,15 @@ func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfi
 				"trade_id", trade.TradeID,
 				"client", trade.ClientID,
 				"status", trade.Status)
+			return
 		}
 	}
 
+	// The event could not be queued with the trade; publish it directly.
 	if s.publisher == nil {
 		return
 	}
-	subject := "evt.trade." + trade.Status + ".v1.XFX"
-	if err := s.publisher.Publish(ctx, subject, map[string]any{
-		"client_id": trade.ClientID,
-		"trade_id":  trade.TradeID,
-		"status":    trade.Status,
-		"final":     true,
-		"timestamp": time.Now().UTC(),
-	}); err != nil {
+	if err := s.publisher.Publish(ctx, subject, event, publisher.WithMsgID(msgID)); err != nil {
 		metrics.IncNATSPublishError(subject)
 		slog.Warn("xfx.publish_failed",
 			"subject", subject,
@@ -61,7 +61,7 @@ func TestService_CreateRFQ_SellSide(t *testing.T) {
 			ID:         "xfx-qt-sell",
 			Symbol:     "USDT/COP",
 			Side:       "SELL",
-			Quantity:   50000.0,
+			Quantity:   150000.0,
 			Price:      4250.0,
 			ValidUntil: time.Now().Add(15 * time.Second).UTC().Format(time.RFC3339),
 			Status:     "ACTIVE",
@@ -77,7 +77,7 @@ func TestService_CreateRFQ_SellSide(t *testing.T) {
 		ClientID:     "test-client-id",
 		Side:         "sell",
 		CurrencyPair: "USDT/COP",
-		Amount:       50000,
+		Amount:       150000,
 	}
 
 	quote, err := svc.CreateRFQ(context.Background(), req)
@@ -86,6 +86,40 @@ func TestService_CreateRFQ_SellSide(t *testing.T) {
 	assert.Equal(t, "USDT/COP", quote.Instrument)
 }
 
+func TestService_CreateRFQ_RejectsOutOfBoundsRequests(t *testing.T) {
+	svc := &Service{
+		ctx:            context.Background(),
+		configResolver: &mockConfigResolver{err: assert.AnError},
+		mapper:         NewMapper(),
+	}
+
+	tests := []struct {
+		name   string
+		pair   string
+		amount float64
+		code   string
+	}{
+		{"below minimum", "USD/MXN", 50_000, model.RFQErrBelowMinSize},
+		{"above maximum", "USDC/COP", 20_000_000, model.RFQErrAboveMaxSize},
+		{"sub-cent quantity", "USD/MXN", 100_000.005, model.RFQErrInvalidIncrement},
+		{"unsupported pair", "EUR/MXN", 200_000, model.RFQErrUnsupportedPair},
+		{"non-positive amount", "USD/MXN", 0, model.RFQErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreateRFQ(context.Background(), model.RFQRequest{
+				ClientID:     "test-client-id",
+				Side:         "buy",
+				CurrencyPair: tt.pair,
+				Amount:       tt.amount,
+			})
+			verr, ok := model.AsRFQValidationError(err)
+			require.True(t, ok, "got %v", err)
+			assert.Equal(t, tt.code, verr.Code)
+		})
+	}
+}
+
 func TestService_CreateRFQ_ResolveConfigError(t *testing.T) {
 	svc := &Service{
 		ctx:    context.Background(),
@@ -10,6 +10,7 @@ import (
 	"time"
 
 
+	"github.com/Checker-Finance/adapters/internal/auth"
 	"github.com/Checker-Finance/adapters/xfx-adapter/pkg/config"
 )
 
@@ -40,12 +41,10 @@ func (m *mockConfigResolver) DiscoverClients(_ context.Context) ([]string, error
 
 // seedToken pre-populates the token cache so tests don't hit Auth0.
 func seedToken(tm *TokenManager, clientID string) {
-	tm.mu.Lock()
-	defer tm.mu.Unlock()
-	tm.cache[clientID] = tokenEntry{
-		accessToken: "test-bearer-token",
-		expiresAt:   time.Now().Add(24 * time.Hour),
-	}
+	tm.tokens.Set(clientID, auth.Token{
+		AccessToken: "test-bearer-token",
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+	})
 }
 
 // isPolling reports whether the poller has an active goroutine for txID.
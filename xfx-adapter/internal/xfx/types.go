@@ -9,13 +9,18 @@ import "context"
 //
 
 // XFXClientConfig holds per-client XFX API configuration resolved from AWS Secrets Manager.
-// Secret path: {env}/{clientID}/xfx → {"client_id": "...", "client_secret": "...", "base_url": "...", "auth0_endpoint": "...", "auth0_audience": "..."}
+// Secret path: {env}/{clientID}/xfx → {"client_id": "...", "client_secret": "...", "base_url": "...", "auth0_endpoint": "...", "auth0_audience": "...", "requote_tolerance": "0.001"}
 type XFXClientConfig struct {
 	BaseURL       string // XFX API base URL (e.g. "https://dev-api.xfx.io")
 	ClientID      string // OAuth2 client_id for Auth0 token request
 	ClientSecret  string // OAuth2 client_secret for Auth0 token request
 	Auth0Endpoint string // Auth0 token endpoint URL (per-client, may use different Auth0 tenants)
 	Auth0Audience string // Auth0 API audience identifier
+
+	// RequoteTolerance is the largest adverse price move (fraction, e.g.
+	// 0.001 = 0.1%) accepted when an expired quote is re-quoted at execution.
+	// Zero rejects expired quotes instead.
+	RequoteTolerance float64
 }
 
 // ConfigResolver resolves per-client XFX configuration.
@@ -48,6 +48,15 @@ type Config struct {
 	RFQSweepInterval       time.Duration // How often to expire stale RFQs/quotes in the legacy DB
 	RFQSweepTTL            time.Duration // Age threshold after which an open RFQ/quote is expired
 	SummaryRefreshInterval time.Duration // How often to refresh the balance summary materialized view
+	TokenPersist           bool          // Share auth tokens between replicas via Redis
+	TokenEncryptionKey     string        // Base64 AES-256 key encrypting persisted tokens
+	DiscoveryInterval      time.Duration // How often tokens of clients removed from Secrets Manager are dropped
+
+	// JetStream publishing
+	NATSStream            string        // Stream that must capture the adapter's trade events
+	NATSDedupWindow       time.Duration // Duplicate window enforced on NATSStream
+	NATSPublishAsync      bool          // Publish without waiting for each ack
+	NATSPublishMaxPending int           // Async publishes awaiting an ack before publishing blocks
 }
 
 // Load loads configuration from environment variables, then overlays any values
@@ -84,6 +93,14 @@ func Load(ctx context.Context) *Config {
 		RFQSweepInterval:       pkgconfig.GetEnvDuration("RFQ_SWEEP_INTERVAL", 5*time.Minute),
 		RFQSweepTTL:            pkgconfig.GetEnvDuration("RFQ_SWEEP_TTL", 15*time.Minute),
 		SummaryRefreshInterval: pkgconfig.GetEnvDuration("SUMMARY_REFRESH_INTERVAL", 24*time.Hour),
+		TokenPersist:           pkgconfig.GetEnvBool("AUTH_TOKEN_PERSIST", false),
+		TokenEncryptionKey:     pkgconfig.GetEnv("AUTH_TOKEN_ENCRYPTION_KEY", ""),
+		DiscoveryInterval:      pkgconfig.GetEnvDuration("XFX_CLIENT_DISCOVERY_INTERVAL", 5*time.Minute),
+
+		NATSStream:            pkgconfig.GetEnv("NATS_STREAM", "XFX_EVENTS"),
+		NATSDedupWindow:       pkgconfig.GetEnvDuration("NATS_DEDUP_WINDOW", 2*time.Minute),
+		NATSPublishAsync:      pkgconfig.GetEnvBool("NATS_PUBLISH_ASYNC", false),
+		NATSPublishMaxPending: pkgconfig.GetEnvInt("NATS_PUBLISH_MAX_PENDING", 256),
 	}
 
 	secretPath := fmt.Sprintf("%s/%s", cfg.Env, cfg.ServiceName)
@@ -10,11 +10,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/auth"
 	"github.com/Checker-Finance/adapters/internal/jobs"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	"github.com/Checker-Finance/adapters/internal/outbox"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/rate"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/logger"
 	"github.com/Checker-Finance/adapters/pkg/secrets"
 	"github.com/Checker-Finance/adapters/pkg/utils"
@@ -36,6 +40,13 @@ func main() {
 	cfg.Venue = "zodia"
 
 	logger.Init(cfg.ServiceName, cfg.Env, cfg.LogLevel)
+
+	shutdownTracing, err := tracing.Init(ctx, cfg.ServiceName)
+	if err != nil {
+		slog.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+
 	slog.Info("starting [zodia-adapter]...")
 	slog.Info("connection to DSN", "dsn", utils.MaskDSN(cfg.DatabaseURL))
 
@@ -73,11 +84,26 @@ func main() {
 	}
 
 	// --- Publisher ---
-	pub, err := publisher.New(nc, "evt.zodia", "ZODIA_EVENTS")
+	pub, err := publisher.NewWithOptions(nc, "evt.zodia", "ZODIA_EVENTS", publisher.Options{
+		Async:      cfg.NATSPublishAsync,
+		MaxPending: cfg.NATSPublishMaxPending,
+	})
 	if err != nil {
 		slog.Error("failed to init publisher", "error", err)
 		os.Exit(1)
 	}
+	if err := pub.EnsureStreams(
+		publisher.StreamSpec{
+			Name:       cfg.NATSStream,
+			Subjects:   []string{"evt.trade.*.v1.ZODIA"},
+			Duplicates: cfg.NATSDedupWindow,
+		},
+		// Quote, balance and recon subjects may live in other streams, some shared by several adapters
+		publisher.StreamSpec{Subjects: []string{cfg.OutboundSubject, zodia.SubjectBalanceUpdate, jobs.SubjectSummaryRefreshed}},
+	); err != nil {
+		slog.Error("failed to verify jetstream streams", "error", err)
+		os.Exit(1)
+	}
 
 	// --- Rate limiter (30 req/s for Zodia REST API) ---
 	rateMgr := rate.NewManager(rate.Config{
@@ -102,6 +128,13 @@ func main() {
 	// --- Legacy trade sync writer ---
 	tradeSyncWriter := legacy.NewTradeSyncWriter(st.(*store.HybridStore).PG, "zodia-adapter")
 
+	// --- Outbox relay: publishes terminal trade events queued with their t_order rows ---
+	outboxRelay := outbox.NewRelay(outbox.NewPGStore(st.(*store.HybridStore).PG, "zodia-adapter"), pub, outbox.RelayConfig{})
+	go outboxRelay.Start(ctx)
+
+	// --- Audit trail: append-only record of each RFQ from request to final status ---
+	auditStore := audit.NewPGStore(st.(*store.HybridStore).PG)
+
 	// --- RFQ sweeper: expires stale open RFQs and quotes in the legacy DB ---
 	rfqSweeper := legacy.NewRFQSweeper(
 		st.(*store.HybridStore).PG,
@@ -123,8 +156,22 @@ func main() {
 	signer := zodia.NewHMACSigner()
 	restClient := zodia.NewRESTClient(rateMgr, signer)
 	wsTokenMgr := zodia.NewWSTokenManager(restClient)
+	if cfg.TokenPersist {
+		tokenStore, err := auth.NewEncryptedStore(st, cfg.TokenEncryptionKey)
+		if err != nil {
+			slog.Error("failed to init token store", "error", err)
+			os.Exit(1)
+		}
+		wsTokenMgr.SetStore(tokenStore)
+	}
+	go wsTokenMgr.Run(ctx, 5*time.Minute)
+	go wsTokenMgr.RunClientPruning(ctx, resolver, cfg.DiscoveryInterval)
 	wsClient := zodia.NewWSClient()
 	sessionMgr := zodia.NewSessionManager(wsClient, wsTokenMgr, cfg.WSMaxRetries)
+	sessionMgr.SetPriceMaxAge(cfg.RFSPriceMaxAge)
+	if cfg.RFSIdleTTL > 0 {
+		go sessionMgr.StartSubscriptionJanitor(ctx, cfg.RFSIdleTTL/2, cfg.RFSIdleTTL)
+	}
 
 	// --- Zodia Service ---
 	zodiaSvc := zodia.NewService(
@@ -149,6 +196,11 @@ func main() {
 		tradeSyncWriter,
 	)
 	zodiaSvc.SetPoller(poller)
+	auditRecorder := audit.NewRecorder(auditStore, "zodia")
+	zodiaSvc.SetAuditRecorder(auditRecorder)
+	if cfg.RFQSweepTTL > 0 {
+		go zodiaSvc.StartQuoteJanitor(ctx, cfg.RFQSweepInterval, cfg.RFQSweepTTL)
+	}
 
 	// --- NATS command consumer: quote requests and trade execute commands ---
 	cmdConsumer := zodia.NewCommandConsumer(nc, zodiaSvc)
@@ -172,6 +224,7 @@ func main() {
 		IdleTimeout:  cfg.HTTPIdleTimeout,
 		BodyLimit:    cfg.HTTPBodyLimit,
 	})
+	app.Use(tracing.FiberMiddleware())
 
 	clientValidator := api.NewResolverValidator(resolver)
 	zodiaHandler := api.NewZodiaHandler(zodiaSvc, clientValidator)
@@ -179,9 +232,18 @@ func main() {
 	balanceHandler := api.NewBalanceHandler(st)
 	productsHandler := api.NewProductsHandler(zodiaSvc)
 	mapper := zodia.NewMapper()
-	webhookHandler := api.NewWebhookHandler(st, mapper, tradeSyncWriter, pub)
+	var webhookVerifier api.WebhookVerifier
+	if cfg.WebhookRequireSig {
+		webhookVerifier = zodia.NewWebhookVerifier(resolver, signer)
+	} else {
+		slog.Warn("ZODIA_WEBHOOK_REQUIRE_SIGNATURE=false; accepting unsigned webhooks")
+	}
+	webhookHandler := api.NewWebhookHandler(st, mapper, tradeSyncWriter, pub, webhookVerifier, poller)
+	webhookHandler.SetSignatureHeaders(cfg.WebhookKeyHeader, cfg.WebhookSignHeader)
+	webhookHandler.SetAuditRecorder(auditRecorder)
+	auditHandler := audit.NewHandler(auditStore, "zodia")
 
-	api.RegisterRoutes(app, nc, st, zodiaHandler, resolveHandler, balanceHandler, productsHandler, webhookHandler)
+	api.RegisterRoutes(app, nc, st, zodiaHandler, resolveHandler, balanceHandler, productsHandler, webhookHandler, sessionMgr, auditHandler)
 
 	// Start HTTP server
 	go func() {
@@ -210,12 +272,21 @@ func main() {
 	if err := app.ShutdownWithContext(shutdownCtx); err != nil {
 		slog.Warn("fiber.shutdown_failed", "error", err)
 	}
+	if err := pub.Flush(shutdownCtx); err != nil {
+		slog.Warn("nats.flush_incomplete", "error", err)
+	}
 	if err := nc.Drain(); err != nil {
 		slog.Warn("nats.drain_failed", "error", err)
 	}
+	if err := auditRecorder.Close(shutdownCtx); err != nil {
+		slog.Warn("audit.flush_incomplete", "error", err)
+	}
 	if err := st.Close(); err != nil {
 		slog.Warn("store.close_failed", "error", err)
 	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Warn("tracing.shutdown_failed", "error", err)
+	}
 }
 
 // parseClientIDs splits and trims a comma-separated list of client IDs.
@@ -2,11 +2,13 @@ package api
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/Checker-Finance/adapters/pkg/model"
+	"github.com/Checker-Finance/adapters/zodia-adapter/internal/zodia"
 )
 
 // RFQService defines the interface for RFQ operations used by the handler.
@@ -44,21 +46,26 @@ func (h *ZodiaHandler) CreateRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
 	r := toRFQRequest(req)
 
-	quote, err := h.service.CreateRFQ(c.Context(), r)
+	quote, err := h.service.CreateRFQ(c.UserContext(), r)
 	if err != nil {
 		slog.Error("zodia.create_rfq.failed",
 			"client", req.ClientID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(RFQResponse{
+		resp := RFQResponse{
 			QuoteID:  req.ID,
 			ErrorMsg: err.Error(),
-		})
+		}
+		if verr, ok := model.AsRFQValidationError(err); ok {
+			resp.ErrorCode = verr.Code
+			resp.ErrorMsg = verr.Message
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(resp)
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(RFQResponse{
@@ -79,7 +86,7 @@ func (h *ZodiaHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	if h.validator != nil && !h.validator.IsKnownClient(c.Context(), req.ClientID) {
+	if h.validator != nil && !h.validator.IsKnownClient(c.UserContext(), req.ClientID) {
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "unknown or unauthorized clientId"})
 	}
 
@@ -92,13 +99,22 @@ func (h *ZodiaHandler) ExecuteRFQHandler(c *fiber.Ctx) error {
 		"client", req.ClientID,
 		"quote_id", quoteID)
 
-	trade, err := h.service.ExecuteRFQ(c.Context(), req.ClientID, quoteID)
+	trade, err := h.service.ExecuteRFQ(c.UserContext(), req.ClientID, quoteID)
 	if err != nil {
 		slog.Error("zodia.execute_rfq.failed",
 			"client", req.ClientID,
 			"quote_id", quoteID,
 			"error", err)
-		return c.Status(fiber.StatusBadRequest).JSON(RFQExecutionResponse{
+		status := fiber.StatusBadRequest
+		switch {
+		case errors.Is(err, zodia.ErrSlippageExceeded):
+			status = fiber.StatusConflict
+		case errors.Is(err, zodia.ErrOrderOutcomeUnknown):
+			status = fiber.StatusBadGateway
+		case errors.Is(err, zodia.ErrDisconnected):
+			status = fiber.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(RFQExecutionResponse{
 			OrderID:  req.OrderID,
 			ErrorMsg: err.Error(),
 		})
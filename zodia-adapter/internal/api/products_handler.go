@@ -25,7 +25,7 @@ func NewProductsHandler(svc ProductLister) *ProductsHandler {
 // ListProducts returns the list of Zodia supported products.
 func (h *ProductsHandler) ListProducts(c *fiber.Ctx) error {
 	clientID := c.Query("clientId", "")
-	products := h.service.ListProducts(c.Context(), clientID)
+	products := h.service.ListProducts(c.UserContext(), clientID)
 	return c.JSON(fiber.Map{
 		"count":    len(products),
 		"products": products,
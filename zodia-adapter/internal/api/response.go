@@ -6,6 +6,7 @@ type RFQResponse struct {
 	ProviderQuoteId string  `json:"providerQuoteId,omitempty"`
 	Price           float64 `json:"price,omitempty"`
 	ExpireAt        int64   `json:"expireAt,omitempty"`
+	ErrorCode       string  `json:"errorCode,omitempty"`
 	ErrorMsg        string  `json:"error,omitempty"`
 }
 
@@ -4,13 +4,20 @@ import (
 	"context"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/zodia-adapter/internal/zodia"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/nats-io/nats.go"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// SessionReporter exposes the state of the per-client Zodia WebSocket sessions.
+type SessionReporter interface {
+	SessionStatuses() []zodia.SessionStatus
+}
+
 // RegisterRoutes registers all HTTP routes on the Fiber app.
 func RegisterRoutes(
 	app *fiber.App,
@@ -21,6 +28,8 @@ func RegisterRoutes(
 	balanceHandler *BalanceHandler,
 	productsHandler *ProductsHandler,
 	webhookHandler *WebhookHandler,
+	sessions SessionReporter,
+	auditHandler *audit.Handler,
 ) {
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
 
@@ -51,9 +60,25 @@ func RegisterRoutes(
 			code = fiber.StatusServiceUnavailable
 		}
 
+		// Session state is informational: disconnected sessions reconnect or are
+		// recreated on next use, so they degrade the status without failing the probe.
+		var statuses []zodia.SessionStatus
+		if sessions != nil {
+			statuses = sessions.SessionStatuses()
+		}
+		checks["zodia_ws"] = "ok"
+		for _, st := range statuses {
+			if st.State != zodia.SessionStateConnected {
+				checks["zodia_ws"] = "degraded"
+				status = "degraded"
+				break
+			}
+		}
+
 		return c.Status(code).JSON(fiber.Map{
-			"status": status,
-			"checks": checks,
+			"status":   status,
+			"checks":   checks,
+			"sessions": statuses,
 		})
 	})
 
@@ -64,6 +89,7 @@ func RegisterRoutes(
 	v1.Post("/resolve-order/:quoteId", resolveHandler.ResolveOrder)
 	v1.Get("/balances/:client_id", balanceHandler.GetBalances)
 	v1.Get("/products", productsHandler.ListProducts)
+	v1.Get("/audit/:id", auditHandler.Timeline)
 
 	// Webhook routes
 	app.Post("/webhooks/zodia/transactions", webhookHandler.Handle)
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
 	"github.com/Checker-Finance/adapters/pkg/model"
@@ -14,9 +16,10 @@ import (
 	"github.com/Checker-Finance/adapters/zodia-adapter/internal/zodia"
 )
 
-// WebhookTradeSync syncs terminal trades from webhook events.
+// WebhookTradeSync syncs terminal trades from webhook events, queueing the
+// final event in the same transaction.
 type WebhookTradeSync interface {
-	SyncTradeUpsert(ctx context.Context, trade *model.TradeConfirmation) error
+	SyncTradeWithEvent(ctx context.Context, trade *model.TradeConfirmation, subject, msgID string, payload any) error
 }
 
 // WebhookMapper converts Zodia webhook events to canonical models.
@@ -25,38 +28,104 @@ type WebhookMapper interface {
 	MapTransactionToTrade(tx *zodia.ZodiaTransaction, clientID string) *model.TradeConfirmation
 }
 
+// WebhookVerifier authenticates a webhook body and returns the client it belongs to.
+type WebhookVerifier interface {
+	Verify(ctx context.Context, apiKey, signature string, body []byte) (string, error)
+}
+
+// PollCanceller stops status polling for a trade once its final state is known.
+type PollCanceller interface {
+	CancelPolling(tradeID string)
+}
+
+// webhookStateTTL bounds how long the last seen state of a trade is kept for
+// ordering checks; it matches the dedup window.
+const webhookStateTTL = 48 * time.Hour
+
 // WebhookHandler handles incoming Zodia webhook notifications.
 // POST /webhooks/zodia/transactions
 //
-// Zodia sends a webhook for each transaction state change.
-// This handler processes PROCESSED transactions to finalize trades.
+// Zodia sends a webhook for each transaction state change. Every accepted
+// transition is published as evt.trade.status_changed.v1.ZODIA; terminal
+// states additionally stop polling, sync the trade to the legacy database and
+// publish the final evt.trade.<status>.v1.ZODIA event.
 //
+// Authentication: when a verifier is configured, the body must be signed with
+// the client's API secret (Rest-Key / Rest-Sign headers by default); the key
+// identifies the client and unsigned bodies are rejected.
 // Idempotency: event UUID is stored in Redis with a 48h TTL to prevent duplicate processing.
-// ⚠️ Zodia may not send a signature header. If they do, add HMAC validation here.
+// Ordering: the last accepted state per trade is stored so late or replayed
+// notifications cannot move a trade backwards or out of a terminal state.
+// Both are stored only after the event is handled; a failed legacy sync
+// answers 503 so Zodia retries.
 type WebhookHandler struct {
 	store     store.Store
 	mapper    WebhookMapper
 	tradeSync WebhookTradeSync
 	publisher *publisher.Publisher
+	verifier  WebhookVerifier
+	keyHeader string
+	sigHeader string
+	poller    PollCanceller
+	auditor   *audit.Recorder
 }
 
-// NewWebhookHandler constructs a WebhookHandler.
+// NewWebhookHandler constructs a WebhookHandler. A nil verifier disables
+// signature checks; a nil poller skips poll cancellation.
 func NewWebhookHandler(
 	st store.Store,
 	mapper WebhookMapper,
 	tradeSync WebhookTradeSync,
 	pub *publisher.Publisher,
+	verifier WebhookVerifier,
+	poller PollCanceller,
 ) *WebhookHandler {
 	return &WebhookHandler{
 		store:     st,
 		mapper:    mapper,
 		tradeSync: tradeSync,
 		publisher: pub,
+		verifier:  verifier,
+		keyHeader: zodia.WebhookKeyHeader,
+		sigHeader: zodia.WebhookSignHeader,
+		poller:    poller,
+	}
+}
+
+// SetSignatureHeaders overrides the headers carrying the signing API key and
+// the body signature. Empty names keep the defaults.
+func (h *WebhookHandler) SetSignatureHeaders(keyHeader, sigHeader string) {
+	if keyHeader != "" {
+		h.keyHeader = keyHeader
 	}
+	if sigHeader != "" {
+		h.sigHeader = sigHeader
+	}
+}
+
+// SetAuditRecorder records accepted transitions in the quote-to-trade audit trail.
+func (h *WebhookHandler) SetAuditRecorder(r *audit.Recorder) {
+	h.auditor = r
 }
 
 // Handle processes incoming Zodia webhook events.
 func (h *WebhookHandler) Handle(c *fiber.Ctx) error {
+	ctx := context.Background()
+	sharedmetrics.IncWebhook("zodia", "received")
+
+	// Authenticate before parsing: the signature covers the raw body.
+	clientID := ""
+	if h.verifier != nil {
+		id, err := h.verifier.Verify(ctx, c.Get(h.keyHeader), c.Get(h.sigHeader), c.Body())
+		if err != nil {
+			sharedmetrics.IncWebhook("zodia", "invalid_signature")
+			slog.Warn("zodia.webhook.invalid_signature", "error", err)
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid signature"})
+		}
+		sharedmetrics.IncWebhook("zodia", "verified")
+		clientID = id
+	}
+
 	var event zodia.ZodiaWebhookEvent
 	if err := c.BodyParser(&event); err != nil {
 		slog.Warn("zodia.webhook.parse_failed", "error", err)
@@ -64,8 +133,6 @@ func (h *WebhookHandler) Handle(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ignored", "reason": "parse_error"})
 	}
 
-	ctx := context.Background()
-
 	// Filter: only process trade types
 	if event.Type != "OTCTRADE" && event.Type != "RFSTRADE" {
 		slog.Debug("zodia.webhook.ignored_type",
@@ -74,14 +141,6 @@ func (h *WebhookHandler) Handle(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ignored", "reason": "type_not_applicable"})
 	}
 
-	// Filter: only process PROCESSED (terminal) state
-	if event.State != "PROCESSED" {
-		slog.Debug("zodia.webhook.non_terminal",
-			"state", event.State,
-			"trade_id", event.TradeID)
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "acknowledged", "reason": "non_terminal"})
-	}
-
 	// Idempotency: check if we've already processed this event UUID.
 	// Try to get the key; if it exists, it's a duplicate.
 	dedupKey := "zodia:webhook:dedup:" + event.UUID
@@ -90,34 +149,66 @@ func (h *WebhookHandler) Handle(c *fiber.Ctx) error {
 		getErr := h.store.GetJSON(ctx, dedupKey, &existing)
 		if getErr == nil && existing {
 			// Key exists → duplicate
+			sharedmetrics.IncWebhook("zodia", "duplicate")
 			slog.Debug("zodia.webhook.duplicate",
 				"uuid", event.UUID,
 				"trade_id", event.TradeID)
 			return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "duplicate"})
 		}
-		// Mark as processed (48h TTL)
-		if err := h.store.SetJSON(ctx, dedupKey, true, 48*time.Hour); err != nil {
-			slog.Warn("zodia.webhook.dedup_set_failed",
-				"uuid", event.UUID,
-				"error", err)
-			// Proceed anyway
+	}
+
+	// State machine: discard notifications that would move the trade backwards.
+	stateKey := "zodia:webhook:state:" + event.TradeID
+	var lastState string
+	_ = h.store.GetJSON(ctx, stateKey, &lastState)
+	if !zodia.CanTransition(lastState, event.State) {
+		slog.Info("zodia.webhook.stale_transition",
+			"trade_id", event.TradeID,
+			"from", lastState,
+			"to", event.State)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "acknowledged", "reason": "stale_transition"})
+	}
+
+	// Unsigned webhooks fall back to a best-effort lookup via quote records.
+	if clientID == "" {
+		if qrec, err := h.store.GetQuoteByQuoteID(ctx, event.TradeID); err == nil && qrec != nil {
+			clientID = qrec.ClientID
 		}
 	}
 
-	// Look up internal order by quoteID to get clientID.
-	// NOTE: GetClientIDByTradeID is not available on the store interface.
-	// The webhook must include enough context, or this requires an additional store method.
-	// For now, we use the tradeID as a best-effort lookup via quote records.
-	// ⚠️ This will be a no-op until the store has a TradeID→ClientID index.
-	// Callers are expected to extend the store with a GetClientIDByTradeID method.
-	clientID := ""
-	if qrec, err := h.store.GetQuoteByQuoteID(ctx, event.TradeID); err == nil && qrec != nil {
-		clientID = qrec.ClientID
+	status := zodia.NormalizeTransactionState(event.State)
+	prevStatus := ""
+	if lastState != "" {
+		prevStatus = zodia.NormalizeTransactionState(lastState)
+	}
+	h.auditor.Record(ctx, audit.Event{
+		Kind:       audit.KindStatusTransition,
+		ClientID:   clientID,
+		TradeID:    event.TradeID,
+		PrevStatus: prevStatus,
+		Status:     status,
+		Source:     "webhook",
+	}, c.Body())
+	h.publishStatusChanged(ctx, clientID, event, status)
+
+	if !zodia.IsTerminalState(event.State) {
+		slog.Debug("zodia.webhook.non_terminal",
+			"state", event.State,
+			"trade_id", event.TradeID)
+		h.markProcessed(ctx, dedupKey, stateKey, event)
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "acknowledged", "reason": "non_terminal"})
+	}
+
+	// The webhook has delivered the final state; polling is no longer needed.
+	if h.poller != nil {
+		h.poller.CancelPolling(event.TradeID)
 	}
+
 	if clientID == "" {
 		slog.Warn("zodia.webhook.client_not_found",
 			"trade_id", event.TradeID)
 		// Return 200 to prevent retries; log for investigation
+		h.markProcessed(ctx, dedupKey, stateKey, event)
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ignored", "reason": "client_not_found"})
 	}
 
@@ -126,32 +217,77 @@ func (h *WebhookHandler) Handle(c *fiber.Ctx) error {
 	trade := h.mapper.MapTransactionToTrade(tx, clientID)
 	if trade == nil {
 		slog.Warn("zodia.webhook.map_failed", "trade_id", event.TradeID)
+		h.markProcessed(ctx, dedupKey, stateKey, event)
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "error", "reason": "mapping_failed"})
 	}
 
-	// Sync to legacy database
-	if err := h.tradeSync.SyncTradeUpsert(ctx, trade); err != nil {
+	// Sync to legacy database, queueing the final event in the same transaction
+	subject := "evt.trade." + trade.Status + ".v1.ZODIA"
+	msgID := publisher.TradeEventID("ZODIA", trade.TradeID, trade.Status)
+	if err := h.tradeSync.SyncTradeWithEvent(ctx, trade, subject, msgID, trade); err != nil {
 		slog.Error("zodia.webhook.sync_failed",
 			"trade_id", event.TradeID,
 			"error", err)
-		// Return 200 to prevent infinite retries; logged for alerting
-	} else {
-		slog.Info("zodia.webhook.trade_synced",
-			"trade_id", event.TradeID,
-			"client", clientID,
-			"status", trade.Status)
+		// Publish the final event directly since it was not queued, and leave
+		// the event unmarked so Zodia's retry syncs the trade again; the
+		// message ID keeps the retry from announcing it twice.
+		if h.publisher != nil {
+			if err := h.publisher.Publish(ctx, subject, trade, publisher.WithMsgID(msgID)); err != nil {
+				metrics.IncNATSPublishError(subject)
+				slog.Warn("zodia.webhook.publish_failed",
+					"subject", subject,
+					"error", err)
+			}
+		}
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "error", "reason": "sync_failed"})
 	}
+	slog.Info("zodia.webhook.trade_synced",
+		"trade_id", event.TradeID,
+		"client", clientID,
+		"status", trade.Status)
 
-	// Publish final NATS event
-	if h.publisher != nil {
-		subject := "evt.trade." + trade.Status + ".v1.ZODIA"
-		if err := h.publisher.Publish(ctx, subject, trade); err != nil {
-			metrics.IncNATSPublishError(subject)
-			slog.Warn("zodia.webhook.publish_failed",
-				"subject", subject,
+	h.markProcessed(ctx, dedupKey, stateKey, event)
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "processed"})
+}
+
+// markProcessed records the event UUID for deduplication (48h TTL) and the
+// trade's new state for ordering checks. It runs only once the event has been
+// handled, so an event that failed part-way is processed again on retry.
+func (h *WebhookHandler) markProcessed(ctx context.Context, dedupKey, stateKey string, event zodia.ZodiaWebhookEvent) {
+	if event.UUID != "" {
+		if err := h.store.SetJSON(ctx, dedupKey, true, 48*time.Hour); err != nil {
+			slog.Warn("zodia.webhook.dedup_set_failed",
+				"uuid", event.UUID,
 				"error", err)
 		}
 	}
+	if err := h.store.SetJSON(ctx, stateKey, event.State, webhookStateTTL); err != nil {
+		slog.Warn("zodia.webhook.state_set_failed",
+			"trade_id", event.TradeID,
+			"error", err)
+	}
+}
 
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "processed"})
+// publishStatusChanged emits the interim status event for an accepted transition.
+func (h *WebhookHandler) publishStatusChanged(ctx context.Context, clientID string, event zodia.ZodiaWebhookEvent, status string) {
+	if h.publisher == nil {
+		return
+	}
+	const subject = "evt.trade.status_changed.v1.ZODIA"
+	// Keyed by the raw state so a retried or duplicated notification of the
+	// same transition is published once.
+	msgID := publisher.TradeEventID("ZODIA", event.TradeID, "status_changed."+event.State)
+	if err := h.publisher.Publish(ctx, subject, map[string]any{
+		"client_id":  clientID,
+		"trade_id":   event.TradeID,
+		"status":     status,
+		"raw":        event.State,
+		"source":     "webhook",
+		"updated_at": time.Now().UTC(),
+	}, publisher.WithMsgID(msgID)); err != nil {
+		metrics.IncNATSPublishError(subject)
+		slog.Warn("zodia.webhook.publish_failed",
+			"subject", subject,
+			"error", err)
+	}
 }
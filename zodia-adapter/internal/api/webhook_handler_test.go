@@ -7,6 +7,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -105,19 +106,25 @@ func (m *mockWebhookMapper) MapTransactionToTrade(tx *zodia.ZodiaTransaction, cl
 // ─── mock trade sync ──────────────────────────────────────────────────────────
 
 type mockTradeSync struct {
-	called int
-	err    error
+	called  int
+	subject string
+	err     error
 }
 
-func (m *mockTradeSync) SyncTradeUpsert(_ context.Context, _ *model.TradeConfirmation) error {
+func (m *mockTradeSync) SyncTradeWithEvent(_ context.Context, _ *model.TradeConfirmation, subject, _ string, _ any) error {
 	m.called++
+	m.subject = subject
 	return m.err
 }
 
 // ─── test helpers ─────────────────────────────────────────────────────────────
 
 func newWebhookTestApp(st *mockStore, mapper WebhookMapper, ts *mockTradeSync) *fiber.App {
-	h := NewWebhookHandler(st, mapper, ts, nil) // nil publisher
+	return newSignedWebhookTestApp(st, mapper, ts, nil, nil)
+}
+
+func newSignedWebhookTestApp(st *mockStore, mapper WebhookMapper, ts *mockTradeSync, verifier WebhookVerifier, poller PollCanceller) *fiber.App {
+	h := NewWebhookHandler(st, mapper, ts, nil, verifier, poller) // nil publisher
 	app := fiber.New()
 	app.Post("/webhooks/zodia/transactions", h.Handle)
 	return app
@@ -235,7 +242,8 @@ func TestWebhookHandler_ProcessedOTCTrade(t *testing.T) {
 	var body map[string]string
 	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
 	assert.Equal(t, "processed", body["status"])
-	assert.Equal(t, 1, ts.called, "SyncTradeUpsert should be called once")
+	assert.Equal(t, 1, ts.called, "SyncTradeWithEvent should be called once")
+	assert.Equal(t, "evt.trade.filled.v1.ZODIA", ts.subject)
 }
 
 func TestWebhookHandler_ProcessedRFSTrade(t *testing.T) {
@@ -259,7 +267,7 @@ func TestWebhookHandler_ProcessedRFSTrade(t *testing.T) {
 	assert.Equal(t, "processed", body["status"])
 }
 
-func TestWebhookHandler_SyncError_StillReturns200(t *testing.T) {
+func TestWebhookHandler_SyncError_RetriedByZodia(t *testing.T) {
 	st := newMockStore()
 	st.quoteRecord = &model.QuoteRecord{ClientID: "c"}
 
@@ -269,9 +277,18 @@ func TestWebhookHandler_SyncError_StillReturns200(t *testing.T) {
 	event := zodia.ZodiaWebhookEvent{
 		UUID: "uuid-sync-err", Type: "OTCTRADE", State: "PROCESSED", TradeID: "t1",
 	}
-	// Even if sync fails, we return 200 to prevent retries
+	// A failed sync answers non-2xx so Zodia retries, and marks nothing.
 	resp := postWebhook(t, app, event)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// The retry is not treated as a duplicate or a stale transition.
+	ts.err = nil
+	resp = postWebhook(t, app, event)
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body map[string]string
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "processed", body["status"])
+	assert.Equal(t, 2, ts.called)
 }
 
 func TestWebhookHandler_InvalidBody(t *testing.T) {
@@ -311,9 +328,216 @@ func TestWebhookHandler_Idempotency_SecondCallSkipped(t *testing.T) {
 	// Second call should be a duplicate
 	resp2 := postWebhook(t, app, event)
 	assert.Equal(t, http.StatusOK, resp2.StatusCode)
-	assert.Equal(t, 1, ts.called, "SyncTradeUpsert should not be called again")
+	assert.Equal(t, 1, ts.called, "SyncTradeWithEvent should not be called again")
 
 	var body2 map[string]string
 	require.NoError(t, json.NewDecoder(resp2.Body).Decode(&body2))
 	assert.Equal(t, "duplicate", body2["status"])
 }
+
+// ─── Lifecycle and signatures ─────────────────────────────────────────────────
+
+type mockPollCanceller struct {
+	cancelled []string
+}
+
+func (m *mockPollCanceller) CancelPolling(tradeID string) {
+	m.cancelled = append(m.cancelled, tradeID)
+}
+
+type mockClientResolver struct {
+	configs map[string]*zodia.ZodiaClientConfig
+}
+
+func (m *mockClientResolver) Resolve(_ context.Context, clientID string) (*zodia.ZodiaClientConfig, error) {
+	if cfg, ok := m.configs[clientID]; ok {
+		return cfg, nil
+	}
+	return nil, errors.New("unknown client")
+}
+
+func (m *mockClientResolver) DiscoverClients(_ context.Context) ([]string, error) {
+	ids := make([]string, 0, len(m.configs))
+	for id := range m.configs {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// recordedWebhook returns a Zodia RFSTRADE webhook body as captured from the
+// sandbox, with the given event UUID and state.
+func recordedWebhook(uuid, state string) string {
+	return `{"uuid":"` + uuid + `","type":"RFSTRADE","state":"` + state + `",` +
+		`"tradeId":"RFS-20260301-000042","instrument":"USD.MXN","side":"BUY",` +
+		`"quantity":100000,"price":17.2345,"dealtAmount":100000,"contraAmount":1723450,` +
+		`"createdAt":"2026-03-01T14:02:11Z","updatedAt":"2026-03-01T14:02:13Z"}`
+}
+
+func postRaw(t *testing.T, app *fiber.App, body string, headers map[string]string) (int, map[string]string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/zodia/transactions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := app.Test(req, 5000)
+	require.NoError(t, err)
+	var out map[string]string
+	_ = json.NewDecoder(resp.Body).Decode(&out)
+	return resp.StatusCode, out
+}
+
+func TestWebhookHandler_Lifecycle(t *testing.T) {
+	type step struct {
+		state      string
+		wantStatus string
+		wantReason string
+	}
+	tests := []struct {
+		name        string
+		steps       []step
+		wantSynced  int
+		wantCancels int
+	}{
+		{
+			name: "pending → processing → processed",
+			steps: []step{
+				{"PENDING", "acknowledged", "non_terminal"},
+				{"PROCESSING", "acknowledged", "non_terminal"},
+				{"PROCESSED", "processed", ""},
+			},
+			wantSynced:  1,
+			wantCancels: 1,
+		},
+		{
+			name: "pending → rejected",
+			steps: []step{
+				{"PENDING", "acknowledged", "non_terminal"},
+				{"REJECTED", "processed", ""},
+			},
+			wantSynced:  1,
+			wantCancels: 1,
+		},
+		{
+			name: "processing → failed",
+			steps: []step{
+				{"PROCESSING", "acknowledged", "non_terminal"},
+				{"FAILED", "processed", ""},
+			},
+			wantSynced:  1,
+			wantCancels: 1,
+		},
+		{
+			name: "cancelled directly",
+			steps: []step{
+				{"CANCELLED", "processed", ""},
+			},
+			wantSynced:  1,
+			wantCancels: 1,
+		},
+		{
+			name: "late pending after processed is discarded",
+			steps: []step{
+				{"PROCESSED", "processed", ""},
+				{"PENDING", "acknowledged", "stale_transition"},
+			},
+			wantSynced:  1,
+			wantCancels: 1,
+		},
+		{
+			name: "processing regression is discarded",
+			steps: []step{
+				{"PROCESSING", "acknowledged", "non_terminal"},
+				{"PENDING", "acknowledged", "stale_transition"},
+			},
+		},
+		{
+			name: "second terminal state is discarded",
+			steps: []step{
+				{"PROCESSED", "processed", ""},
+				{"CANCELLED", "acknowledged", "stale_transition"},
+			},
+			wantSynced:  1,
+			wantCancels: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			st := newMockStore()
+			st.quoteRecord = &model.QuoteRecord{ClientID: "client-lc"}
+			ts := &mockTradeSync{}
+			poller := &mockPollCanceller{}
+			app := newSignedWebhookTestApp(st, zodia.NewMapper(), ts, nil, poller)
+
+			for i, s := range tc.steps {
+				code, body := postRaw(t, app, recordedWebhook(tc.name+string(rune('a'+i)), s.state), nil)
+				assert.Equal(t, http.StatusOK, code)
+				assert.Equal(t, s.wantStatus, body["status"], "step %d (%s)", i, s.state)
+				assert.Equal(t, s.wantReason, body["reason"], "step %d (%s)", i, s.state)
+			}
+			assert.Equal(t, tc.wantSynced, ts.called)
+			assert.Len(t, poller.cancelled, tc.wantCancels)
+		})
+	}
+}
+
+func TestWebhookHandler_Signature(t *testing.T) {
+	signer := zodia.NewHMACSigner()
+	resolver := &mockClientResolver{configs: map[string]*zodia.ZodiaClientConfig{
+		"client-sig": {APIKey: "key-sig", APISecret: "secret-sig", BaseURL: "https://example"},
+	}}
+	body := recordedWebhook("uuid-sig", "PROCESSED")
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		wantCode int
+	}{
+		{"valid", map[string]string{"Rest-Key": "key-sig", "Rest-Sign": signer.Sign([]byte(body), "secret-sig")}, http.StatusOK},
+		{"missing", nil, http.StatusUnauthorized},
+		{"wrong secret", map[string]string{"Rest-Key": "key-sig", "Rest-Sign": signer.Sign([]byte(body), "other")}, http.StatusUnauthorized},
+		{"unknown key", map[string]string{"Rest-Key": "nope", "Rest-Sign": signer.Sign([]byte(body), "secret-sig")}, http.StatusUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			st := newMockStore() // no quote record: the client comes from the signing key
+			ts := &mockTradeSync{}
+			app := newSignedWebhookTestApp(st, zodia.NewMapper(), ts, zodia.NewWebhookVerifier(resolver, signer), nil)
+
+			code, resp := postRaw(t, app, body, tc.headers)
+			assert.Equal(t, tc.wantCode, code)
+			if tc.wantCode == http.StatusOK {
+				assert.Equal(t, "processed", resp["status"])
+				assert.Equal(t, 1, ts.called)
+			} else {
+				assert.Equal(t, 0, ts.called)
+			}
+		})
+	}
+}
+
+func TestWebhookHandler_SignatureHeadersConfigurable(t *testing.T) {
+	signer := zodia.NewHMACSigner()
+	resolver := &mockClientResolver{configs: map[string]*zodia.ZodiaClientConfig{
+		"client-sig": {APIKey: "key-sig", APISecret: "secret-sig", BaseURL: "https://example"},
+	}}
+	body := recordedWebhook("uuid-hdr", "PROCESSED")
+	sig := signer.Sign([]byte(body), "secret-sig")
+
+	ts := &mockTradeSync{}
+	h := NewWebhookHandler(newMockStore(), zodia.NewMapper(), ts, nil, zodia.NewWebhookVerifier(resolver, signer), nil)
+	h.SetSignatureHeaders("X-Zodia-Key", "X-Zodia-Signature")
+	app := fiber.New()
+	app.Post("/webhooks/zodia/transactions", h.Handle)
+
+	// The default headers no longer authenticate the body.
+	code, _ := postRaw(t, app, body, map[string]string{"Rest-Key": "key-sig", "Rest-Sign": sig})
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Equal(t, 0, ts.called)
+
+	code, resp := postRaw(t, app, body, map[string]string{"X-Zodia-Key": "key-sig", "X-Zodia-Signature": sig})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "processed", resp["status"])
+	assert.Equal(t, 1, ts.called)
+}
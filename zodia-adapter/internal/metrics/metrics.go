@@ -1,73 +1,48 @@
 package metrics
 
 import (
-	"time"
-
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 )
 
 var (
-	// ZodiaRequestsTotal tracks the number of outbound API calls to Zodia.
-	ZodiaRequestsTotal = promauto.NewCounterVec(
+	// ZodiaWSSessionsDeadTotal tracks sessions that gave up reconnecting after max retries.
+	ZodiaWSSessionsDeadTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
-			Name: "zodia_api_requests_total",
-			Help: "Total number of Zodia API requests made (by endpoint, method, and status).",
-		},
-		[]string{"endpoint", "method", "status"},
-	)
-
-	// ZodiaRequestDuration measures the duration of outbound Zodia API calls.
-	ZodiaRequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "zodia_api_request_duration_seconds",
-			Help:    "Duration of Zodia API requests in seconds.",
-			Buckets: prometheus.ExponentialBuckets(0.001, 2, 15), // 1ms → ~16s
-		},
-		[]string{"endpoint", "method"},
-	)
-
-	// ZodiaWSReconnectsTotal tracks WebSocket reconnect events per client.
-	ZodiaWSReconnectsTotal = promauto.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "zodia_ws_reconnects_total",
-			Help: "Number of WebSocket reconnect events by client ID.",
+			Name: "zodia_ws_sessions_dead_total",
+			Help: "Number of WebSocket sessions that exhausted reconnect retries, by client ID.",
 		},
 		[]string{"client_id"},
 	)
 
-	// NATSPublishErrors tracks NATS publish failures by subject.
-	NATSPublishErrors = promauto.NewCounterVec(
+	// ZodiaRFSRevalidationsTotal tracks execution-time price re-validations by outcome.
+	ZodiaRFSRevalidationsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
-			Name: "nats_publish_errors_total",
-			Help: "Number of NATS publish failures by subject.",
+			Name: "zodia_rfs_revalidations_total",
+			Help: "Number of RFS quotes re-validated against the live price at execution, by outcome.",
 		},
-		[]string{"subject"},
+		[]string{"outcome"},
 	)
 )
 
-// IncZodiaRequest increments the Zodia API request counter.
-func IncZodiaRequest(endpoint, method, status string) {
-	ZodiaRequestsTotal.WithLabelValues(endpoint, method, status).Inc()
+// IncNATSPublishError increments the shared NATS publish error counter for the given subject.
+func IncNATSPublishError(subject string) {
+	sharedmetrics.IncNATSPublishError("zodia", subject)
 }
 
-// ObserveDuration records elapsed time since start into a HistogramVec or SummaryVec.
-func ObserveDuration(v any, start time.Time, labels ...string) {
-	duration := time.Since(start).Seconds()
-	switch metric := v.(type) {
-	case *prometheus.HistogramVec:
-		metric.WithLabelValues(labels...).Observe(duration)
-	case *prometheus.SummaryVec:
-		metric.WithLabelValues(labels...).Observe(duration)
-	}
+// IncWSReconnect increments the shared WebSocket reconnect counter for a client.
+func IncWSReconnect(clientID string) {
+	sharedmetrics.IncWSReconnect("zodia", clientID)
 }
 
-// IncNATSPublishError increments the NATS publish error counter for the given subject.
-func IncNATSPublishError(subject string) {
-	NATSPublishErrors.WithLabelValues(subject).Inc()
+// IncWSSessionDead increments the dead-session counter for a client.
+func IncWSSessionDead(clientID string) {
+	ZodiaWSSessionsDeadTotal.WithLabelValues(clientID).Inc()
 }
 
-// IncWSReconnect increments the WebSocket reconnect counter for a client.
-func IncWSReconnect(clientID string) {
-	ZodiaWSReconnectsTotal.WithLabelValues(clientID).Inc()
+// IncRFSRevalidation increments the RFS re-validation counter for the given outcome.
+func IncRFSRevalidation(outcome string) {
+	ZodiaRFSRevalidationsTotal.WithLabelValues(outcome).Inc()
 }
@@ -7,8 +7,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"log/slog"
-	"sync"
+	"strings"
 	"time"
+
+	"github.com/Checker-Finance/adapters/internal/auth"
 )
 
 const (
@@ -45,6 +47,18 @@ func (s *HMACSigner) Sign(body []byte, apiSecret string) string {
 	return hex.EncodeToString(mac.Sum(nil))
 }
 
+// Verify reports whether signature is the hex HMAC-SHA512 of body under
+// apiSecret. The comparison is constant-time.
+func (s *HMACSigner) Verify(body []byte, apiSecret, signature string) bool {
+	expected, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha512.New, []byte(apiSecret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
 // Tonce returns the current time as Unix microseconds. Used as a nonce in request bodies.
 func Tonce() int64 {
 	return time.Now().UnixMicro()
@@ -56,66 +70,96 @@ func Tonce() int64 {
 // ────────────────────────────────────────────────
 //
 
-// wsTokenEntry caches a WS bearer token with its expiry time.
-type wsTokenEntry struct {
-	token     string
-	expiresAt time.Time
-}
-
 // WSTokenManager fetches and caches Zodia WebSocket auth tokens per client.
 // Tokens are obtained by calling POST /ws/auth (HMAC-signed REST endpoint).
 // Each client's token is keyed by their api_key.
 type WSTokenManager struct {
-	rest  *RESTClient
-	mu    sync.Mutex
-	cache map[string]wsTokenEntry // apiKey → tokenEntry
+	rest   *RESTClient
+	tokens *auth.TokenManager
 }
 
 // NewWSTokenManager creates a new WSTokenManager.
 func NewWSTokenManager(rest *RESTClient) *WSTokenManager {
 	return &WSTokenManager{
-		rest:  rest,
-		cache: make(map[string]wsTokenEntry),
+		rest:   rest,
+		tokens: auth.NewTokenManager("zodia", wsTokenExpiryBuffer),
 	}
 }
 
+// SetStore persists WS tokens in store so replicas share them.
+func (m *WSTokenManager) SetStore(store auth.TokenStore) {
+	m.tokens.SetStore(store)
+}
+
+// Run refreshes cached WS tokens in the background until ctx is cancelled.
+func (m *WSTokenManager) Run(ctx context.Context, interval time.Duration) {
+	m.tokens.Run(ctx, interval)
+}
+
 // GetToken returns a valid WS auth token for the given client config.
 // Returns a cached token if still valid; otherwise fetches a new one via POST /ws/auth.
 func (m *WSTokenManager) GetToken(ctx context.Context, cfg *ZodiaClientConfig) (string, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	return m.tokens.Token(ctx, cfg.APIKey, func(ctx context.Context, _ auth.Token) (auth.Token, error) {
+		token, err := m.rest.GetWSAuthToken(ctx, cfg)
+		if err != nil {
+			return auth.Token{}, fmt.Errorf("zodia: get ws auth token: %w", err)
+		}
 
-	key := cfg.APIKey
-	if entry, ok := m.cache[key]; ok {
-		if time.Now().Before(entry.expiresAt.Add(-wsTokenExpiryBuffer)) {
-			return entry.token, nil
+		masked := cfg.APIKey
+		if len(masked) > 8 {
+			masked = masked[:8] + "..."
 		}
-	}
+		slog.Info("zodia.ws_token_refreshed", "api_key_prefix", masked)
 
-	token, err := m.rest.GetWSAuthToken(ctx, cfg)
+		return auth.Token{
+			AccessToken: token,
+			ExpiresAt:   time.Now().Add(wsTokenDefaultTTL),
+		}, nil
+	})
+}
+
+// PruneClients forgets the WS tokens of clients no longer configured in Secrets
+// Manager, so they stop being refreshed. Nothing is forgotten if any
+// configured client fails to resolve, since its token key would be unknown.
+func (m *WSTokenManager) PruneClients(ctx context.Context, resolver ConfigResolver) error {
+	clientIDs, err := resolver.DiscoverClients(ctx)
 	if err != nil {
-		return "", fmt.Errorf("zodia: get ws auth token: %w", err)
+		return err
 	}
-
-	m.cache[key] = wsTokenEntry{
-		token:     token,
-		expiresAt: time.Now().Add(wsTokenDefaultTTL),
+	keys := make([]string, 0, len(clientIDs))
+	for _, clientID := range clientIDs {
+		cfg, err := resolver.Resolve(ctx, clientID)
+		if err != nil {
+			return fmt.Errorf("resolve %q: %w", clientID, err)
+		}
+		keys = append(keys, cfg.APIKey)
 	}
-
-	masked := key
-	if len(masked) > 8 {
-		masked = masked[:8] + "..."
+	if n := m.tokens.Retain(keys); n > 0 {
+		slog.Info("zodia.ws_tokens_forgotten", "count", n)
 	}
-	slog.Info("zodia.ws_token_refreshed", "api_key_prefix", masked)
+	return nil
+}
 
-	return token, nil
+// RunClientPruning calls PruneClients every interval until ctx is cancelled.
+func (m *WSTokenManager) RunClientPruning(ctx context.Context, resolver ConfigResolver, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.PruneClients(ctx, resolver); err != nil {
+				slog.Warn("zodia.ws_token_prune_failed", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
 }
 
-// InvalidateToken removes the cached WS token for the given api_key,
-// forcing a re-fetch on the next GetToken call.
-func (m *WSTokenManager) InvalidateToken(apiKey string) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-	delete(m.cache, apiKey)
+// InvalidateToken discards token for the given api_key after Zodia rejected
+// it, forcing a re-fetch on the next GetToken call. An empty token discards
+// whatever is cached.
+func (m *WSTokenManager) InvalidateToken(ctx context.Context, apiKey, token string) {
+	m.tokens.Invalidate(ctx, apiKey, token)
 	slog.Debug("zodia.ws_token_invalidated")
 }
@@ -11,6 +11,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/internal/auth"
 )
 
 // ─── HMACSigner ───────────────────────────────────────────────────────────────
@@ -77,62 +79,76 @@ func testZodiaCfg(apiKey string) *ZodiaClientConfig {
 
 
 
-func TestWSTokenManager_GetToken_CacheHit(t *testing.T) {
-	mgr := &WSTokenManager{
-		cache:  make(map[string]wsTokenEntry),
-	}
+// newWSAuthServer serves POST /ws/auth with token and counts the calls.
+func newWSAuthServer(t *testing.T, token string, calls *int) *httptest.Server {
+	t.Helper()
+	resp, _ := json.Marshal(ZodiaWSAuthResponse{Token: token})
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(resp)
+	}))
+}
 
-	// Pre-populate cache with valid token
-	mgr.cache["api-key-1"] = wsTokenEntry{
-		token:     "cached-ws-token",
-		expiresAt: time.Now().Add(25 * time.Hour),
-	}
+func TestWSTokenManager_GetToken_CacheHit(t *testing.T) {
+	// A nil REST client proves the cached token is served without a fetch.
+	mgr := NewWSTokenManager(nil)
+	mgr.tokens.Set("api-key-1", auth.Token{
+		AccessToken: "cached-ws-token",
+		ExpiresAt:   time.Now().Add(25 * time.Hour),
+	})
 
-	// Since we can't call GetToken without a real RESTClient,
-	// we test the cache directly to verify the TTL logic
-	entry, ok := mgr.cache["api-key-1"]
-	assert.True(t, ok)
-	assert.Equal(t, "cached-ws-token", entry.token)
-	assert.True(t, time.Now().Before(entry.expiresAt.Add(-wsTokenExpiryBuffer)),
-		"token within valid window (>30min from expiry)")
+	token, err := mgr.GetToken(context.Background(), testZodiaCfg("api-key-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "cached-ws-token", token)
 }
 
 func TestWSTokenManager_GetToken_NearExpiry(t *testing.T) {
-	mgr := &WSTokenManager{
-		cache:  make(map[string]wsTokenEntry),
-	}
+	calls := 0
+	srv := newWSAuthServer(t, "fresh-ws-token", &calls)
+	defer srv.Close()
 
+	mgr := NewWSTokenManager(NewRESTClient(nil, NewHMACSigner()))
 	// Token expires in 10 minutes — within the 30-minute buffer
-	mgr.cache["api-key-2"] = wsTokenEntry{
-		token:     "expiring-soon-token",
-		expiresAt: time.Now().Add(10 * time.Minute),
-	}
+	mgr.tokens.Set("api-key-2", auth.Token{
+		AccessToken: "expiring-soon-token",
+		ExpiresAt:   time.Now().Add(10 * time.Minute),
+	})
 
-	entry := mgr.cache["api-key-2"]
-	// Check: within buffer means we should NOT reuse
-	shouldRefresh := !time.Now().Before(entry.expiresAt.Add(-wsTokenExpiryBuffer))
-	assert.True(t, shouldRefresh, "token near expiry should trigger refresh")
+	cfg := testZodiaCfg("api-key-2")
+	cfg.BaseURL = srv.URL
+	token, err := mgr.GetToken(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "fresh-ws-token", token)
+	assert.Equal(t, 1, calls)
 }
 
 func TestWSTokenManager_InvalidateToken(t *testing.T) {
-	mgr := &WSTokenManager{
-		cache: map[string]wsTokenEntry{
-			"api-key-3": {token: "valid-token", expiresAt: time.Now().Add(24 * time.Hour)},
-		},
-	}
+	calls := 0
+	srv := newWSAuthServer(t, "new-ws-token", &calls)
+	defer srv.Close()
+
+	mgr := NewWSTokenManager(NewRESTClient(nil, NewHMACSigner()))
+	mgr.tokens.Set("api-key-3", auth.Token{
+		AccessToken: "valid-token",
+		ExpiresAt:   time.Now().Add(24 * time.Hour),
+	})
+
+	mgr.InvalidateToken(context.Background(), "api-key-3", "valid-token")
 
-	mgr.InvalidateToken("api-key-3")
-	_, ok := mgr.cache["api-key-3"]
-	assert.False(t, ok, "token should be removed after invalidation")
+	cfg := testZodiaCfg("api-key-3")
+	cfg.BaseURL = srv.URL
+	token, err := mgr.GetToken(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "new-ws-token", token, "token should be re-fetched after invalidation")
+	assert.Equal(t, 1, calls)
 }
 
 func TestWSTokenManager_InvalidateToken_NonExistent(t *testing.T) {
-	mgr := &WSTokenManager{
-		cache:  make(map[string]wsTokenEntry),
-	}
+	mgr := NewWSTokenManager(nil)
 	// Should not panic
 	assert.NotPanics(t, func() {
-		mgr.InvalidateToken("non-existent-key")
+		mgr.InvalidateToken(context.Background(), "non-existent-key", "")
 	})
 }
 
@@ -174,3 +190,15 @@ func TestWSTokenManager_GetToken_ViaRESTServer(t *testing.T) {
 	assert.Equal(t, "server-ws-token", token2)
 	assert.Equal(t, 1, callCount, "second call should use cache")
 }
+
+func TestHMACSigner_Verify(t *testing.T) {
+	s := NewHMACSigner()
+	body := []byte(`{"uuid":"u1","state":"PROCESSED"}`)
+	sig := s.Sign(body, "secret")
+
+	assert.True(t, s.Verify(body, "secret", sig))
+	assert.True(t, s.Verify(body, "secret", " "+sig+" "), "surrounding whitespace is ignored")
+	assert.False(t, s.Verify(body, "other", sig))
+	assert.False(t, s.Verify([]byte(`{"uuid":"u1","state":"PENDING"}`), "secret", sig))
+	assert.False(t, s.Verify(body, "secret", "not-hex"))
+}
@@ -34,14 +34,7 @@ func (m *Mapper) MapWSPriceToQuote(price WSPricePayload, req model.RFQRequest) *
 	}
 
 	side := strings.ToUpper(price.Side)
-	p := price.Price
-	if p == 0 {
-		if strings.EqualFold(side, "BUY") {
-			p = price.Ask
-		} else {
-			p = price.Bid
-		}
-	}
+	p := effectivePrice(price)
 
 	return &model.Quote{
 		ID:         price.QuoteID,
@@ -60,6 +53,18 @@ func (m *Mapper) MapWSPriceToQuote(price WSPricePayload, req model.RFQRequest) *
 	}
 }
 
+// effectivePrice returns the executable price of a WS price update: the
+// explicit price if set, otherwise the ask for BUY and the bid for SELL.
+func effectivePrice(price WSPricePayload) float64 {
+	if price.Price != 0 {
+		return price.Price
+	}
+	if strings.EqualFold(price.Side, "BUY") {
+		return price.Ask
+	}
+	return price.Bid
+}
+
 // quoteFromInstrument extracts the quote currency from a Zodia instrument like "USD.MXN" → "MXN".
 func quoteFromInstrument(instrument string) string {
 	parts := strings.Split(instrument, ".")
@@ -176,6 +181,8 @@ func (m *Mapper) MapInstrumentToProduct(instr ZodiaInstrument) model.Product {
 		InstrumentSymbol: FromZodiaPair(instr.Symbol),
 		ProductID:        instr.Symbol,
 		ProductName:      FromZodiaPair(instr.Symbol),
+		MinSize:          instr.MinSize,
+		NotionalCurrency: instr.Base,
 	}
 }
 
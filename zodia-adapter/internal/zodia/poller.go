@@ -7,9 +7,13 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
+	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/pkg/model"
 	"github.com/Checker-Finance/adapters/zodia-adapter/internal/metrics"
 	"github.com/Checker-Finance/adapters/zodia-adapter/pkg/config"
 )
@@ -52,6 +56,21 @@ func (p *Poller) Stop() {
 	close(p.stopCh)
 }
 
+// CancelPolling stops active status polling for tradeID, if any. Called when
+// a webhook has already delivered the trade's terminal state.
+func (p *Poller) CancelPolling(tradeID string) {
+	if cancel, ok := p.activeTrades.LoadAndDelete(tradeID); ok {
+		cancel.(context.CancelFunc)()
+		slog.Info("zodia.trade_poll_cancelled", "trade_id", tradeID)
+	}
+}
+
+// IsPolling reports whether tradeID's status is being polled.
+func (p *Poller) IsPolling(tradeID string) bool {
+	_, ok := p.activeTrades.Load(tradeID)
+	return ok
+}
+
 // PollTradeStatus continuously polls Zodia for a transaction's status until it
 // reaches a terminal state or the poller is stopped.
 func (p *Poller) PollTradeStatus(
@@ -71,11 +90,14 @@ func (p *Poller) PollTradeStatus(
 
 	ctx, cancel := context.WithCancel(parentCtx)
 	p.activeTrades.Store(tradeID, cancel)
+	submitted := time.Now()
+	untrack := sharedmetrics.TrackPolledTrade("zodia")
 
 	go func() {
 		defer func() {
 			p.activeTrades.Delete(tradeID)
 			cancel()
+			untrack()
 		}()
 
 		ticker := time.NewTicker(p.pollInterval)
@@ -99,7 +121,10 @@ func (p *Poller) PollTradeStatus(
 				return
 
 			case <-ticker.C:
-				tx, err := p.service.FetchTransactionStatus(ctx, clientID, tradeID)
+				pollStart := time.Now()
+				pollCtx, raw := httpclient.CaptureResponse(ctx)
+				tx, err := p.service.FetchTransactionStatus(pollCtx, clientID, tradeID)
+				sharedmetrics.ObservePoll("zodia", pollStart)
 				if err != nil {
 					slog.Warn("zodia.trade_poll_error",
 						"trade_id", tradeID,
@@ -113,6 +138,15 @@ func (p *Poller) PollTradeStatus(
 
 				// Emit status change only when it actually changes
 				if status != lastStatus {
+					p.service.auditor.Record(ctx, audit.Event{
+						Kind:       audit.KindStatusTransition,
+						ClientID:   clientID,
+						QuoteID:    quoteID,
+						TradeID:    tradeID,
+						PrevStatus: lastStatus,
+						Status:     status,
+						Source:     "poller",
+					}, raw.Body)
 					lastStatus = status
 
 					if p.publisher != nil {
@@ -141,6 +175,10 @@ func (p *Poller) PollTradeStatus(
 				}
 
 				if IsTerminalState(rawState) {
+					sharedmetrics.ObserveTimeToTerminal("zodia", status, submitted)
+					if status == model.StatusFilled {
+						sharedmetrics.ObserveQuoteFill("zodia", quoteID, time.Now())
+					}
 					p.handleTerminalState(ctx, clientID, tradeID, quoteID, tx, status)
 					return
 				}
@@ -203,16 +241,29 @@ func (p *Poller) handleTerminalState(
 	tx *ZodiaTransaction,
 	status string,
 ) {
-	// 1. Sync to legacy database
+	finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.ZODIA"
+	msgID := publisher.TradeEventID("ZODIA", tradeID, status)
+	event := map[string]any{
+		"client_id": clientID,
+		"trade_id":  tradeID,
+		"quote_id":  quoteID,
+		"status":    status,
+		"final":     true,
+		"timestamp": time.Now().UTC(),
+	}
+
+	// 1. Sync to legacy database, queueing the final event in the same transaction
+	queued := false
 	if p.tradeSync != nil {
 		trade := p.service.BuildTradeConfirmationFromTransaction(clientID, tx)
 		if trade != nil {
-			if err := p.tradeSync.SyncTradeUpsert(ctx, trade); err != nil {
+			if err := p.tradeSync.SyncTradeWithEvent(ctx, trade, finalSubject, msgID, event); err != nil {
 				slog.Warn("legacy.trade_sync_failed",
 					"trade_id", tradeID,
 					"client", clientID,
 					"error", err)
 			} else {
+				queued = true
 				slog.Info("legacy.trade_sync_upsert",
 					"trade_id", tradeID,
 					"client", clientID,
@@ -222,17 +273,9 @@ func (p *Poller) handleTerminalState(
 		}
 	}
 
-	// 2. Emit final event
-	if p.publisher != nil {
-		finalSubject := "evt.trade." + strings.ToLower(status) + ".v1.ZODIA"
-		if err := p.publisher.Publish(ctx, finalSubject, map[string]any{
-			"client_id": clientID,
-			"trade_id":  tradeID,
-			"quote_id":  quoteID,
-			"status":    status,
-			"final":     true,
-			"timestamp": time.Now().UTC(),
-		}); err != nil {
+	// 2. Emit final event directly when it could not be queued
+	if !queued && p.publisher != nil {
+		if err := p.publisher.Publish(ctx, finalSubject, event, publisher.WithMsgID(msgID)); err != nil {
 			metrics.IncNATSPublishError(finalSubject)
 			slog.Debug("nats.publish_failed",
 				"subject", finalSubject,
@@ -1,19 +1,79 @@
 package zodia
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
-// zodiaSupportedProducts is the static list of Zodia Markets supported currency pairs.
-// These are the RFS-eligible instruments. Verify and expand from GET /zm/rest/available-instruments.
+const (
+	// instrumentsTTL is how long a client's available-instruments list is
+	// reused before it is fetched again.
+	instrumentsTTL = 10 * time.Minute
+	// instrumentsRetryAfter is how long a failed fetch is remembered, so an
+	// unreachable endpoint is not called on every RFQ.
+	instrumentsRetryAfter = time.Minute
+)
+
+// zodiaSupportedProducts is the static list of Zodia Markets supported currency pairs,
+// served by ListProducts when GET /zm/rest/available-instruments cannot be read.
+// It is not used to validate RFQs.
 var zodiaSupportedProducts = []model.Product{
-	{VenueCode: "ZODIA", InstrumentSymbol: "USD:MXN", ProductID: "USD.MXN", ProductName: "USD/MXN", AsOf: time.Time{}},
-	{VenueCode: "ZODIA", InstrumentSymbol: "USD:COP", ProductID: "USD.COP", ProductName: "USD/COP", AsOf: time.Time{}},
-	{VenueCode: "ZODIA", InstrumentSymbol: "USD:BRL", ProductID: "USD.BRL", ProductName: "USD/BRL", AsOf: time.Time{}},
-	{VenueCode: "ZODIA", InstrumentSymbol: "BTC:USD", ProductID: "BTC.USD", ProductName: "BTC/USD", AsOf: time.Time{}},
-	{VenueCode: "ZODIA", InstrumentSymbol: "ETH:USD", ProductID: "ETH.USD", ProductName: "ETH/USD", AsOf: time.Time{}},
-	{VenueCode: "ZODIA", InstrumentSymbol: "BTC:USDC", ProductID: "BTC.USDC", ProductName: "BTC/USDC", AsOf: time.Time{}},
-	{VenueCode: "ZODIA", InstrumentSymbol: "ETH:USDC", ProductID: "ETH.USDC", ProductName: "ETH/USDC", AsOf: time.Time{}},
+	{VenueCode: "ZODIA", InstrumentSymbol: "USD:MXN", ProductID: "USD.MXN", ProductName: "USD/MXN", NotionalCurrency: "USD", AsOf: time.Time{}},
+	{VenueCode: "ZODIA", InstrumentSymbol: "USD:COP", ProductID: "USD.COP", ProductName: "USD/COP", NotionalCurrency: "USD", AsOf: time.Time{}},
+	{VenueCode: "ZODIA", InstrumentSymbol: "USD:BRL", ProductID: "USD.BRL", ProductName: "USD/BRL", NotionalCurrency: "USD", AsOf: time.Time{}},
+	{VenueCode: "ZODIA", InstrumentSymbol: "BTC:USD", ProductID: "BTC.USD", ProductName: "BTC/USD", NotionalCurrency: "BTC", AsOf: time.Time{}},
+	{VenueCode: "ZODIA", InstrumentSymbol: "ETH:USD", ProductID: "ETH.USD", ProductName: "ETH/USD", NotionalCurrency: "ETH", AsOf: time.Time{}},
+	{VenueCode: "ZODIA", InstrumentSymbol: "BTC:USDC", ProductID: "BTC.USDC", ProductName: "BTC/USDC", NotionalCurrency: "BTC", AsOf: time.Time{}},
+	{VenueCode: "ZODIA", InstrumentSymbol: "ETH:USDC", ProductID: "ETH.USDC", ProductName: "ETH/USDC", NotionalCurrency: "ETH", AsOf: time.Time{}},
+}
+
+// instrumentCache holds each client's active instruments from
+// GET /zm/rest/available-instruments.
+type instrumentCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedInstruments // client ID → instruments
+}
+
+type cachedInstruments struct {
+	products  []model.Product
+	err       error
+	fetchedAt time.Time
+}
+
+// liveProducts returns the client's active Zodia instruments, fetched at most
+// once per instrumentsTTL. An error or an empty list means the catalogue is
+// unknown.
+func (s *Service) liveProducts(ctx context.Context, clientID string, cfg *ZodiaClientConfig) ([]model.Product, error) {
+	s.instruments.mu.Lock()
+	e, ok := s.instruments.entries[clientID]
+	s.instruments.mu.Unlock()
+	if ok {
+		ttl := instrumentsTTL
+		if e.err != nil {
+			ttl = instrumentsRetryAfter
+		}
+		if time.Since(e.fetchedAt) < ttl {
+			return e.products, e.err
+		}
+	}
+
+	var products []model.Product
+	resp, err := s.restClient.GetInstruments(ctx, cfg)
+	if err == nil {
+		for _, instr := range resp.Instruments {
+			if instr.Status == "" || instr.Status == "active" {
+				products = append(products, s.mapper.MapInstrumentToProduct(instr))
+			}
+		}
+	}
+	s.instruments.mu.Lock()
+	if s.instruments.entries == nil {
+		s.instruments.entries = make(map[string]cachedInstruments)
+	}
+	s.instruments.entries[clientID] = cachedInstruments{products: products, err: err, fetchedAt: time.Now()}
+	s.instruments.mu.Unlock()
+	return products, err
 }
@@ -0,0 +1,182 @@
+package zodia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Checker-Finance/adapters/pkg/model"
+	"github.com/Checker-Finance/adapters/zodia-adapter/internal/metrics"
+)
+
+// ErrSlippageExceeded is returned by ExecuteRFQ when the live price has moved
+// against the client by more than the configured tolerance since quoting.
+var ErrSlippageExceeded = errors.New("zodia: price moved beyond slippage tolerance")
+
+// subjectPricePrefix is suffixed with the Zodia instrument, e.g.
+// evt.md.price.v1.ZODIA.USD.MXN.
+const subjectPricePrefix = "evt.md.price.v1.ZODIA."
+
+// issuedQuote records the terms of a quote handed out by CreateRFQ so that
+// ExecuteRFQ can re-validate it against the live stream.
+type issuedQuote struct {
+	clientID   string
+	instrument string
+	side       string
+	quantity   float64
+	price      float64
+	issuedAt   time.Time
+}
+
+// adverseSlippage returns the fractional move of current against quoted that
+// is unfavourable to the client: up for BUY, down for SELL. Favourable moves
+// return zero.
+func adverseSlippage(side string, quoted, current float64) float64 {
+	if quoted <= 0 {
+		return 0
+	}
+	move := (current - quoted) / quoted
+	if !strings.EqualFold(side, "BUY") {
+		move = -move
+	}
+	return max(move, 0)
+}
+
+// rememberQuote stores the terms of an issued quote. StartQuoteJanitor drops
+// it if it is never executed.
+func (s *Service) rememberQuote(clientID string, price WSPricePayload) {
+	s.quotes.Store(price.QuoteID, issuedQuote{
+		clientID:   clientID,
+		instrument: price.Instrument,
+		side:       price.Side,
+		quantity:   price.Quantity,
+		price:      effectivePrice(price),
+		issuedAt:   time.Now(),
+	})
+}
+
+// PruneQuotes forgets issued quotes older than maxAge and returns how many
+// it dropped.
+func (s *Service) PruneQuotes(maxAge time.Duration) int {
+	cutoff := time.Now().Add(-maxAge)
+	pruned := 0
+	s.quotes.Range(func(key, value any) bool {
+		if value.(issuedQuote).issuedAt.Before(cutoff) && s.quotes.CompareAndDelete(key, value) {
+			pruned++
+		}
+		return true
+	})
+	return pruned
+}
+
+// StartQuoteJanitor forgets issued quotes older than maxAge every interval
+// until ctx is done.
+func (s *Service) StartQuoteJanitor(ctx context.Context, interval, maxAge time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if n := s.PruneQuotes(maxAge); n > 0 {
+				slog.Info("zodia.quote_janitor.pruned", "count", n)
+			}
+		case <-ctx.Done():
+			slog.Info("zodia.quote_janitor.stopped")
+			return
+		}
+	}
+}
+
+// lookupQuote returns the terms of quoteID if it was issued to clientID, or
+// nil if unknown. The quote is kept until forgetQuote, so a failed execution
+// can be retried against the same terms.
+func (s *Service) lookupQuote(clientID, quoteID string) *issuedQuote {
+	v, ok := s.quotes.Load(quoteID)
+	if !ok {
+		return nil
+	}
+	issued := v.(issuedQuote)
+	if issued.clientID != clientID {
+		return nil
+	}
+	return &issued
+}
+
+// forgetQuote drops an issued quote once it has been executed.
+func (s *Service) forgetQuote(quoteID string) {
+	s.quotes.Delete(quoteID)
+}
+
+// revalidateQuote checks an issued quote against the session's live price and
+// returns the quote ID to execute and the price it is expected to fill at.
+// Unknown quotes, or all quotes when re-validation is disabled, pass through
+// unchanged.
+func (s *Service) revalidateQuote(ctx context.Context, sess *Session, clientID, quoteID string, issued *issuedQuote) (string, float64, error) {
+	if issued == nil {
+		return quoteID, 0, nil
+	}
+	if !s.cfg.RFSRevalidate {
+		return quoteID, issued.price, nil
+	}
+	tolerance := max(s.cfg.RFSSlippageTolerance, 0)
+
+	current, err := sess.Subscribe(ctx, issued.instrument, issued.side, issued.quantity)
+	if err != nil {
+		return "", 0, fmt.Errorf("zodia: refresh price for %s: %w", quoteID, err)
+	}
+	if current.QuoteID == quoteID {
+		metrics.IncRFSRevalidation("unchanged")
+		return quoteID, issued.price, nil
+	}
+
+	livePrice := effectivePrice(*current)
+	slippage := adverseSlippage(issued.side, issued.price, livePrice)
+	if slippage > tolerance {
+		metrics.IncRFSRevalidation("rejected")
+		slog.Warn("zodia.execute_rfq.slippage_exceeded",
+			"client", clientID,
+			"quote_id", quoteID,
+			"quoted_price", issued.price,
+			"live_price", livePrice,
+			"slippage", slippage,
+			"tolerance", tolerance)
+		return "", 0, fmt.Errorf("%w: quoted %v, live %v (%.4f%% > %.4f%%)",
+			ErrSlippageExceeded, issued.price, livePrice, slippage*100, tolerance*100)
+	}
+
+	metrics.IncRFSRevalidation("requoted")
+	slog.Info("zodia.execute_rfq.requoted",
+		"client", clientID,
+		"quote_id", quoteID,
+		"live_quote_id", current.QuoteID,
+		"quoted_price", issued.price,
+		"live_price", livePrice)
+	return current.QuoteID, livePrice, nil
+}
+
+// publishPrice publishes a streamed price update to core NATS. Market data is
+// high-rate and superseded by the next update, so it bypasses JetStream.
+func (s *Service) publishPrice(clientID string, price WSPricePayload) {
+	if s.nc == nil {
+		return
+	}
+	quote := s.mapper.MapWSPriceToQuote(price, model.RFQRequest{ClientID: clientID})
+	quote.Status = "STREAMING"
+	data, err := json.Marshal(quote)
+	if err != nil {
+		slog.Warn("zodia.price_marshal_failed", "error", err)
+		return
+	}
+	subject := subjectPricePrefix + price.Instrument
+	if err := s.nc.Publish(subject, data); err != nil {
+		metrics.IncNATSPublishError(subject)
+		slog.Warn("zodia.price_publish_failed",
+			"subject", subject,
+			"error", err)
+	}
+}
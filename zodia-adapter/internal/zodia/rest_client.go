@@ -11,7 +11,6 @@ import (
 
 	"github.com/Checker-Finance/adapters/internal/httpclient"
 	"github.com/Checker-Finance/adapters/internal/rate"
-	"github.com/Checker-Finance/adapters/zodia-adapter/internal/metrics"
 )
 
 // RESTClient wraps low-level HMAC-signed HTTP communication with the Zodia REST API.
@@ -53,12 +52,9 @@ func NewRESTClient(rateMgr *rate.Manager, signer *HMACSigner) *RESTClient {
 // GetAccounts fetches account balances for a client.
 // POST /api/3/account (HMAC-signed)
 func (c *RESTClient) GetAccounts(ctx context.Context, cfg *ZodiaClientConfig) (*ZodiaAccountResponse, error) {
-	const endpoint, method = "/api/3/account", http.MethodPost
-	start := time.Now()
+	const endpoint = "/api/3/account"
 	var resp ZodiaAccountResponse
 	err := c.postSigned(ctx, cfg, endpoint, ZodiaAccountRequest{Tonce: Tonce()}, &resp)
-	metrics.IncZodiaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.ZodiaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -69,12 +65,9 @@ func (c *RESTClient) GetAccounts(ctx context.Context, cfg *ZodiaClientConfig) (*
 // GET /zm/rest/available-instruments
 // ⚠️ Auth requirements unknown — attempts unauthenticated first; add signing if needed.
 func (c *RESTClient) GetInstruments(ctx context.Context, cfg *ZodiaClientConfig) (*ZodiaInstrumentsResponse, error) {
-	const endpoint, method = "/zm/rest/available-instruments", http.MethodGet
-	start := time.Now()
+	const endpoint = "/zm/rest/available-instruments"
 	var resp ZodiaInstrumentsResponse
 	err := c.getJSON(ctx, cfg, endpoint, &resp)
-	metrics.IncZodiaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.ZodiaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -84,13 +77,10 @@ func (c *RESTClient) GetInstruments(ctx context.Context, cfg *ZodiaClientConfig)
 // ListTransactions fetches transactions matching the given filter.
 // POST /api/3/transaction/list (HMAC-signed)
 func (c *RESTClient) ListTransactions(ctx context.Context, cfg *ZodiaClientConfig, filter ZodiaTransactionFilter) (*ZodiaTransactionListResponse, error) {
-	const endpoint, method = "/api/3/transaction/list", http.MethodPost
-	start := time.Now()
+	const endpoint = "/api/3/transaction/list"
 	filter.Tonce = Tonce()
 	var resp ZodiaTransactionListResponse
 	err := c.postSigned(ctx, cfg, endpoint, filter, &resp)
-	metrics.IncZodiaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.ZodiaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return nil, err
 	}
@@ -100,12 +90,9 @@ func (c *RESTClient) ListTransactions(ctx context.Context, cfg *ZodiaClientConfi
 // GetWSAuthToken obtains a WebSocket auth token via the REST API.
 // POST /ws/auth (HMAC-signed)
 func (c *RESTClient) GetWSAuthToken(ctx context.Context, cfg *ZodiaClientConfig) (string, error) {
-	const endpoint, method = "/ws/auth", http.MethodPost
-	start := time.Now()
+	const endpoint = "/ws/auth"
 	var resp ZodiaWSAuthResponse
 	err := c.postSigned(ctx, cfg, endpoint, ZodiaWSAuthRequest{Tonce: Tonce()}, &resp)
-	metrics.IncZodiaRequest(endpoint, method, statusLabel(err))
-	metrics.ObserveDuration(metrics.ZodiaRequestDuration, start, endpoint, method)
 	if err != nil {
 		return "", err
 	}
@@ -2,20 +2,51 @@ package zodia
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/nats-io/nats.go"
 
+	"github.com/Checker-Finance/adapters/internal/audit"
 	"github.com/Checker-Finance/adapters/internal/legacy"
+	sharedmetrics "github.com/Checker-Finance/adapters/internal/metrics"
 	"github.com/Checker-Finance/adapters/internal/publisher"
 	"github.com/Checker-Finance/adapters/internal/store"
+	"github.com/Checker-Finance/adapters/internal/tracing"
 	"github.com/Checker-Finance/adapters/pkg/model"
 	"github.com/Checker-Finance/adapters/zodia-adapter/internal/metrics"
 	"github.com/Checker-Finance/adapters/zodia-adapter/pkg/config"
 )
 
+// ErrOrderOutcomeUnknown is returned by ExecuteRFQ when an order was cut off by
+// a disconnect and its outcome could not be established from Zodia's
+// transaction list. The order must not be retried until resolved.
+var ErrOrderOutcomeUnknown = errors.New("zodia: order outcome unknown after disconnect")
+
+// SubjectBalanceUpdate is published for every balance fetched from Zodia.
+const SubjectBalanceUpdate = "evt.balance.update.v1"
+
+// In-flight order reconciliation: only transactions created after the send
+// time, less a small allowance for clock skew, are considered, and the lookup
+// is repeated to absorb settlement lag.
+const (
+	reconcileClockSkew = 2 * time.Second
+	reconcileAttempts  = 3
+
+	// reconcileQuantityTolerance and reconcilePriceTolerance are the relative
+	// differences under which a transaction matches the lost order.
+	reconcileQuantityTolerance = 1e-9
+	reconcilePriceTolerance    = 1e-6
+)
+
+// reconcileRetryDelay is the wait between reconciliation lookups.
+var reconcileRetryDelay = time.Second
+
 // Service orchestrates Zodia API operations: quote creation via WebSocket RFS,
 // trade execution, status tracking, and normalized event publishing to NATS.
 type Service struct {
@@ -30,6 +61,10 @@ type Service struct {
 	mapper          *Mapper
 	tradeSyncWriter *legacy.TradeSyncWriter
 	poller          *Poller
+	auditor         *audit.Recorder
+	instruments     instrumentCache
+
+	quotes sync.Map // quote ID → issuedQuote
 }
 
 // NewService constructs a fully wired Zodia adapter service.
@@ -44,7 +79,7 @@ func NewService(
 	st store.Store,
 	tradeSyncWriter *legacy.TradeSyncWriter,
 ) *Service {
-	return &Service{
+	s := &Service{
 		ctx:             ctx,
 		cfg:             cfg,
 		nc:              nc,
@@ -56,6 +91,10 @@ func NewService(
 		mapper:          NewMapper(),
 		tradeSyncWriter: tradeSyncWriter,
 	}
+	if sessionMgr != nil {
+		sessionMgr.SetPriceHandler(s.publishPrice)
+	}
+	return s
 }
 
 // SetPoller sets the poller reference for async trade status tracking.
@@ -63,6 +102,11 @@ func (s *Service) SetPoller(p *Poller) {
 	s.poller = p
 }
 
+// SetAuditRecorder sets the recorder for the quote-to-trade audit trail.
+func (s *Service) SetAuditRecorder(r *audit.Recorder) {
+	s.auditor = r
+}
+
 // resolveConfig resolves the per-client Zodia configuration.
 func (s *Service) resolveConfig(ctx context.Context, clientID string) (*ZodiaClientConfig, error) {
 	cfg, err := s.configResolver.Resolve(ctx, clientID)
@@ -75,7 +119,8 @@ func (s *Service) resolveConfig(ctx context.Context, clientID string) (*ZodiaCli
 	return cfg, nil
 }
 
-// CreateRFQ creates a new executable quote via the Zodia WebSocket RFS flow.
+// CreateRFQ answers a quote request from the client's live Zodia RFS price
+// stream, subscribing on first use.
 func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Quote, error) {
 	slog.Info("zodia.create_rfq.start",
 		"client", req.ClientID,
@@ -89,6 +134,23 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 		return nil, err
 	}
 
+	// Without the live instrument list only the amount is checked; Zodia
+	// rejects pairs it does not stream.
+	products, err := s.liveProducts(ctx, req.ClientID, clientCfg)
+	if err != nil {
+		slog.Warn("zodia.create_rfq.instruments_unavailable",
+			"client", req.ClientID,
+			"error", err)
+	}
+	if err := model.ValidateRFQ(products, req); err != nil {
+		slog.Warn("zodia.create_rfq.rejected",
+			"client", req.ClientID,
+			"pair", req.CurrencyPair,
+			"amount", req.Amount,
+			"error", err)
+		return nil, err
+	}
+
 	sess, err := s.sessionMgr.GetOrCreate(ctx, req.ClientID, clientCfg)
 	if err != nil {
 		slog.Error("zodia.create_rfq.session_failed",
@@ -98,9 +160,19 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 	}
 
 	instrument := ToZodiaPair(req.CurrencyPair)
+	s.auditor.Record(ctx, audit.Event{Kind: audit.KindRFQRequest, ClientID: req.ClientID}, map[string]any{
+		"instrument": instrument,
+		"side":       req.Side,
+		"quantity":   req.Amount,
+	})
 
 	pricePayload, err := sess.RequestPrice(ctx, instrument, req.Side, req.Amount)
 	if err != nil {
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindQuoteResponse,
+			ClientID: req.ClientID,
+			Error:    err.Error(),
+		}, nil)
 		slog.Error("zodia.create_rfq.price_failed",
 			"client", req.ClientID,
 			"instrument", instrument,
@@ -109,6 +181,13 @@ func (s *Service) CreateRFQ(ctx context.Context, req model.RFQRequest) (*model.Q
 	}
 
 	quote := s.mapper.MapWSPriceToQuote(*pricePayload, req)
+	sharedmetrics.RecordQuote("zodia", quote.ID, time.Now())
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindQuoteResponse,
+		ClientID: req.ClientID,
+		QuoteID:  quote.ID,
+	}, pricePayload)
+	s.rememberQuote(req.ClientID, *pricePayload)
 
 	slog.Info("zodia.rfq_created",
 		"client", req.ClientID,
@@ -137,16 +216,95 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 		return nil, fmt.Errorf("zodia: get ws session: %w", err)
 	}
 
-	confirm, err := sess.ExecuteOrder(ctx, quoteID)
+	// Re-validate against the live stream; a moved price is executed under its
+	// current quote ID if the move is within the slippage tolerance. Audit and
+	// status events stay keyed by the issued quote ID the caller holds, and
+	// carry the executed one in their payload.
+	issued := s.lookupQuote(clientID, quoteID)
+	execQuoteID, execPrice, err := s.revalidateQuote(ctx, sess, clientID, quoteID, issued)
 	if err != nil {
+		return nil, err
+	}
+	s.auditor.Record(ctx, audit.Event{
+		Kind:     audit.KindExecuteRequest,
+		ClientID: clientID,
+		QuoteID:  quoteID,
+	}, map[string]string{"quote_id": quoteID, "exec_quote_id": execQuoteID})
+
+	var (
+		trade *model.TradeConfirmation
+		state string
+	)
+	sentAt := time.Now()
+	confirm, err := sess.ExecuteOrder(ctx, execQuoteID)
+	switch {
+	case errors.Is(err, ErrDisconnected):
+		// The order may have reached Zodia before the connection dropped, so
+		// its outcome is read back rather than retried.
+		slog.Warn("zodia.execute_rfq.disconnected",
+			"client", clientID,
+			"quote_id", quoteID,
+			"exec_quote_id", execQuoteID,
+			"error", err)
+		tx, rerr := s.reconcileInFlightOrder(ctx, clientCfg, execQuoteID, issued, execPrice, sentAt)
+		if rerr != nil {
+			s.auditor.Record(ctx, audit.Event{
+				Kind:     audit.KindExecuteResponse,
+				ClientID: clientID,
+				QuoteID:  quoteID,
+				Source:   "reconciler",
+				Error:    rerr.Error(),
+			}, map[string]string{"exec_quote_id": execQuoteID})
+			slog.Error("zodia.execute_rfq.reconcile_failed",
+				"client", clientID,
+				"quote_id", quoteID,
+				"exec_quote_id", execQuoteID,
+				"error", rerr)
+			return nil, rerr
+		}
+		trade = s.mapper.MapTransactionToTrade(tx, clientID)
+		trade.ProviderRFQID = quoteID
+		state = tx.State
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			TradeID:  trade.TradeID,
+			Status:   trade.Status,
+			Source:   "reconciler",
+		}, map[string]any{"exec_quote_id": execQuoteID, "transaction": tx})
+		slog.Info("zodia.execute_rfq.reconciled",
+			"client", clientID,
+			"quote_id", quoteID,
+			"exec_quote_id", execQuoteID,
+			"trade_id", tx.TradeID,
+			"state", tx.State)
+	case err != nil:
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			Error:    err.Error(),
+		}, map[string]string{"exec_quote_id": execQuoteID})
 		slog.Error("zodia.execute_rfq.failed",
 			"client", clientID,
 			"quote_id", quoteID,
+			"exec_quote_id", execQuoteID,
 			"error", err)
 		return nil, fmt.Errorf("zodia ws execute order failed: %w", err)
+	default:
+		trade = s.mapper.MapWSOrderToTrade(*confirm, clientID, quoteID)
+		state = confirm.Status
+		s.auditor.Record(ctx, audit.Event{
+			Kind:     audit.KindExecuteResponse,
+			ClientID: clientID,
+			QuoteID:  quoteID,
+			TradeID:  trade.TradeID,
+			Status:   trade.Status,
+		}, map[string]any{"exec_quote_id": execQuoteID, "confirmation": confirm})
 	}
 
-	trade := s.mapper.MapWSOrderToTrade(*confirm, clientID, quoteID)
+	s.forgetQuote(quoteID)
 
 	slog.Info("zodia.trade_created",
 		"client", clientID,
@@ -156,18 +314,134 @@ func (s *Service) ExecuteRFQ(ctx context.Context, clientID, quoteID string) (*mo
 	)
 
 	// Start async polling if not in terminal state.
-	if !IsTerminalState(confirm.Status) && s.poller != nil {
+	if !IsTerminalState(state) && s.poller != nil {
 		slog.Info("zodia.starting_status_poll",
 			"trade_id", trade.TradeID,
 			"client", clientID)
-		go s.poller.PollTradeStatus(s.ctx, clientID, quoteID, trade.TradeID)
-	} else if IsTerminalState(confirm.Status) {
+		go s.poller.PollTradeStatus(tracing.CarryCorrelationID(s.ctx, ctx), clientID, quoteID, trade.TradeID)
+	} else if IsTerminalState(state) {
+		if trade.Status == model.StatusFilled {
+			sharedmetrics.ObserveQuoteFill("zodia", quoteID, time.Now())
+		}
 		s.syncTerminalTrade(ctx, trade)
 	}
 
 	return trade, nil
 }
 
+// reconcileInFlightOrder looks up the transaction for an order whose
+// WebSocket confirmation was lost to a disconnect. Candidates are RFS trades
+// created since the order was sent that match its instrument, side, quantity
+// and expected price, excluding trades already attributed to another order.
+// The lookup is retried briefly to allow for settlement lag. No match means
+// the order never reached Zodia and ErrDisconnected is returned; an ambiguous
+// or failed lookup returns ErrOrderOutcomeUnknown so the caller does not retry
+// blindly.
+func (s *Service) reconcileInFlightOrder(
+	ctx context.Context,
+	clientCfg *ZodiaClientConfig,
+	quoteID string,
+	issued *issuedQuote,
+	price float64,
+	sentAt time.Time,
+) (*ZodiaTransaction, error) {
+	if issued == nil {
+		return nil, fmt.Errorf("%w: quote %s was not issued by this adapter", ErrOrderOutcomeUnknown, quoteID)
+	}
+
+	since := sentAt.Add(-reconcileClockSkew)
+	filter := ZodiaTransactionFilter{
+		Type:      "RFSTRADE",
+		StartTime: since.UTC().Format(time.RFC3339),
+	}
+	for attempt := 1; ; attempt++ {
+		resp, err := s.restClient.ListTransactions(ctx, clientCfg, filter)
+		if err != nil {
+			return nil, fmt.Errorf("%w: list transactions: %w", ErrOrderOutcomeUnknown, err)
+		}
+
+		var matches []ZodiaTransaction
+		for _, tx := range resp.Result {
+			if matchesInFlightOrder(tx, issued, price, since) {
+				matches = append(matches, tx)
+			}
+		}
+		matches, err = s.excludeKnownTrades(ctx, matches)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrOrderOutcomeUnknown, err)
+		}
+		switch {
+		case len(matches) == 1:
+			return &matches[0], nil
+		case len(matches) > 1:
+			return nil, fmt.Errorf("%w: %d candidate transactions for quote %s", ErrOrderOutcomeUnknown, len(matches), quoteID)
+		case attempt >= reconcileAttempts:
+			return nil, fmt.Errorf("zodia: order for quote %s was not executed: %w", quoteID, ErrDisconnected)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrOrderOutcomeUnknown, ctx.Err())
+		case <-time.After(reconcileRetryDelay):
+		}
+	}
+}
+
+// matchesInFlightOrder reports whether tx could be the lost order for issued,
+// expected to fill at price. Transactions without a creation time or price
+// are not ruled out on those fields.
+func matchesInFlightOrder(tx ZodiaTransaction, issued *issuedQuote, price float64, since time.Time) bool {
+	if tx.Instrument != issued.instrument ||
+		!strings.EqualFold(tx.Side, issued.side) ||
+		!approxEqual(tx.Quantity, issued.quantity, reconcileQuantityTolerance) {
+		return false
+	}
+	if createdAt, err := time.Parse(time.RFC3339, tx.CreatedAt); err == nil && createdAt.Before(since) {
+		return false
+	}
+	if tx.Price > 0 && price > 0 && !approxEqual(tx.Price, price, reconcilePriceTolerance) {
+		return false
+	}
+	return true
+}
+
+// approxEqual reports whether a and b differ by at most tol relative to the
+// larger of the two.
+func approxEqual(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol*math.Max(math.Abs(a), math.Abs(b))
+}
+
+// excludeKnownTrades drops transactions already attributed to an order: those
+// being polled by this adapter or already synced to activity.t_order.
+func (s *Service) excludeKnownTrades(ctx context.Context, txs []ZodiaTransaction) ([]ZodiaTransaction, error) {
+	if len(txs) == 0 {
+		return txs, nil
+	}
+	var synced map[string]legacy.SyncedTrade
+	if s.tradeSyncWriter != nil {
+		ids := make([]string, len(txs))
+		for i, tx := range txs {
+			ids[i] = tx.TradeID
+		}
+		var err error
+		if synced, err = s.tradeSyncWriter.LookupTrades(ctx, ids); err != nil {
+			return nil, fmt.Errorf("look up synced trades: %w", err)
+		}
+	}
+
+	out := txs[:0]
+	for _, tx := range txs {
+		if _, ok := synced[tx.TradeID]; ok {
+			continue
+		}
+		if s.poller != nil && s.poller.IsPolling(tx.TradeID) {
+			continue
+		}
+		out = append(out, tx)
+	}
+	return out, nil
+}
+
 // FetchTransactionStatus retrieves the latest transaction status from Zodia REST API.
 func (s *Service) FetchTransactionStatus(ctx context.Context, clientID, tradeID string) (*ZodiaTransaction, error) {
 	clientCfg, err := s.resolveConfig(ctx, clientID)
@@ -203,8 +477,18 @@ func (s *Service) BuildTradeConfirmationFromTransaction(clientID string, tx *Zod
 
 // syncTerminalTrade syncs a terminal trade to the legacy database and publishes final event.
 func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfirmation) {
+	subject := "evt.trade." + trade.Status + ".v1.ZODIA"
+	msgID := publisher.TradeEventID("ZODIA", trade.TradeID, trade.Status)
+	event := map[string]any{
+		"client_id": trade.ClientID,
+		"trade_id":  trade.TradeID,
+		"status":    trade.Status,
+		"final":     true,
+		"timestamp": time.Now().UTC(),
+	}
+
 	if s.tradeSyncWriter != nil {
-		if err := s.tradeSyncWriter.SyncTradeUpsert(ctx, trade); err != nil {
+		if err := s.tradeSyncWriter.SyncTradeWithEvent(ctx, trade, subject, msgID, event); err != nil {
 			slog.Warn("zodia.trade_sync_failed",
 				"trade_id", trade.TradeID,
 				"client", trade.ClientID,
@@ -214,20 +498,15 @@ func (s *Service) syncTerminalTrade(ctx context.Context, trade *model.TradeConfi
 				"trade_id", trade.TradeID,
 				"client", trade.ClientID,
 				"status", trade.Status)
+			return
 		}
 	}
 
+	// The event could not be queued with the trade; publish it directly.
 	if s.publisher == nil {
 		return
 	}
-	subject := "evt.trade." + trade.Status + ".v1.ZODIA"
-	if err := s.publisher.Publish(ctx, subject, map[string]any{
-		"client_id": trade.ClientID,
-		"trade_id":  trade.TradeID,
-		"status":    trade.Status,
-		"final":     true,
-		"timestamp": time.Now().UTC(),
-	}); err != nil {
+	if err := s.publisher.Publish(ctx, subject, event, publisher.WithMsgID(msgID)); err != nil {
 		metrics.IncNATSPublishError(subject)
 		slog.Warn("zodia.publish_failed",
 			"subject", subject,
@@ -277,8 +556,8 @@ func (s *Service) FetchAndPublishBalances(ctx context.Context, clientID string)
 				"error", err)
 		}
 		if s.publisher != nil {
-			if err := s.publisher.Publish(ctx, "evt.balance.update.v1", bal); err != nil {
-				metrics.IncNATSPublishError("evt.balance.update.v1")
+			if err := s.publisher.Publish(ctx, SubjectBalanceUpdate, bal); err != nil {
+				metrics.IncNATSPublishError(SubjectBalanceUpdate)
 				slog.Warn("zodia.balance_publish_failed",
 					"instrument", bal.Instrument,
 					"error", err)
@@ -299,24 +578,16 @@ func (s *Service) ListProducts(ctx context.Context, clientID string) []model.Pro
 		return zodiaSupportedProducts
 	}
 
-	resp, err := s.restClient.GetInstruments(ctx, clientCfg)
+	products, err := s.liveProducts(ctx, clientID, clientCfg)
 	if err != nil {
 		slog.Warn("zodia.list_products.fetch_failed",
 			"client", clientID,
 			"error", err)
 		return zodiaSupportedProducts
 	}
-
-	if len(resp.Instruments) == 0 {
+	if len(products) == 0 {
 		return zodiaSupportedProducts
 	}
-
-	products := make([]model.Product, 0, len(resp.Instruments))
-	for _, instr := range resp.Instruments {
-		if instr.Status == "" || instr.Status == "active" {
-			products = append(products, s.mapper.MapInstrumentToProduct(instr))
-		}
-	}
 	return products
 }
 
@@ -165,6 +165,44 @@ func TestZodiaService_CreateRFQ_ConfigError(t *testing.T) {
 	assert.Contains(t, err.Error(), "resolve client config")
 }
 
+func TestZodiaService_CreateRFQ_ValidatesAgainstAvailableInstruments(t *testing.T) {
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/zm/rest/available-instruments" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fetches++
+		_ = json.NewEncoder(w).Encode(ZodiaInstrumentsResponse{Instruments: []ZodiaInstrument{
+			{Symbol: "USD.MXN", Base: "USD", Quote: "MXN", Status: "active", MinSize: 50_000},
+			{Symbol: "EUR.USD", Base: "EUR", Quote: "USD", Status: "inactive"},
+		}})
+	}))
+	defer srv.Close()
+
+	cfg := &ZodiaClientConfig{APIKey: "k", APISecret: "s", BaseURL: srv.URL}
+	svc := newZodiaTestService(t, &mockZodiaConfigResolver{cfg: cfg}, NewRESTClient(nil, NewHMACSigner()), nil)
+
+	tests := []struct {
+		pair   string
+		amount float64
+		code   string
+	}{
+		{"EUR:USD", 100_000, model.RFQErrUnsupportedPair},
+		{"GBP:USD", 100_000, model.RFQErrUnsupportedPair},
+		{"USD:MXN", 10_000, model.RFQErrBelowMinSize},
+	}
+	for _, tt := range tests {
+		_, err := svc.CreateRFQ(context.Background(), model.RFQRequest{
+			ClientID: "client-001", CurrencyPair: tt.pair, Side: "BUY", Amount: tt.amount,
+		})
+		verr, ok := model.AsRFQValidationError(err)
+		require.True(t, ok, "%s: expected a validation error, got %v", tt.pair, err)
+		assert.Equal(t, tt.code, verr.Code, tt.pair)
+	}
+	assert.Equal(t, 1, fetches, "the instrument list is cached")
+}
+
 func TestZodiaService_CreateRFQ_SessionError(t *testing.T) {
 	// WS server that immediately rejects auth → GetOrCreate / Connect fails.
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -367,3 +405,145 @@ func TestZodiaService_SyncTerminalTrade_NilPublisher(t *testing.T) {
 	// Should not panic.
 	svc.syncTerminalTrade(context.Background(), trade)
 }
+
+// makeDisconnectServer serves WS auth, drops the connection on execute_order,
+// and answers transaction/list with txs.
+func makeDisconnectServer(t *testing.T, txs []ZodiaTransaction) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws/auth", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ZodiaWSAuthResponse{Token: "tok"})
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := wsUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close() //nolint:errcheck
+		wsAuthThenHandler(func(conn *websocket.Conn) {
+			_, _, _ = conn.ReadMessage() // execute_order, then drop
+		})(conn)
+	})
+	mux.HandleFunc("/api/3/transaction/list", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ZodiaTransactionListResponse{Result: txs})
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestZodiaService_ExecuteRFQ_DisconnectReconciled(t *testing.T) {
+	reconcileRetryDelay = time.Millisecond
+
+	tests := []struct {
+		name      string
+		txs       []ZodiaTransaction
+		remember  bool
+		polling   []string
+		wantTrade string
+		wantErr   error
+	}{
+		{
+			name:     "executed before disconnect",
+			remember: true,
+			txs: []ZodiaTransaction{
+				{TradeID: "other", Instrument: "USD.COP", Side: "BUY", Quantity: 1000, State: "PROCESSED"},
+				{TradeID: "trade-77", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.2, State: "PROCESSED"},
+			},
+			wantTrade: "trade-77",
+		},
+		{
+			name:     "quantity compared with tolerance",
+			remember: true,
+			txs: []ZodiaTransaction{
+				{TradeID: "trade-78", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000.0000000001, Price: 17.2, State: "PROCESSED"},
+			},
+			wantTrade: "trade-78",
+		},
+		{
+			name:     "earlier identical trade ignored",
+			remember: true,
+			txs: []ZodiaTransaction{
+				{TradeID: "earlier", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.2, State: "PROCESSED",
+					CreatedAt: time.Now().Add(-20 * time.Second).UTC().Format(time.RFC3339)},
+			},
+			wantErr: ErrDisconnected,
+		},
+		{
+			name:     "different price ignored",
+			remember: true,
+			txs: []ZodiaTransaction{
+				{TradeID: "other-price", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.3, State: "PROCESSED"},
+			},
+			wantErr: ErrDisconnected,
+		},
+		{
+			name:     "tracked trade ignored",
+			remember: true,
+			polling:  []string{"tracked"},
+			txs: []ZodiaTransaction{
+				{TradeID: "tracked", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.2, State: "PENDING"},
+				{TradeID: "trade-79", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.2, State: "PROCESSED"},
+			},
+			wantTrade: "trade-79",
+		},
+		{
+			name:     "never reached zodia",
+			remember: true,
+			wantErr:  ErrDisconnected,
+		},
+		{
+			name:     "ambiguous",
+			remember: true,
+			txs: []ZodiaTransaction{
+				{TradeID: "a", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000},
+				{TradeID: "b", Instrument: "USD.MXN", Side: "BUY", Quantity: 1000},
+			},
+			wantErr: ErrOrderOutcomeUnknown,
+		},
+		{
+			name:    "unknown quote",
+			wantErr: ErrOrderOutcomeUnknown,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := makeDisconnectServer(t, tc.txs)
+			defer srv.Close()
+
+			cfg := &ZodiaClientConfig{APIKey: "k", APISecret: "s", BaseURL: srv.URL}
+			rc := NewRESTClient(nil, NewHMACSigner())
+			sessionMgr := NewSessionManager(NewWSClient(), NewWSTokenManager(rc), 0)
+			svc := newZodiaTestService(t, &mockZodiaConfigResolver{cfg: cfg}, rc, sessionMgr)
+			if tc.polling != nil {
+				poller := NewPoller(config.Config{}, svc, nil, nil, time.Hour, nil)
+				for _, id := range tc.polling {
+					poller.activeTrades.Store(id, context.CancelFunc(func() {}))
+				}
+				svc.SetPoller(poller)
+			}
+			if tc.remember {
+				svc.rememberQuote("client-dc", WSPricePayload{
+					QuoteID:    "q-dc",
+					Instrument: "USD.MXN",
+					Side:       "BUY",
+					Quantity:   1000,
+					Price:      17.2,
+				})
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			trade, err := svc.ExecuteRFQ(ctx, "client-dc", "q-dc")
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, tc.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantTrade, trade.TradeID)
+			assert.Equal(t, "q-dc", trade.ProviderRFQID)
+		})
+	}
+}
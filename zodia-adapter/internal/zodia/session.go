@@ -3,8 +3,11 @@ package zodia
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -14,12 +17,28 @@ import (
 	"github.com/Checker-Finance/adapters/zodia-adapter/internal/metrics"
 )
 
+// ErrDisconnected is returned to in-flight requests when the session's
+// WebSocket drops or the session is closed before a response arrives.
+var ErrDisconnected = errors.New("zodia: websocket disconnected")
+
+// Session states reported by Session.State.
+const (
+	SessionStateConnected    = "connected"
+	SessionStateReconnecting = "reconnecting"
+	SessionStateDead         = "dead"
+	SessionStateClosed       = "closed"
+)
+
 // SessionConfig holds parameters for a per-client WebSocket session.
 type SessionConfig struct {
 	ClientID   string
 	ZodiaCfg   *ZodiaClientConfig
 	MaxRetries int
 	RetryDelay time.Duration
+
+	// PriceMaxAge bounds how long a streamed price without an expiry is
+	// treated as fresh. Zero uses defaultPriceMaxAge.
+	PriceMaxAge time.Duration
 }
 
 // priceResult wraps a WS price update or an error for channel dispatch.
@@ -35,22 +54,33 @@ type orderResult struct {
 }
 
 // Session manages a per-client WebSocket connection to Zodia, including
-// authentication, request dispatch via pending channels, and reconnection.
+// authentication, long-lived RFS price subscriptions, request dispatch via
+// pending channels, and reconnection.
 //
-// ⚠️ WS action strings ("auth", "auth_success", "subscribe_price", "price_update",
-// "execute_order", "order_confirmation") are inferred. Verify against Zodia sandbox.
+// ⚠️ WS action strings ("auth", "auth_success", "subscribe_price", "unsubscribe_price",
+// "price_update", "execute_order", "order_confirmation") are inferred. Verify against
+// Zodia sandbox.
 type Session struct {
 	cfg      SessionConfig
 	wsClient *WSClient
 	tokenMgr *WSTokenManager
 
-	mu        sync.Mutex
-	conn      WSConn
-	connected atomic.Bool
-	sendMu    sync.Mutex // serialises WS writes
+	mu         sync.Mutex
+	conn       WSConn
+	connected  atomic.Bool
+	closed     atomic.Bool // set by Close; stops reconnection
+	dead       atomic.Bool // set once reconnection gives up after MaxRetries
+	reconnects atomic.Int64
+	sendMu     sync.Mutex // serialises WS writes
 
-	pendingPrices sync.Map // clientRef → chan priceResult
 	pendingOrders sync.Map // clientRef → chan orderResult
+
+	subsMu  sync.Mutex
+	subs    map[string]*priceSubscription // subscription key → subscription
+	subRefs map[string]*priceSubscription // clientRef → subscription
+
+	// onPrice, when set, receives every streamed price_update.
+	onPrice func(clientID string, price WSPricePayload)
 }
 
 // NewSession constructs a new Session (not yet connected).
@@ -105,7 +135,7 @@ func (s *Session) connect(ctx context.Context) error {
 	if authResult.Action != "auth_success" {
 		_ = conn.Close()
 		// Invalidate cached token on auth failure
-		s.tokenMgr.InvalidateToken(s.cfg.ZodiaCfg.APIKey)
+		s.tokenMgr.InvalidateToken(ctx, s.cfg.ZodiaCfg.APIKey, token)
 		return fmt.Errorf("zodia.session.auth_failed: %s", authResult.Message)
 	}
 
@@ -115,6 +145,9 @@ func (s *Session) connect(ctx context.Context) error {
 		"client_id", s.cfg.ClientID,
 		"url", wsURL)
 
+	// Restore price subscriptions carried over from a previous connection.
+	s.resubscribe()
+
 	// Start read loop in background
 	go s.readLoop(ctx)
 
@@ -139,6 +172,7 @@ func (s *Session) readLoop(ctx context.Context) {
 				"client_id", s.cfg.ClientID,
 				"error", err)
 			s.connected.Store(false)
+			s.failPending(ErrDisconnected)
 			go s.reconnectWithBackoff(ctx)
 			return
 		}
@@ -162,9 +196,7 @@ func (s *Session) dispatch(raw []byte) {
 			slog.Warn("zodia.session.price_parse_failed", "error", err)
 			return
 		}
-		if ch, ok := s.pendingPrices.Load(payload.ClientRef); ok {
-			ch.(chan priceResult) <- priceResult{payload: payload}
-		}
+		s.handlePriceUpdate(payload)
 
 	case "order_confirmation":
 		var payload WSOrderConfirmPayload
@@ -172,9 +204,7 @@ func (s *Session) dispatch(raw []byte) {
 			slog.Warn("zodia.session.order_parse_failed", "error", err)
 			return
 		}
-		if ch, ok := s.pendingOrders.Load(payload.ClientRef); ok {
-			ch.(chan orderResult) <- orderResult{payload: payload}
-		}
+		s.resolveOrder(payload.ClientRef, orderResult{payload: payload})
 
 	case "error":
 		var payload WSErrorPayload
@@ -189,12 +219,10 @@ func (s *Session) dispatch(raw []byte) {
 
 		errMsg := fmt.Errorf("zodia ws error: %s", payload.Message)
 		if payload.ClientRef != "" {
-			if ch, ok := s.pendingPrices.Load(payload.ClientRef); ok {
-				ch.(chan priceResult) <- priceResult{err: errMsg}
-			}
-			if ch, ok := s.pendingOrders.Load(payload.ClientRef); ok {
-				ch.(chan orderResult) <- orderResult{err: errMsg}
+			if s.handleSubscriptionError(payload.ClientRef, errMsg) {
+				return
 			}
+			s.resolveOrder(payload.ClientRef, orderResult{err: errMsg})
 		}
 
 	default:
@@ -210,6 +238,9 @@ func (s *Session) reconnectWithBackoff(ctx context.Context) {
 	maxDelay := 60 * time.Second
 
 	for i := 0; i < s.cfg.MaxRetries; i++ {
+		if s.closed.Load() {
+			return
+		}
 		select {
 		case <-ctx.Done():
 			slog.Info("zodia.session.reconnect_cancelled",
@@ -224,6 +255,10 @@ func (s *Session) reconnectWithBackoff(ctx context.Context) {
 			"delay", delay)
 
 		s.mu.Lock()
+		if s.closed.Load() {
+			s.mu.Unlock()
+			return
+		}
 		err := s.connect(ctx)
 		s.mu.Unlock()
 
@@ -236,6 +271,7 @@ func (s *Session) reconnectWithBackoff(ctx context.Context) {
 			continue
 		}
 
+		s.reconnects.Add(1)
 		metrics.IncWSReconnect(s.cfg.ClientID)
 		slog.Info("zodia.session.reconnected",
 			"client_id", s.cfg.ClientID,
@@ -243,45 +279,44 @@ func (s *Session) reconnectWithBackoff(ctx context.Context) {
 		return
 	}
 
+	if s.closed.Load() {
+		return
+	}
+	s.dead.Store(true)
+	metrics.IncWSSessionDead(s.cfg.ClientID)
 	slog.Error("zodia.session.max_retries_exceeded",
 		"client_id", s.cfg.ClientID,
 		"max_retries", s.cfg.MaxRetries)
 }
 
-// RequestPrice sends a subscribe_price message and waits for the price_update response.
-func (s *Session) RequestPrice(ctx context.Context, instrument, side string, quantity float64) (*WSPricePayload, error) {
-	if !s.connected.Load() {
-		return nil, fmt.Errorf("zodia.session: not connected (client: %s)", s.cfg.ClientID)
-	}
-
-	clientRef := uuid.New().String()
-	ch := make(chan priceResult, 1)
-	s.pendingPrices.Store(clientRef, ch)
-	defer s.pendingPrices.Delete(clientRef)
-
-	msg := WSSubscribePriceMessage{
-		Action:     "subscribe_price",
-		ClientRef:  clientRef,
-		Instrument: instrument,
-		Side:       side,
-		Quantity:   quantity,
+// resolveOrder delivers result to the in-flight order for clientRef, if any.
+// The send never blocks: a disconnect may already have failed the order.
+func (s *Session) resolveOrder(clientRef string, result orderResult) {
+	if ch, ok := s.pendingOrders.LoadAndDelete(clientRef); ok {
+		select {
+		case ch.(chan orderResult) <- result:
+		default:
+		}
 	}
+}
 
-	s.sendMu.Lock()
-	err := s.wsClient.SendJSON(s.conn, msg)
-	s.sendMu.Unlock()
-	if err != nil {
-		return nil, fmt.Errorf("zodia.session.request_price.send: %w", err)
+// failPending fails every in-flight order and every caller waiting on a price
+// subscription with err. Subscriptions themselves are kept for resubscribe.
+func (s *Session) failPending(err error) {
+	s.pendingOrders.Range(func(key, _ any) bool {
+		s.resolveOrder(key.(string), orderResult{err: err})
+		return true
+	})
+
+	s.subsMu.Lock()
+	var waiters []chan priceResult
+	for _, sub := range s.subs {
+		waiters = append(waiters, sub.waiters...)
+		sub.waiters = nil
 	}
-
-	select {
-	case result := <-ch:
-		if result.err != nil {
-			return nil, result.err
-		}
-		return &result.payload, nil
-	case <-ctx.Done():
-		return nil, fmt.Errorf("zodia.session.request_price: %w", ctx.Err())
+	s.subsMu.Unlock()
+	for _, ch := range waiters {
+		ch <- priceResult{err: err}
 	}
 }
 
@@ -306,7 +341,7 @@ func (s *Session) ExecuteOrder(ctx context.Context, quoteID string) (*WSOrderCon
 	err := s.wsClient.SendJSON(s.conn, msg)
 	s.sendMu.Unlock()
 	if err != nil {
-		return nil, fmt.Errorf("zodia.session.execute_order.send: %w", err)
+		return nil, fmt.Errorf("zodia.session.execute_order.send: %w: %w", ErrDisconnected, err)
 	}
 
 	select {
@@ -320,9 +355,12 @@ func (s *Session) ExecuteOrder(ctx context.Context, quoteID string) (*WSOrderCon
 	}
 }
 
-// Close closes the underlying WebSocket connection gracefully.
+// Close closes the underlying WebSocket connection gracefully, failing any
+// in-flight requests with ErrDisconnected and stopping reconnection.
 func (s *Session) Close() {
+	s.closed.Store(true)
 	s.connected.Store(false)
+	s.failPending(ErrDisconnected)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.conn != nil {
@@ -336,6 +374,25 @@ func (s *Session) IsConnected() bool {
 	return s.connected.Load()
 }
 
+// State returns the session's lifecycle state, one of the SessionState constants.
+func (s *Session) State() string {
+	switch {
+	case s.closed.Load():
+		return SessionStateClosed
+	case s.connected.Load():
+		return SessionStateConnected
+	case s.dead.Load():
+		return SessionStateDead
+	default:
+		return SessionStateReconnecting
+	}
+}
+
+// Reconnects returns the number of successful reconnects of this session.
+func (s *Session) Reconnects() int64 {
+	return s.reconnects.Load()
+}
+
 //
 // ────────────────────────────────────────────────
 //   SessionManager — one session per client
@@ -349,6 +406,9 @@ type SessionManager struct {
 	wsClient   *WSClient
 	tokenMgr   *WSTokenManager
 	maxRetries int
+
+	priceMaxAge time.Duration
+	onPrice     func(clientID string, price WSPricePayload)
 }
 
 // NewSessionManager constructs a new SessionManager.
@@ -361,21 +421,52 @@ func NewSessionManager(wsClient *WSClient, tokenMgr *WSTokenManager, maxRetries
 	}
 }
 
+// SetPriceHandler registers fn to receive every streamed price update from
+// sessions created after the call.
+func (m *SessionManager) SetPriceHandler(fn func(clientID string, price WSPricePayload)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onPrice = fn
+}
+
+// SetPriceMaxAge sets how long a streamed price without an expiry stays fresh.
+func (m *SessionManager) SetPriceMaxAge(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.priceMaxAge = d
+}
+
 // GetOrCreate returns the existing session for clientID or creates and connects a new one.
 func (m *SessionManager) GetOrCreate(ctx context.Context, clientID string, cfg *ZodiaClientConfig) (*Session, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if sess, ok := m.sessions[clientID]; ok && sess.IsConnected() {
-		return sess, nil
+	if sess, ok := m.sessions[clientID]; ok {
+		switch sess.State() {
+		case SessionStateConnected:
+			return sess, nil
+		case SessionStateDead:
+			slog.Warn("zodia.session_manager.evict_dead",
+				"client_id", clientID)
+		default:
+			slog.Info("zodia.session_manager.replace_disconnected",
+				"client_id", clientID,
+				"state", sess.State())
+		}
+		// Closing stops any reconnect loop so the replacement is the only
+		// connection for this client.
+		sess.Close()
+		delete(m.sessions, clientID)
 	}
 
 	sess := NewSession(SessionConfig{
-		ClientID:   clientID,
-		ZodiaCfg:   cfg,
-		MaxRetries: m.maxRetries,
-		RetryDelay: time.Second,
+		ClientID:    clientID,
+		ZodiaCfg:    cfg,
+		MaxRetries:  m.maxRetries,
+		RetryDelay:  time.Second,
+		PriceMaxAge: m.priceMaxAge,
 	}, m.wsClient, m.tokenMgr)
+	sess.onPrice = m.onPrice
 
 	if err := sess.Connect(ctx); err != nil {
 		return nil, fmt.Errorf("zodia.session_manager.connect: %w", err)
@@ -385,6 +476,34 @@ func (m *SessionManager) GetOrCreate(ctx context.Context, clientID string, cfg *
 	return sess, nil
 }
 
+// SessionStatus describes one client's WebSocket session for health reporting.
+type SessionStatus struct {
+	ClientID      string `json:"clientId"`
+	State         string `json:"state"`
+	Subscriptions int    `json:"subscriptions"`
+	Reconnects    int64  `json:"reconnects"`
+}
+
+// SessionStatuses returns the state of every managed session, sorted by client ID.
+func (m *SessionManager) SessionStatuses() []SessionStatus {
+	m.mu.Lock()
+	out := make([]SessionStatus, 0, len(m.sessions))
+	for clientID, sess := range m.sessions {
+		out = append(out, SessionStatus{
+			ClientID:      clientID,
+			State:         sess.State(),
+			Subscriptions: sess.SubscriptionCount(),
+			Reconnects:    sess.Reconnects(),
+		})
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(out, func(a, b SessionStatus) int {
+		return strings.Compare(a.ClientID, b.ClientID)
+	})
+	return out
+}
+
 // Close closes the session for the given clientID.
 func (m *SessionManager) Close(clientID string) {
 	m.mu.Lock()
@@ -404,3 +523,41 @@ func (m *SessionManager) CloseAll() {
 		delete(m.sessions, clientID)
 	}
 }
+
+// PruneSubscriptions cancels price subscriptions idle for longer than idle
+// across all sessions and returns how many were removed.
+func (m *SessionManager) PruneSubscriptions(idle time.Duration) int {
+	m.mu.Lock()
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, sess := range m.sessions {
+		sessions = append(sessions, sess)
+	}
+	m.mu.Unlock()
+
+	pruned := 0
+	for _, sess := range sessions {
+		pruned += sess.PruneSubscriptions(idle)
+	}
+	return pruned
+}
+
+// StartSubscriptionJanitor prunes idle price subscriptions every interval
+// until ctx is cancelled.
+func (m *SessionManager) StartSubscriptionJanitor(ctx context.Context, interval, idle time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	slog.Info("zodia.subscription_janitor.started", "interval", interval, "idle", idle)
+
+	for {
+		select {
+		case <-ticker.C:
+			if n := m.PruneSubscriptions(idle); n > 0 {
+				slog.Info("zodia.subscription_janitor.pruned", "count", n)
+			}
+		case <-ctx.Done():
+			slog.Info("zodia.subscription_janitor.stopped")
+			return
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -213,7 +214,9 @@ func TestSession_Close_Idempotent(t *testing.T) {
 func TestSession_dispatch_PriceUpdate(t *testing.T) {
 	sess := &Session{cfg: SessionConfig{ClientID: "c"}}
 	ch := make(chan priceResult, 1)
-	sess.pendingPrices.Store("ref-abc", ch)
+	sub := &priceSubscription{key: "k", clientRef: "ref-abc", waiters: []chan priceResult{ch}}
+	sess.subs = map[string]*priceSubscription{"k": sub}
+	sess.subRefs = map[string]*priceSubscription{"ref-abc": sub}
 
 	raw, _ := json.Marshal(WSPricePayload{
 		Action:    "price_update",
@@ -257,7 +260,9 @@ func TestSession_dispatch_OrderConfirmation(t *testing.T) {
 func TestSession_dispatch_ErrorWithClientRef(t *testing.T) {
 	sess := &Session{cfg: SessionConfig{ClientID: "c"}}
 	ch := make(chan priceResult, 1)
-	sess.pendingPrices.Store("ref-err", ch)
+	sub := &priceSubscription{key: "k", clientRef: "ref-err", waiters: []chan priceResult{ch}}
+	sess.subs = map[string]*priceSubscription{"k": sub}
+	sess.subRefs = map[string]*priceSubscription{"ref-err": sub}
 
 	raw, _ := json.Marshal(WSErrorPayload{
 		Action:    "error",
@@ -354,3 +359,59 @@ func TestSessionManager_CloseAll(t *testing.T) {
 	mgr.mu.Unlock()
 	assert.Equal(t, 0, count, "all sessions should be removed after CloseAll")
 }
+
+// ─── Disconnect handling ──────────────────────────────────────────────────────
+
+func TestSession_Close_FailsInFlightOrders(t *testing.T) {
+	sess := &Session{cfg: SessionConfig{ClientID: "c"}}
+	ch := make(chan orderResult, 1)
+	sess.pendingOrders.Store("ref-inflight", ch)
+
+	sess.Close()
+
+	select {
+	case result := <-ch:
+		assert.ErrorIs(t, result.err, ErrDisconnected)
+	case <-time.After(time.Second):
+		t.Fatal("in-flight order was not failed on Close")
+	}
+	assert.Equal(t, SessionStateClosed, sess.State())
+}
+
+func TestSessionManager_EvictsDeadSession(t *testing.T) {
+	var conns atomic.Int32
+	cs := makeCombinedServer(t, "tok", func(conn *websocket.Conn) {
+		n := conns.Add(1)
+		_, _, _ = conn.ReadMessage()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"action":"auth_success"}`))
+		if n == 1 {
+			return // drop the first connection right after auth
+		}
+		time.Sleep(500 * time.Millisecond)
+	})
+	defer cs.srv.Close()
+
+	mgr := NewSessionManager(NewWSClient(), NewWSTokenManager(cs.rest), 0)
+	cfg := &ZodiaClientConfig{APIKey: "k", APISecret: "s", BaseURL: cs.srv.URL}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	first, err := mgr.GetOrCreate(ctx, "client-D", cfg)
+	require.NoError(t, err)
+
+	// With MaxRetries 0 the dropped session gives up immediately.
+	require.Eventually(t, func() bool {
+		return first.State() == SessionStateDead
+	}, 2*time.Second, 10*time.Millisecond)
+
+	statuses := mgr.SessionStatuses()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, SessionStateDead, statuses[0].State)
+
+	second, err := mgr.GetOrCreate(ctx, "client-D", cfg)
+	require.NoError(t, err)
+	assert.NotSame(t, first, second, "dead session should be replaced")
+	assert.Equal(t, SessionStateConnected, second.State())
+	assert.Equal(t, SessionStateClosed, first.State())
+}
@@ -0,0 +1,276 @@
+package zodia
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// defaultPriceMaxAge bounds how long a streamed price without an explicit
+// expiry is treated as executable.
+const defaultPriceMaxAge = 5 * time.Second
+
+// priceSubscription is a long-lived RFS subscription for one instrument, side
+// and quantity. Zodia keeps streaming price_update messages for it until it is
+// cancelled with unsubscribe_price.
+type priceSubscription struct {
+	key        string
+	clientRef  string
+	instrument string
+	side       string
+	quantity   float64
+
+	latest     *WSPricePayload
+	receivedAt time.Time
+	lastUsed   time.Time
+	waiters    []chan priceResult // callers waiting for the next price_update
+}
+
+// subscriptionKey identifies a subscription within a session.
+func subscriptionKey(instrument, side string, quantity float64) string {
+	return instrument + "|" + strings.ToUpper(side) + "|" + strconv.FormatFloat(quantity, 'f', -1, 64)
+}
+
+// fresh reports whether the cached price can still be quoted. Prices carrying
+// an expiry are fresh until it passes; others for maxAge after receipt.
+func (sub *priceSubscription) fresh(now time.Time, maxAge time.Duration) bool {
+	if sub.latest == nil {
+		return false
+	}
+	if sub.latest.ExpiresAt > 0 {
+		return now.Before(time.Unix(sub.latest.ExpiresAt, 0))
+	}
+	return now.Sub(sub.receivedAt) < maxAge
+}
+
+// priceMaxAge returns the configured max age, falling back to the default.
+func (s *Session) priceMaxAge() time.Duration {
+	if s.cfg.PriceMaxAge > 0 {
+		return s.cfg.PriceMaxAge
+	}
+	return defaultPriceMaxAge
+}
+
+// Subscribe returns the latest streamed price for instrument/side/quantity.
+// The first call opens a long-lived subscription and waits for its first
+// price_update; later calls are answered from the cache while the price is
+// fresh and otherwise wait for the next update.
+func (s *Session) Subscribe(ctx context.Context, instrument, side string, quantity float64) (*WSPricePayload, error) {
+	if !s.connected.Load() {
+		return nil, fmt.Errorf("zodia.session: not connected (client: %s)", s.cfg.ClientID)
+	}
+
+	now := time.Now()
+	key := subscriptionKey(instrument, side, quantity)
+	ch := make(chan priceResult, 1)
+
+	s.subsMu.Lock()
+	sub, ok := s.subs[key]
+	if ok {
+		sub.lastUsed = now
+		if sub.fresh(now, s.priceMaxAge()) {
+			price := *sub.latest
+			s.subsMu.Unlock()
+			return &price, nil
+		}
+		sub.waiters = append(sub.waiters, ch)
+		s.subsMu.Unlock()
+	} else {
+		sub = &priceSubscription{
+			key:        key,
+			clientRef:  uuid.New().String(),
+			instrument: instrument,
+			side:       side,
+			quantity:   quantity,
+			lastUsed:   now,
+			waiters:    []chan priceResult{ch},
+		}
+		if s.subs == nil {
+			s.subs = make(map[string]*priceSubscription)
+			s.subRefs = make(map[string]*priceSubscription)
+		}
+		s.subs[key] = sub
+		s.subRefs[sub.clientRef] = sub
+		s.subsMu.Unlock()
+
+		if err := s.sendSubscribe(sub); err != nil {
+			s.removeSubscription(sub)
+			return nil, fmt.Errorf("zodia.session.subscribe.send: %w", err)
+		}
+		slog.Info("zodia.session.subscribed",
+			"client_id", s.cfg.ClientID,
+			"instrument", instrument,
+			"side", side,
+			"quantity", quantity)
+	}
+
+	select {
+	case result := <-ch:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return &result.payload, nil
+	case <-ctx.Done():
+		s.dropWaiter(sub, ch)
+		return nil, fmt.Errorf("zodia.session.subscribe: %w", ctx.Err())
+	}
+}
+
+// dropWaiter removes an abandoned caller's channel from sub, so it neither
+// leaks nor keeps the subscription from being pruned.
+func (s *Session) dropWaiter(sub *priceSubscription, ch chan priceResult) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	sub.waiters = slices.DeleteFunc(sub.waiters, func(w chan priceResult) bool { return w == ch })
+}
+
+// RequestPrice returns an executable price for the instrument, served from the
+// session's live RFS subscription.
+func (s *Session) RequestPrice(ctx context.Context, instrument, side string, quantity float64) (*WSPricePayload, error) {
+	return s.Subscribe(ctx, instrument, side, quantity)
+}
+
+// SubscriptionCount returns the number of live price subscriptions.
+func (s *Session) SubscriptionCount() int {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	return len(s.subs)
+}
+
+// PruneSubscriptions cancels subscriptions that have not been used for idle
+// and returns how many were removed. A subscription with callers still
+// waiting on it is kept; callers that give up are removed from it.
+func (s *Session) PruneSubscriptions(idle time.Duration) int {
+	cutoff := time.Now().Add(-idle)
+
+	s.subsMu.Lock()
+	var stale []*priceSubscription
+	for key, sub := range s.subs {
+		if sub.lastUsed.Before(cutoff) && len(sub.waiters) == 0 {
+			stale = append(stale, sub)
+			delete(s.subs, key)
+			delete(s.subRefs, sub.clientRef)
+		}
+	}
+	s.subsMu.Unlock()
+
+	for _, sub := range stale {
+		slog.Info("zodia.session.unsubscribed",
+			"client_id", s.cfg.ClientID,
+			"instrument", sub.instrument,
+			"side", sub.side,
+			"quantity", sub.quantity)
+		if !s.connected.Load() {
+			continue
+		}
+		s.sendMu.Lock()
+		err := s.wsClient.SendJSON(s.conn, WSUnsubscribePriceMessage{
+			Action:    "unsubscribe_price",
+			ClientRef: sub.clientRef,
+		})
+		s.sendMu.Unlock()
+		if err != nil {
+			slog.Warn("zodia.session.unsubscribe_failed",
+				"client_id", s.cfg.ClientID,
+				"instrument", sub.instrument,
+				"error", err)
+		}
+	}
+	return len(stale)
+}
+
+// sendSubscribe writes the subscribe_price message for sub.
+func (s *Session) sendSubscribe(sub *priceSubscription) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	return s.wsClient.SendJSON(s.conn, WSSubscribePriceMessage{
+		Action:     "subscribe_price",
+		ClientRef:  sub.clientRef,
+		Instrument: sub.instrument,
+		Side:       sub.side,
+		Quantity:   sub.quantity,
+	})
+}
+
+// resubscribe re-sends every live subscription after a reconnect. Cached
+// prices from the previous connection are discarded.
+func (s *Session) resubscribe() {
+	s.subsMu.Lock()
+	subs := make([]*priceSubscription, 0, len(s.subs))
+	for _, sub := range s.subs {
+		sub.latest = nil
+		subs = append(subs, sub)
+	}
+	s.subsMu.Unlock()
+
+	for _, sub := range subs {
+		if err := s.sendSubscribe(sub); err != nil {
+			slog.Warn("zodia.session.resubscribe_failed",
+				"client_id", s.cfg.ClientID,
+				"instrument", sub.instrument,
+				"error", err)
+		}
+	}
+}
+
+// removeSubscription drops sub from the session without notifying Zodia.
+func (s *Session) removeSubscription(sub *priceSubscription) {
+	s.subsMu.Lock()
+	defer s.subsMu.Unlock()
+	delete(s.subs, sub.key)
+	delete(s.subRefs, sub.clientRef)
+}
+
+// handlePriceUpdate caches a streamed price, wakes waiting callers and hands
+// the update to the session's price handler.
+func (s *Session) handlePriceUpdate(payload WSPricePayload) {
+	s.subsMu.Lock()
+	sub, ok := s.subRefs[payload.ClientRef]
+	if !ok {
+		s.subsMu.Unlock()
+		slog.Debug("zodia.session.price_unmatched",
+			"client_id", s.cfg.ClientID,
+			"client_ref", payload.ClientRef)
+		return
+	}
+	price := payload
+	sub.latest = &price
+	sub.receivedAt = time.Now()
+	waiters := sub.waiters
+	sub.waiters = nil
+	s.subsMu.Unlock()
+
+	for _, ch := range waiters {
+		ch <- priceResult{payload: payload}
+	}
+	if s.onPrice != nil {
+		s.onPrice(s.cfg.ClientID, payload)
+	}
+}
+
+// handleSubscriptionError fails waiting callers of the subscription matching
+// clientRef and drops it. Returns false if no subscription matched.
+func (s *Session) handleSubscriptionError(clientRef string, err error) bool {
+	s.subsMu.Lock()
+	sub, ok := s.subRefs[clientRef]
+	if !ok {
+		s.subsMu.Unlock()
+		return false
+	}
+	delete(s.subs, sub.key)
+	delete(s.subRefs, clientRef)
+	waiters := sub.waiters
+	sub.waiters = nil
+	s.subsMu.Unlock()
+
+	for _, ch := range waiters {
+		ch <- priceResult{err: err}
+	}
+	return true
+}
@@ -0,0 +1,289 @@
+package zodia
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Checker-Finance/adapters/zodia-adapter/pkg/config"
+)
+
+// liveSession returns a connected-looking Session holding one subscription
+// whose latest price is price. No WebSocket is attached.
+func liveSession(price WSPricePayload) (*Session, *priceSubscription) {
+	sess := &Session{cfg: SessionConfig{ClientID: "c"}}
+	sess.connected.Store(true)
+	key := subscriptionKey(price.Instrument, price.Side, price.Quantity)
+	sub := &priceSubscription{
+		key:        key,
+		clientRef:  "ref-live",
+		instrument: price.Instrument,
+		side:       price.Side,
+		quantity:   price.Quantity,
+		latest:     &price,
+		receivedAt: time.Now(),
+		lastUsed:   time.Now(),
+	}
+	sess.subs = map[string]*priceSubscription{key: sub}
+	sess.subRefs = map[string]*priceSubscription{sub.clientRef: sub}
+	return sess, sub
+}
+
+// ─── Session.Subscribe ────────────────────────────────────────────────────────
+
+func TestSession_Subscribe_ReusesStreamAndUnsubscribesWhenIdle(t *testing.T) {
+	var subscribes atomic.Int32
+	unsubscribed := make(chan string, 1)
+
+	cs := makeCombinedServer(t, "tok", wsAuthThenHandler(func(conn *websocket.Conn) {
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			var raw map[string]any
+			_ = json.Unmarshal(msg, &raw)
+			switch raw["action"] {
+			case "subscribe_price":
+				subscribes.Add(1)
+				var sub WSSubscribePriceMessage
+				_ = json.Unmarshal(msg, &sub)
+				for i, quoteID := range []string{"q-1", "q-2"} {
+					data, _ := json.Marshal(WSPricePayload{
+						Action:     "price_update",
+						ClientRef:  sub.ClientRef,
+						Instrument: sub.Instrument,
+						Side:       sub.Side,
+						Quantity:   sub.Quantity,
+						Ask:        17.20 + float64(i)*0.01,
+						QuoteID:    quoteID,
+						ExpiresAt:  time.Now().Add(15 * time.Second).Unix(),
+					})
+					_ = conn.WriteMessage(websocket.TextMessage, data)
+				}
+			case "unsubscribe_price":
+				unsubscribed <- raw["clientRef"].(string)
+			}
+		}
+	}))
+	defer cs.srv.Close()
+
+	published := make(chan WSPricePayload, 4)
+	mgr := NewSessionManager(NewWSClient(), NewWSTokenManager(cs.rest), 0)
+	mgr.SetPriceHandler(func(_ string, p WSPricePayload) { published <- p })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cfg := &ZodiaClientConfig{APIKey: "k", APISecret: "s", BaseURL: cs.srv.URL}
+	sess, err := mgr.GetOrCreate(ctx, "c1", cfg)
+	require.NoError(t, err)
+	defer sess.Close()
+
+	first, err := sess.Subscribe(ctx, "USD.MXN", "BUY", 100_000)
+	require.NoError(t, err)
+	assert.Equal(t, "q-1", first.QuoteID)
+
+	for range 2 {
+		select {
+		case <-published:
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for published prices")
+		}
+	}
+
+	second, err := sess.Subscribe(ctx, "USD.MXN", "BUY", 100_000)
+	require.NoError(t, err)
+	assert.Equal(t, "q-2", second.QuoteID, "should be answered from the live stream")
+	assert.Equal(t, int32(1), subscribes.Load(), "subscription should be reused")
+	assert.Equal(t, 1, sess.SubscriptionCount())
+
+	assert.Equal(t, 1, mgr.PruneSubscriptions(0))
+	assert.Equal(t, 0, sess.SubscriptionCount())
+	select {
+	case ref := <-unsubscribed:
+		assert.NotEmpty(t, ref)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for unsubscribe_price")
+	}
+}
+
+func TestSession_Subscribe_StalePriceWaitsForUpdate(t *testing.T) {
+	sess, sub := liveSession(WSPricePayload{
+		Instrument: "USD.MXN",
+		Side:       "BUY",
+		Quantity:   1000,
+		Ask:        17.20,
+		QuoteID:    "expired",
+		ExpiresAt:  time.Now().Add(-time.Second).Unix(),
+	})
+
+	got := make(chan *WSPricePayload, 1)
+	go func() {
+		p, _ := sess.Subscribe(context.Background(), "USD.MXN", "BUY", 1000)
+		got <- p
+	}()
+
+	require.Eventually(t, func() bool {
+		sess.subsMu.Lock()
+		defer sess.subsMu.Unlock()
+		return len(sub.waiters) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	raw, _ := json.Marshal(WSPricePayload{
+		Action:    "price_update",
+		ClientRef: sub.clientRef,
+		Side:      "BUY",
+		Ask:       17.21,
+		QuoteID:   "fresh",
+	})
+	sess.dispatch(raw)
+
+	select {
+	case p := <-got:
+		require.NotNil(t, p)
+		assert.Equal(t, "fresh", p.QuoteID)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for refreshed price")
+	}
+}
+
+func TestPriceSubscription_Fresh(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		sub  priceSubscription
+		want bool
+	}{
+		{"no price yet", priceSubscription{}, false},
+		{"before expiry", priceSubscription{latest: &WSPricePayload{ExpiresAt: now.Add(time.Minute).Unix()}}, true},
+		{"after expiry", priceSubscription{latest: &WSPricePayload{ExpiresAt: now.Add(-time.Second).Unix()}}, false},
+		{"no expiry, recent", priceSubscription{latest: &WSPricePayload{}, receivedAt: now.Add(-time.Second)}, true},
+		{"no expiry, old", priceSubscription{latest: &WSPricePayload{}, receivedAt: now.Add(-time.Minute)}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.sub.fresh(now, 5*time.Second))
+		})
+	}
+}
+
+// ─── Slippage re-validation ───────────────────────────────────────────────────
+
+func TestAdverseSlippage(t *testing.T) {
+	assert.InDelta(t, 0.01, adverseSlippage("BUY", 100, 101), 1e-9)
+	assert.Equal(t, 0.0, adverseSlippage("BUY", 100, 99))
+	assert.InDelta(t, 0.01, adverseSlippage("SELL", 100, 99), 1e-9)
+	assert.Equal(t, 0.0, adverseSlippage("SELL", 100, 101))
+	assert.Equal(t, 0.0, adverseSlippage("BUY", 0, 101))
+}
+
+func TestService_revalidateQuote(t *testing.T) {
+	tests := []struct {
+		name      string
+		side      string
+		quoted    float64
+		live      float64
+		tolerance float64
+		wantID    string
+		wantErr   error
+	}{
+		{"within tolerance", "BUY", 17.20, 17.21, 0.001, "q-live", nil},
+		{"adverse beyond tolerance", "BUY", 17.20, 17.30, 0.001, "", ErrSlippageExceeded},
+		{"favourable move", "BUY", 17.20, 17.00, 0.001, "q-live", nil},
+		{"sell adverse beyond tolerance", "SELL", 17.20, 17.10, 0.001, "", ErrSlippageExceeded},
+		{"zero tolerance rejects adverse move", "BUY", 17.20, 17.21, 0, "", ErrSlippageExceeded},
+		{"zero tolerance accepts favourable move", "BUY", 17.20, 17.00, 0, "q-live", nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &Service{
+				cfg:    config.Config{RFSRevalidate: true, RFSSlippageTolerance: tc.tolerance},
+				mapper: NewMapper(),
+			}
+			svc.rememberQuote("c", WSPricePayload{
+				Instrument: "USD.MXN",
+				Side:       tc.side,
+				Quantity:   1000,
+				Price:      tc.quoted,
+				QuoteID:    "q-issued",
+			})
+			sess, _ := liveSession(WSPricePayload{
+				Instrument: "USD.MXN",
+				Side:       tc.side,
+				Quantity:   1000,
+				Price:      tc.live,
+				QuoteID:    "q-live",
+				ExpiresAt:  time.Now().Add(time.Minute).Unix(),
+			})
+
+			issued := svc.lookupQuote("c", "q-issued")
+			require.NotNil(t, issued)
+			id, _, err := svc.revalidateQuote(context.Background(), sess, "c", "q-issued", issued)
+			if tc.wantErr != nil {
+				assert.True(t, errors.Is(err, tc.wantErr), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantID, id)
+		})
+	}
+}
+
+func TestService_revalidateQuote_Disabled(t *testing.T) {
+	svc := &Service{cfg: config.Config{RFSRevalidate: false}, mapper: NewMapper()}
+	issued := &issuedQuote{clientID: "c", instrument: "USD.MXN", side: "BUY", quantity: 1000, price: 17.20}
+
+	// No session is consulted when re-validation is off.
+	id, price, err := svc.revalidateQuote(context.Background(), nil, "c", "q-issued", issued)
+	require.NoError(t, err)
+	assert.Equal(t, "q-issued", id)
+	assert.Equal(t, 17.20, price)
+}
+
+func TestService_IssuedQuoteKeptUntilForgotten(t *testing.T) {
+	svc := &Service{mapper: NewMapper()}
+	svc.rememberQuote("c", WSPricePayload{Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.20, QuoteID: "q-1"})
+
+	require.NotNil(t, svc.lookupQuote("c", "q-1"))
+	require.NotNil(t, svc.lookupQuote("c", "q-1"), "a retry must still see the issued terms")
+	assert.Nil(t, svc.lookupQuote("other", "q-1"))
+
+	svc.forgetQuote("q-1")
+	assert.Nil(t, svc.lookupQuote("c", "q-1"))
+}
+
+func TestService_PruneQuotes(t *testing.T) {
+	svc := &Service{mapper: NewMapper()}
+	svc.rememberQuote("c", WSPricePayload{Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.20, QuoteID: "q-1"})
+
+	assert.Zero(t, svc.PruneQuotes(time.Hour))
+	require.NotNil(t, svc.lookupQuote("c", "q-1"))
+	assert.Equal(t, 1, svc.PruneQuotes(0))
+	assert.Nil(t, svc.lookupQuote("c", "q-1"))
+}
+
+func TestSession_Subscribe_DropsWaiterOnCancel(t *testing.T) {
+	sess, sub := liveSession(WSPricePayload{Instrument: "USD.MXN", Side: "BUY", Quantity: 1000, Price: 17.20, QuoteID: "q-1"})
+	sub.receivedAt = time.Now().Add(-time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := sess.Subscribe(ctx, "USD.MXN", "BUY", 1000)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	sess.subsMu.Lock()
+	waiters := len(sub.waiters)
+	sess.subsMu.Unlock()
+	assert.Zero(t, waiters)
+
+	sess.connected.Store(false)
+	assert.Equal(t, 1, sess.PruneSubscriptions(0))
+}
@@ -188,6 +188,12 @@ type WSPricePayload struct {
 	ExpiresAt  int64   `json:"expiresAt"` // Unix timestamp
 }
 
+// WSUnsubscribePriceMessage cancels a streaming price subscription.
+type WSUnsubscribePriceMessage struct {
+	Action    string `json:"action"`    // "unsubscribe_price"
+	ClientRef string `json:"clientRef"` // clientRef of the original subscribe_price
+}
+
 // WSExecuteOrderMessage requests execution of a quoted price.
 type WSExecuteOrderMessage struct {
 	Action    string `json:"action"`    // "execute_order"
@@ -6,22 +6,33 @@ import (
 	"github.com/Checker-Finance/adapters/pkg/model"
 )
 
+// Zodia transaction states. A transaction moves PENDING → PROCESSING →
+// one of the terminal states; PROCESSING may be skipped.
+//
+// ⚠️ PROCESSING, REJECTED, CANCELLED and FAILED are taken from Zodia's
+// transaction docs and have not all been observed in sandbox yet.
+const (
+	TxStatePending    = "PENDING"
+	TxStateProcessing = "PROCESSING"
+	TxStateProcessed  = "PROCESSED"
+	TxStateRejected   = "REJECTED"
+	TxStateCancelled  = "CANCELLED"
+	TxStateFailed     = "FAILED"
+)
+
 // NormalizeTransactionState maps Zodia transaction states to canonical status strings.
-// Known Zodia states: "PENDING", "PROCESSED"
-// ⚠️ Failure/cancellation states (e.g. "REJECTED", "CANCELLED", "FAILED") need
-// verification against sandbox — add them here when confirmed.
+// PENDING and PROCESSING are both in flight and map to "pending"; FAILED maps to
+// "rejected". Unknown states are passed through lowercased.
 func NormalizeTransactionState(state string) string {
 	switch strings.ToUpper(strings.TrimSpace(state)) {
-	case "PENDING":
-		return "pending"
-	case "PROCESSED":
-		return "filled"
-	case "FAILED":
-		return "rejected"
-	case "REJECTED":
-		return "rejected"
-	case "CANCELLED":
-		return "cancelled"
+	case TxStatePending, TxStateProcessing:
+		return model.StatusPending
+	case TxStateProcessed:
+		return model.StatusFilled
+	case TxStateFailed, TxStateRejected:
+		return model.StatusRejected
+	case TxStateCancelled:
+		return model.StatusCancelled
 	default:
 		return strings.ToLower(strings.TrimSpace(state))
 	}
@@ -33,6 +44,39 @@ func IsTerminalState(state string) bool {
 	return model.IsTerminal(NormalizeTransactionState(state))
 }
 
+// transactionStateRank orders states along the lifecycle. Terminal states share
+// the highest rank; unknown states rank as zero.
+func transactionStateRank(state string) int {
+	switch strings.ToUpper(strings.TrimSpace(state)) {
+	case TxStatePending:
+		return 1
+	case TxStateProcessing:
+		return 2
+	case TxStateProcessed, TxStateRejected, TxStateCancelled, TxStateFailed:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// CanTransition reports whether a transaction may move from state from to
+// state to. Terminal states are final, and a transaction never moves back to
+// an earlier state, so out-of-order notifications can be discarded. An empty
+// from (no state seen yet) and unknown states accept any transition.
+func CanTransition(from, to string) bool {
+	if from == "" {
+		return true
+	}
+	if IsTerminalState(from) {
+		return false
+	}
+	fromRank, toRank := transactionStateRank(from), transactionStateRank(to)
+	if fromRank == 0 || toRank == 0 {
+		return true
+	}
+	return toRank > fromRank
+}
+
 // ToZodiaPair converts a canonical currency pair to Zodia's dot notation.
 // Example: "USD:MXN" → "USD.MXN", "USD/MXN" → "USD.MXN"
 func ToZodiaPair(canonicalPair string) string {
@@ -48,11 +92,3 @@ func ToZodiaPair(canonicalPair string) string {
 func FromZodiaPair(zodiaPair string) string {
 	return strings.ReplaceAll(strings.ToUpper(zodiaPair), ".", ":")
 }
-
-// statusLabel returns "ok" or "error" for use as a Prometheus label.
-func statusLabel(err error) string {
-	if err != nil {
-		return "error"
-	}
-	return "ok"
-}
@@ -16,6 +16,7 @@ func TestNormalizeTransactionState(t *testing.T) {
 	}{
 		{"PENDING lowercase", "PENDING", "pending"},
 		{"pending lowercase", "pending", "pending"},
+		{"PROCESSING → pending", "PROCESSING", "pending"},
 		{"PROCESSED → filled", "PROCESSED", "filled"},
 		{"processed lowercase", "processed", "filled"},
 		{"FAILED → rejected", "FAILED", "rejected"},
@@ -35,6 +36,34 @@ func TestNormalizeTransactionState(t *testing.T) {
 	}
 }
 
+// ─── CanTransition ────────────────────────────────────────────────────────────
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{"", "PENDING", true},
+		{"", "PROCESSED", true},
+		{"PENDING", "PROCESSING", true},
+		{"PENDING", "PROCESSED", true},
+		{"PENDING", "FAILED", true},
+		{"PROCESSING", "CANCELLED", true},
+		{"PROCESSING", "PENDING", false},
+		{"PENDING", "PENDING", false},
+		{"PROCESSED", "PENDING", false},
+		{"PROCESSED", "PROCESSED", false},
+		{"REJECTED", "PROCESSED", false},
+		{"PENDING", "SOME_NEW_STATE", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from+"→"+tt.to, func(t *testing.T) {
+			assert.Equal(t, tt.allowed, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
 // ─── IsTerminalState ──────────────────────────────────────────────────────────
 
 func TestIsTerminalState(t *testing.T) {
@@ -53,6 +82,7 @@ func TestIsTerminalState(t *testing.T) {
 		// Non-terminal
 		{"PENDING", false},
 		{"pending", false},
+		{"PROCESSING", false},
 		{"", false},
 		{"UNKNOWN", false},
 		{"IN_PROGRESS", false},
@@ -121,10 +151,3 @@ func TestPairRoundTrip(t *testing.T) {
 		})
 	}
 }
-
-// ─── statusLabel ─────────────────────────────────────────────────────────────
-
-func TestStatusLabel(t *testing.T) {
-	assert.Equal(t, "ok", statusLabel(nil))
-	assert.Equal(t, "error", statusLabel(assert.AnError))
-}
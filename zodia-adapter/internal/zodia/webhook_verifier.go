@@ -0,0 +1,128 @@
+package zodia
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Default webhook signature headers. Zodia signs webhook bodies the same way
+// REST requests are signed: the account's API key and
+// hex(HMAC-SHA512(body, apiSecret)), carried in the same headers the REST
+// client sends. ZODIA_WEBHOOK_KEY_HEADER and ZODIA_WEBHOOK_SIGN_HEADER
+// override them.
+const (
+	WebhookKeyHeader  = "Rest-Key"
+	WebhookSignHeader = "Rest-Sign"
+)
+
+var (
+	// ErrWebhookUnsigned is returned when a webhook carries no key or signature.
+	ErrWebhookUnsigned = errors.New("zodia: webhook signature missing")
+	// ErrWebhookUnknownKey is returned when the signing key matches no configured client.
+	ErrWebhookUnknownKey = errors.New("zodia: webhook signed with unknown api key")
+	// ErrWebhookBadSignature is returned when the signature does not match the body.
+	ErrWebhookBadSignature = errors.New("zodia: webhook signature mismatch")
+)
+
+// webhookIndexMinRefresh rate-limits index rebuilds triggered by unknown keys.
+const webhookIndexMinRefresh = time.Minute
+
+// WebhookVerifier authenticates Zodia webhooks against per-client API secrets
+// and identifies the client the webhook belongs to. The API key → client
+// index is built from the configured clients and rebuilt when an unknown key
+// arrives, at most once per webhookIndexMinRefresh.
+type WebhookVerifier struct {
+	resolver ConfigResolver
+	signer   *HMACSigner
+
+	mu        sync.Mutex
+	byKey     map[string]string // api_key → client ID
+	builtAt   time.Time
+	rebuildMu sync.Mutex
+}
+
+// NewWebhookVerifier constructs a WebhookVerifier.
+func NewWebhookVerifier(resolver ConfigResolver, signer *HMACSigner) *WebhookVerifier {
+	return &WebhookVerifier{
+		resolver: resolver,
+		signer:   signer,
+	}
+}
+
+// Verify checks signature over body for the client owning apiKey and returns
+// that client's ID.
+func (v *WebhookVerifier) Verify(ctx context.Context, apiKey, signature string, body []byte) (string, error) {
+	if apiKey == "" || signature == "" {
+		return "", ErrWebhookUnsigned
+	}
+
+	clientID, ok := v.lookup(apiKey)
+	if !ok {
+		v.rebuild(ctx)
+		if clientID, ok = v.lookup(apiKey); !ok {
+			return "", ErrWebhookUnknownKey
+		}
+	}
+
+	cfg, err := v.resolver.Resolve(ctx, clientID)
+	if err != nil {
+		return "", err
+	}
+	if cfg.APIKey != apiKey {
+		// Credentials rotated since the index was built.
+		v.rebuild(ctx)
+		return "", ErrWebhookUnknownKey
+	}
+	if !v.signer.Verify(body, cfg.APISecret, signature) {
+		return "", ErrWebhookBadSignature
+	}
+	return clientID, nil
+}
+
+// lookup returns the client ID indexed for apiKey.
+func (v *WebhookVerifier) lookup(apiKey string) (string, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	clientID, ok := v.byKey[apiKey]
+	return clientID, ok
+}
+
+// rebuild refreshes the API key index from the configured clients unless it
+// was rebuilt recently.
+func (v *WebhookVerifier) rebuild(ctx context.Context) {
+	v.rebuildMu.Lock()
+	defer v.rebuildMu.Unlock()
+
+	v.mu.Lock()
+	fresh := !v.builtAt.IsZero() && time.Since(v.builtAt) < webhookIndexMinRefresh
+	v.mu.Unlock()
+	if fresh {
+		return
+	}
+
+	clients, err := v.resolver.DiscoverClients(ctx)
+	if err != nil {
+		slog.Warn("zodia.webhook.discover_clients_failed", "error", err)
+		return
+	}
+	byKey := make(map[string]string, len(clients))
+	for _, clientID := range clients {
+		cfg, err := v.resolver.Resolve(ctx, clientID)
+		if err != nil {
+			slog.Warn("zodia.webhook.resolve_client_failed",
+				"client", clientID,
+				"error", err)
+			continue
+		}
+		byKey[cfg.APIKey] = clientID
+	}
+
+	v.mu.Lock()
+	v.byKey = byKey
+	v.builtAt = time.Now()
+	v.mu.Unlock()
+	slog.Debug("zodia.webhook.key_index_rebuilt", "clients", len(byKey))
+}
@@ -9,6 +9,9 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Checker-Finance/adapters/internal/tracing"
 )
 
 // WSConn is the interface for a WebSocket connection, enabling mock-based testing.
@@ -52,8 +55,12 @@ func NewWSClient() *WSClient {
 
 // Dial connects to the Zodia WebSocket server at the given URL.
 // Returns a WSConn that implements the WSConn interface.
-func (c *WSClient) Dial(ctx context.Context, url string) (WSConn, error) {
+func (c *WSClient) Dial(ctx context.Context, url string) (_ WSConn, err error) {
+	ctx, span := tracing.Start(ctx, "zodia.ws.dial", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() { tracing.End(span, err) }()
+
 	reqHeader := http.Header{}
+	tracing.InjectHTTP(ctx, reqHeader)
 	conn, _, err := c.dialer.DialContext(ctx, url, reqHeader)
 	if err != nil {
 		return nil, fmt.Errorf("zodia: ws dial %q: %w", url, err)
@@ -47,11 +47,27 @@ type Config struct {
 	ZodiaPollInterval      time.Duration // Polling interval for transaction status
 	WSRequestTimeout       time.Duration // Timeout for WS price/order requests
 	WSMaxRetries           int           // Max WebSocket reconnect attempts
+	RFSPriceMaxAge         time.Duration // How long a streamed price without an expiry stays quotable
+	RFSIdleTTL             time.Duration // Idle time after which an RFS price subscription is cancelled
+	RFSRevalidate          bool          // Re-validate quotes against the live price at execution
+	RFSSlippageTolerance   float64       // Max adverse price move (fraction) accepted at execution; 0 rejects any adverse move
 	RFQSweepInterval       time.Duration // How often to expire stale RFQs/quotes in the legacy DB
 	RFQSweepTTL            time.Duration // Age threshold after which an open RFQ/quote is expired
 	SummaryRefreshInterval time.Duration // How often to refresh the balance summary materialized view
 	BalancePollInterval    time.Duration // How often to poll Zodia account balances
 	ClientBalanceIDs       string        // Comma-separated list of client IDs for balance polling
+	WebhookRequireSig      bool          // Reject webhooks not signed with a configured client's API secret
+	WebhookKeyHeader       string        // Header carrying the API key that signed a webhook
+	WebhookSignHeader      string        // Header carrying the webhook body signature
+	TokenPersist           bool          // Share WS auth tokens between replicas via Redis
+	TokenEncryptionKey     string        // Base64 AES-256 key encrypting persisted tokens
+	DiscoveryInterval      time.Duration // How often tokens of clients removed from Secrets Manager are dropped
+
+	// JetStream publishing
+	NATSStream            string        // Stream that must capture the adapter's trade events
+	NATSDedupWindow       time.Duration // Duplicate window enforced on NATSStream
+	NATSPublishAsync      bool          // Publish without waiting for each ack
+	NATSPublishMaxPending int           // Async publishes awaiting an ack before publishing blocks
 }
 
 // Load loads configuration from environment variables, then overlays any values
@@ -86,11 +102,26 @@ func Load(ctx context.Context) *Config {
 		ZodiaPollInterval:      pkgconfig.GetEnvDuration("ZODIA_POLL_INTERVAL", 15*time.Second),
 		WSRequestTimeout:       pkgconfig.GetEnvDuration("WS_REQUEST_TIMEOUT", 10*time.Second),
 		WSMaxRetries:           pkgconfig.GetEnvInt("WS_MAX_RETRIES", 5),
+		RFSPriceMaxAge:         pkgconfig.GetEnvDuration("ZODIA_RFS_PRICE_MAX_AGE", 5*time.Second),
+		RFSIdleTTL:             pkgconfig.GetEnvDuration("ZODIA_RFS_IDLE_TTL", 5*time.Minute),
+		RFSRevalidate:          pkgconfig.GetEnvBool("ZODIA_RFS_REVALIDATE", true),
+		RFSSlippageTolerance:   pkgconfig.GetEnvFloat("ZODIA_RFS_SLIPPAGE_TOLERANCE", 0.001),
 		RFQSweepInterval:       pkgconfig.GetEnvDuration("RFQ_SWEEP_INTERVAL", 5*time.Minute),
 		RFQSweepTTL:            pkgconfig.GetEnvDuration("RFQ_SWEEP_TTL", 15*time.Minute),
 		SummaryRefreshInterval: pkgconfig.GetEnvDuration("SUMMARY_REFRESH_INTERVAL", 24*time.Hour),
 		BalancePollInterval:    pkgconfig.GetEnvDuration("BALANCE_POLL_INTERVAL", 5*time.Minute),
 		ClientBalanceIDs:       pkgconfig.GetEnv("CLIENT_BALANCE_IDS", ""),
+		WebhookRequireSig:      pkgconfig.GetEnvBool("ZODIA_WEBHOOK_REQUIRE_SIGNATURE", true),
+		WebhookKeyHeader:       pkgconfig.GetEnv("ZODIA_WEBHOOK_KEY_HEADER", "Rest-Key"),
+		WebhookSignHeader:      pkgconfig.GetEnv("ZODIA_WEBHOOK_SIGN_HEADER", "Rest-Sign"),
+		TokenPersist:           pkgconfig.GetEnvBool("AUTH_TOKEN_PERSIST", false),
+		TokenEncryptionKey:     pkgconfig.GetEnv("AUTH_TOKEN_ENCRYPTION_KEY", ""),
+		DiscoveryInterval:      pkgconfig.GetEnvDuration("ZODIA_CLIENT_DISCOVERY_INTERVAL", 5*time.Minute),
+
+		NATSStream:            pkgconfig.GetEnv("NATS_STREAM", "ZODIA_EVENTS"),
+		NATSDedupWindow:       pkgconfig.GetEnvDuration("NATS_DEDUP_WINDOW", 2*time.Minute),
+		NATSPublishAsync:      pkgconfig.GetEnvBool("NATS_PUBLISH_ASYNC", false),
+		NATSPublishMaxPending: pkgconfig.GetEnvInt("NATS_PUBLISH_MAX_PENDING", 256),
 	}
 
 	secretPath := fmt.Sprintf("%s/%s", cfg.Env, cfg.ServiceName)